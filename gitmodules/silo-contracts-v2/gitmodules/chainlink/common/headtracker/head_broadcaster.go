@@ -28,9 +28,11 @@ type HeadBroadcaster[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] struct
 	logger    logger.Logger
 	callbacks callbackSet[H, BLOCK_HASH]
 	mailbox   *utils.Mailbox[H]
-	mutex     *sync.Mutex
-	chClose   utils.StopChan
-	wgDone    sync.WaitGroup
+	// finalizedMailbox holds finalized heads, which are only delivered to HeadFinalityTrackable callbacks
+	finalizedMailbox *utils.Mailbox[H]
+	mutex            *sync.Mutex
+	chClose          utils.StopChan
+	wgDone           sync.WaitGroup
 	utils.StartStopOnce
 	latest         H
 	lastCallbackID int
@@ -44,13 +46,14 @@ func NewHeadBroadcaster[
 	lggr logger.Logger,
 ) *HeadBroadcaster[H, BLOCK_HASH] {
 	return &HeadBroadcaster[H, BLOCK_HASH]{
-		logger:        lggr.Named("HeadBroadcaster"),
-		callbacks:     make(callbackSet[H, BLOCK_HASH]),
-		mailbox:       utils.NewSingleMailbox[H](),
-		mutex:         &sync.Mutex{},
-		chClose:       make(chan struct{}),
-		wgDone:        sync.WaitGroup{},
-		StartStopOnce: utils.StartStopOnce{},
+		logger:           lggr.Named("HeadBroadcaster"),
+		callbacks:        make(callbackSet[H, BLOCK_HASH]),
+		mailbox:          utils.NewSingleMailbox[H](),
+		finalizedMailbox: utils.NewSingleMailbox[H](),
+		mutex:            &sync.Mutex{},
+		chClose:          make(chan struct{}),
+		wgDone:           sync.WaitGroup{},
+		StartStopOnce:    utils.StartStopOnce{},
 	}
 }
 
@@ -87,8 +90,13 @@ func (hb *HeadBroadcaster[H, BLOCK_HASH]) BroadcastNewLongestChain(head H) {
 	hb.mailbox.Deliver(head)
 }
 
+func (hb *HeadBroadcaster[H, BLOCK_HASH]) BroadcastNewFinalizedHead(head H) {
+	hb.finalizedMailbox.Deliver(head)
+}
+
 // Subscribe subscribes to OnNewLongestChain and Connect until HeadBroadcaster is closed,
-// or unsubscribe callback is called explicitly
+// or unsubscribe callback is called explicitly. Callbacks implementing HeadFinalityTrackable
+// are also subscribed to OnNewFinalizedHead.
 func (hb *HeadBroadcaster[H, BLOCK_HASH]) Subscribe(callback types.HeadTrackable[H, BLOCK_HASH]) (currentLongestChain H, unsubscribe func()) {
 	hb.mutex.Lock()
 	defer hb.mutex.Unlock()
@@ -116,6 +124,8 @@ func (hb *HeadBroadcaster[H, BLOCK_HASH]) run() {
 			return
 		case <-hb.mailbox.Notify():
 			hb.executeCallbacks()
+		case <-hb.finalizedMailbox.Notify():
+			hb.executeFinalityCallbacks()
 		}
 	}
 }
@@ -161,3 +171,48 @@ func (hb *HeadBroadcaster[H, BLOCK_HASH]) executeCallbacks() {
 
 	wg.Wait()
 }
+
+// executeFinalityCallbacks delivers the latest finalized head to the callbacks that opted into finality events
+// by implementing HeadFinalityTrackable. The same delivery caveats as executeCallbacks apply.
+func (hb *HeadBroadcaster[H, BLOCK_HASH]) executeFinalityCallbacks() {
+	head, exists := hb.finalizedMailbox.Retrieve()
+	if !exists {
+		hb.logger.Info("No finalized head to retrieve. It might have been skipped")
+		return
+	}
+
+	hb.mutex.Lock()
+	var callbacks []types.HeadFinalityTrackable[H, BLOCK_HASH]
+	for _, callback := range hb.callbacks {
+		if trackable, ok := callback.(types.HeadFinalityTrackable[H, BLOCK_HASH]); ok {
+			callbacks = append(callbacks, trackable)
+		}
+	}
+	hb.mutex.Unlock()
+
+	hb.logger.Debugw("Initiating finality callbacks",
+		"headNum", head.BlockNumber(),
+		"numCallbacks", len(callbacks),
+	)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(callbacks))
+
+	ctx, cancel := hb.chClose.NewCtx()
+	defer cancel()
+
+	for _, callback := range callbacks {
+		go func(trackable types.HeadFinalityTrackable[H, BLOCK_HASH]) {
+			defer wg.Done()
+			start := time.Now()
+			cctx, cancel := context.WithTimeout(ctx, TrackableCallbackTimeout)
+			defer cancel()
+			trackable.OnNewFinalizedHead(cctx, head)
+			elapsed := time.Since(start)
+			hb.logger.Debugw(fmt.Sprintf("Finished finality callback in %s", elapsed),
+				"callbackType", reflect.TypeOf(trackable), "blockNumber", head.BlockNumber(), "time", elapsed)
+		}(callback)
+	}
+
+	wg.Wait()
+}
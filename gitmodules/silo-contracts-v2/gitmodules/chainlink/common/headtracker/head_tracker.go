@@ -28,6 +28,17 @@ var (
 		Name: "head_tracker_very_old_head",
 		Help: "Counter is incremented every time we get a head that is much lower than the highest seen head ('much lower' is defined as a block that is EVM.FinalityDepth or greater below the highest seen head)",
 	}, []string{"evmChainID"})
+
+	promFinalizedHead = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "head_tracker_finalized_head",
+		Help: "The highest head reported by the finalized block tag",
+	}, []string{"evmChainID"})
+
+	promReorgDepth = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "head_tracker_reorg_depth",
+		Help:    "The number of blocks dropped from the longest chain by a reorg",
+		Buckets: []float64{1, 2, 3, 5, 10, 20, 50, 100, 200, 500},
+	}, []string{"evmChainID"})
 )
 
 // HeadsBufferSize - The buffer is used when heads sampling is disabled, to ensure the callback is run for every head
@@ -50,11 +61,18 @@ type HeadTracker[
 
 	backfillMB   *utils.Mailbox[HTH]
 	broadcastMB  *utils.Mailbox[HTH]
+	finalityMB   *utils.Mailbox[HTH]
 	headListener types.HeadListener[HTH, BLOCK_HASH]
 	chStop       utils.StopChan
 	wgDone       sync.WaitGroup
 	utils.StartStopOnce
 	getNilHead func() HTH
+
+	safeMu   sync.RWMutex
+	safeHead HTH
+
+	// lastBackfilledHead is the previous longest chain handled by backfillLoop, used for reorg detection
+	lastBackfilledHead HTH
 }
 
 // NewHeadTracker instantiates a new HeadTracker using HeadSaver to persist new block numbers.
@@ -76,19 +94,22 @@ func NewHeadTracker[
 	chStop := make(chan struct{})
 	lggr = lggr.Named("HeadTracker")
 	return &HeadTracker[HTH, S, ID, BLOCK_HASH]{
-		headBroadcaster: headBroadcaster,
-		client:          client,
-		chainID:         client.ConfiguredChainID(),
-		config:          config,
-		htConfig:        htConfig,
-		log:             lggr,
-		backfillMB:      utils.NewSingleMailbox[HTH](),
-		broadcastMB:     utils.NewMailbox[HTH](HeadsBufferSize),
-		chStop:          chStop,
-		headListener:    NewHeadListener[HTH, S, ID, BLOCK_HASH](lggr, client, config, chStop),
-		headSaver:       headSaver,
-		mailMon:         mailMon,
-		getNilHead:      getNilHead,
+		headBroadcaster:    headBroadcaster,
+		client:             client,
+		chainID:            client.ConfiguredChainID(),
+		config:             config,
+		htConfig:           htConfig,
+		log:                lggr,
+		backfillMB:         utils.NewSingleMailbox[HTH](),
+		broadcastMB:        utils.NewMailbox[HTH](HeadsBufferSize),
+		finalityMB:         utils.NewSingleMailbox[HTH](),
+		chStop:             chStop,
+		headListener:       NewHeadListener[HTH, S, ID, BLOCK_HASH](lggr, client, config, chStop),
+		headSaver:          headSaver,
+		mailMon:            mailMon,
+		getNilHead:         getNilHead,
+		safeHead:           getNilHead(),
+		lastBackfilledHead: getNilHead(),
 	}
 }
 
@@ -133,6 +154,10 @@ func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) Start(ctx context.Context) error
 		go ht.headListener.ListenForNewHeads(ht.handleNewHead, ht.wgDone.Done)
 		go ht.backfillLoop()
 		go ht.broadcastLoop()
+		if ht.config.FinalityTagEnabled() {
+			ht.wgDone.Add(1)
+			go ht.finalityLoop()
+		}
 
 		ht.mailMon.Monitor(ht.broadcastMB, "HeadTracker", "Broadcast", ht.chainID.String())
 
@@ -178,6 +203,16 @@ func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) LatestChain() HTH {
 	return ht.headSaver.LatestChain()
 }
 
+func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) LatestFinalizedHead() HTH {
+	return ht.headSaver.LatestFinalizedHead()
+}
+
+func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) LatestSafeHead() HTH {
+	ht.safeMu.RLock()
+	defer ht.safeMu.RUnlock()
+	return ht.safeHead
+}
+
 func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) getInitialHead(ctx context.Context) (HTH, error) {
 	head, err := ht.client.HeadByNumber(ctx, nil)
 	if err != nil {
@@ -216,6 +251,9 @@ func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) handleNewHead(ctx context.Context
 		}
 		ht.backfillMB.Deliver(headWithChain)
 		ht.broadcastMB.Deliver(headWithChain)
+		if ht.config.FinalityTagEnabled() {
+			ht.finalityMB.Deliver(headWithChain)
+		}
 	} else if head.BlockNumber() == prevHead.BlockNumber() {
 		if head.BlockHash() != prevHead.BlockHash() {
 			ht.log.Debugw("Got duplicate head", "blockNum", head.BlockNumber(), "head", head.BlockHash(), "prevHead", prevHead.BlockHash())
@@ -225,6 +263,9 @@ func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) handleNewHead(ctx context.Context
 	} else {
 		ht.log.Debugw("Got out of order head", "blockNum", head.BlockNumber(), "head", head.BlockHash(), "prevHead", prevHead.BlockNumber())
 		prevUnFinalizedHead := prevHead.BlockNumber() - int64(ht.config.FinalityDepth())
+		if finalized := ht.LatestFinalizedHead(); ht.config.FinalityTagEnabled() && finalized.IsValid() {
+			prevUnFinalizedHead = finalized.BlockNumber()
+		}
 		if head.BlockNumber() < prevUnFinalizedHead {
 			promOldHead.WithLabelValues(ht.chainID.String()).Inc()
 			ht.log.Criticalf("Got very old block with number %d (highest seen was %d). This is a problem and either means a very deep re-org occurred, one of the RPC nodes has gotten far out of sync, or the chain went backwards in block numbers. This node may not function correctly without manual intervention.", head.BlockNumber(), prevHead.BlockNumber())
@@ -290,11 +331,13 @@ func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) backfillLoop() {
 					break
 				}
 				{
-					err := ht.Backfill(ctx, head, uint(ht.config.FinalityDepth()))
+					err := ht.Backfill(ctx, head, ht.backfillDepth(head))
 					if err != nil {
 						ht.log.Warnw("Unexpected error while backfilling heads", "err", err)
 					} else if ctx.Err() != nil {
 						break
+					} else {
+						ht.detectReorg(ctx, head)
 					}
 				}
 			}
@@ -302,6 +345,167 @@ func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) backfillLoop() {
 	}
 }
 
+// backfillDepth returns the number of heads to keep in the chain ending at head. This is FinalityDepth, unless
+// finality tags are enabled and a finalized head is known, in which case the chain is backfilled down to the
+// finalized head, capped at HistoryDepth.
+func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) backfillDepth(head HTH) uint {
+	if ht.config.FinalityTagEnabled() {
+		finalized := ht.LatestFinalizedHead()
+		if finalized.IsValid() && finalized.BlockNumber() <= head.BlockNumber() {
+			depth := uint(head.BlockNumber()-finalized.BlockNumber()) + 1
+			if historyDepth := uint(ht.htConfig.HistoryDepth()); depth > historyDepth {
+				depth = historyDepth
+			}
+			return depth
+		}
+	}
+	return uint(ht.config.FinalityDepth())
+}
+
+func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) finalityLoop() {
+	defer ht.wgDone.Done()
+
+	ctx, cancel := ht.chStop.NewCtx()
+	defer cancel()
+
+	for {
+		select {
+		case <-ht.chStop:
+			return
+		case <-ht.finalityMB.Notify():
+			if _, exists := ht.finalityMB.Retrieve(); !exists {
+				continue
+			}
+			if err := ht.pollFinalizedHead(ctx); err != nil {
+				ht.log.Warnw("Unexpected error while polling finalized head", "err", err)
+			}
+			if err := ht.pollSafeHead(ctx); err != nil {
+				ht.log.Warnw("Unexpected error while polling safe head", "err", err)
+			}
+		}
+	}
+}
+
+// pollFinalizedHead fetches the latest finalized head from the RPC, persists it and notifies subscribers if it is new
+func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) pollFinalizedHead(ctx context.Context) error {
+	finalized, err := ht.client.LatestFinalizedHead(ctx)
+	if ctx.Err() != nil {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to fetch finalized head")
+	} else if !finalized.IsValid() {
+		return errors.New("got nil finalized head")
+	}
+
+	prevFinalized := ht.headSaver.LatestFinalizedHead()
+	if prevFinalized.IsValid() {
+		if finalized.BlockNumber() < prevFinalized.BlockNumber() {
+			// may happen when the node pool switches to an RPC that is lagging behind
+			ht.log.Debugw("Got finalized head lower than the previous one, ignoring", "blockNum", finalized.BlockNumber(), "prevBlockNum", prevFinalized.BlockNumber())
+			return nil
+		}
+		if finalized.BlockNumber() == prevFinalized.BlockNumber() {
+			if finalized.BlockHash() != prevFinalized.BlockHash() {
+				ht.log.Criticalw("Got a finalized head with a different hash than the previous finalized head at the same height. This means finality was violated, either by the chain or one of the RPC nodes. This node may not function correctly without manual intervention.",
+					"blockNum", finalized.BlockNumber(), "head", finalized.BlockHash(), "prevHead", prevFinalized.BlockHash())
+				ht.SvcErrBuffer.Append(errors.New("finalized head mismatch"))
+			}
+			return nil
+		}
+	}
+
+	if err = ht.headSaver.SaveFinalized(ctx, finalized); err != nil {
+		return errors.Wrapf(err, "failed to save finalized head: %#v", finalized)
+	}
+	promFinalizedHead.WithLabelValues(ht.chainID.String()).Set(float64(finalized.BlockNumber()))
+	ht.log.Debugw(fmt.Sprintf("New finalized head %v", config.FriendlyNumber(finalized.BlockNumber())),
+		"blockHeight", finalized.BlockNumber(),
+		"blockHash", finalized.BlockHash(),
+	)
+	ht.headBroadcaster.BroadcastNewFinalizedHead(ht.headSaver.LatestFinalizedHead())
+	return nil
+}
+
+// pollSafeHead fetches the latest safe head from the RPC and keeps it in memory
+func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) pollSafeHead(ctx context.Context) error {
+	safe, err := ht.client.LatestSafeHead(ctx)
+	if ctx.Err() != nil {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to fetch safe head")
+	} else if !safe.IsValid() {
+		return errors.New("got nil safe head")
+	}
+
+	ht.safeMu.Lock()
+	defer ht.safeMu.Unlock()
+	if !ht.safeHead.IsValid() || safe.BlockNumber() >= ht.safeHead.BlockNumber() {
+		ht.safeHead = safe
+	}
+	return nil
+}
+
+// detectReorg compares the freshly backfilled chain of head against the previous longest chain, and records a
+// report if the previous longest head is no longer part of the chain.
+func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) detectReorg(ctx context.Context, head HTH) {
+	chain := ht.headSaver.Chain(head.BlockHash())
+	if !chain.IsValid() {
+		return
+	}
+	prev := ht.lastBackfilledHead
+	ht.lastBackfilledHead = chain
+	if !prev.IsValid() || chain.BlockNumber() <= prev.BlockNumber() {
+		return
+	}
+	if chain.EarliestHeadInChain().BlockNumber() > prev.BlockNumber() {
+		// the new chain does not reach back to the previous head, so there is nothing to compare against
+		return
+	}
+	if chain.HashAtHeight(prev.BlockNumber()) == prev.BlockHash() {
+		return
+	}
+
+	reorg := types.Reorg[BLOCK_HASH]{
+		PrevHeadNumber: prev.BlockNumber(),
+		PrevHeadHash:   prev.BlockHash(),
+		NewHeadNumber:  chain.BlockNumber(),
+		NewHeadHash:    chain.BlockHash(),
+	}
+	earliest := chain.EarliestHeadInChain().BlockNumber()
+	lowestDropped := prev.BlockNumber()
+	for h := types.Head[BLOCK_HASH](prev); h != nil && h.BlockNumber() >= earliest; h = h.GetParent() {
+		if chain.HashAtHeight(h.BlockNumber()) == h.BlockHash() {
+			reorg.HasCommonAncestor = true
+			reorg.CommonAncestorNumber = h.BlockNumber()
+			reorg.CommonAncestorHash = h.BlockHash()
+			break
+		}
+		reorg.DroppedHashes = append(reorg.DroppedHashes, h.BlockHash())
+		lowestDropped = h.BlockNumber()
+	}
+	for h := types.Head[BLOCK_HASH](chain); h != nil && h.BlockNumber() >= lowestDropped; h = h.GetParent() {
+		if reorg.HasCommonAncestor && h.BlockNumber() <= reorg.CommonAncestorNumber {
+			break
+		}
+		reorg.NewHashes = append(reorg.NewHashes, h.BlockHash())
+	}
+	reorg.Depth = int64(len(reorg.DroppedHashes))
+
+	promReorgDepth.WithLabelValues(ht.chainID.String()).Observe(float64(reorg.Depth))
+	ht.log.Warnw(fmt.Sprintf("Detected reorg of depth %d", reorg.Depth),
+		"prevHeadNumber", reorg.PrevHeadNumber,
+		"prevHeadHash", reorg.PrevHeadHash,
+		"newHeadNumber", reorg.NewHeadNumber,
+		"newHeadHash", reorg.NewHeadHash,
+		"hasCommonAncestor", reorg.HasCommonAncestor,
+		"commonAncestorNumber", reorg.CommonAncestorNumber,
+		"commonAncestorHash", reorg.CommonAncestorHash,
+	)
+	if err := ht.headSaver.SaveReorg(ctx, reorg); err != nil && ctx.Err() == nil {
+		ht.log.Errorw("Failed to save reorg", "err", err)
+	}
+}
+
 // backfill fetches all missing heads up until the base height
 func (ht *HeadTracker[HTH, S, ID, BLOCK_HASH]) backfill(ctx context.Context, head types.Head[BLOCK_HASH], baseHeight int64) (err error) {
 	headBlockNumber := head.BlockNumber()
@@ -10,6 +10,10 @@ import (
 type Client[H types.Head[BLOCK_HASH], S types.Subscription, ID types.ID, BLOCK_HASH types.Hashable] interface {
 	HeadByNumber(ctx context.Context, number *big.Int) (head H, err error)
 	HeadByHash(ctx context.Context, hash BLOCK_HASH) (head H, err error)
+	// LatestFinalizedHead returns the head tagged as `finalized` by the RPC
+	LatestFinalizedHead(ctx context.Context) (head H, err error)
+	// LatestSafeHead returns the head tagged as `safe` by the RPC
+	LatestSafeHead(ctx context.Context) (head H, err error)
 	// ConfiguredChainID returns the chain ID that the node is configured to connect to
 	ConfiguredChainID() (id ID)
 	// SubscribeNewHead is the method in which the client receives new Head.
@@ -14,6 +14,11 @@ type HeadBroadcaster[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] struct
 	mock.Mock
 }
 
+// BroadcastNewFinalizedHead provides a mock function with given fields: _a0
+func (_m *HeadBroadcaster[H, BLOCK_HASH]) BroadcastNewFinalizedHead(_a0 H) {
+	_m.Called(_a0)
+}
+
 // BroadcastNewLongestChain provides a mock function with given fields: _a0
 func (_m *HeadBroadcaster[H, BLOCK_HASH]) BroadcastNewLongestChain(_a0 H) {
 	_m.Called(_a0)
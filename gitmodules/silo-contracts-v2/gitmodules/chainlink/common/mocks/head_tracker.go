@@ -72,6 +72,34 @@ func (_m *HeadTracker[H, BLOCK_HASH]) LatestChain() H {
 	return r0
 }
 
+// LatestFinalizedHead provides a mock function with given fields:
+func (_m *HeadTracker[H, BLOCK_HASH]) LatestFinalizedHead() H {
+	ret := _m.Called()
+
+	var r0 H
+	if rf, ok := ret.Get(0).(func() H); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(H)
+	}
+
+	return r0
+}
+
+// LatestSafeHead provides a mock function with given fields:
+func (_m *HeadTracker[H, BLOCK_HASH]) LatestSafeHead() H {
+	ret := _m.Called()
+
+	var r0 H
+	if rf, ok := ret.Get(0).(func() H); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(H)
+	}
+
+	return r0
+}
+
 // Name provides a mock function with given fields:
 func (_m *HeadTracker[H, BLOCK_HASH]) Name() string {
 	ret := _m.Called()
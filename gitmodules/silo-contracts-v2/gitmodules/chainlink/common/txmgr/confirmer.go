@@ -65,6 +65,10 @@ var (
 		Name: "tx_manager_fwd_tx_count",
 		Help: "The number of forwarded transaction attempts labeled by status",
 	}, []string{"chainID", "successful"})
+	promFwdFallbackCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tx_manager_fwd_fallback_count",
+		Help: "The number of transactions sent directly to their destination because the forwarder set upstream could not be used",
+	}, []string{"chainID"})
 	promTxAttemptCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "tx_manager_tx_attempt_count",
 		Help: "The number of transaction attempts that are currently being processed by the transaction manager",
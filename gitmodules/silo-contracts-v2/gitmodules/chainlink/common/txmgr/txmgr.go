@@ -440,7 +440,7 @@ func (b *Txm[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) CreateTran
 	}
 
 	if b.txConfig.ForwardersEnabled() && (!utils.IsZero(txRequest.ForwarderAddress)) {
-		fwdPayload, fwdErr := b.fwdMgr.ConvertPayload(txRequest.ToAddress, txRequest.EncodedPayload)
+		fwdPayload, fwdErr := b.fwdMgr.ConvertPayload(txRequest.FromAddress, txRequest.ForwarderAddress, txRequest.ToAddress, txRequest.EncodedPayload, txRequest.FeeLimit)
 		if fwdErr == nil {
 			// Handling meta not set at caller.
 			if txRequest.Meta != nil {
@@ -453,7 +453,9 @@ func (b *Txm[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) CreateTran
 			txRequest.ToAddress = txRequest.ForwarderAddress
 			txRequest.EncodedPayload = fwdPayload
 		} else {
-			b.logger.Errorf("Failed to use forwarder set upstream: %s", fwdErr.Error())
+			b.logger.Errorw("Failed to use forwarder set upstream, sending the transaction directly to its destination instead",
+				"forwarder", txRequest.ForwarderAddress, "from", txRequest.FromAddress, "to", txRequest.ToAddress, "err", fwdErr)
+			promFwdFallbackCount.WithLabelValues(b.chainID.String()).Inc()
 		}
 	}
 
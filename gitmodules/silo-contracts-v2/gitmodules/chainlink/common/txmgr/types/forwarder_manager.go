@@ -9,6 +9,7 @@ import (
 type ForwarderManager[ADDR types.Hashable] interface {
 	services.ServiceCtx
 	ForwarderFor(addr ADDR) (forwarder ADDR, err error)
-	// Converts payload to be forwarder-friendly
-	ConvertPayload(dest ADDR, origPayload []byte) ([]byte, error)
+	// Converts payload, sent by from and destined for dest, to be routed through forwarder
+	// in a transaction with the given gas limit
+	ConvertPayload(from, forwarder, dest ADDR, origPayload []byte, gasLimit uint32) ([]byte, error)
 }
@@ -29,25 +29,25 @@ func (_m *ForwarderManager[ADDR]) Close() error {
 	return r0
 }
 
-// ConvertPayload provides a mock function with given fields: dest, origPayload
-func (_m *ForwarderManager[ADDR]) ConvertPayload(dest ADDR, origPayload []byte) ([]byte, error) {
-	ret := _m.Called(dest, origPayload)
+// ConvertPayload provides a mock function with given fields: from, forwarder, dest, origPayload, gasLimit
+func (_m *ForwarderManager[ADDR]) ConvertPayload(from ADDR, forwarder ADDR, dest ADDR, origPayload []byte, gasLimit uint32) ([]byte, error) {
+	ret := _m.Called(from, forwarder, dest, origPayload, gasLimit)
 
 	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func(ADDR, []byte) ([]byte, error)); ok {
-		return rf(dest, origPayload)
+	if rf, ok := ret.Get(0).(func(ADDR, ADDR, ADDR, []byte, uint32) ([]byte, error)); ok {
+		return rf(from, forwarder, dest, origPayload, gasLimit)
 	}
-	if rf, ok := ret.Get(0).(func(ADDR, []byte) []byte); ok {
-		r0 = rf(dest, origPayload)
+	if rf, ok := ret.Get(0).(func(ADDR, ADDR, ADDR, []byte, uint32) []byte); ok {
+		r0 = rf(from, forwarder, dest, origPayload, gasLimit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(ADDR, []byte) error); ok {
-		r1 = rf(dest, origPayload)
+	if rf, ok := ret.Get(1).(func(ADDR, ADDR, ADDR, []byte, uint32) error); ok {
+		r1 = rf(from, forwarder, dest, origPayload, gasLimit)
 	} else {
 		r1 = ret.Error(1)
 	}
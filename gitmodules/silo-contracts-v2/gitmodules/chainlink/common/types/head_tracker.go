@@ -16,6 +16,12 @@ type HeadTracker[H Head[BLOCK_HASH], BLOCK_HASH Hashable] interface {
 	// (used for testing)
 	Backfill(ctx context.Context, headWithChain H, depth uint) (err error)
 	LatestChain() H
+	// LatestFinalizedHead returns the highest head reported by the chain's `finalized` block tag,
+	// or an invalid head if finality tags are disabled or none has been seen yet.
+	LatestFinalizedHead() H
+	// LatestSafeHead returns the highest head reported by the chain's `safe` block tag,
+	// or an invalid head if finality tags are disabled or none has been seen yet.
+	LatestSafeHead() H
 }
 
 // HeadTrackable is implemented by the core txm,
@@ -31,6 +37,18 @@ type HeadTrackable[H Head[BLOCK_HASH], BLOCK_HASH Hashable] interface {
 	OnNewLongestChain(ctx context.Context, head H)
 }
 
+// HeadFinalityTrackable may optionally be implemented by a HeadTrackable to also receive finality events.
+// Finality events are only emitted for chains with finality tags enabled, where the head tracker polls the
+// RPC for the latest `finalized` head. Heads are delivered in increasing order of block number, but
+// intermediate finalized heads may be skipped.
+//
+//go:generate mockery --quiet --name HeadFinalityTrackable --output ./mocks/ --case=underscore
+type HeadFinalityTrackable[H Head[BLOCK_HASH], BLOCK_HASH Hashable] interface {
+	HeadTrackable[H, BLOCK_HASH]
+	// OnNewFinalizedHead is called with the new latest finalized head.
+	OnNewFinalizedHead(ctx context.Context, head H)
+}
+
 // HeadSaver is an chain agnostic interface for saving and loading heads
 // Different chains will instantiate generic HeadSaver type with their native Head and BlockHash types.
 type HeadSaver[H Head[BLOCK_HASH], BLOCK_HASH Hashable] interface {
@@ -43,6 +61,12 @@ type HeadSaver[H Head[BLOCK_HASH], BLOCK_HASH Hashable] interface {
 	LatestChain() H
 	// Chain returns a head for the specified hash, or nil.
 	Chain(hash BLOCK_HASH) H
+	// SaveFinalized persists the given head as the latest finalized head, replacing the previous one.
+	SaveFinalized(ctx context.Context, head H) error
+	// LatestFinalizedHead returns the latest head saved by SaveFinalized, or nil.
+	LatestFinalizedHead() H
+	// SaveReorg persists the report of a reorg of the longest chain.
+	SaveReorg(ctx context.Context, reorg Reorg[BLOCK_HASH]) error
 }
 
 // HeadListener is a chain agnostic interface that manages connection of Client that receives heads from the blockchain node
@@ -70,6 +94,8 @@ type NewHeadHandler[H Head[BLOCK_HASH], BLOCK_HASH Hashable] func(ctx context.Co
 type HeadBroadcaster[H Head[BLOCK_HASH], BLOCK_HASH Hashable] interface {
 	services.ServiceCtx
 	BroadcastNewLongestChain(H)
+	// BroadcastNewFinalizedHead relays the head to all subscribers implementing HeadFinalityTrackable.
+	BroadcastNewFinalizedHead(H)
 	HeadBroadcasterRegistry[H, BLOCK_HASH]
 }
 
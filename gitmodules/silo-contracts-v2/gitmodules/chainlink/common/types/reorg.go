@@ -0,0 +1,22 @@
+package types
+
+// Reorg describes a switch of the longest chain to a fork that does not include the previous longest head.
+type Reorg[BLOCK_HASH Hashable] struct {
+	// PrevHeadNumber and PrevHeadHash identify the longest head before the reorg
+	PrevHeadNumber int64
+	PrevHeadHash   BLOCK_HASH
+	// NewHeadNumber and NewHeadHash identify the longest head after the reorg
+	NewHeadNumber int64
+	NewHeadHash   BLOCK_HASH
+	// HasCommonAncestor is false if the common ancestor is older than the heads available to the head tracker,
+	// in which case CommonAncestorNumber and CommonAncestorHash are unset.
+	HasCommonAncestor    bool
+	CommonAncestorNumber int64
+	CommonAncestorHash   BLOCK_HASH
+	// Depth is the number of blocks dropped from the previous longest chain
+	Depth int64
+	// DroppedHashes are the hashes of the blocks dropped from the previous longest chain, from highest to lowest
+	DroppedHashes []BLOCK_HASH
+	// NewHashes are the hashes of the blocks of the new longest chain above the common ancestor, from highest to lowest
+	NewHashes []BLOCK_HASH
+}
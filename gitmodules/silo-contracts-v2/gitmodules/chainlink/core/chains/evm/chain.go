@@ -211,15 +211,21 @@ func NewTOMLChain(ctx context.Context, chain *toml.EVMConfig, opts ChainRelayExt
 	}
 	cfg := evmconfig.NewTOMLChainScopedConfig(opts.AppConfig, chain, l)
 	// note: per-chain validation is not necessary at this point since everything is checked earlier on boot.
-	return newChain(ctx, cfg, chain.Nodes, opts)
+	return newChain(ctx, cfg, chain.Nodes, &chain.SimulatedFork, opts)
 }
 
-func newChain(ctx context.Context, cfg *evmconfig.ChainScoped, nodes []*toml.Node, opts ChainRelayExtenderConfig) (*chain, error) {
+func newChain(ctx context.Context, cfg *evmconfig.ChainScoped, nodes []*toml.Node, fork *toml.SimulatedFork, opts ChainRelayExtenderConfig) (*chain, error) {
 	chainID, chainType := cfg.EVM().ChainID(), cfg.EVM().ChainType()
 	l := opts.Logger.Named(chainID.String()).With("evmChainID", chainID.String())
 	var client evmclient.Client
 	if !cfg.EVMRPCEnabled() {
 		client = evmclient.NewNullClient(chainID, l)
+	} else if fork.IsEnabled() {
+		var err2 error
+		client, err2 = evmclient.NewSimulatedForkClientFromSnapshot(l, chainID, *fork.SnapshotFile, *fork.GasLimit, fork.BlockTime.Duration())
+		if err2 != nil {
+			return nil, fmt.Errorf("failed to instantiate simulated fork client for chain with ID %s: %w", chainID.String(), err2)
+		}
 	} else if opts.GenEthClient == nil {
 		var err2 error
 		client, err2 = newEthClientFromChain(cfg.EVM().NodePool(), cfg.EVM().NodeNoNewHeadsThreshold(), l, chainID, chainType, nodes)
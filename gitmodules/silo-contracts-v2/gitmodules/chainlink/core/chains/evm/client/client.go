@@ -59,6 +59,10 @@ type Client interface {
 	// correct hash from the RPC response.
 	HeadByNumber(ctx context.Context, n *big.Int) (*evmtypes.Head, error)
 	HeadByHash(ctx context.Context, n common.Hash) (*evmtypes.Head, error)
+	// LatestFinalizedHead and LatestSafeHead fetch the heads tagged as `finalized` and `safe` respectively.
+	// They are only meaningful on chains that support these block tags, see EVM.FinalityTagEnabled.
+	LatestFinalizedHead(ctx context.Context) (*evmtypes.Head, error)
+	LatestSafeHead(ctx context.Context) (*evmtypes.Head, error)
 	SubscribeNewHead(ctx context.Context, ch chan<- *evmtypes.Head) (ethereum.Subscription, error)
 
 	SendTransactionReturnCode(ctx context.Context, tx *types.Transaction, fromAddress common.Address) (clienttypes.SendTxReturnCode, error)
@@ -297,10 +301,23 @@ func (client *client) HeadByHash(ctx context.Context, hash common.Hash) (head *e
 	return
 }
 
+func (client *client) LatestFinalizedHead(ctx context.Context) (*evmtypes.Head, error) {
+	return client.HeadByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+}
+
+func (client *client) LatestSafeHead(ctx context.Context) (*evmtypes.Head, error) {
+	return client.HeadByNumber(ctx, big.NewInt(rpc.SafeBlockNumber.Int64()))
+}
+
+// ToBlockNumArg converts a block number to its RPC representation.
+// Negative numbers are interpreted as block tags, see rpc.BlockNumber.
 func ToBlockNumArg(number *big.Int) string {
 	if number == nil {
 		return "latest"
 	}
+	if number.Sign() < 0 && number.IsInt64() {
+		return rpc.BlockNumber(number.Int64()).String()
+	}
 	return hexutil.EncodeBig(number)
 }
 
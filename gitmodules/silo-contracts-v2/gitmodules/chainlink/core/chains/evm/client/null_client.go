@@ -67,6 +67,16 @@ func (nc *NullClient) HeadByHash(ctx context.Context, h common.Hash) (*evmtypes.
 	return nil, nil
 }
 
+func (nc *NullClient) LatestFinalizedHead(ctx context.Context) (*evmtypes.Head, error) {
+	nc.lggr.Debug("LatestFinalizedHead")
+	return nil, nil
+}
+
+func (nc *NullClient) LatestSafeHead(ctx context.Context) (*evmtypes.Head, error) {
+	nc.lggr.Debug("LatestSafeHead")
+	return nil, nil
+}
+
 type nullSubscription struct {
 	lggr logger.Logger
 }
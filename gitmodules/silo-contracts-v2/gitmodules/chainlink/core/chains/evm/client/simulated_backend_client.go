@@ -29,7 +29,7 @@ import (
 // blockchain backend. Note that not all RPC methods are implemented here.
 type SimulatedBackendClient struct {
 	b       *backends.SimulatedBackend
-	t       testing.TB
+	lggr    logger.Logger
 	chainId *big.Int
 }
 
@@ -37,7 +37,7 @@ type SimulatedBackendClient struct {
 func NewSimulatedBackendClient(t testing.TB, b *backends.SimulatedBackend, chainId *big.Int) *SimulatedBackendClient {
 	return &SimulatedBackendClient{
 		b:       b,
-		t:       t,
+		lggr:    logger.TestLogger(t),
 		chainId: chainId,
 	}
 }
@@ -259,6 +259,16 @@ func (c *SimulatedBackendClient) HeadByHash(ctx context.Context, h common.Hash)
 	}, nil
 }
 
+// LatestFinalizedHead returns the latest head, since every block on the simulated backend is final.
+func (c *SimulatedBackendClient) LatestFinalizedHead(ctx context.Context) (*evmtypes.Head, error) {
+	return c.HeadByNumber(ctx, nil)
+}
+
+// LatestSafeHead returns the latest head, since every block on the simulated backend is final.
+func (c *SimulatedBackendClient) LatestSafeHead(ctx context.Context) (*evmtypes.Head, error) {
+	return c.HeadByNumber(ctx, nil)
+}
+
 // BlockByNumber returns a geth block type.
 func (c *SimulatedBackendClient) BlockByNumber(ctx context.Context, n *big.Int) (*types.Block, error) {
 	return c.b.BlockByNumber(ctx, n)
@@ -384,7 +394,7 @@ func (c *SimulatedBackendClient) SendTransactionReturnCode(ctx context.Context,
 func (c *SimulatedBackendClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	sender, err := types.Sender(types.NewLondonSigner(c.chainId), tx)
 	if err != nil {
-		logger.TestLogger(c.t).Panic(fmt.Errorf("invalid transaction: %v (tx: %#v)", err, tx))
+		c.lggr.Panic(fmt.Errorf("invalid transaction: %v (tx: %#v)", err, tx))
 	}
 	pendingNonce, err := c.b.PendingNonceAt(ctx, sender)
 	if err != nil {
@@ -0,0 +1,1051 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+
+	clienttypes "github.com/smartcontractkit/chainlink/v2/common/chains/client"
+	"github.com/smartcontractkit/chainlink/v2/core/assets"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// LoadStateSnapshot reads the accounts, code and storage to fork from. The file is JSON, either a
+// genesis alloc mapping addresses to accounts, or a genesis file with an "alloc" field:
+//
+//	{"0x...": {"balance": "0x...", "nonce": "0x1", "code": "0x...", "storage": {"0x...": "0x..."}}}
+func LoadStateSnapshot(path string) (core.GenesisAlloc, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read state snapshot")
+	}
+	var genesis struct {
+		Alloc core.GenesisAlloc `json:"alloc"`
+	}
+	if err = json.Unmarshal(b, &genesis); err == nil && genesis.Alloc != nil {
+		return genesis.Alloc, nil
+	}
+	var alloc core.GenesisAlloc
+	if err = json.Unmarshal(b, &alloc); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode state snapshot %s", path)
+	}
+	return alloc, nil
+}
+
+// txPoolErrors are the errors a transaction pool would reject a transaction with. They are returned
+// unwrapped from SendTransaction, so that they are classified the same way as a geth node's errors.
+var txPoolErrors = []error{
+	core.ErrNonceTooLow,
+	core.ErrNonceTooHigh,
+	core.ErrInsufficientFunds,
+	core.ErrIntrinsicGas,
+	core.ErrGasLimitReached,
+	core.ErrFeeCapTooLow,
+	core.ErrTipAboveFeeCap,
+	core.ErrTxTypeNotSupported,
+}
+
+var (
+	errAlreadyKnown           = errors.New("already known")
+	errReplacementUnderpriced = errors.New("replacement transaction underpriced")
+)
+
+// SimulatedForkClient is a Client backed by an in-process chain, forked from a state snapshot and
+// running with the configured chain ID. A block is mined every blockTime, so that it can stand in
+// for a live chain. Unlike SimulatedBackendClient, it is meant to run inside a node: every method
+// returns an error rather than panicking, and RPC calls are answered the way a geth node would.
+type SimulatedForkClient struct {
+	chainID   *big.Int
+	config    *params.ChainConfig
+	signer    types.Signer
+	db        ethdb.Database
+	engine    *ethash.Ethash
+	bc        *core.BlockChain
+	blockTime time.Duration
+	lggr      logger.Logger
+
+	mu            sync.Mutex
+	pending       []*types.Transaction
+	pendingHeader *types.Header
+	pendingState  *state.StateDB
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	chStop    utils.StopChan
+	wg        sync.WaitGroup
+}
+
+var _ Client = (*SimulatedForkClient)(nil)
+
+// NewSimulatedForkClient creates a client forked from alloc, signing with chainID. Blocks are only
+// mined by calling Commit if blockTime is zero.
+func NewSimulatedForkClient(lggr logger.Logger, chainID *big.Int, alloc core.GenesisAlloc, gasLimit uint64, blockTime time.Duration) (*SimulatedForkClient, error) {
+	// geth requires every genesis account to have a balance
+	for addr, account := range alloc {
+		if account.Balance == nil {
+			account.Balance = new(big.Int)
+			alloc[addr] = account
+		}
+	}
+	config := *params.AllEthashProtocolChanges
+	config.ChainID = chainID
+	genesis := core.Genesis{Config: &config, GasLimit: gasLimit, Alloc: alloc}
+
+	db := rawdb.NewMemoryDatabase()
+	// Blocks are produced locally, so there are no seals or consensus rules to verify.
+	engine := ethash.NewFullFaker()
+	bc, err := core.NewBlockChain(db, nil, &genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create simulated chain")
+	}
+	c := &SimulatedForkClient{
+		chainID:   chainID,
+		config:    &config,
+		signer:    types.LatestSigner(&config),
+		db:        db,
+		engine:    engine,
+		bc:        bc,
+		blockTime: blockTime,
+		lggr:      lggr.Named("SimulatedFork"),
+		chStop:    make(utils.StopChan),
+	}
+	if err = c.resetPending(nil); err != nil {
+		bc.Stop()
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewSimulatedForkClientFromSnapshot creates a client forked from the state snapshot at path.
+func NewSimulatedForkClientFromSnapshot(lggr logger.Logger, chainID *big.Int, path string, gasLimit uint64, blockTime time.Duration) (*SimulatedForkClient, error) {
+	alloc, err := LoadStateSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	lggr.Infow("Forking simulated chain from state snapshot", "snapshot", path, "accounts", len(alloc), "evmChainID", chainID)
+	return NewSimulatedForkClient(lggr, chainID, alloc, gasLimit, blockTime)
+}
+
+// Dial starts mining blocks.
+func (c *SimulatedForkClient) Dial(context.Context) error {
+	c.startOnce.Do(func() {
+		if c.blockTime <= 0 {
+			return
+		}
+		c.wg.Add(1)
+		go c.mineLoop()
+	})
+	return nil
+}
+
+// Close stops mining and the chain.
+func (c *SimulatedForkClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.chStop)
+		c.wg.Wait()
+		c.bc.Stop()
+	})
+}
+
+func (c *SimulatedForkClient) mineLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.blockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Commit(); err != nil {
+				c.lggr.Errorw("Failed to mine block", "err", err)
+			}
+		case <-c.chStop:
+			return
+		}
+	}
+}
+
+// Commit mines a block with the pending transactions. Transactions that no longer fit in the block
+// stay pending, any other transaction that cannot be included is dropped.
+func (c *SimulatedForkClient) Commit() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	parent := c.bc.CurrentBlock()
+	header := c.nextHeader(parent)
+	st, err := c.bc.StateAt(parent.Root)
+	if err != nil {
+		return errors.Wrap(err, "failed to load head state")
+	}
+	var txs, deferred []*types.Transaction
+	var receipts types.Receipts
+	gasPool := new(core.GasPool).AddGas(header.GasLimit)
+	for _, tx := range c.pending {
+		st.SetTxContext(tx.Hash(), len(txs))
+		receipt, err2 := core.ApplyTransaction(c.config, c.bc, &header.Coinbase, gasPool, st, header, tx, &header.GasUsed, vm.Config{})
+		if errors.Is(err2, core.ErrGasLimitReached) {
+			deferred = append(deferred, tx)
+			continue
+		} else if err2 != nil {
+			c.lggr.Warnw("Dropping transaction that can no longer be included", "txHash", tx.Hash(), "err", err2)
+			continue
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	block, err := c.engine.FinalizeAndAssemble(c.bc, header, st, txs, nil, receipts, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to assemble block")
+	}
+	if _, err = c.bc.InsertChain(types.Blocks{block}); err != nil {
+		return errors.Wrapf(err, "failed to insert block %d", block.NumberU64())
+	}
+	return c.resetPending(deferred)
+}
+
+// nextHeader returns the header of the block to be mined on top of parent.
+func (c *SimulatedForkClient) nextHeader(parent *types.Header) *types.Header {
+	ts := uint64(time.Now().Unix())
+	if ts <= parent.Time {
+		ts = parent.Time + 1
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		GasLimit:   parent.GasLimit,
+		Time:       ts,
+		Difficulty: c.engine.CalcDifficulty(c.bc, ts, parent),
+	}
+	if c.config.IsLondon(header.Number) {
+		header.BaseFee = misc.CalcBaseFee(c.config, parent)
+	}
+	return header
+}
+
+// resetPending rebuilds the pending block on top of the head from txs. It must be called with mu held.
+func (c *SimulatedForkClient) resetPending(txs []*types.Transaction) error {
+	header := c.nextHeader(c.bc.CurrentBlock())
+	st, included, err := c.applyPending(header, txs)
+	if err != nil {
+		return err
+	}
+	c.pending, c.pendingHeader, c.pendingState = included, header, st
+	return nil
+}
+
+// applyPending executes txs on the head state in a block with header, failing on the first
+// transaction that cannot be included.
+func (c *SimulatedForkClient) applyPending(header *types.Header, txs []*types.Transaction) (*state.StateDB, []*types.Transaction, error) {
+	st, err := c.bc.StateAt(c.bc.CurrentBlock().Root)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to load head state")
+	}
+	header = types.CopyHeader(header)
+	gasPool := new(core.GasPool).AddGas(header.GasLimit)
+	for i, tx := range txs {
+		st.SetTxContext(tx.Hash(), i)
+		if _, err = core.ApplyTransaction(c.config, c.bc, &header.Coinbase, gasPool, st, header, tx, &header.GasUsed, vm.Config{}); err != nil {
+			return nil, nil, err
+		}
+	}
+	return st, txs, nil
+}
+
+// blockHeader returns the header of the given block, including the pending block.
+func (c *SimulatedForkClient) blockHeader(number rpc.BlockNumber) (*types.Header, error) {
+	var header *types.Header
+	switch number {
+	case rpc.PendingBlockNumber:
+		c.mu.Lock()
+		header = types.CopyHeader(c.pendingHeader)
+		c.mu.Unlock()
+	case rpc.LatestBlockNumber, rpc.FinalizedBlockNumber, rpc.SafeBlockNumber:
+		// every block is final, since the chain is never reorged
+		header = c.bc.CurrentBlock()
+	case rpc.EarliestBlockNumber:
+		header = c.bc.GetHeaderByNumber(0)
+	default:
+		if number < 0 {
+			return nil, errors.Errorf("unsupported block number %d", number)
+		}
+		header = c.bc.GetHeaderByNumber(uint64(number))
+	}
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+// stateAt returns the header and a copy of the state of the given block.
+func (c *SimulatedForkClient) stateAt(number rpc.BlockNumber) (*types.Header, *state.StateDB, error) {
+	if number == rpc.PendingBlockNumber {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return types.CopyHeader(c.pendingHeader), c.pendingState.Copy(), nil
+	}
+	header, err := c.blockHeader(number)
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err := c.bc.StateAt(header.Root)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "state of block %d is not available", header.Number)
+	}
+	return header, st, nil
+}
+
+func toBlockNumber(n *big.Int) rpc.BlockNumber {
+	if n == nil {
+		return rpc.LatestBlockNumber
+	}
+	return rpc.BlockNumber(n.Int64())
+}
+
+func (c *SimulatedForkClient) ConfiguredChainID() *big.Int {
+	return c.chainID
+}
+
+func (c *SimulatedForkClient) ChainID() (*big.Int, error) {
+	return c.chainID, nil
+}
+
+// NodeStates implements evmclient.Client
+func (c *SimulatedForkClient) NodeStates() map[string]string { return nil }
+
+func (c *SimulatedForkClient) IsL2() bool { return false }
+
+func (c *SimulatedForkClient) TokenBalance(ctx context.Context, address common.Address, contractAddress common.Address) (*big.Int, error) {
+	data, err := balanceOfABI.Pack("balanceOf", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while seeking the ERC20 balance of %s on %s", address, contractAddress)
+	}
+	b, err := c.CallContract(ctx, ethereum.CallMsg{To: &contractAddress, Data: data}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while calling ERC20 balanceOf method on %s for balance of %s", contractAddress, address)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (c *SimulatedForkClient) LINKBalance(ctx context.Context, address common.Address, linkAddress common.Address) (*assets.Link, error) {
+	balance, err := c.TokenBalance(ctx, address, linkAddress)
+	if err != nil {
+		return assets.NewLinkFromJuels(0), err
+	}
+	return (*assets.Link)(balance), nil
+}
+
+func (c *SimulatedForkClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	_, st, err := c.stateAt(toBlockNumber(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	return st.GetBalance(account), nil
+}
+
+func (c *SimulatedForkClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	_, st, err := c.stateAt(toBlockNumber(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	return st.GetCode(account), nil
+}
+
+func (c *SimulatedForkClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return c.CodeAt(ctx, account, big.NewInt(int64(rpc.PendingBlockNumber)))
+}
+
+func (c *SimulatedForkClient) SequenceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (evmtypes.Nonce, error) {
+	_, st, err := c.stateAt(toBlockNumber(blockNumber))
+	if err != nil {
+		return 0, err
+	}
+	return evmtypes.Nonce(st.GetNonce(account)), nil
+}
+
+func (c *SimulatedForkClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pendingState.GetNonce(account), nil
+}
+
+func (c *SimulatedForkClient) newHead(header *types.Header) *evmtypes.Head {
+	head := &evmtypes.Head{
+		Hash:             header.Hash(),
+		Number:           header.Number.Int64(),
+		ParentHash:       header.ParentHash,
+		EVMChainID:       utils.NewBig(c.chainID),
+		Timestamp:        time.Unix(int64(header.Time), 0).UTC(),
+		BaseFeePerGas:    assets.NewWei(header.BaseFee),
+		ReceiptsRoot:     header.ReceiptHash,
+		TransactionsRoot: header.TxHash,
+		StateRoot:        header.Root,
+		Difficulty:       utils.NewBig(header.Difficulty),
+	}
+	if td := c.bc.GetTd(header.Hash(), header.Number.Uint64()); td != nil {
+		head.TotalDifficulty = utils.NewBig(td)
+	}
+	return head
+}
+
+func (c *SimulatedForkClient) HeadByNumber(ctx context.Context, n *big.Int) (*evmtypes.Head, error) {
+	header, err := c.HeaderByNumber(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	return c.newHead(header), nil
+}
+
+func (c *SimulatedForkClient) HeadByHash(ctx context.Context, h common.Hash) (*evmtypes.Head, error) {
+	header, err := c.HeaderByHash(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	return c.newHead(header), nil
+}
+
+// LatestFinalizedHead returns the latest head, since the simulated chain is never reorged.
+func (c *SimulatedForkClient) LatestFinalizedHead(ctx context.Context) (*evmtypes.Head, error) {
+	return c.HeadByNumber(ctx, nil)
+}
+
+// LatestSafeHead returns the latest head, since the simulated chain is never reorged.
+func (c *SimulatedForkClient) LatestSafeHead(ctx context.Context) (*evmtypes.Head, error) {
+	return c.HeadByNumber(ctx, nil)
+}
+
+func (c *SimulatedForkClient) LatestBlockHeight(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.bc.CurrentBlock().Number), nil
+}
+
+func (c *SimulatedForkClient) HeaderByNumber(ctx context.Context, n *big.Int) (*types.Header, error) {
+	return c.blockHeader(toBlockNumber(n))
+}
+
+func (c *SimulatedForkClient) HeaderByHash(ctx context.Context, h common.Hash) (*types.Header, error) {
+	header := c.bc.GetHeaderByHash(h)
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (c *SimulatedForkClient) BlockByNumber(ctx context.Context, n *big.Int) (*types.Block, error) {
+	number := toBlockNumber(n)
+	if number == rpc.PendingBlockNumber {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return types.NewBlockWithHeader(c.pendingHeader).WithBody(c.pending, nil), nil
+	}
+	header, err := c.blockHeader(number)
+	if err != nil {
+		return nil, err
+	}
+	return c.BlockByHash(ctx, header.Hash())
+}
+
+func (c *SimulatedForkClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	block := c.bc.GetBlockByHash(hash)
+	if block == nil {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+func (c *SimulatedForkClient) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, error) {
+	c.mu.Lock()
+	for _, tx := range c.pending {
+		if tx.Hash() == txHash {
+			c.mu.Unlock()
+			return tx, nil
+		}
+	}
+	c.mu.Unlock()
+	tx, _, _, _ := rawdb.ReadTransaction(c.db, txHash)
+	if tx == nil {
+		return nil, ethereum.NotFound
+	}
+	return tx, nil
+}
+
+func (c *SimulatedForkClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, _, _, _ := rawdb.ReadReceipt(c.db, txHash, c.config)
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (c *SimulatedForkClient) SendTransactionReturnCode(ctx context.Context, tx *types.Transaction, fromAddress common.Address) (clienttypes.SendTxReturnCode, error) {
+	err := c.SendTransaction(ctx, tx)
+	return NewSendErrorReturnCode(err, c.lggr, tx, fromAddress, c.IsL2())
+}
+
+// SendTransaction adds tx to the pending block. A pending transaction from the same sender with the
+// same nonce is replaced if tx pays a higher fee, like in a transaction pool.
+func (c *SimulatedForkClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	sender, err := types.Sender(c.signer, tx)
+	if err != nil {
+		return errors.Wrap(err, "invalid sender")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	txs := make([]*types.Transaction, 0, len(c.pending)+1)
+	replaced := false
+	for _, p := range c.pending {
+		if p.Hash() == tx.Hash() {
+			return errAlreadyKnown
+		}
+		if from, _ := types.Sender(c.signer, p); from == sender && p.Nonce() == tx.Nonce() {
+			if tx.GasFeeCapCmp(p) <= 0 || tx.GasTipCapCmp(p) <= 0 {
+				return errReplacementUnderpriced
+			}
+			p, replaced = tx, true
+		}
+		txs = append(txs, p)
+	}
+	if !replaced {
+		if nonce := c.pendingState.GetNonce(sender); tx.Nonce() < nonce {
+			return core.ErrNonceTooLow
+		} else if tx.Nonce() > nonce {
+			return core.ErrNonceTooHigh
+		}
+		txs = append(txs, tx)
+	}
+
+	header := types.CopyHeader(c.pendingHeader)
+	st, included, err := c.applyPending(header, txs)
+	if err != nil {
+		for _, poolErr := range txPoolErrors {
+			if errors.Is(err, poolErr) {
+				return poolErr
+			}
+		}
+		return err
+	}
+	c.pending, c.pendingState = included, st
+	return nil
+}
+
+// call executes msg on st without committing it. The sender's balance is raised to cover any value
+// and gas, like an eth_call.
+func (c *SimulatedForkClient) call(ctx context.Context, msg ethereum.CallMsg, header *types.Header, st *state.StateDB) (*core.ExecutionResult, error) {
+	orZero := func(vs ...*big.Int) *big.Int {
+		for _, v := range vs {
+			if v != nil {
+				return v
+			}
+		}
+		return new(big.Int)
+	}
+	if msg.Gas == 0 {
+		msg.Gas = header.GasLimit
+	}
+	m := &core.Message{
+		From:              msg.From,
+		To:                msg.To,
+		Value:             orZero(msg.Value),
+		GasLimit:          msg.Gas,
+		GasPrice:          orZero(msg.GasPrice),
+		GasFeeCap:         orZero(msg.GasFeeCap, msg.GasPrice),
+		GasTipCap:         orZero(msg.GasTipCap, msg.GasPrice),
+		Data:              msg.Data,
+		AccessList:        msg.AccessList,
+		SkipAccountChecks: true,
+	}
+	st.SetBalance(msg.From, math.MaxBig256)
+
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, c.bc, nil), core.NewEVMTxContext(m), st, c.config, vm.Config{NoBaseFee: true})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			evm.Cancel()
+		case <-done:
+		}
+	}()
+	return core.ApplyMessage(evm, m, new(core.GasPool).AddGas(math.MaxUint64))
+}
+
+// executionError returns the error a geth node responds with to a failed call.
+func executionError(res *core.ExecutionResult) error {
+	if !errors.Is(res.Err, vm.ErrExecutionReverted) {
+		return &JsonError{Code: -32000, Message: res.Err.Error()}
+	}
+	msg := res.Err.Error()
+	if reason, err := abi.UnpackRevert(res.Revert()); err == nil {
+		msg += ": " + reason
+	}
+	return &JsonError{Code: 3, Message: msg, Data: hexutil.Encode(res.Revert())}
+}
+
+func (c *SimulatedForkClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	header, st, err := c.stateAt(toBlockNumber(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.call(ctx, msg, header, st)
+	if err != nil {
+		return nil, &JsonError{Code: -32000, Message: err.Error()}
+	}
+	if res.Failed() {
+		return nil, executionError(res)
+	}
+	return res.Return(), nil
+}
+
+// EstimateGas binary searches for the lowest gas limit msg succeeds with on the pending state.
+func (c *SimulatedForkClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	header, st, err := c.stateAt(rpc.PendingBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+	lo, hi := params.TxGas-1, header.GasLimit
+	if msg.Gas >= params.TxGas {
+		hi = msg.Gas
+	}
+	execute := func(gas uint64) (*core.ExecutionResult, error) {
+		msg.Gas = gas
+		res, err2 := c.call(ctx, msg, header, st.Copy())
+		if errors.Is(err2, core.ErrIntrinsicGas) {
+			return &core.ExecutionResult{Err: err2}, nil
+		}
+		return res, err2
+	}
+	res, err := execute(hi)
+	if err != nil {
+		return 0, &JsonError{Code: -32000, Message: err.Error()}
+	}
+	if res.Failed() {
+		if errors.Is(res.Err, vm.ErrExecutionReverted) {
+			return 0, executionError(res)
+		}
+		return 0, errors.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		if res, err = execute(mid); err != nil {
+			return 0, err
+		}
+		if res.Failed() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil
+}
+
+// SuggestGasPrice returns the base fee of the pending block, plus the suggested tip.
+func (c *SimulatedForkClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tip, _ := c.SuggestGasTipCap(ctx)
+	if c.pendingHeader.BaseFee == nil {
+		return tip, nil
+	}
+	return new(big.Int).Add(c.pendingHeader.BaseFee, tip), nil
+}
+
+// SuggestGasTipCap returns a minimal tip, since every pending transaction is mined.
+func (c *SimulatedForkClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+// FilterLogs returns the logs of mined blocks matching q.
+func (c *SimulatedForkClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var headers []*types.Header
+	if q.BlockHash != nil {
+		header := c.bc.GetHeaderByHash(*q.BlockHash)
+		if header == nil {
+			return nil, errors.New("unknown block")
+		}
+		headers = append(headers, header)
+	} else {
+		latest := c.bc.CurrentBlock().Number.Uint64()
+		from, to := latest, latest
+		if q.FromBlock != nil && q.FromBlock.Sign() >= 0 {
+			from = q.FromBlock.Uint64()
+		}
+		if q.ToBlock != nil && q.ToBlock.Sign() >= 0 && q.ToBlock.Uint64() < latest {
+			to = q.ToBlock.Uint64()
+		}
+		for n := from; n <= to; n++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			headers = append(headers, c.bc.GetHeaderByNumber(n))
+		}
+	}
+
+	var logs []types.Log
+	for _, header := range headers {
+		for _, receipt := range c.bc.GetReceiptsByHash(header.Hash()) {
+			for _, log := range receipt.Logs {
+				if matchesFilter(q, log) {
+					logs = append(logs, *log)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+func matchesFilter(q ethereum.FilterQuery, log *types.Log) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, addr := range q.Addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(q.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range q.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range topics {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SubscribeFilterLogs streams the logs of newly mined blocks matching q.
+func (c *SimulatedForkClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	chLogs := make(chan []*types.Log)
+	sub := c.bc.SubscribeLogsEvent(chLogs)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case logs := <-chLogs:
+				for _, log := range logs {
+					if !matchesFilter(q, log) {
+						continue
+					}
+					select {
+					case ch <- *log:
+					case <-quit:
+						return nil
+					}
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// SubscribeNewHead streams the heads of newly mined blocks.
+func (c *SimulatedForkClient) SubscribeNewHead(ctx context.Context, ch chan<- *evmtypes.Head) (ethereum.Subscription, error) {
+	chHeads := make(chan core.ChainHeadEvent)
+	sub := c.bc.SubscribeChainHeadEvent(chHeads)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-chHeads:
+				select {
+				case ch <- c.newHead(ev.Block.Header()):
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// CallContext answers the RPC methods used by the node the way a geth node would, by encoding the
+// response as JSON and decoding it into result.
+func (c *SimulatedForkClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	resp, err := c.handleRPC(ctx, method, args)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode %s response", method)
+	}
+	return json.Unmarshal(b, result)
+}
+
+func (c *SimulatedForkClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b[i].Error = c.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func (c *SimulatedForkClient) BatchCallContextAll(ctx context.Context, b []rpc.BatchElem) error {
+	return c.BatchCallContext(ctx, b)
+}
+
+// forkCallArgs are the arguments of an eth_call.
+type forkCallArgs struct {
+	From     *common.Address `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     *hexutil.Bytes  `json:"data"`
+	Input    *hexutil.Bytes  `json:"input"`
+}
+
+func (a forkCallArgs) callMsg() ethereum.CallMsg {
+	var msg ethereum.CallMsg
+	if a.From != nil {
+		msg.From = *a.From
+	}
+	msg.To = a.To
+	if a.Gas != nil {
+		msg.Gas = uint64(*a.Gas)
+	}
+	msg.GasPrice = a.GasPrice.ToInt()
+	msg.Value = a.Value.ToInt()
+	if a.Input != nil {
+		msg.Data = *a.Input
+	} else if a.Data != nil {
+		msg.Data = *a.Data
+	}
+	return msg
+}
+
+// decodeArg decodes the i-th RPC argument into v, as the node would receive it over the wire.
+func decodeArg(args []interface{}, i int, v interface{}) error {
+	if i >= len(args) {
+		return errors.Errorf("missing argument %d", i)
+	}
+	if n, ok := args[i].(*big.Int); ok {
+		if bn, ok2 := v.(*rpc.BlockNumber); ok2 {
+			*bn = rpc.BlockNumber(n.Int64())
+			return nil
+		}
+	}
+	b, err := json.Marshal(args[i])
+	if err != nil {
+		return errors.Wrapf(err, "invalid argument %d", i)
+	}
+	return errors.Wrapf(json.Unmarshal(b, v), "invalid argument %d", i)
+}
+
+// decodeOptionalBlock decodes the i-th RPC argument as a block number, defaulting to latest.
+func decodeOptionalBlock(args []interface{}, i int) (rpc.BlockNumber, error) {
+	number := rpc.LatestBlockNumber
+	if i >= len(args) {
+		return number, nil
+	}
+	err := decodeArg(args, i, &number)
+	return number, err
+}
+
+// jsonFields returns the JSON object v encodes to, so that fields can be added to it.
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	return fields, json.Unmarshal(b, &fields)
+}
+
+func (c *SimulatedForkClient) rpcTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber uint64, index uint64) (map[string]interface{}, error) {
+	fields, err := jsonFields(tx)
+	if err != nil {
+		return nil, err
+	}
+	from, _ := types.Sender(c.signer, tx)
+	fields["from"] = from
+	if blockHash != (common.Hash{}) {
+		fields["blockHash"] = blockHash
+		fields["blockNumber"] = hexutil.Uint64(blockNumber)
+		fields["transactionIndex"] = hexutil.Uint64(index)
+	}
+	return fields, nil
+}
+
+func (c *SimulatedForkClient) rpcBlock(block *types.Block, fullTx bool) (map[string]interface{}, error) {
+	if block == nil {
+		return nil, nil
+	}
+	fields, err := jsonFields(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]interface{}, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		if !fullTx {
+			txs[i] = tx.Hash()
+		} else if txs[i], err = c.rpcTransaction(tx, block.Hash(), block.NumberU64(), uint64(i)); err != nil {
+			return nil, err
+		}
+	}
+	fields["transactions"] = txs
+	fields["uncles"] = []common.Hash{}
+	fields["size"] = hexutil.Uint64(block.Size())
+	if td := c.bc.GetTd(block.Hash(), block.NumberU64()); td != nil {
+		fields["totalDifficulty"] = (*hexutil.Big)(td)
+	}
+	return fields, nil
+}
+
+func (c *SimulatedForkClient) handleRPC(ctx context.Context, method string, args []interface{}) (interface{}, error) {
+	switch method {
+	case "eth_chainId":
+		return (*hexutil.Big)(c.chainID), nil
+	case "eth_blockNumber":
+		return hexutil.Uint64(c.bc.CurrentBlock().Number.Uint64()), nil
+	case "eth_gasPrice":
+		price, err := c.SuggestGasPrice(ctx)
+		return (*hexutil.Big)(price), err
+	case "eth_getBalance", "eth_getTransactionCount":
+		var account common.Address
+		if err := decodeArg(args, 0, &account); err != nil {
+			return nil, err
+		}
+		number, err := decodeOptionalBlock(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		_, st, err := c.stateAt(number)
+		if err != nil {
+			return nil, err
+		}
+		if method == "eth_getBalance" {
+			return (*hexutil.Big)(st.GetBalance(account)), nil
+		}
+		return hexutil.Uint64(st.GetNonce(account)), nil
+	case "eth_getBlockByNumber", "eth_getBlockByHash":
+		var fullTx bool
+		if len(args) > 1 {
+			if err := decodeArg(args, 1, &fullTx); err != nil {
+				return nil, err
+			}
+		}
+		var block *types.Block
+		var err error
+		if method == "eth_getBlockByHash" {
+			var hash common.Hash
+			if err = decodeArg(args, 0, &hash); err != nil {
+				return nil, err
+			}
+			block, err = c.BlockByHash(ctx, hash)
+		} else {
+			var number rpc.BlockNumber
+			if err = decodeArg(args, 0, &number); err != nil {
+				return nil, err
+			}
+			block, err = c.BlockByNumber(ctx, big.NewInt(number.Int64()))
+		}
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return c.rpcBlock(block, fullTx)
+	case "eth_getHeaderByNumber":
+		var number rpc.BlockNumber
+		if err := decodeArg(args, 0, &number); err != nil {
+			return nil, err
+		}
+		header, err := c.blockHeader(number)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		return header, err
+	case "eth_getTransactionByHash":
+		var hash common.Hash
+		if err := decodeArg(args, 0, &hash); err != nil {
+			return nil, err
+		}
+		if tx, blockHash, blockNumber, index := rawdb.ReadTransaction(c.db, hash); tx != nil {
+			return c.rpcTransaction(tx, blockHash, blockNumber, index)
+		}
+		tx, err := c.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return c.rpcTransaction(tx, common.Hash{}, 0, 0)
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		if err := decodeArg(args, 0, &hash); err != nil {
+			return nil, err
+		}
+		receipt, err := c.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		return receipt, err
+	case "eth_call":
+		var callArgs forkCallArgs
+		if err := decodeArg(args, 0, &callArgs); err != nil {
+			return nil, err
+		}
+		number, err := decodeOptionalBlock(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := c.CallContract(ctx, callArgs.callMsg(), big.NewInt(number.Int64()))
+		return hexutil.Bytes(b), err
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		if err := decodeArg(args, 0, &raw); err != nil {
+			return nil, err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, errors.Wrap(err, "failed to decode transaction")
+		}
+		return tx.Hash(), c.SendTransaction(ctx, tx)
+	default:
+		return nil, errors.Errorf("method %s is not supported by the simulated fork", method)
+	}
+}
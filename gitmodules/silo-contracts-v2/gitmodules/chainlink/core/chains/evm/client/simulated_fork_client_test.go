@@ -0,0 +1,202 @@
+package client_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clienttypes "github.com/smartcontractkit/chainlink/v2/common/chains/client"
+	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// returns storage slot 0: PUSH1 0 SLOAD PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+var slotZeroCode = hexutil.MustDecode("0x60005460005260206000f3")
+
+func writeSnapshot(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestLoadStateSnapshot(t *testing.T) {
+	t.Parallel()
+
+	const alloc = `{"0x0000000000000000000000000000000000000001": {"balance": "0x64"}}`
+
+	t.Run("alloc", func(t *testing.T) {
+		loaded, err := evmclient.LoadStateSnapshot(writeSnapshot(t, alloc))
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		assert.Equal(t, big.NewInt(100), loaded[common.HexToAddress("0x1")].Balance)
+	})
+
+	t.Run("genesis", func(t *testing.T) {
+		loaded, err := evmclient.LoadStateSnapshot(writeSnapshot(t, `{"gasLimit": "0x1c9c380", "alloc": `+alloc+`}`))
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		assert.Equal(t, big.NewInt(100), loaded[common.HexToAddress("0x1")].Balance)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := evmclient.LoadStateSnapshot(writeSnapshot(t, `not json`))
+		require.ErrorContains(t, err, "failed to decode state snapshot")
+
+		_, err = evmclient.LoadStateSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+		require.ErrorContains(t, err, "failed to read state snapshot")
+	})
+}
+
+func TestSimulatedForkClient(t *testing.T) {
+	t.Parallel()
+
+	account := testutils.NewAddress()
+	contract := testutils.NewAddress()
+	alloc := core.GenesisAlloc{
+		account: {Balance: big.NewInt(1e18)},
+		contract: {
+			Code:    slotZeroCode,
+			Storage: map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(42))},
+		},
+	}
+
+	client, err := evmclient.NewSimulatedForkClient(logger.TestLogger(t), testutils.SimulatedChainID, alloc, 30_000_000, 100*time.Millisecond)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	ctx := testutils.Context(t)
+
+	balance, err := client.BalanceAt(ctx, account, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1e18), balance)
+
+	code, err := client.CodeAt(ctx, contract, nil)
+	require.NoError(t, err)
+	assert.Equal(t, slotZeroCode, code)
+
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &contract}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), new(big.Int).SetBytes(out))
+
+	head, err := client.HeadByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), head.Number)
+
+	// blocks are mined once dialed
+	require.NoError(t, client.Dial(ctx))
+	gomega.NewWithT(t).Eventually(func() int64 {
+		head, err := client.HeadByNumber(ctx, nil)
+		require.NoError(t, err)
+		return head.Number
+	}, testutils.WaitTimeout(t), testutils.TestInterval).Should(gomega.BeNumerically(">=", 2))
+}
+
+func TestSimulatedForkClient_ManualCommit(t *testing.T) {
+	t.Parallel()
+
+	client, err := evmclient.NewSimulatedForkClient(logger.TestLogger(t), testutils.SimulatedChainID, core.GenesisAlloc{}, 30_000_000, 0)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	ctx := testutils.Context(t)
+	require.NoError(t, client.Dial(ctx))
+
+	require.NoError(t, client.Commit())
+	head, err := client.HeadByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), head.Number)
+}
+
+func TestSimulatedForkClient_SendTransaction(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := testutils.NewAddress()
+	chainID := testutils.NewRandomEVMChainID()
+
+	client, err := evmclient.NewSimulatedForkClient(logger.TestLogger(t), chainID, core.GenesisAlloc{from: {Balance: big.NewInt(1e18)}}, 30_000_000, 0)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	ctx := testutils.Context(t)
+
+	id, err := client.ChainID()
+	require.NoError(t, err)
+	assert.Equal(t, chainID, id)
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+
+	newTx := func(signChainID *big.Int, nonce uint64, price *big.Int) *types.Transaction {
+		return types.MustSignNewTx(key, types.LatestSignerForChainID(signChainID), &types.LegacyTx{
+			Nonce: nonce, GasPrice: price, Gas: 21_000, To: &to, Value: big.NewInt(1),
+		})
+	}
+
+	tx := newTx(chainID, 0, gasPrice)
+	require.NoError(t, client.SendTransaction(ctx, tx))
+	code, err := client.SendTransactionReturnCode(ctx, tx, from)
+	require.Error(t, err)
+	assert.Equal(t, clienttypes.Successful, code, "already known")
+
+	// gas bumped transactions replace the pending one
+	bumped := newTx(chainID, 0, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+	require.NoError(t, client.SendTransaction(ctx, bumped))
+
+	require.ErrorContains(t, client.SendTransaction(ctx, newTx(big.NewInt(1337), 1, gasPrice)), "invalid chain id")
+	require.ErrorIs(t, client.SendTransaction(ctx, newTx(chainID, 5, gasPrice)), core.ErrNonceTooHigh)
+
+	nonce, err := client.PendingNonceAt(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+	pending, err := client.HeadByNumber(ctx, big.NewInt(int64(rpc.PendingBlockNumber)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pending.Number)
+
+	require.NoError(t, client.Commit())
+
+	height, err := client.LatestBlockHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), height)
+	_, err = client.TransactionReceipt(ctx, tx.Hash())
+	require.ErrorIs(t, err, ethereum.NotFound)
+	receipt, err := client.TransactionReceipt(ctx, bumped.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	// the receipt is also served over RPC, as a node would serve it
+	var rpcReceipt evmtypes.Receipt
+	require.NoError(t, client.CallContext(ctx, &rpcReceipt, "eth_getTransactionReceipt", bumped.Hash()))
+	assert.Equal(t, bumped.Hash(), rpcReceipt.TxHash)
+	assert.Equal(t, receipt.BlockHash, rpcReceipt.BlockHash)
+
+	code, err = client.SendTransactionReturnCode(ctx, tx, from)
+	require.ErrorIs(t, err, core.ErrNonceTooLow)
+	assert.Equal(t, clienttypes.TransactionAlreadyKnown, code)
+
+	balance, err := client.BalanceAt(ctx, to, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), balance)
+
+	reqs := []rpc.BatchElem{
+		{Method: "eth_getBlockByNumber", Args: []interface{}{"latest", false}, Result: &evmtypes.Head{}},
+		{Method: "debug_traceTransaction", Args: []interface{}{bumped.Hash()}, Result: new(interface{})},
+	}
+	require.NoError(t, client.BatchCallContext(ctx, reqs))
+	require.NoError(t, reqs[0].Error)
+	assert.Equal(t, int64(1), reqs[0].Result.(*evmtypes.Head).Number)
+	require.ErrorContains(t, reqs[1].Error, "not supported")
+}
@@ -459,6 +459,51 @@ func Test_chainScopedConfig_Validate(t *testing.T) {
 	})
 }
 
+func Test_chainScopedConfig_Validate_SimulatedFork(t *testing.T) {
+	configWithFork := func(t *testing.T, id int64, fork toml.SimulatedFork, nodes toml.EVMNodes) config.AppConfig {
+		return configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+			chainID := utils.NewBigI(id)
+			c.EVM[0] = &toml.EVMConfig{ChainID: chainID, Enabled: ptr(true), Chain: toml.Defaults(chainID),
+				SimulatedFork: fork, Nodes: nodes}
+		})
+	}
+	second := models.MustMakeDuration(time.Second)
+	validFork := toml.SimulatedFork{
+		Enabled:      ptr(true),
+		SnapshotFile: ptr("snapshot.json"),
+		BlockTime:    &second,
+		GasLimit:     ptr[uint64](30_000_000),
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := configWithFork(t, 1337, validFork, nil)
+		assert.NoError(t, cfg.Validate())
+		assert.True(t, cfg.EVMRPCEnabled())
+	})
+
+	t.Run("any chain ID", func(t *testing.T) {
+		cfg := configWithFork(t, 1, validFork, nil)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("nodes", func(t *testing.T) {
+		cfg := configWithFork(t, 1337, validFork, toml.EVMNodes{{
+			Name:    ptr("fake"),
+			WSURL:   models.MustParseURL("wss://foo.test/ws"),
+			HTTPURL: models.MustParseURL("http://foo.test"),
+		}})
+		assert.ErrorContains(t, cfg.Validate(), "must not be set when SimulatedFork is enabled")
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		cfg := configWithFork(t, 1337, toml.SimulatedFork{Enabled: ptr(true)}, nil)
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "SimulatedFork.SnapshotFile: missing: required when enabled")
+		assert.ErrorContains(t, err, "SimulatedFork.BlockTime: missing: required when enabled")
+		assert.ErrorContains(t, err, "SimulatedFork.GasLimit: missing: required when enabled")
+	})
+}
+
 func TestNodePoolConfig(t *testing.T) {
 	gcfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
 		id := utils.NewBig(big.NewInt(rand.Int63()))
@@ -253,7 +253,8 @@ type EVMConfig struct {
 	ChainID *utils.Big
 	Enabled *bool
 	Chain
-	Nodes EVMNodes
+	SimulatedFork SimulatedFork `toml:",omitempty"`
+	Nodes         EVMNodes
 }
 
 func (c *EVMConfig) IsEnabled() bool {
@@ -268,6 +269,7 @@ func (c *EVMConfig) SetFrom(f *EVMConfig) {
 		c.Enabled = f.Enabled
 	}
 	c.Chain.SetFrom(&f.Chain)
+	c.SimulatedFork.setFrom(&f.SimulatedFork)
 	c.Nodes.SetFrom(&f.Nodes)
 }
 
@@ -294,7 +296,12 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 		}
 	}
 
-	if len(c.Nodes) == 0 {
+	if c.SimulatedFork.IsEnabled() {
+		if len(c.Nodes) > 0 {
+			err = multierr.Append(err, configutils.ErrInvalid{Name: "Nodes", Value: len(c.Nodes),
+				Msg: "must not be set when SimulatedFork is enabled"})
+		}
+	} else if len(c.Nodes) == 0 {
 		err = multierr.Append(err, configutils.ErrMissing{Name: "Nodes", Msg: "must have at least one node"})
 	} else {
 		var hasPrimary bool
@@ -324,6 +331,57 @@ func (c *EVMConfig) TOMLString() (string, error) {
 	return string(b), nil
 }
 
+// SimulatedFork runs the chain on an in-process simulated chain, forked from a state snapshot, instead of RPC nodes.
+type SimulatedFork struct {
+	Enabled      *bool
+	SnapshotFile *string
+	BlockTime    *models.Duration
+	GasLimit     *uint64
+}
+
+func (s *SimulatedFork) IsEnabled() bool {
+	return s.Enabled != nil && *s.Enabled
+}
+
+func (s *SimulatedFork) setFrom(f *SimulatedFork) {
+	if v := f.Enabled; v != nil {
+		s.Enabled = v
+	}
+	if v := f.SnapshotFile; v != nil {
+		s.SnapshotFile = v
+	}
+	if v := f.BlockTime; v != nil {
+		s.BlockTime = v
+	}
+	if v := f.GasLimit; v != nil {
+		s.GasLimit = v
+	}
+}
+
+func (s *SimulatedFork) ValidateConfig() (err error) {
+	if !s.IsEnabled() {
+		return
+	}
+	if s.SnapshotFile == nil {
+		err = multierr.Append(err, configutils.ErrMissing{Name: "SnapshotFile", Msg: "required when enabled"})
+	} else if *s.SnapshotFile == "" {
+		err = multierr.Append(err, configutils.ErrEmpty{Name: "SnapshotFile", Msg: "required when enabled"})
+	}
+	if s.BlockTime == nil {
+		err = multierr.Append(err, configutils.ErrMissing{Name: "BlockTime", Msg: "required when enabled"})
+	} else if s.BlockTime.Duration() <= 0 {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "BlockTime", Value: s.BlockTime.String(),
+			Msg: "must be greater than zero"})
+	}
+	if s.GasLimit == nil {
+		err = multierr.Append(err, configutils.ErrMissing{Name: "GasLimit", Msg: "required when enabled"})
+	} else if *s.GasLimit == 0 {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "GasLimit", Value: *s.GasLimit,
+			Msg: "must be greater than zero"})
+	}
+	return
+}
+
 type Chain struct {
 	AutoCreateKey            *bool
 	BlockBackfillDepth       *uint32
@@ -5,14 +5,29 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
+// MaxHops is the maximum number of forwarders a transaction can be routed through.
+const MaxHops = 4
+
 // Forwarder is the struct for Forwarder Addresses
 type Forwarder struct {
 	ID         int64
 	Address    common.Address
 	EVMChainID utils.Big
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	Type       Type
+	// NextHop is the forwarder the forwarder calls, instead of the destination of the transaction.
+	NextHop *common.Address
+	// StopRoutingOnDrift disables routing through the forwarder for keys it no longer authorizes.
+	StopRoutingOnDrift bool
+	// AuthorizedSenders are the enabled keys the forwarder authorized at the last health check.
+	AuthorizedSenders models.AddressCollection
+	// DriftedSenders are enabled keys the forwarder authorized at an earlier health check, but not
+	// since. Keys it never authorized are not drifted, as they may not be meant for forwarding.
+	DriftedSenders  models.AddressCollection
+	HealthCheckedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
@@ -0,0 +1,197 @@
+package forwarders
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// healthCheckInterval is how often forwarder authorizations are compared against the enabled keys.
+var healthCheckInterval = 5 * time.Minute
+
+// KeyStore lists the keys the forwarders are expected to authorize, and signs the requests of
+// forwarders relaying signed calls.
+type KeyStore interface {
+	EnabledAddressesForChain(chainID *big.Int) (addresses []common.Address, err error)
+	Get(id string) (ethkey.KeyV2, error)
+}
+
+func (f *FwdMgr) healthCheckLoop() {
+	defer f.wg.Done()
+	tick := time.After(0)
+
+	for ; ; tick = time.After(utils.WithJitter(healthCheckInterval)) {
+		select {
+		case <-tick:
+			f.checkHealth(f.ctx)
+		case <-f.ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth compares each forwarder's authorized senders against the enabled keys it authorized
+// before, and checks that the next hops of forwarders authorize them.
+func (f *FwdMgr) checkHealth(ctx context.Context) {
+	chainID := f.evmClient.ConfiguredChainID()
+	keys, err := f.keyStore.EnabledAddressesForChain(chainID)
+	if err != nil {
+		f.logger.Errorw("Skipping forwarder health check, failed to load enabled keys", "err", err)
+		return
+	}
+	fwdrs, err := f.loadForwarders()
+	if err != nil {
+		f.logger.Errorw("Skipping forwarder health check, failed to load forwarders", "err", err)
+		return
+	}
+
+	byAddress := make(map[common.Address]Forwarder, len(fwdrs))
+	for _, fwdr := range fwdrs {
+		byAddress[fwdr.Address] = fwdr
+	}
+	health := make(map[common.Address]error, len(fwdrs))
+	for _, fwdr := range fwdrs {
+		err = f.checkForwarderHealth(ctx, fwdr, keys, isNextHop(fwdrs, fwdr.Address))
+		if err == nil && fwdr.NextHop != nil {
+			err = f.checkNextHopHealth(ctx, fwdr, byAddress)
+		}
+		health[fwdr.Address] = err
+	}
+
+	f.healthMu.Lock()
+	defer f.healthMu.Unlock()
+	f.health = health
+}
+
+// checkForwarderHealth checks that fwdr still authorizes the enabled keys it authorized at earlier
+// checks. Keys it no longer authorizes are recorded as drifted until it authorizes them again, keys
+// it never authorized are ignored. Forwarders which are the next hop of another forwarder authorize
+// forwarders instead of keys, so their keys are not checked.
+func (f *FwdMgr) checkForwarderHealth(ctx context.Context, fwdr Forwarder, keys []common.Address, nextHop bool) error {
+	var authorizedKeys, drifted []common.Address
+	if !nextHop {
+		authorized, err := f.authorizedKeys(ctx, fwdr, keys)
+		if err != nil {
+			f.logger.Warnw("Failed to check forwarder authorized senders", "forwarder", fwdr.Address, "type", fwdr.Type, "err", err)
+			return errors.Wrapf(err, "failed to check authorized senders of forwarder %s", fwdr.Address)
+		}
+		for _, key := range keys {
+			if _, ok := authorized[key]; ok {
+				authorizedKeys = append(authorizedKeys, key)
+			} else if wasAuthorized(fwdr, key) || isDrifted(fwdr, key) {
+				drifted = append(drifted, key)
+			}
+		}
+	}
+
+	if err := f.ORM.UpdateForwarderHealth(fwdr.ID, authorizedKeys, drifted); err != nil {
+		f.logger.Errorw("Failed to record forwarder health", "forwarder", fwdr.Address, "err", err)
+	} else {
+		wasDrifted := len(fwdr.DriftedSenders) > 0
+		fwdr.AuthorizedSenders = authorizedKeys
+		fwdr.DriftedSenders = drifted
+		f.setCachedForwarder(fwdr)
+		if wasDrifted && len(drifted) == 0 {
+			f.logger.Infow("Forwarder authorizes all its keys again", "forwarder", fwdr.Address)
+		}
+	}
+
+	if len(drifted) > 0 {
+		f.logger.Criticalw("Forwarder no longer authorizes keys", "forwarder", fwdr.Address, "type", fwdr.Type,
+			"driftedSenders", drifted, "stopRoutingOnDrift", fwdr.StopRoutingOnDrift)
+		return errors.Errorf("forwarder %s no longer authorizes keys %v", fwdr.Address, drifted)
+	}
+	return nil
+}
+
+// checkNextHopHealth checks that the next hop of fwdr authorizes it.
+func (f *FwdMgr) checkNextHopHealth(ctx context.Context, fwdr Forwarder, fwdrs map[common.Address]Forwarder) error {
+	next, ok := fwdrs[*fwdr.NextHop]
+	if !ok {
+		return errors.Errorf("next hop %s of forwarder %s is not tracked", fwdr.NextHop, fwdr.Address)
+	}
+	authorized, err := f.authorizedKeys(ctx, next, []common.Address{fwdr.Address})
+	if err != nil {
+		return errors.Wrapf(err, "failed to check authorized senders of next hop %s", next.Address)
+	}
+	if _, ok := authorized[fwdr.Address]; !ok {
+		f.logger.Criticalw("Next hop no longer authorizes forwarder", "forwarder", fwdr.Address, "nextHop", next.Address, "type", next.Type)
+		return errors.Errorf("next hop %s does not authorize forwarder %s", next.Address, fwdr.Address)
+	}
+	return nil
+}
+
+// authorizedKeys returns the subset of keys authorized to send through fwdr, bypassing the senders cache.
+func (f *FwdMgr) authorizedKeys(ctx context.Context, fwdr Forwarder, keys []common.Address) (map[common.Address]struct{}, error) {
+	enc, err := EncoderFor(fwdr.Type)
+	if err != nil {
+		return nil, err
+	}
+	authorized := make(map[common.Address]struct{})
+	if authorizer, ok := enc.(SenderAuthorizer); ok {
+		for _, key := range keys {
+			ok, err := authorizer.IsAuthorizedSender(ctx, f.evmClient, fwdr.Address, key)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				authorized[key] = struct{}{}
+			}
+		}
+		return authorized, nil
+	}
+
+	senders, err := f.getAuthorizedSenders(ctx, fwdr.Address)
+	if err != nil {
+		return nil, err
+	}
+	f.setCachedSenders(fwdr.Address, senders)
+	for _, sender := range senders {
+		authorized[sender] = struct{}{}
+	}
+	return authorized, nil
+}
+
+func (f *FwdMgr) healthReport() map[string]error {
+	f.healthMu.RLock()
+	defer f.healthMu.RUnlock()
+	report := make(map[string]error, len(f.health))
+	for addr, err := range f.health {
+		report[fmt.Sprintf("%s.%s", f.Name(), addr)] = err
+	}
+	return report
+}
+
+func isNextHop(fwdrs []Forwarder, addr common.Address) bool {
+	for _, fwdr := range fwdrs {
+		if fwdr.NextHop != nil && *fwdr.NextHop == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func wasAuthorized(fwdr Forwarder, sender common.Address) bool {
+	for _, authorized := range fwdr.AuthorizedSenders {
+		if authorized == sender {
+			return true
+		}
+	}
+	return false
+}
+
+func isDrifted(fwdr Forwarder, sender common.Address) bool {
+	for _, drifted := range fwdr.DriftedSenders {
+		if drifted == sender {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package forwarders
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	evmclimocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_receiver"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+var getAuthorizedSendersABI = evmtypes.MustGetABI(authorized_receiver.AuthorizedReceiverABI).Methods["getAuthorizedSenders"]
+
+type fakeConfig struct{}
+
+func (fakeConfig) FinalityDepth() uint32 { return 1 }
+
+type fakeKeyStore []common.Address
+
+func (ks fakeKeyStore) EnabledAddressesForChain(*big.Int) ([]common.Address, error) {
+	return ks, nil
+}
+
+func (ks fakeKeyStore) Get(id string) (ethkey.KeyV2, error) {
+	return ethkey.KeyV2{}, errors.Errorf("unable to find eth key with id %s", id)
+}
+
+func TestFwdMgr_CheckHealth(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	lggr, observed := logger.TestLoggerObserved(t, zapcore.DPanicLevel)
+	ethClient := evmclimocks.NewClient(t)
+	ethClient.On("ConfiguredChainID").Return(testutils.FixtureChainID).Maybe()
+
+	k1, k2 := testutils.NewAddress(), testutils.NewAddress()
+	fwdMgr := NewFwdMgr(db, ethClient, nil, fakeKeyStore{k1, k2}, lggr, fakeConfig{}, pgtest.NewQConfig(true))
+
+	fwdAddr := testutils.NewAddress()
+	_, err := fwdMgr.ORM.CreateForwarder(fwdAddr, utils.Big(*testutils.FixtureChainID), TypeAuthorizedForwarder, nil, false)
+	require.NoError(t, err)
+
+	authorize := func(senders ...common.Address) {
+		out, err := getAuthorizedSendersABI.Outputs.Pack(senders)
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(out, nil).Once()
+	}
+	findForwarder := func() Forwarder {
+		fwdrs, err := fwdMgr.ORM.FindForwardersInListByChain(utils.Big(*testutils.FixtureChainID), []common.Address{fwdAddr})
+		require.NoError(t, err)
+		require.Len(t, fwdrs, 1)
+		return fwdrs[0]
+	}
+	healthErr := func() error {
+		return fwdMgr.HealthReport()[fwdMgr.Name()+"."+fwdAddr.String()]
+	}
+
+	// k1 is not meant for forwarding, so it is not drifted
+	authorize(k2)
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.NoError(t, healthErr())
+	fwd := findForwarder()
+	assert.Equal(t, []common.Address{k2}, []common.Address(fwd.AuthorizedSenders))
+	assert.Empty(t, fwd.DriftedSenders)
+	assert.NotNil(t, fwd.HealthCheckedAt)
+
+	// both keys authorized
+	authorize(k1, k2)
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.NoError(t, healthErr())
+	assert.Equal(t, []common.Address{k1, k2}, []common.Address(findForwarder().AuthorizedSenders))
+
+	// k1 loses its authorization
+	authorize(k2)
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.ErrorContains(t, healthErr(), "no longer authorizes keys")
+	assert.Equal(t, []common.Address{k1}, []common.Address(findForwarder().DriftedSenders))
+	assert.Equal(t, 1, observed.FilterMessage("Forwarder no longer authorizes keys").Len())
+
+	// routing continues unless the forwarder opts in to stopping
+	_, err = fwdMgr.ConvertPayload(k1, fwdAddr, testutils.NewAddress(), []byte{0x01}, 100_000)
+	require.NoError(t, err)
+
+	// k1 stays drifted until it is authorized again
+	authorize(k2)
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.ErrorContains(t, healthErr(), "no longer authorizes keys")
+	assert.Equal(t, []common.Address{k1}, []common.Address(findForwarder().DriftedSenders))
+
+	authorize(k1, k2)
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.NoError(t, healthErr())
+	assert.Empty(t, findForwarder().DriftedSenders)
+	assert.Equal(t, 1, observed.FilterMessage("Forwarder authorizes all its keys again").Len())
+
+	// none authorized
+	authorize(testutils.NewAddress())
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.ErrorContains(t, healthErr(), "no longer authorizes keys")
+	assert.ElementsMatch(t, []common.Address{k1, k2}, []common.Address(findForwarder().DriftedSenders))
+}
+
+func TestFwdMgr_CheckHealth_StopRoutingOnDrift(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	ethClient := evmclimocks.NewClient(t)
+	ethClient.On("ConfiguredChainID").Return(testutils.FixtureChainID).Maybe()
+
+	k1, k2 := testutils.NewAddress(), testutils.NewAddress()
+	fwdMgr := NewFwdMgr(db, ethClient, nil, fakeKeyStore{k1, k2}, logger.TestLogger(t), fakeConfig{}, pgtest.NewQConfig(true))
+	fwdAddr := testutils.NewAddress()
+	fwd, err := fwdMgr.ORM.CreateForwarder(fwdAddr, utils.Big(*testutils.FixtureChainID), TypeAuthorizedForwarder, nil, true)
+	require.NoError(t, err)
+	require.True(t, fwd.StopRoutingOnDrift)
+
+	authorize := func(senders ...common.Address) {
+		out, err := getAuthorizedSendersABI.Outputs.Pack(senders)
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(out, nil).Once()
+	}
+	authorize(k1, k2)
+	fwdMgr.checkHealth(testutils.Context(t))
+	authorize(k2)
+	fwdMgr.checkHealth(testutils.Context(t))
+
+	_, err = fwdMgr.ConvertPayload(k1, fwdAddr, testutils.NewAddress(), []byte{0x01}, 100_000)
+	require.ErrorContains(t, err, "does not authorize")
+	_, err = fwdMgr.ConvertPayload(k2, fwdAddr, testutils.NewAddress(), []byte{0x01}, 100_000)
+	require.NoError(t, err)
+}
+
+func TestFwdMgr_CheckHealth_NextHop(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	ethClient := evmclimocks.NewClient(t)
+	ethClient.On("ConfiguredChainID").Return(testutils.FixtureChainID).Maybe()
+
+	key := testutils.NewAddress()
+	fwdMgr := NewFwdMgr(db, ethClient, nil, fakeKeyStore{key}, logger.TestLogger(t), fakeConfig{}, pgtest.NewQConfig(true))
+	chainID := utils.Big(*testutils.FixtureChainID)
+	next, err := fwdMgr.ORM.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, nil, false)
+	require.NoError(t, err)
+	fwd, err := fwdMgr.ORM.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, &next.Address, false)
+	require.NoError(t, err)
+
+	authorize := func(senders ...common.Address) {
+		out, err := getAuthorizedSendersABI.Outputs.Pack(senders)
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(out, nil).Once()
+	}
+	report := func(addr common.Address) error {
+		return fwdMgr.HealthReport()[fwdMgr.Name()+"."+addr.String()]
+	}
+
+	// the key is checked on the first hop, and the first hop on the next hop
+	authorize(key)
+	authorize(fwd.Address)
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.NoError(t, report(fwd.Address))
+	assert.NoError(t, report(next.Address))
+
+	authorize(key)
+	authorize(testutils.NewAddress())
+	fwdMgr.checkHealth(testutils.Context(t))
+	assert.ErrorContains(t, report(fwd.Address), "does not authorize forwarder")
+	assert.NoError(t, report(next.Address))
+}
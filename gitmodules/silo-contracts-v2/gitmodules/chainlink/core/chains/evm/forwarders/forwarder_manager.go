@@ -2,12 +2,14 @@ package forwarders
 
 import (
 	"context"
+	"math/big"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/sqlx"
 
@@ -36,11 +38,18 @@ type FwdMgr struct {
 	cfg       Config
 	logger    logger.SugaredLogger
 	logpoller evmlogpoller.LogPoller
+	keyStore  KeyStore
 
 	// TODO(samhassan): sendersCache should be an LRU capped cache
 	// https://app.shortcut.com/chainlinklabs/story/37884/forwarder-manager-uses-lru-for-caching-dest-addresses
 	sendersCache map[common.Address][]common.Address
-	latestBlock  int64
+	// fwdrCache holds the forwarders tracked on the chain, by address
+	fwdrCache   map[common.Address]Forwarder
+	latestBlock int64
+
+	healthMu sync.RWMutex
+	// health is the result of the last health check, by forwarder address
+	health map[common.Address]error
 
 	authRcvr    authorized_receiver.AuthorizedReceiverInterface
 	offchainAgg offchain_aggregator_wrapper.OffchainAggregatorInterface
@@ -52,7 +61,7 @@ type FwdMgr struct {
 	wg      sync.WaitGroup
 }
 
-func NewFwdMgr(db *sqlx.DB, client evmclient.Client, logpoller evmlogpoller.LogPoller, l logger.Logger, cfg Config, dbConfig pg.QConfig) *FwdMgr {
+func NewFwdMgr(db *sqlx.DB, client evmclient.Client, logpoller evmlogpoller.LogPoller, keyStore KeyStore, l logger.Logger, cfg Config, dbConfig pg.QConfig) *FwdMgr {
 	lggr := logger.Sugared(l.Named("EVMForwarderManager"))
 	fwdMgr := FwdMgr{
 		logger:       lggr,
@@ -60,7 +69,10 @@ func NewFwdMgr(db *sqlx.DB, client evmclient.Client, logpoller evmlogpoller.LogP
 		evmClient:    client,
 		ORM:          NewORM(db, lggr, dbConfig),
 		logpoller:    logpoller,
+		keyStore:     keyStore,
 		sendersCache: make(map[common.Address][]common.Address),
+		fwdrCache:    make(map[common.Address]Forwarder),
+		health:       make(map[common.Address]error),
 		cacheMu:      sync.RWMutex{},
 		wg:           sync.WaitGroup{},
 		latestBlock:  0,
@@ -77,11 +89,9 @@ func (f *FwdMgr) Name() string {
 func (f *FwdMgr) Start(ctx context.Context) error {
 	return f.StartOnce("EVMForwarderManager", func() error {
 		f.logger.Debug("Initializing EVM forwarder manager")
-		chainId := f.evmClient.ConfiguredChainID()
-
-		fwdrs, err := f.ORM.FindForwardersByChain(utils.Big(*chainId))
+		fwdrs, err := f.loadForwarders()
 		if err != nil {
-			return errors.Wrapf(err, "Failed to retrieve forwarders for chain %d", chainId)
+			return err
 		}
 		if len(fwdrs) != 0 {
 			f.initForwardersCache(ctx, fwdrs)
@@ -100,8 +110,9 @@ func (f *FwdMgr) Start(ctx context.Context) error {
 			return errors.Wrap(err, "Failed to init OffchainAggregator")
 		}
 
-		f.wg.Add(1)
+		f.wg.Add(2)
 		go f.runLoop()
+		go f.healthCheckLoop()
 		return nil
 	})
 }
@@ -112,46 +123,139 @@ func FilterName(addr common.Address) string {
 
 func (f *FwdMgr) ForwarderFor(addr common.Address) (forwarder common.Address, err error) {
 	// Gets forwarders for current chain.
-	fwdrs, err := f.ORM.FindForwardersByChain(utils.Big(*f.evmClient.ConfiguredChainID()))
+	fwdrs, err := f.loadForwarders()
 	if err != nil {
 		return common.Address{}, err
 	}
 
 	for _, fwdr := range fwdrs {
-		eoas, err := f.getContractSenders(fwdr.Address)
+		authorized, err := f.isAuthorizedSender(fwdr, addr)
 		if err != nil {
-			f.logger.Errorw("Failed to get forwarder senders", "forwarder", fwdr.Address, "err", err)
+			f.logger.Errorw("Failed to get forwarder senders", "forwarder", fwdr.Address, "type", fwdr.Type, "err", err)
 			continue
 		}
-		for _, eoa := range eoas {
-			if eoa == addr {
-				return fwdr.Address, nil
-			}
+		if authorized {
+			return fwdr.Address, nil
 		}
 	}
 	return common.Address{}, errors.Errorf("Cannot find forwarder for given EOA")
 }
 
-func (f *FwdMgr) ConvertPayload(dest common.Address, origPayload []byte) ([]byte, error) {
-	databytes, err := f.getForwardedPayload(dest, origPayload)
+// ConvertPayload wraps origPayload, sent by from and destined for dest, in a call to forwarder,
+// encoded according to the forwarder's type. If the forwarder has a next hop, the call is routed
+// through each hop of the route in turn.
+func (f *FwdMgr) ConvertPayload(from, forwarder, dest common.Address, origPayload []byte, gasLimit uint32) ([]byte, error) {
+	route, err := f.route(forwarder)
 	if err != nil {
+		return nil, err
+	}
+	if route[0].StopRoutingOnDrift && isDrifted(route[0], from) {
+		return nil, errors.Errorf("Forwarder %s does not authorize %s", forwarder, from)
+	}
+
+	b := &backend{ContractCaller: f.evmClient, chainID: f.evmClient.ConfiguredChainID(), keyStore: f.keyStore}
+	payload := origPayload
+	for i := len(route) - 1; i >= 0; i-- {
+		req := EncodeRequest{From: from, Forwarder: route[i].Address, Dest: dest, Payload: payload, GasLimit: gasLimit}
+		if i > 0 {
+			req.From = route[i-1].Address
+		}
+		if i < len(route)-1 {
+			req.Dest = route[i+1].Address
+		}
+		enc, err := EncoderFor(route[i].Type)
+		if err != nil {
+			return nil, err
+		}
+		payload, err = enc.Encode(f.ctx, b, req)
 		if err != nil {
-			f.logger.AssumptionViolationw("Forwarder encoding failed, this should never happen",
-				"err", err, "to", dest, "payload", origPayload)
-			f.SvcErrBuffer.Append(err)
+			f.logger.Errorw("Forwarder encoding failed", "err", err, "to", req.Dest, "forwarder", req.Forwarder, "type", route[i].Type)
+			return nil, errors.Wrapf(err, "failed to encode call to forwarder %s", req.Forwarder)
 		}
 	}
-	return databytes, nil
+	return payload, nil
 }
 
-func (f *FwdMgr) getForwardedPayload(dest common.Address, origPayload []byte) ([]byte, error) {
-	callArgs, err := forwardABI.Inputs.Pack(dest, origPayload)
+// route returns the forwarders a call to forwarder goes through, following their next hops.
+func (f *FwdMgr) route(forwarder common.Address) ([]Forwarder, error) {
+	var route []Forwarder
+	for addr := &forwarder; addr != nil; {
+		if len(route) == MaxHops {
+			return nil, errors.Errorf("Route through forwarder %s has more than %d hops", forwarder, MaxHops)
+		}
+		fwdr, err := f.trackedForwarder(*addr)
+		if err != nil {
+			return nil, err
+		}
+		route = append(route, fwdr)
+		addr = fwdr.NextHop
+	}
+	return route, nil
+}
+
+// trackedForwarder returns the cached forwarder at addr, reloading the forwarders of the chain if it is
+// not cached yet.
+func (f *FwdMgr) trackedForwarder(addr common.Address) (Forwarder, error) {
+	if fwdr, ok := f.getCachedForwarder(addr); ok {
+		return fwdr, nil
+	}
+	if _, err := f.loadForwarders(); err != nil {
+		return Forwarder{}, err
+	}
+	if fwdr, ok := f.getCachedForwarder(addr); ok {
+		return fwdr, nil
+	}
+	return Forwarder{}, errors.Errorf("Forwarder %s is not tracked", addr)
+}
+
+// loadForwarders loads the forwarders tracked on the chain and replaces the cached ones.
+func (f *FwdMgr) loadForwarders() ([]Forwarder, error) {
+	chainID := f.evmClient.ConfiguredChainID()
+	fwdrs, err := f.ORM.FindForwardersByChain(utils.Big(*chainID))
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to pack forwarder payload")
+		return nil, errors.Wrapf(err, "Failed to retrieve forwarders for chain %d", chainID)
+	}
+	cache := make(map[common.Address]Forwarder, len(fwdrs))
+	for _, fwdr := range fwdrs {
+		cache[fwdr.Address] = fwdr
 	}
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+	f.fwdrCache = cache
+	return fwdrs, nil
+}
+
+func (f *FwdMgr) setCachedForwarder(fwdr Forwarder) {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+	f.fwdrCache[fwdr.Address] = fwdr
+}
 
-	dataBytes := append(forwardABI.ID, callArgs...)
-	return dataBytes, nil
+func (f *FwdMgr) getCachedForwarder(addr common.Address) (Forwarder, bool) {
+	f.cacheMu.RLock()
+	defer f.cacheMu.RUnlock()
+	fwdr, ok := f.fwdrCache[addr]
+	return fwdr, ok
+}
+
+func (f *FwdMgr) isAuthorizedSender(fwdr Forwarder, addr common.Address) (bool, error) {
+	enc, err := EncoderFor(fwdr.Type)
+	if err != nil {
+		return false, err
+	}
+	if authorizer, ok := enc.(SenderAuthorizer); ok {
+		return authorizer.IsAuthorizedSender(f.ctx, f.evmClient, fwdr.Address, addr)
+	}
+	eoas, err := f.getContractSenders(fwdr.Address)
+	if err != nil {
+		return false, err
+	}
+	for _, eoa := range eoas {
+		if eoa == addr {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (f *FwdMgr) getContractSenders(addr common.Address) ([]common.Address, error) {
@@ -184,6 +288,9 @@ func (f *FwdMgr) getAuthorizedSenders(ctx context.Context, addr common.Address)
 
 func (f *FwdMgr) initForwardersCache(ctx context.Context, fwdrs []Forwarder) {
 	for _, fwdr := range fwdrs {
+		if !tracksAuthorizedSenders(fwdr.Type) {
+			continue
+		}
 		senders, err := f.getAuthorizedSenders(ctx, fwdr.Address)
 		if err != nil {
 			f.logger.Warnw("Failed to call getAuthorizedSenders on forwarder", fwdr, "err", err)
@@ -196,6 +303,9 @@ func (f *FwdMgr) initForwardersCache(ctx context.Context, fwdrs []Forwarder) {
 
 func (f *FwdMgr) subscribeForwardersLogs(fwdrs []Forwarder) error {
 	for _, fwdr := range fwdrs {
+		if !tracksAuthorizedSenders(fwdr.Type) {
+			continue
+		}
 		if err := f.subscribeSendersChangedLogs(fwdr.Address); err != nil {
 			return err
 		}
@@ -238,6 +348,9 @@ func (f *FwdMgr) runLoop() {
 	for ; ; tick = time.After(utils.WithJitter(time.Duration(time.Minute))) {
 		select {
 		case <-tick:
+			if _, err := f.loadForwarders(); err != nil {
+				f.logger.Errorw("Failed to refresh forwarders", "err", err)
+			}
 			if err := f.logpoller.Ready(); err != nil {
 				f.logger.Warnw("Skipping log syncing", "err", err)
 				continue
@@ -322,5 +435,28 @@ func (f *FwdMgr) Close() error {
 }
 
 func (f *FwdMgr) HealthReport() map[string]error {
-	return map[string]error{f.Name(): f.StartStopOnce.Healthy()}
+	report := f.healthReport()
+	report[f.Name()] = f.StartStopOnce.Healthy()
+	return report
+}
+
+// backend gives the forwarder Encoders access to the chain and the keystore.
+type backend struct {
+	bind.ContractCaller
+	chainID  *big.Int
+	keyStore KeyStore
+}
+
+var _ Backend = (*backend)(nil)
+
+func (b *backend) ChainID() *big.Int {
+	return b.chainID
+}
+
+func (b *backend) SignHash(from common.Address, hash []byte) ([]byte, error) {
+	key, err := b.keyStore.Get(from.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, key.ToEcdsaPrivKey())
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_forwarder"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_receiver"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/operator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
@@ -59,10 +60,11 @@ func TestFwdMgr_MaybeForwardTransaction(t *testing.T) {
 
 	evmClient := client.NewSimulatedBackendClient(t, ec, testutils.FixtureChainID)
 	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), evmClient, lggr, 100*time.Millisecond, 2, 3, 2, 1000)
-	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, lggr, evmcfg.EVM(), evmcfg.Database())
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg.Database()).Eth()
+	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, ethKeyStore, lggr, evmcfg.EVM(), evmcfg.Database())
 	fwdMgr.ORM = forwarders.NewORM(db, logger.TestLogger(t), cfg.Database())
 
-	fwd, err := fwdMgr.ORM.CreateForwarder(forwarderAddr, utils.Big(*testutils.FixtureChainID))
+	fwd, err := fwdMgr.ORM.CreateForwarder(forwarderAddr, utils.Big(*testutils.FixtureChainID), forwarders.TypeAuthorizedForwarder, nil, false)
 	require.NoError(t, err)
 	lst, err := fwdMgr.ORM.FindForwardersByChain(utils.Big(*testutils.FixtureChainID))
 	require.NoError(t, err)
@@ -112,10 +114,11 @@ func TestFwdMgr_AccountUnauthorizedToForward_SkipsForwarding(t *testing.T) {
 
 	evmClient := client.NewSimulatedBackendClient(t, ec, testutils.FixtureChainID)
 	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), evmClient, lggr, 100*time.Millisecond, 2, 3, 2, 1000)
-	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, lggr, evmcfg.EVM(), evmcfg.Database())
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg.Database()).Eth()
+	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, ethKeyStore, lggr, evmcfg.EVM(), evmcfg.Database())
 	fwdMgr.ORM = forwarders.NewORM(db, logger.TestLogger(t), cfg.Database())
 
-	_, err = fwdMgr.ORM.CreateForwarder(forwarderAddr, utils.Big(*testutils.FixtureChainID))
+	_, err = fwdMgr.ORM.CreateForwarder(forwarderAddr, utils.Big(*testutils.FixtureChainID), forwarders.TypeAuthorizedForwarder, nil, false)
 	require.NoError(t, err)
 	lst, err := fwdMgr.ORM.FindForwardersByChain(utils.Big(*testutils.FixtureChainID))
 	require.NoError(t, err)
@@ -130,3 +133,63 @@ func TestFwdMgr_AccountUnauthorizedToForward_SkipsForwarding(t *testing.T) {
 	err = fwdMgr.Close()
 	require.NoError(t, err)
 }
+
+func TestFwdMgr_ConvertPayload(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	fwdMgr := forwarders.NewFwdMgr(db, ethClient, nil, nil, lggr, evmcfg.EVM(), evmcfg.Database())
+	chainID := utils.Big(*testutils.FixtureChainID)
+
+	from := testutils.NewAddress()
+	dest := testutils.NewAddress()
+	payload := []byte{0x01, 0x02}
+	req := forwarders.EncodeRequest{From: from, Dest: dest, Payload: payload, GasLimit: 100_000}
+
+	for _, fwdType := range []forwarders.Type{forwarders.TypeAuthorizedForwarder, forwarders.TypeSafeModule} {
+		fwdType := fwdType
+		t.Run(string(fwdType), func(t *testing.T) {
+			fwd, err := fwdMgr.ORM.CreateForwarder(testutils.NewAddress(), chainID, fwdType, nil, false)
+			require.NoError(t, err)
+			require.Equal(t, fwdType, fwd.Type)
+
+			enc, err := forwarders.EncoderFor(fwdType)
+			require.NoError(t, err)
+			req := req
+			req.Forwarder = fwd.Address
+			expected, err := enc.Encode(testutils.Context(t), nil, req)
+			require.NoError(t, err)
+
+			data, err := fwdMgr.ConvertPayload(from, fwd.Address, dest, payload, req.GasLimit)
+			require.NoError(t, err)
+			assert.Equal(t, expected, data)
+		})
+	}
+
+	t.Run("multi-hop", func(t *testing.T) {
+		safe, err := fwdMgr.ORM.CreateForwarder(testutils.NewAddress(), chainID, forwarders.TypeSafeModule, nil, false)
+		require.NoError(t, err)
+		fwd, err := fwdMgr.ORM.CreateForwarder(testutils.NewAddress(), chainID, forwarders.TypeAuthorizedForwarder, &safe.Address, false)
+		require.NoError(t, err)
+
+		data, err := fwdMgr.ConvertPayload(from, fwd.Address, dest, payload, req.GasLimit)
+		require.NoError(t, err)
+
+		// the key calls the forwarder, which calls the Safe, which calls the destination
+		require.Equal(t, forwardABI.ID, data[:4])
+		args, err := forwardABI.Inputs.Unpack(data[4:])
+		require.NoError(t, err)
+		assert.Equal(t, safe.Address, args[0].(common.Address))
+		inner := args[1].([]byte)
+		require.Equal(t, execTransactionFromModuleABI.ID, inner[:4])
+		args, err = execTransactionFromModuleABI.Inputs.Unpack(inner[4:])
+		require.NoError(t, err)
+		assert.Equal(t, dest, args[0].(common.Address))
+		assert.Equal(t, payload, args[2].([]byte))
+	})
+
+	_, err := fwdMgr.ConvertPayload(from, testutils.NewAddress(), dest, payload, req.GasLimit)
+	require.ErrorContains(t, err, "is not tracked")
+}
@@ -0,0 +1,364 @@
+package forwarders
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+)
+
+// Type identifies how transactions are routed through a forwarder contract.
+type Type string
+
+const (
+	// TypeAuthorizedForwarder is the Chainlink AuthorizedForwarder, called with forward(to, data).
+	TypeAuthorizedForwarder Type = "authorized_forwarder"
+	// TypeERC2771 is an ERC-2771 trusted forwarder compatible with OpenZeppelin's MinimalForwarder,
+	// called with execute(ForwardRequest, signature). The request is signed with the sending key,
+	// which the destination sees as the sender through ERC2771Context._msgSender(). The forwarder
+	// relays requests signed by any key, so it can only be the first hop of a route.
+	TypeERC2771 Type = "erc2771"
+	// TypeSafeModule is a Gnosis Safe that has the sender enabled as a module,
+	// called with execTransactionFromModule(to, 0, data, Call).
+	TypeSafeModule Type = "safe_module"
+)
+
+// EncodeRequest is a call to be routed through a forwarder.
+type EncodeRequest struct {
+	// From is the sender of the call to the forwarder: the sending key, or the previous hop of the route.
+	From      common.Address
+	Forwarder common.Address
+	// Dest is the destination of the forwarded call: the contract called by the transaction, or the
+	// next hop of the route.
+	Dest    common.Address
+	Payload []byte
+	// GasLimit is the gas limit of the transaction.
+	GasLimit uint32
+}
+
+// Backend gives Encoders access to the chain and the sending keys.
+type Backend interface {
+	bind.ContractCaller
+	ChainID() *big.Int
+	// SignHash signs hash with the key of from, returning a 65 byte [R || S || V] signature with V 0 or 1.
+	SignHash(from common.Address, hash []byte) ([]byte, error)
+}
+
+// Encoder converts a payload destined for a contract into a call to a forwarder.
+type Encoder interface {
+	// Encode wraps the payload of req in a call to req.Forwarder.
+	Encode(ctx context.Context, b Backend, req EncodeRequest) ([]byte, error)
+}
+
+// SenderAuthorizer is implemented by Encoders whose forwarder contracts do not expose
+// getAuthorizedSenders. Forwarders of such types are queried directly instead of being
+// tracked through AuthorizedSendersChanged logs.
+type SenderAuthorizer interface {
+	IsAuthorizedSender(ctx context.Context, caller bind.ContractCaller, forwarder, sender common.Address) (bool, error)
+}
+
+// firstHopOnly is implemented by Encoders which can only be called by a sending key.
+type firstHopOnly interface {
+	firstHopOnly()
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[Type]Encoder{
+		TypeAuthorizedForwarder: authorizedForwarderEncoder{},
+		TypeERC2771:             newERC2771Encoder(),
+		TypeSafeModule:          safeModuleEncoder{},
+	}
+)
+
+// RegisterType makes a forwarder type available for tracking and routing.
+// It panics if the type is already registered.
+func RegisterType(t Type, enc Encoder) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[t]; ok {
+		panic(fmt.Sprintf("forwarder type %q already registered", t))
+	}
+	registry[t] = enc
+}
+
+// EncoderFor returns the Encoder registered for t.
+func EncoderFor(t Type) (Encoder, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	enc, ok := registry[t]
+	if !ok {
+		return nil, errors.Errorf("unknown forwarder type %q", t)
+	}
+	return enc, nil
+}
+
+// ParseType returns the registered Type for s. An empty string defaults to TypeAuthorizedForwarder.
+func ParseType(s string) (Type, error) {
+	if s == "" {
+		return TypeAuthorizedForwarder, nil
+	}
+	t := Type(strings.ToLower(s))
+	if _, err := EncoderFor(t); err != nil {
+		return "", errors.Errorf("unknown forwarder type %q, must be one of: %s", s, strings.Join(Types(), ", "))
+	}
+	return t, nil
+}
+
+// Types returns the names of all registered forwarder types.
+func Types() (ts []string) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for t := range registry {
+		ts = append(ts, string(t))
+	}
+	sort.Strings(ts)
+	return
+}
+
+func tracksAuthorizedSenders(t Type) bool {
+	enc, err := EncoderFor(t)
+	if err != nil {
+		return false
+	}
+	_, ok := enc.(SenderAuthorizer)
+	return !ok
+}
+
+// CanBeNextHop returns whether forwarders of type t can be called by another forwarder.
+func CanBeNextHop(t Type) bool {
+	enc, err := EncoderFor(t)
+	if err != nil {
+		return false
+	}
+	_, ok := enc.(firstHopOnly)
+	return !ok
+}
+
+type authorizedForwarderEncoder struct{}
+
+func (authorizedForwarderEncoder) Encode(_ context.Context, _ Backend, req EncodeRequest) ([]byte, error) {
+	return packCall(forwardABI, req.Dest, req.Payload)
+}
+
+const erc2771ForwarderABIJSON = `[
+	{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"gas","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"signature","type":"bytes"}],"outputs":[{"name":"","type":"bool"},{"name":"","type":"bytes"}]},
+	{"type":"function","name":"getNonce","stateMutability":"view","inputs":[{"name":"from","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var erc2771ForwarderABI = evmtypes.MustGetABI(erc2771ForwarderABIJSON)
+
+var (
+	eip712DomainTypeHash   = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	forwardRequestTypeHash = crypto.Keccak256([]byte("ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,bytes data)"))
+	// the EIP-712 domain of OpenZeppelin's MinimalForwarder
+	erc2771DomainName    = crypto.Keccak256([]byte("MinimalForwarder"))
+	erc2771DomainVersion = crypto.Keccak256([]byte("0.0.1"))
+)
+
+// erc2771GasOverhead is the gas of the transaction kept by the forwarder for verifying and relaying
+// the request, the remainder is forwarded to the destination.
+const erc2771GasOverhead = 50_000
+
+// ForwardRequest is the meta-transaction relayed by an ERC-2771 forwarder.
+type ForwardRequest struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Gas   *big.Int
+	Nonce *big.Int
+	Data  []byte
+}
+
+// Hash returns the EIP-712 digest of the request signed for the forwarder on chainID.
+func (r ForwardRequest) Hash(chainID *big.Int, forwarder common.Address) []byte {
+	domainSeparator := crypto.Keccak256(
+		eip712DomainTypeHash,
+		erc2771DomainName,
+		erc2771DomainVersion,
+		common.LeftPadBytes(chainID.Bytes(), 32),
+		common.LeftPadBytes(forwarder.Bytes(), 32),
+	)
+	structHash := crypto.Keccak256(
+		forwardRequestTypeHash,
+		common.LeftPadBytes(r.From.Bytes(), 32),
+		common.LeftPadBytes(r.To.Bytes(), 32),
+		common.LeftPadBytes(r.Value.Bytes(), 32),
+		common.LeftPadBytes(r.Gas.Bytes(), 32),
+		common.LeftPadBytes(r.Nonce.Bytes(), 32),
+		crypto.Keccak256(r.Data),
+	)
+	return crypto.Keccak256([]byte("\x19\x01"), domainSeparator, structHash)
+}
+
+// erc2771NonceResyncTimeout is how long the forwarder nonce of a sender may stay behind the
+// nonces signed for it before they are signed from the forwarder nonce again. A request which is
+// never executed, because its transaction failed or was dropped, would otherwise stall all the
+// later requests of the sender.
+const erc2771NonceResyncTimeout = 10 * time.Minute
+
+type erc2771NonceKey struct {
+	chainID   string
+	forwarder common.Address
+	from      common.Address
+}
+
+type erc2771NonceState struct {
+	// next is the nonce of the next request
+	next *big.Int
+	// onChain is the last nonce returned by the forwarder, unchanged since onChainSince
+	onChain      *big.Int
+	onChainSince time.Time
+}
+
+// erc2771Encoder signs forward requests with consecutive forwarder nonces. The forwarder only
+// increments the nonce of a sender when a request is executed, so the nonces of requests not
+// mined yet are tracked in memory, until the forwarder nonce does not advance for
+// erc2771NonceResyncTimeout.
+type erc2771Encoder struct {
+	mu     sync.Mutex
+	nonces map[erc2771NonceKey]*erc2771NonceState
+	now    func() time.Time
+}
+
+func newERC2771Encoder() *erc2771Encoder {
+	return &erc2771Encoder{nonces: make(map[erc2771NonceKey]*erc2771NonceState), now: time.Now}
+}
+
+func (*erc2771Encoder) firstHopOnly() {}
+
+func (e *erc2771Encoder) Encode(ctx context.Context, b Backend, req EncodeRequest) ([]byte, error) {
+	if req.GasLimit <= erc2771GasOverhead {
+		return nil, errors.Errorf("gas limit %d does not cover the ERC-2771 forwarder overhead of %d", req.GasLimit, erc2771GasOverhead)
+	}
+	chainID := b.ChainID()
+	nonce, err := e.nextNonce(ctx, b, erc2771NonceKey{chainID.String(), req.Forwarder, req.From})
+	if err != nil {
+		return nil, err
+	}
+	fwdReq := ForwardRequest{
+		From:  req.From,
+		To:    req.Dest,
+		Value: big.NewInt(0),
+		Gas:   big.NewInt(int64(req.GasLimit - erc2771GasOverhead)),
+		Nonce: nonce,
+		Data:  req.Payload,
+	}
+	sig, err := b.SignHash(req.From, fwdReq.Hash(chainID, req.Forwarder))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign forward request with %s", req.From)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, errors.Errorf("unexpected signature length %d", len(sig))
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return packCall(erc2771ForwarderABI.Methods["execute"], fwdReq, sig)
+}
+
+func (e *erc2771Encoder) nextNonce(ctx context.Context, b Backend, key erc2771NonceKey) (*big.Int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	onChain, err := erc2771Nonce(ctx, b, key.forwarder, key.from)
+	if err != nil {
+		return nil, err
+	}
+	now := e.now()
+	state, ok := e.nonces[key]
+	if !ok {
+		state = &erc2771NonceState{next: onChain}
+		e.nonces[key] = state
+	}
+	if state.onChain == nil || state.onChain.Cmp(onChain) != 0 {
+		state.onChain, state.onChainSince = onChain, now
+	}
+
+	nonce := onChain
+	if state.next.Cmp(onChain) > 0 {
+		if now.Sub(state.onChainSince) < erc2771NonceResyncTimeout {
+			nonce = state.next
+		} else {
+			// the requests signed with the nonces ahead of the forwarder were not executed
+			state.onChainSince = now
+		}
+	}
+	state.next = new(big.Int).Add(nonce, big.NewInt(1))
+	return nonce, nil
+}
+
+// IsAuthorizedSender checks that the forwarder answers getNonce for sender. ERC-2771 forwarders
+// relay requests signed by any key.
+func (e *erc2771Encoder) IsAuthorizedSender(ctx context.Context, caller bind.ContractCaller, forwarder, sender common.Address) (bool, error) {
+	if _, err := erc2771Nonce(ctx, caller, forwarder, sender); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func erc2771Nonce(ctx context.Context, caller bind.ContractCaller, forwarder, from common.Address) (*big.Int, error) {
+	c := bind.NewBoundContract(forwarder, erc2771ForwarderABI, caller, nil, nil)
+	var out []interface{}
+	if err := c.Call(&bind.CallOpts{Context: ctx}, &out, "getNonce", from); err != nil {
+		return nil, errors.Wrap(err, "failed to call getNonce")
+	}
+	if len(out) != 1 {
+		return nil, errors.Errorf("unexpected getNonce result length %d", len(out))
+	}
+	nonce, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, errors.Errorf("unexpected getNonce result type %T", out[0])
+	}
+	return nonce, nil
+}
+
+const safeModuleABIJSON = `[
+	{"type":"function","name":"execTransactionFromModule","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"}],"outputs":[{"name":"success","type":"bool"}]},
+	{"type":"function","name":"isModuleEnabled","stateMutability":"view","inputs":[{"name":"module","type":"address"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+var safeModuleABI = evmtypes.MustGetABI(safeModuleABIJSON)
+
+// safeOperationCall is Enum.Operation.Call in the Safe contracts.
+const safeOperationCall uint8 = 0
+
+type safeModuleEncoder struct{}
+
+func (safeModuleEncoder) Encode(_ context.Context, _ Backend, req EncodeRequest) ([]byte, error) {
+	return packCall(safeModuleABI.Methods["execTransactionFromModule"], req.Dest, big.NewInt(0), req.Payload, safeOperationCall)
+}
+
+func (safeModuleEncoder) IsAuthorizedSender(ctx context.Context, caller bind.ContractCaller, forwarder, sender common.Address) (bool, error) {
+	c := bind.NewBoundContract(forwarder, safeModuleABI, caller, nil, nil)
+	var out []interface{}
+	if err := c.Call(&bind.CallOpts{Context: ctx}, &out, "isModuleEnabled", sender); err != nil {
+		return false, errors.Wrap(err, "failed to call isModuleEnabled")
+	}
+	if len(out) != 1 {
+		return false, errors.Errorf("unexpected isModuleEnabled result length %d", len(out))
+	}
+	enabled, ok := out[0].(bool)
+	if !ok {
+		return false, errors.Errorf("unexpected isModuleEnabled result type %T", out[0])
+	}
+	return enabled, nil
+}
+
+func packCall(method abi.Method, args ...interface{}) ([]byte, error) {
+	callArgs, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pack %s payload", method.Name)
+	}
+	data := make([]byte, 0, len(method.ID)+len(callArgs))
+	data = append(data, method.ID...)
+	return append(data, callArgs...), nil
+}
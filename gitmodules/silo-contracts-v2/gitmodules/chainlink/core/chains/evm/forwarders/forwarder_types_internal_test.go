@@ -0,0 +1,64 @@
+package forwarders
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+// nonceCaller answers getNonce calls with nonce.
+type nonceCaller struct {
+	nonce int64
+}
+
+func (c *nonceCaller) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (c *nonceCaller) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return erc2771ForwarderABI.Methods["getNonce"].Outputs.Pack(big.NewInt(c.nonce))
+}
+
+func (c *nonceCaller) ChainID() *big.Int { return testutils.FixtureChainID }
+
+func (c *nonceCaller) SignHash(common.Address, []byte) ([]byte, error) { return nil, nil }
+
+func TestERC2771Encoder_NextNonce(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	e := newERC2771Encoder()
+	e.now = func() time.Time { return now }
+	b := &nonceCaller{nonce: 3}
+	key := erc2771NonceKey{testutils.FixtureChainID.String(), testutils.NewAddress(), testutils.NewAddress()}
+	nextNonce := func(t *testing.T) int64 {
+		nonce, err := e.nextNonce(testutils.Context(t), b, key)
+		require.NoError(t, err)
+		return nonce.Int64()
+	}
+
+	// requests not mined yet get consecutive nonces
+	assert.Equal(t, int64(3), nextNonce(t))
+	assert.Equal(t, int64(4), nextNonce(t))
+	now = now.Add(erc2771NonceResyncTimeout - time.Second)
+	assert.Equal(t, int64(5), nextNonce(t))
+
+	// the forwarder nonce advancing delays the resync
+	b.nonce = 4
+	now = now.Add(time.Minute)
+	assert.Equal(t, int64(6), nextNonce(t))
+
+	// the request with nonce 4 was never executed, so the nonces are signed from the forwarder
+	// nonce again
+	now = now.Add(erc2771NonceResyncTimeout)
+	assert.Equal(t, int64(4), nextNonce(t))
+	assert.Equal(t, int64(5), nextNonce(t))
+}
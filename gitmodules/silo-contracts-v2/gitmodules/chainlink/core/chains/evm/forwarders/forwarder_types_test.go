@@ -0,0 +1,145 @@
+package forwarders_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/forwarders"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_forwarder"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+var forwardABI = evmtypes.MustGetABI(authorized_forwarder.AuthorizedForwarderABI).Methods["forward"]
+
+var erc2771ABI = evmtypes.MustGetABI(`[
+	{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"gas","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"signature","type":"bytes"}],"outputs":[{"name":"","type":"bool"},{"name":"","type":"bytes"}]},
+	{"type":"function","name":"getNonce","stateMutability":"view","inputs":[{"name":"from","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`)
+
+// fakeBackend answers getNonce calls with nonce, and signs with key.
+type fakeBackend struct {
+	key   *ecdsa.PrivateKey
+	nonce int64
+}
+
+func (b *fakeBackend) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (b *fakeBackend) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return erc2771ABI.Methods["getNonce"].Outputs.Pack(big.NewInt(b.nonce))
+}
+
+func (b *fakeBackend) ChainID() *big.Int { return testutils.FixtureChainID }
+
+func (b *fakeBackend) SignHash(_ common.Address, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, b.key)
+}
+
+var execTransactionFromModuleABI = evmtypes.MustGetABI(`[{"type":"function","name":"execTransactionFromModule","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"}],"outputs":[{"name":"success","type":"bool"}]}]`).Methods["execTransactionFromModule"]
+
+func TestEncoders(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	b := &fakeBackend{key: key, nonce: 5}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	dest := testutils.NewAddress()
+	payload := []byte{0xde, 0xad, 0xbe, 0xef}
+	req := forwarders.EncodeRequest{From: from, Forwarder: testutils.NewAddress(), Dest: dest, Payload: payload, GasLimit: 500_000}
+
+	t.Run("authorized forwarder", func(t *testing.T) {
+		enc, err := forwarders.EncoderFor(forwarders.TypeAuthorizedForwarder)
+		require.NoError(t, err)
+		data, err := enc.Encode(testutils.Context(t), b, req)
+		require.NoError(t, err)
+
+		require.Equal(t, forwardABI.ID, data[:4])
+		args, err := forwardABI.Inputs.Unpack(data[4:])
+		require.NoError(t, err)
+		assert.Equal(t, dest, args[0].(common.Address))
+		assert.Equal(t, payload, args[1].([]byte))
+	})
+
+	t.Run("erc2771", func(t *testing.T) {
+		enc, err := forwarders.EncoderFor(forwarders.TypeERC2771)
+		require.NoError(t, err)
+		nonces := []int64{5, 6, 9}
+		for i, onChain := range []int64{5, 5, 9} {
+			b.nonce = onChain
+			data, err := enc.Encode(testutils.Context(t), b, req)
+			require.NoError(t, err)
+
+			execute := erc2771ABI.Methods["execute"]
+			require.Equal(t, execute.ID, data[:4])
+			args, err := execute.Inputs.Unpack(data[4:])
+			require.NoError(t, err)
+			fwdReq := *abi.ConvertType(args[0], new(forwarders.ForwardRequest)).(*forwarders.ForwardRequest)
+			assert.Equal(t, from, fwdReq.From)
+			assert.Equal(t, dest, fwdReq.To)
+			assert.Equal(t, payload, fwdReq.Data)
+			assert.Equal(t, int64(450_000), fwdReq.Gas.Int64())
+			// requests not mined yet get consecutive nonces
+			assert.Equal(t, nonces[i], fwdReq.Nonce.Int64())
+
+			sig := args[1].([]byte)
+			require.Len(t, sig, 65)
+			assert.True(t, sig[64] == 27 || sig[64] == 28)
+			sig[64] -= 27
+			pub, err := crypto.SigToPub(fwdReq.Hash(testutils.FixtureChainID, req.Forwarder), sig)
+			require.NoError(t, err)
+			assert.Equal(t, from, crypto.PubkeyToAddress(*pub))
+		}
+
+		lowGas := req
+		lowGas.GasLimit = 21_000
+		_, err = enc.Encode(testutils.Context(t), b, lowGas)
+		require.ErrorContains(t, err, "does not cover the ERC-2771 forwarder overhead")
+		assert.False(t, forwarders.CanBeNextHop(forwarders.TypeERC2771))
+	})
+
+	t.Run("safe module", func(t *testing.T) {
+		enc, err := forwarders.EncoderFor(forwarders.TypeSafeModule)
+		require.NoError(t, err)
+		require.Implements(t, (*forwarders.SenderAuthorizer)(nil), enc)
+		data, err := enc.Encode(testutils.Context(t), b, req)
+		require.NoError(t, err)
+
+		require.Equal(t, execTransactionFromModuleABI.ID, data[:4])
+		args, err := execTransactionFromModuleABI.Inputs.Unpack(data[4:])
+		require.NoError(t, err)
+		assert.Equal(t, dest, args[0].(common.Address))
+		assert.Equal(t, big.NewInt(0), args[1].(*big.Int))
+		assert.Equal(t, payload, args[2].([]byte))
+		assert.Equal(t, uint8(0), args[3].(uint8))
+	})
+}
+
+func TestParseType(t *testing.T) {
+	t.Parallel()
+
+	fwdType, err := forwarders.ParseType("")
+	require.NoError(t, err)
+	assert.Equal(t, forwarders.TypeAuthorizedForwarder, fwdType)
+
+	fwdType, err = forwarders.ParseType("Safe_Module")
+	require.NoError(t, err)
+	assert.Equal(t, forwarders.TypeSafeModule, fwdType)
+
+	_, err = forwarders.ParseType("bogus")
+	require.ErrorContains(t, err, `unknown forwarder type "bogus"`)
+
+	assert.Equal(t, []string{"authorized_forwarder", "erc2771", "safe_module"}, forwarders.Types())
+	assert.Panics(t, func() { forwarders.RegisterType(forwarders.TypeERC2771, nil) })
+}
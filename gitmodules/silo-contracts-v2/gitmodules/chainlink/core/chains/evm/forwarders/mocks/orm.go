@@ -17,23 +17,23 @@ type ORM struct {
 	mock.Mock
 }
 
-// CreateForwarder provides a mock function with given fields: addr, evmChainId
-func (_m *ORM) CreateForwarder(addr common.Address, evmChainId utils.Big) (forwarders.Forwarder, error) {
-	ret := _m.Called(addr, evmChainId)
+// CreateForwarder provides a mock function with given fields: addr, evmChainId, fwdType, nextHop, stopRoutingOnDrift
+func (_m *ORM) CreateForwarder(addr common.Address, evmChainId utils.Big, fwdType forwarders.Type, nextHop *common.Address, stopRoutingOnDrift bool) (forwarders.Forwarder, error) {
+	ret := _m.Called(addr, evmChainId, fwdType, nextHop, stopRoutingOnDrift)
 
 	var r0 forwarders.Forwarder
 	var r1 error
-	if rf, ok := ret.Get(0).(func(common.Address, utils.Big) (forwarders.Forwarder, error)); ok {
-		return rf(addr, evmChainId)
+	if rf, ok := ret.Get(0).(func(common.Address, utils.Big, forwarders.Type, *common.Address, bool) (forwarders.Forwarder, error)); ok {
+		return rf(addr, evmChainId, fwdType, nextHop, stopRoutingOnDrift)
 	}
-	if rf, ok := ret.Get(0).(func(common.Address, utils.Big) forwarders.Forwarder); ok {
-		r0 = rf(addr, evmChainId)
+	if rf, ok := ret.Get(0).(func(common.Address, utils.Big, forwarders.Type, *common.Address, bool) forwarders.Forwarder); ok {
+		r0 = rf(addr, evmChainId, fwdType, nextHop, stopRoutingOnDrift)
 	} else {
 		r0 = ret.Get(0).(forwarders.Forwarder)
 	}
 
-	if rf, ok := ret.Get(1).(func(common.Address, utils.Big) error); ok {
-		r1 = rf(addr, evmChainId)
+	if rf, ok := ret.Get(1).(func(common.Address, utils.Big, forwarders.Type, *common.Address, bool) error); ok {
+		r1 = rf(addr, evmChainId, fwdType, nextHop, stopRoutingOnDrift)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -140,6 +140,44 @@ func (_m *ORM) FindForwardersInListByChain(evmChainId utils.Big, addrs []common.
 	return r0, r1
 }
 
+// SetStopRoutingOnDrift provides a mock function with given fields: id, stopRouting
+func (_m *ORM) SetStopRoutingOnDrift(id int64, stopRouting bool) (forwarders.Forwarder, error) {
+	ret := _m.Called(id, stopRouting)
+
+	var r0 forwarders.Forwarder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, bool) (forwarders.Forwarder, error)); ok {
+		return rf(id, stopRouting)
+	}
+	if rf, ok := ret.Get(0).(func(int64, bool) forwarders.Forwarder); ok {
+		r0 = rf(id, stopRouting)
+	} else {
+		r0 = ret.Get(0).(forwarders.Forwarder)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, bool) error); ok {
+		r1 = rf(id, stopRouting)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateForwarderHealth provides a mock function with given fields: id, authorizedSenders, driftedSenders
+func (_m *ORM) UpdateForwarderHealth(id int64, authorizedSenders []common.Address, driftedSenders []common.Address) error {
+	ret := _m.Called(id, authorizedSenders, driftedSenders)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []common.Address, []common.Address) error); ok {
+		r0 = rf(id, authorizedSenders, driftedSenders)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewORM interface {
 	mock.TestingT
 	Cleanup(func())
@@ -9,17 +9,20 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 //go:generate mockery --quiet --name ORM --output ./mocks/ --case=underscore
 
 type ORM interface {
-	CreateForwarder(addr common.Address, evmChainId utils.Big) (fwd Forwarder, err error)
+	CreateForwarder(addr common.Address, evmChainId utils.Big, fwdType Type, nextHop *common.Address, stopRoutingOnDrift bool) (fwd Forwarder, err error)
 	FindForwarders(offset, limit int) ([]Forwarder, int, error)
 	FindForwardersByChain(evmChainId utils.Big) ([]Forwarder, error)
 	DeleteForwarder(id int64, cleanup func(tx pg.Queryer, evmChainId int64, addr common.Address) error) error
 	FindForwardersInListByChain(evmChainId utils.Big, addrs []common.Address) ([]Forwarder, error)
+	UpdateForwarderHealth(id int64, authorizedSenders, driftedSenders []common.Address) error
+	SetStopRoutingOnDrift(id int64, stopRouting bool) (fwd Forwarder, err error)
 }
 
 type orm struct {
@@ -32,13 +35,49 @@ func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) *orm {
 	return &orm{pg.NewQ(db, lggr, cfg)}
 }
 
-// CreateForwarder creates the Forwarder address associated with the current EVM chain id.
-func (o *orm) CreateForwarder(addr common.Address, evmChainId utils.Big) (fwd Forwarder, err error) {
-	sql := `INSERT INTO evm_forwarders (address, evm_chain_id, created_at, updated_at) VALUES ($1, $2, now(), now()) RETURNING *`
-	err = o.q.Get(&fwd, sql, addr, evmChainId)
+// CreateForwarder creates the Forwarder address of the given type associated with the current EVM chain id.
+// If nextHop is non-nil, the forwarder routes transactions through that forwarder, which must already be
+// tracked on the same chain. If stopRoutingOnDrift is set, transactions from keys the forwarder does not
+// authorize are not routed through it.
+func (o *orm) CreateForwarder(addr common.Address, evmChainId utils.Big, fwdType Type, nextHop *common.Address, stopRoutingOnDrift bool) (fwd Forwarder, err error) {
+	if _, err = EncoderFor(fwdType); err != nil {
+		return fwd, err
+	}
+	err = o.q.Transaction(func(tx pg.Queryer) error {
+		if nextHop != nil {
+			if err = validateNextHop(tx, evmChainId, *nextHop); err != nil {
+				return err
+			}
+		}
+		sql := `INSERT INTO evm_forwarders (address, evm_chain_id, type, next_hop, stop_routing_on_drift, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, now(), now()) RETURNING *`
+		return tx.Get(&fwd, sql, addr, evmChainId, fwdType, nextHop, stopRoutingOnDrift)
+	})
 	return fwd, err
 }
 
+// validateNextHop checks that nextHop can be called by a forwarder, and that routes through it
+// stay within MaxHops.
+func validateNextHop(tx pg.Queryer, evmChainId utils.Big, nextHop common.Address) error {
+	hops := 1
+	for addr := &nextHop; addr != nil; hops++ {
+		if hops == MaxHops {
+			return errors.Errorf("route through next hop %s has more than %d hops", nextHop, MaxHops)
+		}
+		var next Forwarder
+		err := tx.Get(&next, `SELECT * FROM evm_forwarders WHERE address = $1 AND evm_chain_id = $2`, *addr, evmChainId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Errorf("next hop %s is not a forwarder tracked on chain %s", *addr, evmChainId.String())
+		} else if err != nil {
+			return errors.Wrap(err, "failed to load next hop")
+		}
+		if *addr == nextHop && !CanBeNextHop(next.Type) {
+			return errors.Errorf("forwarders of type %s cannot be a next hop", next.Type)
+		}
+		addr = next.NextHop
+	}
+	return nil
+}
+
 // DeleteForwarder removes a forwarder address.
 // If cleanup is non-nil, it can be used to perform any chain- or contract-specific cleanup that need to happen atomically
 // on forwarder deletion.  If cleanup returns an error, forwarder deletion will be aborted.
@@ -54,6 +93,13 @@ func (o *orm) DeleteForwarder(id int64, cleanup func(tx pg.Queryer, evmChainID i
 		if err != nil {
 			return err
 		}
+		var routedThrough int
+		if err = tx.Get(&routedThrough, `SELECT count(*) FROM evm_forwarders WHERE next_hop = $1`, dest.Address); err != nil {
+			return err
+		}
+		if routedThrough > 0 {
+			return errors.Errorf("forwarder %s is the next hop of %d other forwarders", dest.Address, routedThrough)
+		}
 		if cleanup != nil {
 			if err = cleanup(tx, dest.EvmChainId, dest.Address); err != nil {
 				return err
@@ -133,3 +179,17 @@ func (o *orm) FindForwardersInListByChain(evmChainId utils.Big, addrs []common.A
 
 	return fwdrs, nil
 }
+
+// UpdateForwarderHealth records the result of a forwarder health check.
+func (o *orm) UpdateForwarderHealth(id int64, authorizedSenders, driftedSenders []common.Address) error {
+	sql := `UPDATE evm_forwarders SET authorized_senders = $2, drifted_senders = $3, health_checked_at = now() WHERE id = $1`
+	_, err := o.q.Exec(sql, id, models.AddressCollection(authorizedSenders), models.AddressCollection(driftedSenders))
+	return err
+}
+
+// SetStopRoutingOnDrift sets whether routing through the forwarder stops for senders it no longer authorizes.
+func (o *orm) SetStopRoutingOnDrift(id int64, stopRouting bool) (fwd Forwarder, err error) {
+	sql := `UPDATE evm_forwarders SET stop_routing_on_drift = $2, updated_at = now() WHERE id = $1 RETURNING *`
+	err = o.q.Get(&fwd, sql, id, stopRouting)
+	return fwd, err
+}
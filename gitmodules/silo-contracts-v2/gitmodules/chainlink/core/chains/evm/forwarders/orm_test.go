@@ -42,7 +42,7 @@ func Test_DeleteForwarder(t *testing.T) {
 	addr := testutils.NewAddress()
 	chainID := testutils.FixtureChainID
 
-	fwd, err := orm.CreateForwarder(addr, *utils.NewBig(chainID))
+	fwd, err := orm.CreateForwarder(addr, *utils.NewBig(chainID), TypeAuthorizedForwarder, nil, false)
 	require.NoError(t, err)
 	assert.Equal(t, addr, fwd.Address)
 
@@ -68,3 +68,95 @@ func Test_DeleteForwarder(t *testing.T) {
 	}
 	assert.Equal(t, 2, cleanupCalled)
 }
+
+func Test_CreateForwarder_Type(t *testing.T) {
+	t.Parallel()
+	orm := setupORM(t)
+	chainID := *utils.NewBig(testutils.FixtureChainID)
+
+	fwd, err := orm.CreateForwarder(testutils.NewAddress(), chainID, TypeSafeModule, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, TypeSafeModule, fwd.Type)
+
+	fwds, err := orm.FindForwardersByChain(chainID)
+	require.NoError(t, err)
+	require.Len(t, fwds, 1)
+	assert.Equal(t, TypeSafeModule, fwds[0].Type)
+
+	_, err = orm.CreateForwarder(testutils.NewAddress(), chainID, Type("bogus"), nil, false)
+	require.ErrorContains(t, err, `unknown forwarder type "bogus"`)
+}
+
+func Test_UpdateForwarderHealth(t *testing.T) {
+	t.Parallel()
+	orm := setupORM(t)
+	chainID := *utils.NewBig(testutils.FixtureChainID)
+
+	fwd, err := orm.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, nil, false)
+	require.NoError(t, err)
+	assert.False(t, fwd.StopRoutingOnDrift)
+	assert.Empty(t, fwd.DriftedSenders)
+	assert.Nil(t, fwd.HealthCheckedAt)
+
+	stopping, err := orm.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, nil, true)
+	require.NoError(t, err)
+	assert.True(t, stopping.StopRoutingOnDrift)
+
+	authorized := []common.Address{testutils.NewAddress()}
+	drifted := []common.Address{testutils.NewAddress(), testutils.NewAddress()}
+	require.NoError(t, orm.UpdateForwarderHealth(fwd.ID, authorized, drifted))
+
+	fwds, err := orm.FindForwardersInListByChain(chainID, []common.Address{fwd.Address})
+	require.NoError(t, err)
+	require.Len(t, fwds, 1)
+	assert.Equal(t, authorized, []common.Address(fwds[0].AuthorizedSenders))
+	assert.Equal(t, drifted, []common.Address(fwds[0].DriftedSenders))
+	assert.NotNil(t, fwds[0].HealthCheckedAt)
+
+	require.NoError(t, orm.UpdateForwarderHealth(fwd.ID, authorized, nil))
+	fwds, err = orm.FindForwardersInListByChain(chainID, []common.Address{fwd.Address})
+	require.NoError(t, err)
+	assert.Empty(t, fwds[0].DriftedSenders)
+
+	fwd, err = orm.SetStopRoutingOnDrift(fwd.ID, true)
+	require.NoError(t, err)
+	assert.True(t, fwd.StopRoutingOnDrift)
+	stopping, err = orm.SetStopRoutingOnDrift(stopping.ID, false)
+	require.NoError(t, err)
+	assert.False(t, stopping.StopRoutingOnDrift)
+}
+
+func Test_CreateForwarder_NextHop(t *testing.T) {
+	t.Parallel()
+	orm := setupORM(t)
+	chainID := *utils.NewBig(testutils.FixtureChainID)
+
+	safe, err := orm.CreateForwarder(testutils.NewAddress(), chainID, TypeSafeModule, nil, false)
+	require.NoError(t, err)
+	fwd, err := orm.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, &safe.Address, false)
+	require.NoError(t, err)
+	require.NotNil(t, fwd.NextHop)
+	assert.Equal(t, safe.Address, *fwd.NextHop)
+
+	untracked := testutils.NewAddress()
+	_, err = orm.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, &untracked, false)
+	require.ErrorContains(t, err, "is not a forwarder tracked on chain")
+
+	erc2771, err := orm.CreateForwarder(testutils.NewAddress(), chainID, TypeERC2771, nil, false)
+	require.NoError(t, err)
+	_, err = orm.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, &erc2771.Address, false)
+	require.ErrorContains(t, err, "cannot be a next hop")
+
+	// routes are limited to MaxHops forwarders
+	last := fwd
+	for i := 2; i < MaxHops; i++ {
+		last, err = orm.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, &last.Address, false)
+		require.NoError(t, err)
+	}
+	_, err = orm.CreateForwarder(testutils.NewAddress(), chainID, TypeAuthorizedForwarder, &last.Address, false)
+	require.ErrorContains(t, err, "hops")
+
+	// next hops cannot be deleted while forwarders route through them
+	err = orm.DeleteForwarder(safe.ID, nil)
+	require.ErrorContains(t, err, "is the next hop of 1 other forwarders")
+}
@@ -140,6 +140,43 @@ func TestHeadBroadcaster_BroadcastNewLongestChain(t *testing.T) {
 	require.Equal(t, int32(1), subscriber3.OnNewLongestChainCount())
 }
 
+func TestHeadBroadcaster_BroadcastNewFinalizedHead(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.TestLogger(t)
+	broadcaster := headtracker.NewHeadBroadcaster(lggr)
+
+	err := broadcaster.Start(testutils.Context(t))
+	require.NoError(t, err)
+
+	waitHeadBroadcasterToStart(t, broadcaster)
+
+	finalitySubscriber := &finalitySubscriber{finalized: make(chan *evmtypes.Head, 1)}
+	plainSubscriber := &cltest.MockHeadTrackable{}
+	_, unsubscribe1 := broadcaster.Subscribe(finalitySubscriber)
+	_, unsubscribe2 := broadcaster.Subscribe(plainSubscriber)
+
+	finalized := cltest.Head(5)
+	broadcaster.BroadcastNewFinalizedHead(finalized)
+
+	select {
+	case head := <-finalitySubscriber.finalized:
+		assert.Equal(t, finalized.Hash, head.Hash)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("timed out waiting for finalized head")
+	}
+
+	// finalized heads are not delivered as new longest chains
+	assert.Equal(t, int32(0), plainSubscriber.OnNewLongestChainCount())
+	assert.Equal(t, int32(0), finalitySubscriber.OnNewLongestChainCount())
+
+	unsubscribe1()
+	unsubscribe2()
+
+	err = broadcaster.Close()
+	require.NoError(t, err)
+}
+
 func TestHeadBroadcaster_TrackableCallbackTimeout(t *testing.T) {
 	t.Parallel()
 
@@ -187,3 +224,12 @@ func (ss *sleepySubscriber) OnNewLongestChain(ctx context.Context, head *evmtype
 	}
 	ss.awaiter.ItHappened()
 }
+
+type finalitySubscriber struct {
+	cltest.MockHeadTrackable
+	finalized chan *evmtypes.Head
+}
+
+func (fs *finalitySubscriber) OnNewFinalizedHead(ctx context.Context, head *evmtypes.Head) {
+	fs.finalized <- head
+}
@@ -2,6 +2,7 @@ package headtracker
 
 import (
 	"context"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -17,6 +18,9 @@ type headSaver struct {
 	htConfig HeadTrackerConfig
 	logger   logger.Logger
 	heads    Heads
+
+	finalizedMu sync.RWMutex
+	finalized   *evmtypes.Head
 }
 
 var _ commontypes.HeadSaver[*evmtypes.Head, common.Hash] = (*headSaver)(nil)
@@ -50,6 +54,13 @@ func (hs *headSaver) Load(ctx context.Context) (chain *evmtypes.Head, err error)
 	}
 
 	hs.heads.AddHeads(historyDepth, heads...)
+
+	finalized, err := hs.orm.LatestFinalizedHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hs.setFinalized(finalized)
+
 	return hs.heads.LatestHead(), nil
 }
 
@@ -72,6 +83,38 @@ func (hs *headSaver) Chain(hash common.Hash) *evmtypes.Head {
 	return hs.heads.HeadByHash(hash)
 }
 
+func (hs *headSaver) SaveFinalized(ctx context.Context, head *evmtypes.Head) error {
+	if err := hs.orm.SetFinalizedHead(ctx, head); err != nil {
+		return err
+	}
+	hs.setFinalized(head)
+	return nil
+}
+
+func (hs *headSaver) LatestFinalizedHead() *evmtypes.Head {
+	hs.finalizedMu.RLock()
+	defer hs.finalizedMu.RUnlock()
+	return hs.finalized
+}
+
+func (hs *headSaver) SaveReorg(ctx context.Context, reorg commontypes.Reorg[common.Hash]) error {
+	return hs.orm.InsertReorg(ctx, NewReorg(reorg))
+}
+
+func (hs *headSaver) setFinalized(head *evmtypes.Head) {
+	if head == nil {
+		return
+	}
+	// copy to avoid sharing the Parent chain with callers
+	headCopy := *head
+	headCopy.Parent = nil
+	headCopy.IsFinalized = true
+
+	hs.finalizedMu.Lock()
+	defer hs.finalizedMu.Unlock()
+	hs.finalized = &headCopy
+}
+
 var NullSaver httypes.HeadSaver = &nullSaver{}
 
 type nullSaver struct{}
@@ -81,3 +124,8 @@ func (*nullSaver) Load(ctx context.Context) (*evmtypes.Head, error)
 func (*nullSaver) LatestHeadFromDB(ctx context.Context) (*evmtypes.Head, error) { return nil, nil }
 func (*nullSaver) LatestChain() *evmtypes.Head                                  { return nil }
 func (*nullSaver) Chain(hash common.Hash) *evmtypes.Head                        { return nil }
+func (*nullSaver) SaveFinalized(ctx context.Context, head *evmtypes.Head) error { return nil }
+func (*nullSaver) LatestFinalizedHead() *evmtypes.Head                          { return nil }
+func (*nullSaver) SaveReorg(ctx context.Context, reorg commontypes.Reorg[common.Hash]) error {
+	return nil
+}
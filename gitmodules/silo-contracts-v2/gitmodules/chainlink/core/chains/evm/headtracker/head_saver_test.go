@@ -33,10 +33,12 @@ func (h *headTrackerConfig) MaxBufferSize() uint32 {
 
 type config struct {
 	finalityDepth                     uint32
+	finalityTagEnabled                bool
 	blockEmissionIdleWarningThreshold time.Duration
 }
 
-func (c *config) FinalityDepth() uint32 { return c.finalityDepth }
+func (c *config) FinalityDepth() uint32    { return c.finalityDepth }
+func (c *config) FinalityTagEnabled() bool { return c.finalityTagEnabled }
 func (c *config) BlockEmissionIdleWarningThreshold() time.Duration {
 	return c.blockEmissionIdleWarningThreshold
 }
@@ -92,3 +94,38 @@ func TestHeadSaver_Load(t *testing.T) {
 	require.NotNil(t, latestChain)
 	require.Equal(t, int64(4), latestChain.Number)
 }
+
+func TestHeadSaver_SaveFinalized(t *testing.T) {
+	t.Parallel()
+
+	saver, orm := configureSaver(t)
+	require.Nil(t, saver.LatestFinalizedHead())
+
+	head := cltest.Head(1)
+	require.NoError(t, saver.SaveFinalized(testutils.Context(t), head))
+
+	finalized := saver.LatestFinalizedHead()
+	require.NotNil(t, finalized)
+	require.Equal(t, head.Hash, finalized.Hash)
+	require.True(t, finalized.IsFinalized)
+
+	fromDB, err := orm.LatestFinalizedHead(testutils.Context(t))
+	require.NoError(t, err)
+	require.Equal(t, head.Hash, fromDB.Hash)
+}
+
+func TestHeadSaver_Load_RestoresFinalizedHead(t *testing.T) {
+	t.Parallel()
+
+	saver, orm := configureSaver(t)
+
+	head := cltest.Head(3)
+	require.NoError(t, orm.SetFinalizedHead(testutils.Context(t), head))
+
+	_, err := saver.Load(testutils.Context(t))
+	require.NoError(t, err)
+
+	finalized := saver.LatestFinalizedHead()
+	require.NotNil(t, finalized)
+	require.Equal(t, head.Hash, finalized.Hash)
+}
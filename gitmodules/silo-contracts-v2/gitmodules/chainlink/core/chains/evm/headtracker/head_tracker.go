@@ -55,4 +55,6 @@ func (*nullTracker) SetLogLevel(zapcore.Level)      {}
 func (*nullTracker) Backfill(ctx context.Context, headWithChain *evmtypes.Head, depth uint) (err error) {
 	return nil
 }
-func (*nullTracker) LatestChain() *evmtypes.Head { return nil }
+func (*nullTracker) LatestChain() *evmtypes.Head         { return nil }
+func (*nullTracker) LatestFinalizedHead() *evmtypes.Head { return nil }
+func (*nullTracker) LatestSafeHead() *evmtypes.Head      { return nil }
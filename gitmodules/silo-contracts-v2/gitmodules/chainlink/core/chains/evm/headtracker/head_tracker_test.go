@@ -263,6 +263,59 @@ func TestHeadTracker_CallsHeadTrackableCallbacks(t *testing.T) {
 	assert.Equal(t, int32(1), checker.OnNewLongestChainCount())
 }
 
+func TestHeadTracker_FinalityTagEnabled_PollsFinalizedAndSafeHeads(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	db := pgtest.NewSqlxDB(t)
+	logger := logger.TestLogger(t)
+	config := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].FinalityTagEnabled = ptr(true)
+		c.EVM[0].HeadTracker.SamplingInterval = &models.Duration{}
+	})
+	evmcfg := evmtest.NewChainScopedConfig(t, config)
+	orm := headtracker.NewORM(db, logger, config.Database(), cltest.FixtureChainID)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	chchHeaders := make(chan evmtest.RawSub[*evmtypes.Head], 1)
+	mockEth := &evmtest.MockEth{EthClient: ethClient}
+	ethClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Return(
+			func(ctx context.Context, ch chan<- *evmtypes.Head) ethereum.Subscription {
+				sub := mockEth.NewSub(t)
+				chchHeaders <- evmtest.NewRawSub(ch, sub.Err())
+				return sub
+			},
+			func(ctx context.Context, ch chan<- *evmtypes.Head) error { return nil },
+		)
+	ethClient.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(cltest.Head(0), nil)
+	ethClient.On("HeadByHash", mock.Anything, mock.Anything).Return(cltest.Head(0), nil).Maybe()
+	finalized := cltest.Head(0)
+	safe := cltest.Head(1)
+	ethClient.On("LatestFinalizedHead", mock.Anything).Return(finalized, nil)
+	ethClient.On("LatestSafeHead", mock.Anything).Return(safe, nil)
+
+	checker := &finalitySubscriber{finalized: make(chan *evmtypes.Head, 1)}
+	ht := createHeadTrackerWithChecker(t, ethClient, evmcfg.EVM(), evmcfg.EVM().HeadTracker(), orm, checker)
+	ht.Start(t)
+
+	select {
+	case head := <-checker.finalized:
+		assert.Equal(t, finalized.Hash, head.Hash)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("timed out waiting for finalized head")
+	}
+
+	require.NotNil(t, ht.headTracker.LatestFinalizedHead())
+	assert.Equal(t, finalized.Hash, ht.headTracker.LatestFinalizedHead().Hash)
+	g.Eventually(func() *evmtypes.Head { return ht.headTracker.LatestSafeHead() }).ShouldNot(gomega.BeNil())
+	assert.Equal(t, safe.Hash, ht.headTracker.LatestSafeHead().Hash)
+
+	fromDB, err := orm.LatestFinalizedHead(testutils.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, finalized.Hash, fromDB.Hash)
+}
+
 func TestHeadTracker_ReconnectOnError(t *testing.T) {
 	t.Parallel()
 	g := gomega.NewWithT(t)
@@ -412,6 +465,79 @@ func TestHeadTracker_Start_LoadsLatestChain(t *testing.T) {
 	assert.Equal(t, h.Number, int64(3))
 }
 
+func TestHeadTracker_RecordsReorg(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	logger := logger.TestLogger(t)
+	config := cltest.NewTestChainScopedConfig(t)
+	orm := headtracker.NewORM(db, logger, config.Database(), cltest.FixtureChainID)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+
+	newHead := func(number int64, parent *evmtypes.Head) *evmtypes.Head {
+		h := &evmtypes.Head{Number: number, Hash: utils.NewHash(), ParentHash: utils.NewHash(), EVMChainID: utils.NewBig(&cltest.FixtureChainID)}
+		if parent != nil {
+			h.ParentHash = parent.Hash
+		}
+		return h
+	}
+	// 0 -> 1 -> 2 -> 3 is replaced by 0 -> 1 -> 2' -> 3' -> 4'
+	h0 := newHead(0, nil)
+	h1 := newHead(1, h0)
+	h2 := newHead(2, h1)
+	h3 := newHead(3, h2)
+	h2Fork := newHead(2, h1)
+	h3Fork := newHead(3, h2Fork)
+	h4Fork := newHead(4, h3Fork)
+
+	require.NoError(t, orm.IdempotentInsertHead(testutils.Context(t), h1))
+	require.NoError(t, orm.IdempotentInsertHead(testutils.Context(t), h2))
+
+	chBackfilled := make(chan struct{})
+	ethClient.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(h3, nil)
+	ethClient.On("HeadByHash", mock.Anything, h0.Hash).Run(func(mock.Arguments) { close(chBackfilled) }).Return(h0, nil).Once()
+
+	chchHeaders := make(chan evmtest.RawSub[*evmtypes.Head], 1)
+	mockEth := &evmtest.MockEth{EthClient: ethClient}
+	ethClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Return(
+			func(ctx context.Context, ch chan<- *evmtypes.Head) ethereum.Subscription {
+				sub := mockEth.NewSub(t)
+				chchHeaders <- evmtest.NewRawSub(ch, sub.Err())
+				return sub
+			},
+			func(ctx context.Context, ch chan<- *evmtypes.Head) error { return nil },
+		)
+
+	ht := createHeadTracker(t, ethClient, config.EVM(), config.EVM().HeadTracker(), orm)
+	ht.Start(t)
+
+	// the initial head has been backfilled, so the fork is compared against it
+	<-chBackfilled
+	headers := <-chchHeaders
+	headers.TrySend(h2Fork)
+	headers.TrySend(h3Fork)
+	headers.TrySend(h4Fork)
+
+	var reorgs []headtracker.Reorg
+	gomega.NewWithT(t).Eventually(func() int {
+		var err error
+		reorgs, _, err = orm.Reorgs(testutils.Context(t), 0, 10)
+		require.NoError(t, err)
+		return len(reorgs)
+	}, testutils.WaitTimeout(t), testutils.TestInterval).Should(gomega.Equal(1))
+
+	reorg := reorgs[0]
+	assert.Equal(t, int64(2), reorg.Depth)
+	assert.Equal(t, h3.Hash, reorg.PrevHeadHash)
+	assert.Equal(t, h4Fork.Hash, reorg.NewHeadHash)
+	require.NotNil(t, reorg.CommonAncestorHash)
+	assert.Equal(t, h1.Hash, *reorg.CommonAncestorHash)
+	assert.Equal(t, int64(1), reorg.CommonAncestorNumber.Int64)
+	assert.Equal(t, []gethCommon.Hash{h3.Hash, h2.Hash}, reorg.DroppedHashes)
+	assert.Equal(t, []gethCommon.Hash{h4Fork.Hash, h3Fork.Hash, h2Fork.Hash}, reorg.NewHashes)
+}
+
 func TestHeadTracker_SwitchesToLongestChainWithHeadSamplingEnabled(t *testing.T) {
 	t.Parallel()
 
@@ -28,6 +28,15 @@ type ORM interface {
 	LatestHeads(ctx context.Context, limit uint) (heads []*evmtypes.Head, err error)
 	// HeadByHash fetches the head with the given hash from the db, returns nil if none exists
 	HeadByHash(ctx context.Context, hash common.Hash) (head *evmtypes.Head, err error)
+	// SetFinalizedHead inserts the head if the hash is new and flags it as the latest finalized head,
+	// clearing the flag from the previously finalized head.
+	SetFinalizedHead(ctx context.Context, head *evmtypes.Head) error
+	// LatestFinalizedHead returns the head flagged as finalized, returns nil if none exists
+	LatestFinalizedHead(ctx context.Context) (head *evmtypes.Head, err error)
+	// InsertReorg records a reorg of the longest chain
+	InsertReorg(ctx context.Context, reorg *Reorg) error
+	// Reorgs returns the recorded reorgs, most recent first, along with the total count
+	Reorgs(ctx context.Context, offset, limit int) (reorgs []Reorg, count int, err error)
 }
 
 type orm struct {
@@ -54,7 +63,7 @@ func (orm *orm) TrimOldHeads(ctx context.Context, n uint) (err error) {
 	q := orm.q.WithOpts(pg.WithParentCtx(ctx))
 	return q.ExecQ(`
 	DELETE FROM evm_heads
-	WHERE evm_chain_id = $1 AND number < (
+	WHERE evm_chain_id = $1 AND NOT is_finalized AND number < (
 		SELECT min(number) FROM (
 			SELECT number
 			FROM evm_heads
@@ -92,3 +101,60 @@ func (orm *orm) HeadByHash(ctx context.Context, hash common.Hash) (head *evmtype
 	}
 	return head, err
 }
+
+func (orm *orm) SetFinalizedHead(ctx context.Context, head *evmtypes.Head) error {
+	q := orm.q.WithOpts(pg.WithParentCtx(ctx))
+	err := q.Transaction(func(tx pg.Queryer) error {
+		if _, err := tx.Exec(`UPDATE evm_heads SET is_finalized = false WHERE evm_chain_id = $1 AND is_finalized AND hash <> $2`, orm.chainID, head.Hash); err != nil {
+			return errors.Wrap(err, "failed to clear previous finalized head")
+		}
+		query := `
+		INSERT INTO evm_heads (hash, number, parent_hash, created_at, timestamp, l1_block_number, evm_chain_id, base_fee_per_gas, is_finalized) VALUES (
+		:hash, :number, :parent_hash, :created_at, :timestamp, :l1_block_number, :evm_chain_id, :base_fee_per_gas, true)
+		ON CONFLICT (evm_chain_id, hash) DO UPDATE SET is_finalized = true`
+		_, err := tx.NamedExec(query, head)
+		return err
+	})
+	return errors.Wrap(err, "SetFinalizedHead failed")
+}
+
+func (orm *orm) LatestFinalizedHead(ctx context.Context) (head *evmtypes.Head, err error) {
+	q := orm.q.WithOpts(pg.WithParentCtx(ctx))
+	head = new(evmtypes.Head)
+	err = q.Get(head, `SELECT * FROM evm_heads WHERE evm_chain_id = $1 AND is_finalized`, orm.chainID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	err = errors.Wrap(err, "LatestFinalizedHead failed")
+	return
+}
+
+func (orm *orm) InsertReorg(ctx context.Context, reorg *Reorg) error {
+	reorg.EVMChainID = orm.chainID
+	q := orm.q.WithOpts(pg.WithParentCtx(ctx))
+	query := `
+	INSERT INTO evm_reorgs (evm_chain_id, prev_head_number, prev_head_hash, new_head_number, new_head_hash, common_ancestor_number, common_ancestor_hash, depth, dropped_hashes, new_hashes, created_at) VALUES (
+	:evm_chain_id, :prev_head_number, :prev_head_hash, :new_head_number, :new_head_hash, :common_ancestor_number, :common_ancestor_hash, :depth, :dropped_hashes, :new_hashes, NOW())
+	RETURNING id, created_at`
+	row := toReorgRow(reorg)
+	err := q.GetNamed(query, reorg, row)
+	return errors.Wrap(err, "InsertReorg failed")
+}
+
+func (orm *orm) Reorgs(ctx context.Context, offset, limit int) (reorgs []Reorg, count int, err error) {
+	q := orm.q.WithOpts(pg.WithParentCtx(ctx))
+	err = q.Transaction(func(tx pg.Queryer) error {
+		if err = tx.Get(&count, `SELECT count(*) FROM evm_reorgs WHERE evm_chain_id = $1`, orm.chainID); err != nil {
+			return errors.Wrap(err, "failed to count reorgs")
+		}
+		var rows []reorgRow
+		if err = tx.Select(&rows, `SELECT * FROM evm_reorgs WHERE evm_chain_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`, orm.chainID, limit, offset); err != nil {
+			return errors.Wrap(err, "failed to load reorgs")
+		}
+		for _, row := range rows {
+			reorgs = append(reorgs, row.toReorg())
+		}
+		return nil
+	}, pg.OptReadOnlyTx())
+	return
+}
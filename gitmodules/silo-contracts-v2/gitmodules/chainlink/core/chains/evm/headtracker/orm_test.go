@@ -11,7 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	commontypes "github.com/smartcontractkit/chainlink/v2/common/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -119,3 +121,132 @@ func TestORM_LatestHeads_NoRows(t *testing.T) {
 	require.Zero(t, len(heads))
 	require.NoError(t, err)
 }
+
+func TestORM_SetFinalizedHead(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	logger := logger.TestLogger(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	orm := headtracker.NewORM(db, logger, cfg.Database(), cltest.FixtureChainID)
+
+	finalized, err := orm.LatestFinalizedHead(testutils.Context(t))
+	require.NoError(t, err)
+	require.Nil(t, finalized)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, orm.IdempotentInsertHead(testutils.Context(t), cltest.Head(i)))
+	}
+
+	// marks an existing head as finalized
+	heads, err := orm.LatestHeads(testutils.Context(t), 10)
+	require.NoError(t, err)
+	require.NoError(t, orm.SetFinalizedHead(testutils.Context(t), heads[7]))
+
+	finalized, err = orm.LatestFinalizedHead(testutils.Context(t))
+	require.NoError(t, err)
+	require.Equal(t, heads[7].Hash, finalized.Hash)
+	require.True(t, finalized.IsFinalized)
+
+	// inserts an unknown head and moves the pointer
+	head12 := cltest.Head(12)
+	require.NoError(t, orm.SetFinalizedHead(testutils.Context(t), head12))
+
+	finalized, err = orm.LatestFinalizedHead(testutils.Context(t))
+	require.NoError(t, err)
+	require.Equal(t, head12.Hash, finalized.Hash)
+
+	previous, err := orm.HeadByHash(testutils.Context(t), heads[7].Hash)
+	require.NoError(t, err)
+	require.False(t, previous.IsFinalized)
+}
+
+func TestORM_TrimOldHeads_KeepsFinalizedHead(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	logger := logger.TestLogger(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	orm := headtracker.NewORM(db, logger, cfg.Database(), cltest.FixtureChainID)
+
+	finalized := cltest.Head(1)
+	require.NoError(t, orm.SetFinalizedHead(testutils.Context(t), finalized))
+	for i := 2; i < 10; i++ {
+		require.NoError(t, orm.IdempotentInsertHead(testutils.Context(t), cltest.Head(i)))
+	}
+
+	require.NoError(t, orm.TrimOldHeads(testutils.Context(t), 5))
+
+	head, err := orm.HeadByHash(testutils.Context(t), finalized.Hash)
+	require.NoError(t, err)
+	require.NotNil(t, head)
+	require.True(t, head.IsFinalized)
+}
+
+func TestORM_InsertReorg(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	logger := logger.TestLogger(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	orm := headtracker.NewORM(db, logger, cfg.Database(), cltest.FixtureChainID)
+
+	reorgs, count, err := orm.Reorgs(testutils.Context(t), 0, 10)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.Empty(t, reorgs)
+
+	ancestor := cltest.Head(1)
+	dropped := []*evmtypes.Head{cltest.Head(3), cltest.Head(2)}
+	added := []*evmtypes.Head{cltest.Head(4), cltest.Head(3), cltest.Head(2)}
+	withAncestor := headtracker.NewReorg(commontypes.Reorg[common.Hash]{
+		PrevHeadNumber:       3,
+		PrevHeadHash:         dropped[0].Hash,
+		NewHeadNumber:        4,
+		NewHeadHash:          added[0].Hash,
+		HasCommonAncestor:    true,
+		CommonAncestorNumber: 1,
+		CommonAncestorHash:   ancestor.Hash,
+		Depth:                2,
+		DroppedHashes:        []common.Hash{dropped[0].Hash, dropped[1].Hash},
+		NewHashes:            []common.Hash{added[0].Hash, added[1].Hash, added[2].Hash},
+	})
+	require.NoError(t, orm.InsertReorg(testutils.Context(t), withAncestor))
+	require.NotZero(t, withAncestor.ID)
+
+	withoutAncestor := headtracker.NewReorg(commontypes.Reorg[common.Hash]{
+		PrevHeadNumber: 10,
+		PrevHeadHash:   cltest.Head(10).Hash,
+		NewHeadNumber:  11,
+		NewHeadHash:    cltest.Head(11).Hash,
+		Depth:          1,
+		DroppedHashes:  []common.Hash{cltest.Head(10).Hash},
+		NewHashes:      []common.Hash{cltest.Head(11).Hash},
+	})
+	require.NoError(t, orm.InsertReorg(testutils.Context(t), withoutAncestor))
+
+	reorgs, count, err = orm.Reorgs(testutils.Context(t), 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Len(t, reorgs, 2)
+
+	// most recent first
+	assert.Equal(t, withoutAncestor.ID, reorgs[0].ID)
+	assert.Nil(t, reorgs[0].CommonAncestorHash)
+	assert.False(t, reorgs[0].CommonAncestorNumber.Valid)
+
+	assert.Equal(t, withAncestor.ID, reorgs[1].ID)
+	assert.Equal(t, cltest.FixtureChainID.String(), reorgs[1].EVMChainID.String())
+	assert.Equal(t, int64(2), reorgs[1].Depth)
+	require.NotNil(t, reorgs[1].CommonAncestorHash)
+	assert.Equal(t, ancestor.Hash, *reorgs[1].CommonAncestorHash)
+	assert.Equal(t, withAncestor.DroppedHashes, reorgs[1].DroppedHashes)
+	assert.Equal(t, withAncestor.NewHashes, reorgs[1].NewHashes)
+
+	// paginates
+	reorgs, count, err = orm.Reorgs(testutils.Context(t), 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Len(t, reorgs, 1)
+	assert.Equal(t, withAncestor.ID, reorgs[0].ID)
+}
@@ -0,0 +1,85 @@
+package headtracker
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+
+	commontypes "github.com/smartcontractkit/chainlink/v2/common/types"
+	"github.com/smartcontractkit/chainlink/v2/core/null"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// Reorg is the persisted report of a reorg of the longest chain, as detected by the head tracker.
+type Reorg struct {
+	ID             int64
+	EVMChainID     utils.Big
+	PrevHeadNumber int64
+	PrevHeadHash   common.Hash
+	NewHeadNumber  int64
+	NewHeadHash    common.Hash
+	// CommonAncestorNumber and CommonAncestorHash are null if the common ancestor
+	// is older than the heads available to the head tracker
+	CommonAncestorNumber null.Int64
+	CommonAncestorHash   *common.Hash
+	Depth                int64
+	DroppedHashes        []common.Hash
+	NewHashes            []common.Hash
+	CreatedAt            time.Time
+}
+
+// NewReorg converts a reorg reported by the head tracker for storage.
+func NewReorg(r commontypes.Reorg[common.Hash]) *Reorg {
+	reorg := &Reorg{
+		PrevHeadNumber: r.PrevHeadNumber,
+		PrevHeadHash:   r.PrevHeadHash,
+		NewHeadNumber:  r.NewHeadNumber,
+		NewHeadHash:    r.NewHeadHash,
+		Depth:          r.Depth,
+		DroppedHashes:  r.DroppedHashes,
+		NewHashes:      r.NewHashes,
+	}
+	if r.HasCommonAncestor {
+		reorg.CommonAncestorNumber = null.Int64From(r.CommonAncestorNumber)
+		hash := r.CommonAncestorHash
+		reorg.CommonAncestorHash = &hash
+	}
+	return reorg
+}
+
+// reorgRow is a helper type for reading and writing reorgs to the database. This is necessary
+// because the bytea[] in the DB is not automatically convertible to or from the reorg's
+// hash fields. pq.ByteaArray must be used instead.
+type reorgRow struct {
+	*Reorg
+	DroppedHashes pq.ByteaArray
+	NewHashes     pq.ByteaArray
+}
+
+func toReorgRow(reorg *Reorg) reorgRow {
+	return reorgRow{Reorg: reorg, DroppedHashes: toByteaArray(reorg.DroppedHashes), NewHashes: toByteaArray(reorg.NewHashes)}
+}
+
+func (r reorgRow) toReorg() Reorg {
+	reorg := *r.Reorg
+	reorg.DroppedHashes = fromByteaArray(r.DroppedHashes)
+	reorg.NewHashes = fromByteaArray(r.NewHashes)
+	return reorg
+}
+
+func toByteaArray(hashes []common.Hash) pq.ByteaArray {
+	arr := make(pq.ByteaArray, len(hashes))
+	for i, h := range hashes {
+		arr[i] = h.Bytes()
+	}
+	return arr
+}
+
+func fromByteaArray(arr pq.ByteaArray) []common.Hash {
+	hashes := make([]common.Hash, len(arr))
+	for i, b := range arr {
+		hashes[i] = common.BytesToHash(b)
+	}
+	return hashes
+}
@@ -39,7 +39,7 @@ func NewTxm(
 	var fwdMgr FwdMgr
 
 	if txConfig.ForwardersEnabled() {
-		fwdMgr = forwarders.NewFwdMgr(db, client, logPoller, lggr, chainConfig, dbConfig)
+		fwdMgr = forwarders.NewFwdMgr(db, client, logPoller, keyStore, lggr, chainConfig, dbConfig)
 	} else {
 		lggr.Info("EvmForwarderManager: Disabled")
 	}
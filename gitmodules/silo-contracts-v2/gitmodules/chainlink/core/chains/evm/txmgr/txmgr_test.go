@@ -300,7 +300,7 @@ func TestTxm_CreateTransaction(t *testing.T) {
 		// Create mock forwarder, mock authorizedsenders call.
 		form := forwarders.NewORM(db, logger.TestLogger(t), cfg.Database())
 		fwdrAddr := testutils.NewAddress()
-		fwdr, err := form.CreateForwarder(fwdrAddr, utils.Big(cltest.FixtureChainID))
+		fwdr, err := form.CreateForwarder(fwdrAddr, utils.Big(cltest.FixtureChainID), forwarders.TypeAuthorizedForwarder, nil, false)
 		require.NoError(t, err)
 		require.Equal(t, fwdr.Address, fwdrAddr)
 
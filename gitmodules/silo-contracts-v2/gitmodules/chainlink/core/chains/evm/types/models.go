@@ -41,6 +41,8 @@ type Head struct {
 	StateRoot        common.Hash
 	Difficulty       *utils.Big
 	TotalDifficulty  *utils.Big
+	// IsFinalized is set on the single head per chain that was last reported by the RPC's `finalized` block tag
+	IsFinalized bool
 }
 
 var _ commontypes.Head[common.Hash] = &Head{}
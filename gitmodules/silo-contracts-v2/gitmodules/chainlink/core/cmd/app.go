@@ -300,6 +300,11 @@ func NewApp(s *Shell) *cli.App {
 			Usage:       "Commands for managing forwarder addresses.",
 			Subcommands: initFowardersSubCmds(s),
 		},
+		{
+			Name:        "subpipelines",
+			Usage:       "Commands for managing named subpipelines invoked by subpipeline tasks.",
+			Subcommands: initSubpipelinesSubCmds(s),
+		},
 	}...)
 	return app
 }
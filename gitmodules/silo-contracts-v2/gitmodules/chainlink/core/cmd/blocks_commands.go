@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
 
 func initBlocksSubCmds(s *Shell) []cli.Command {
@@ -34,6 +37,22 @@ func initBlocksSubCmds(s *Shell) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "reorgs",
+			Usage:  "Lists the reorgs detected by the head tracker, most recent first",
+			Action: s.ListReorgs,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "evm-chain-id",
+					Usage:    "Chain ID of the EVM-based blockchain",
+					Required: false,
+				},
+				cli.IntFlag{
+					Name:  "page",
+					Usage: "page of results to display",
+				},
+			},
+		},
 	}
 }
 
@@ -75,3 +94,59 @@ func (s *Shell) ReplayFromBlock(c *cli.Context) (err error) {
 	fmt.Println("Replay started")
 	return nil
 }
+
+type EVMReorgPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.EVMReorgResource
+}
+
+var evmReorgsHeaders = []string{"ID", "Chain ID", "Depth", "Previous Head", "New Head", "Common Ancestor", "Detected At"}
+
+// ToRow presents the EVMReorgResource as a slice of strings.
+func (p *EVMReorgPresenter) ToRow() []string {
+	commonAncestor := "unknown"
+	if p.CommonAncestorHash != nil && p.CommonAncestorNumber.Valid {
+		commonAncestor = fmt.Sprintf("%d (%s)", p.CommonAncestorNumber.Int64, p.CommonAncestorHash.Hex())
+	}
+	row := []string{
+		p.GetID(),
+		p.EVMChainID.ToInt().String(),
+		strconv.FormatInt(p.Depth, 10),
+		fmt.Sprintf("%d (%s)", p.PrevHeadNumber, p.PrevHeadHash.Hex()),
+		fmt.Sprintf("%d (%s)", p.NewHeadNumber, p.NewHeadHash.Hex()),
+		commonAncestor,
+		p.CreatedAt.Format(time.RFC3339),
+	}
+	return row
+}
+
+// RenderTable implements TableRenderer
+func (p *EVMReorgPresenter) RenderTable(rt RendererTable) error {
+	renderList(evmReorgsHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+// EVMReorgPresenters implements TableRenderer for a slice of EVMReorgPresenter.
+type EVMReorgPresenters []EVMReorgPresenter
+
+// RenderTable implements TableRenderer
+func (ps EVMReorgPresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	renderList(evmReorgsHeaders, rows, rt.Writer)
+
+	return nil
+}
+
+// ListReorgs lists the reorgs recorded by the head tracker of a chain
+func (s *Shell) ListReorgs(c *cli.Context) (err error) {
+	v := url.Values{}
+	if c.IsSet("evm-chain-id") {
+		v.Add("evmChainID", fmt.Sprintf("%d", c.Int64("evm-chain-id")))
+	}
+	return s.getPage("/v2/reorgs/evm?"+v.Encode(), c.Int("page"), &EVMReorgPresenters{})
+}
@@ -5,10 +5,16 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
 
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
@@ -42,3 +48,43 @@ func Test_ReplayFromBlock(t *testing.T) {
 	c = cli.NewContext(nil, set, nil)
 	require.NoError(t, client.ReplayFromBlock(c))
 }
+
+func TestShell_ListReorgs(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].ChainID = (*utils.Big)(big.NewInt(5))
+		c.EVM[0].Enabled = ptr(true)
+	})
+	client, r := app.NewShellAndRenderer()
+
+	orm := headtracker.NewORM(app.GetSqlxDB(), logger.TestLogger(t), app.GetConfig().Database(), *big.NewInt(5))
+	reorg := &headtracker.Reorg{
+		PrevHeadNumber: 10,
+		PrevHeadHash:   utils.NewHash(),
+		NewHeadNumber:  10,
+		NewHeadHash:    utils.NewHash(),
+		Depth:          2,
+		DroppedHashes:  []common.Hash{utils.NewHash(), utils.NewHash()},
+		NewHashes:      []common.Hash{utils.NewHash(), utils.NewHash()},
+	}
+	require.NoError(t, orm.InsertReorg(testutils.Context(t), reorg))
+
+	set := flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.ListReorgs, set, "")
+
+	require.NoError(t, set.Set("evm-chain-id", "5"))
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.ListReorgs(c))
+
+	reorgs := *r.Renders[0].(*cmd.EVMReorgPresenters)
+	require.Len(t, reorgs, 1)
+	assert.Equal(t, reorg.NewHeadHash, reorgs[0].NewHeadHash)
+	assert.Equal(t, int64(2), reorgs[0].Depth)
+	assert.Equal(t, "unknown", reorgs[0].ToRow()[5])
+
+	//Incorrect chain ID
+	require.NoError(t, set.Set("evm-chain-id", "1"))
+	c = cli.NewContext(nil, set, nil)
+	require.Error(t, client.ListReorgs(c))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"strings"
 	"time"
 
 	gethCommon "github.com/ethereum/go-ethereum/common"
@@ -14,6 +15,7 @@ import (
 	"github.com/urfave/cli"
 	"go.uber.org/multierr"
 
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/forwarders"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
@@ -39,6 +41,29 @@ func initFowardersSubCmds(s *Shell) []cli.Command {
 					Name:  "address, a",
 					Usage: "The forwarding address (in hex format)",
 				},
+				cli.StringFlag{
+					Name:  "type, t",
+					Usage: "The forwarder type, one of: " + strings.Join(forwarders.Types(), ", ") + ". Defaults to " + string(forwarders.TypeAuthorizedForwarder),
+				},
+				cli.StringFlag{
+					Name:  "next-hop",
+					Usage: "The address of a tracked forwarder to route transactions through (in hex format)",
+				},
+				cli.BoolFlag{
+					Name:  "stop-routing-on-drift",
+					Usage: "Stop routing transactions through the forwarder from keys it no longer authorizes",
+				},
+			},
+		},
+		{
+			Name:   "update",
+			Usage:  "Update a forwarder, routing transactions from keys it no longer authorizes unless --stop-routing-on-drift is passed",
+			Action: s.UpdateForwarder,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "stop-routing-on-drift",
+					Usage: "Stop routing transactions through the forwarder from keys it no longer authorizes",
+				},
 			},
 		},
 		{
@@ -54,14 +79,31 @@ type EVMForwarderPresenter struct {
 	presenters.EVMForwarderResource
 }
 
-var evmFwdsHeaders = []string{"ID", "Address", "Chain ID", "Created At"}
+var evmFwdsHeaders = []string{"ID", "Address", "Chain ID", "Type", "Next Hop", "Drifted Senders", "Stop Routing On Drift", "Health Checked At", "Created At"}
 
 // ToRow presents the EVMForwarderResource as a slice of strings.
 func (p *EVMForwarderPresenter) ToRow() []string {
+	var drifted []string
+	for _, addr := range p.DriftedSenders {
+		drifted = append(drifted, addr.String())
+	}
+	var nextHop string
+	if p.NextHop != nil {
+		nextHop = p.NextHop.String()
+	}
+	var healthCheckedAt string
+	if p.HealthCheckedAt != nil {
+		healthCheckedAt = p.HealthCheckedAt.Format(time.RFC3339)
+	}
 	row := []string{
 		p.GetID(),
 		p.Address.String(),
 		p.EVMChainID.ToInt().String(),
+		p.Type,
+		nextHop,
+		strings.Join(drifted, "\n"),
+		fmt.Sprintf("%v", p.StopRoutingOnDrift),
+		healthCheckedAt,
 		p.CreatedAt.Format(time.RFC3339),
 	}
 	return row
@@ -97,6 +139,31 @@ func (s *Shell) ListForwarders(c *cli.Context) (err error) {
 	return s.getPage("/v2/nodes/evm/forwarders", c.Int("page"), &EVMForwarderPresenters{})
 }
 
+// UpdateForwarder sets whether routing through a forwarder stops for keys it no longer authorizes.
+func (s *Shell) UpdateForwarder(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the forwarder id to be updated"))
+	}
+	request, err := json.Marshal(web.UpdateEVMForwarderRequest{
+		StopRoutingOnDrift: c.Bool("stop-routing-on-drift"),
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Patch("/v2/nodes/evm/forwarders/"+c.Args().First(), bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &EVMForwarderPresenter{}, "Forwarder updated")
+}
+
 // DeleteForwarder deletes forwarder address from node db by id.
 func (s *Shell) DeleteForwarder(c *cli.Context) (err error) {
 	if !c.Args().Present() {
@@ -120,12 +187,27 @@ func (s *Shell) TrackForwarder(c *cli.Context) (err error) {
 	addressHex := c.String("address")
 	chainIDStr := c.String("evm-chain-id")
 
+	fwdType, err := forwarders.ParseType(c.String("type"))
+	if err != nil {
+		return s.errorOut(err)
+	}
+
 	addressBytes, err := hexutil.Decode(addressHex)
 	if err != nil {
 		return s.errorOut(errors.Wrap(err, "could not decode address"))
 	}
 	address := gethCommon.BytesToAddress(addressBytes)
 
+	var nextHop *gethCommon.Address
+	if c.IsSet("next-hop") {
+		nextHopBytes, err2 := hexutil.Decode(c.String("next-hop"))
+		if err2 != nil {
+			return s.errorOut(errors.Wrap(err2, "could not decode next hop address"))
+		}
+		addr := gethCommon.BytesToAddress(nextHopBytes)
+		nextHop = &addr
+	}
+
 	var chainID *big.Int
 	if chainIDStr != "" {
 		var ok bool
@@ -136,8 +218,11 @@ func (s *Shell) TrackForwarder(c *cli.Context) (err error) {
 	}
 
 	request, err := json.Marshal(web.TrackEVMForwarderRequest{
-		EVMChainID: (*utils.Big)(chainID),
-		Address:    address,
+		EVMChainID:         (*utils.Big)(chainID),
+		Address:            address,
+		Type:               string(fwdType),
+		StopRoutingOnDrift: c.Bool("stop-routing-on-drift"),
+		NextHop:            nextHop,
 	})
 	if err != nil {
 		return s.errorOut(err)
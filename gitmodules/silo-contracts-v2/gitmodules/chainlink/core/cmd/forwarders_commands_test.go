@@ -25,6 +25,8 @@ func TestEVMForwarderPresenter_RenderTable(t *testing.T) {
 		id         = "1"
 		address    = common.HexToAddress("0x5431F5F973781809D18643b87B44921b11355d81")
 		evmChainID = utils.NewBigI(4)
+		drifted    = common.HexToAddress("0x7e57000000000000000000000000000000000001")
+		nextHop    = common.HexToAddress("0x7e57000000000000000000000000000000000002")
 		createdAt  = time.Now()
 		updatedAt  = time.Now().Add(time.Second)
 		buffer     = bytes.NewBufferString("")
@@ -33,11 +35,14 @@ func TestEVMForwarderPresenter_RenderTable(t *testing.T) {
 
 	p := cmd.EVMForwarderPresenter{
 		EVMForwarderResource: presenters.EVMForwarderResource{
-			JAID:       presenters.NewJAID(id),
-			Address:    address,
-			EVMChainID: *evmChainID,
-			CreatedAt:  createdAt,
-			UpdatedAt:  updatedAt,
+			JAID:           presenters.NewJAID(id),
+			Address:        address,
+			EVMChainID:     *evmChainID,
+			Type:           "safe_module",
+			NextHop:        &nextHop,
+			DriftedSenders: []common.Address{drifted},
+			CreatedAt:      createdAt,
+			UpdatedAt:      updatedAt,
 		},
 	}
 
@@ -48,6 +53,9 @@ func TestEVMForwarderPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, id)
 	assert.Contains(t, output, address.String())
 	assert.Contains(t, output, evmChainID.ToInt().String())
+	assert.Contains(t, output, "safe_module")
+	assert.Contains(t, output, nextHop.String())
+	assert.Contains(t, output, drifted.String())
 	assert.Contains(t, output, createdAt.Format(time.RFC3339))
 
 	// Render many resources
@@ -59,6 +67,7 @@ func TestEVMForwarderPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, id)
 	assert.Contains(t, output, address.String())
 	assert.Contains(t, output, evmChainID.ToInt().String())
+	assert.Contains(t, output, "safe_module")
 	assert.Contains(t, output, createdAt.Format(time.RFC3339))
 }
 
@@ -78,12 +87,16 @@ func TestShell_TrackEVMForwarder(t *testing.T) {
 
 	require.NoError(t, set.Set("address", "0x5431F5F973781809D18643b87B44921b11355d81"))
 	require.NoError(t, set.Set("evmChainID", id.String()))
+	require.NoError(t, set.Set("type", "erc2771"))
+	require.NoError(t, set.Set("stop-routing-on-drift", "true"))
 
 	err := client.TrackForwarder(cli.NewContext(nil, set, nil))
 	require.NoError(t, err)
 	require.Len(t, r.Renders, 1)
 	createOutput, ok := r.Renders[0].(*cmd.EVMForwarderPresenter)
 	require.True(t, ok, "Expected Renders[0] to be *cmd.EVMForwarderPresenter, got %T", r.Renders[0])
+	assert.Equal(t, "erc2771", createOutput.Type)
+	assert.True(t, createOutput.StopRoutingOnDrift)
 
 	// Assert fwdr is listed
 	require.Nil(t, client.ListForwarders(cltest.EmptyCLIContext()))
@@ -91,6 +104,17 @@ func TestShell_TrackEVMForwarder(t *testing.T) {
 	require.Equal(t, 1, len(fwds))
 	assert.Equal(t, createOutput.ID, fwds[0].ID)
 
+	// Update fwdr
+	set = flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.UpdateForwarder, set, "")
+	require.NoError(t, set.Parse([]string{createOutput.ID}))
+	require.NoError(t, client.UpdateForwarder(cli.NewContext(nil, set, nil)))
+	require.Len(t, r.Renders, 3)
+	updateOutput, ok := r.Renders[2].(*cmd.EVMForwarderPresenter)
+	require.True(t, ok, "Expected Renders[2] to be *cmd.EVMForwarderPresenter, got %T", r.Renders[2])
+	assert.Equal(t, createOutput.ID, updateOutput.ID)
+	assert.False(t, updateOutput.StopRoutingOnDrift)
+
 	// Delete fwdr
 	set = flag.NewFlagSet("test", 0)
 	cltest.FlagSetApplyFromAction(client.DeleteForwarder, set, "")
@@ -102,8 +126,8 @@ func TestShell_TrackEVMForwarder(t *testing.T) {
 
 	// Assert fwdr is not listed
 	require.Nil(t, client.ListForwarders(cltest.EmptyCLIContext()))
-	require.Len(t, r.Renders, 3)
-	fwds = *r.Renders[2].(*cmd.EVMForwarderPresenters)
+	require.Len(t, r.Renders, 4)
+	fwds = *r.Renders[3].(*cmd.EVMForwarderPresenters)
 	require.Equal(t, 0, len(fwds))
 }
 
@@ -5,14 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/manyminds/api2go/jsonapi"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/urfave/cli"
 	"go.uber.org/multierr"
 
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
@@ -37,22 +44,177 @@ func initJobsSubCmds(s *Shell) []cli.Command {
 		},
 		{
 			Name:   "create",
-			Usage:  "Create a job",
+			Usage:  "Create a job from TOML or a filepath, or from a job template",
 			Action: s.CreateJob,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "template",
+					Usage: "name of the job template to create the job from, instead of TOML",
+				},
+				cli.StringSliceFlag{
+					Name:  "param",
+					Usage: "value of a template parameter as name=value, can be repeated",
+				},
+			},
 		},
 		{
 			Name:   "delete",
 			Usage:  "Delete a job",
 			Action: s.DeleteJob,
 		},
+		{
+			Name:   "pause",
+			Usage:  "Pause a job, stopping its services but keeping its spec, runs and keys",
+			Action: s.PauseJob,
+		},
+		{
+			Name:   "resume",
+			Usage:  "Resume a paused job",
+			Action: s.ResumeJob,
+		},
+		{
+			Name:   "history",
+			Usage:  "List the versions of the spec of a job",
+			Action: s.JobHistory,
+		},
+		{
+			Name:   "diff",
+			Usage:  "Show the changes between two versions of the spec of a job, from and to (default: latest version)",
+			Action: s.DiffJobVersions,
+		},
+		{
+			Name:   "rollback",
+			Usage:  "Replace the spec of a job with one of its previous versions",
+			Action: s.RollbackJob,
+		},
 		{
 			Name:   "run",
 			Usage:  "Trigger a job run",
 			Action: s.TriggerPipelineRun,
 		},
+		{
+			Name:   "replay",
+			Usage:  "Re-execute a stored job run with its recorded http, bridge, ethcall, estimategaslimit and wsLatest results, and show the outputs that changed",
+			Action: s.ReplayPipelineRun,
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "refetch",
+					Usage: "dot ID of an http, bridge, ethcall, estimategaslimit or wsLatest task to execute live instead of using its recorded result, can be repeated",
+				},
+			},
+		},
+		{
+			Name:  "templates",
+			Usage: "Manage parameterized job specs",
+			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "List the latest version of every job template",
+					Action: s.ListJobTemplates,
+				},
+				{
+					Name:   "show",
+					Usage:  "Show the latest version of a job template",
+					Action: s.ShowJobTemplate,
+				},
+				{
+					Name:   "create",
+					Usage:  "Create a job template from a name and a file with its definition, or a new version of it, and list the jobs created from previous versions",
+					Action: s.CreateJobTemplate,
+					Flags: []cli.Flag{
+						cli.StringSliceFlag{
+							Name:  "param",
+							Usage: "parameter of the template as name=type, where type is one of string, int, bool, duration, address or bytes32, can be repeated",
+						},
+					},
+				},
+				{
+					Name:   "jobs",
+					Usage:  "List the jobs created from a job template",
+					Action: s.ListJobTemplateJobs,
+				},
+			},
+		},
+		{
+			Name:  "webhook-secret",
+			Usage: "Manage the secret third parties sign requests running a webhook job with, see POST /v2/webhooks/:externalJobID/runs",
+			Subcommands: []cli.Command{
+				{
+					Name:   "generate",
+					Usage:  "Generate a new secret for a webhook job, replacing the previous one",
+					Action: s.GenerateWebhookSecret,
+				},
+				{
+					Name:   "remove",
+					Usage:  "Remove the secret of a webhook job, so it can no longer be run by signed requests",
+					Action: s.RemoveWebhookSecret,
+				},
+			},
+		},
+		{
+			Name:  "shadow",
+			Usage: "Run a candidate pipeline alongside a job without acting on its results",
+			Subcommands: []cli.Command{
+				{
+					Name:   "set",
+					Usage:  "Set the shadow pipeline of a job from a file with its observation source",
+					Action: s.SetJobShadow,
+				},
+				{
+					Name:   "remove",
+					Usage:  "Remove the shadow pipeline of a job",
+					Action: s.RemoveJobShadow,
+				},
+				{
+					Name:   "report",
+					Usage:  "Compare the results of the shadow runs of a job to those of its live runs",
+					Action: s.JobShadowReport,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "limit",
+							Usage: "number of most recent shadow runs to compare",
+							Value: 1000,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:  "archive",
+			Usage: "Query and re-import job runs from the pipeline run archive",
+			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "List archived job runs",
+					Action: s.ListArchivedPipelineRuns,
+					Flags:  archiveFilterFlags,
+				},
+				{
+					Name:   "import",
+					Usage:  "Import archived job runs back into the database",
+					Action: s.ImportArchivedPipelineRuns,
+					Flags:  archiveFilterFlags,
+				},
+			},
+		},
 	}
 }
 
+var archiveFilterFlags = []cli.Flag{
+	cli.Int64Flag{
+		Name:  "job-id",
+		Usage: "only include runs of this job",
+	},
+	cli.StringFlag{
+		Name:  "from",
+		Usage: "first day (YYYY-MM-DD, UTC) the runs finished on",
+	},
+	cli.StringFlag{
+		Name:  "to",
+		Usage: "last day (YYYY-MM-DD, UTC) the runs finished on",
+	},
+}
+
 // JobPresenter wraps the JSONAPI Job Resource and adds rendering functionality
 type JobPresenter struct {
 	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
@@ -164,6 +326,10 @@ func (p JobPresenter) FriendlyCreatedAt() string {
 		if p.GatewaySpec != nil {
 			return p.GatewaySpec.CreatedAt.Format(time.RFC3339)
 		}
+	case presenters.EVMLogJobSpec:
+		if p.EVMLogSpec != nil {
+			return p.EVMLogSpec.CreatedAt.Format(time.RFC3339)
+		}
 	default:
 		return "unknown"
 	}
@@ -228,18 +394,27 @@ func (s *Shell) ShowJob(c *cli.Context) (err error) {
 // CreateJob creates a job
 // Valid input is a TOML string or a path to TOML file
 func (s *Shell) CreateJob(c *cli.Context) (err error) {
-	if !c.Args().Present() {
-		return s.errorOut(errors.New("must pass in TOML or filepath"))
-	}
-
-	tomlString, err := getTOMLString(c.Args().First())
-	if err != nil {
-		return s.errorOut(err)
+	var jobRequest web.CreateJobRequest
+	if template := c.String("template"); template != "" {
+		if c.Args().Present() {
+			return s.errorOut(errors.New("cannot pass in TOML when creating a job from a template"))
+		}
+		jobRequest.Template = template
+		jobRequest.Params, err = parseKeyValues(c.StringSlice("param"))
+		if err != nil {
+			return s.errorOut(errors.Wrap(err, "invalid param"))
+		}
+	} else {
+		if !c.Args().Present() {
+			return s.errorOut(errors.New("must pass in TOML or filepath"))
+		}
+		jobRequest.TOML, err = getTOMLString(c.Args().First())
+		if err != nil {
+			return s.errorOut(err)
+		}
 	}
 
-	request, err := json.Marshal(web.CreateJobRequest{
-		TOML: tomlString,
-	})
+	request, err := json.Marshal(jobRequest)
 	if err != nil {
 		return s.errorOut(err)
 	}
@@ -286,6 +461,529 @@ func (s *Shell) DeleteJob(c *cli.Context) error {
 	return nil
 }
 
+// PauseJob pauses a job
+func (s *Shell) PauseJob(c *cli.Context) (err error) {
+	return s.setJobPaused(c, "pause", "Job paused")
+}
+
+// ResumeJob resumes a paused job
+func (s *Shell) ResumeJob(c *cli.Context) (err error) {
+	return s.setJobPaused(c, "resume", "Job resumed")
+}
+
+func (s *Shell) setJobPaused(c *cli.Context, action string, header string) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.Errorf("must pass the id of the job to %s", action))
+	}
+	resp, err := s.HTTP.Post("/v2/jobs/"+c.Args().First()+"/"+action, nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobPresenter{}, header)
+}
+
+// JobSpecVersionPresenter wraps the JSONAPI job spec version resource and adds rendering
+// functionality
+type JobSpecVersionPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobSpecVersionResource
+}
+
+// ToRow presents the version as a row
+func (p JobSpecVersionPresenter) ToRow() []string {
+	return []string{
+		strconv.Itoa(int(p.Version)),
+		p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type JobSpecVersionPresenters []JobSpecVersionPresenter
+
+// RenderTable implements TableRenderer
+func (ps JobSpecVersionPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Version", "Created At"})
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+
+	render("Job Spec Versions", table)
+	return nil
+}
+
+// JobSpecDiffPresenter presents the changes between two versions of the spec of a job
+type JobSpecDiffPresenter struct {
+	From int32  `json:"from"`
+	To   int32  `json:"to"`
+	Diff string `json:"diff"`
+}
+
+// RenderTable implements TableRenderer
+func (p *JobSpecDiffPresenter) RenderTable(rt RendererTable) error {
+	if p.Diff == "" {
+		_, err := fmt.Fprintf(rt, "Versions %d and %d are identical\n", p.From, p.To)
+		return err
+	}
+	_, err := fmt.Fprint(rt, p.Diff)
+	return err
+}
+
+// JobHistory lists the versions of the spec of a job
+func (s *Shell) JobHistory(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the job id"))
+	}
+	var versions JobSpecVersionPresenters
+	if err = s.getJobSpecVersions(c.Args().First(), &versions); err != nil {
+		return s.errorOut(err)
+	}
+	return s.errorOut(s.Render(&versions))
+}
+
+// DiffJobVersions shows the changes between two versions of the spec of a job
+func (s *Shell) DiffJobVersions(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return s.errorOut(errors.New("must pass the job id and the version to diff from"))
+	}
+	from, err := strconv.ParseInt(c.Args().Get(1), 10, 32)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "invalid from version"))
+	}
+	to := int64(-1)
+	if c.NArg() > 2 {
+		to, err = strconv.ParseInt(c.Args().Get(2), 10, 32)
+		if err != nil {
+			return s.errorOut(errors.Wrap(err, "invalid to version"))
+		}
+	}
+
+	var versions JobSpecVersionPresenters
+	if err = s.getJobSpecVersions(c.Args().First(), &versions); err != nil {
+		return s.errorOut(err)
+	}
+	if len(versions) == 0 {
+		return s.errorOut(errors.Errorf("job %s has no recorded versions", c.Args().First()))
+	}
+	if to == -1 {
+		to = int64(versions[len(versions)-1].Version)
+	}
+
+	fromVersion, toVersion := findJobSpecVersion(versions, int32(from)), findJobSpecVersion(versions, int32(to))
+	if fromVersion == nil {
+		return s.errorOut(errors.Errorf("job %s has no version %d", c.Args().First(), from))
+	}
+	if toVersion == nil {
+		return s.errorOut(errors.Errorf("job %s has no version %d", c.Args().First(), to))
+	}
+
+	diff, err := jobSpecDiff(fromVersion.JobSpecVersionResource, toVersion.JobSpecVersionResource)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	return s.errorOut(s.Render(&JobSpecDiffPresenter{From: fromVersion.Version, To: toVersion.Version, Diff: diff}))
+}
+
+// RollbackJob replaces the spec of a job with one of its previous versions
+func (s *Shell) RollbackJob(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return s.errorOut(errors.New("must pass the job id and the version to roll back to"))
+	}
+	resp, err := s.HTTP.Post("/v2/jobs/"+c.Args().First()+"/versions/"+c.Args().Get(1)+"/rollback", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobPresenter{}, "Job rolled back")
+}
+
+func (s *Shell) getJobSpecVersions(jobID string, versions *JobSpecVersionPresenters) (err error) {
+	resp, err := s.HTTP.Get("/v2/jobs/" + jobID + "/versions")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var links jsonapi.Links
+	return s.deserializeAPIResponse(resp, versions, &links)
+}
+
+func findJobSpecVersion(versions JobSpecVersionPresenters, version int32) *JobSpecVersionPresenter {
+	for i := range versions {
+		if versions[i].Version == version {
+			return &versions[i]
+		}
+	}
+	return nil
+}
+
+// jobSpecDiff returns the unified diff between the definitions of two versions of a job spec,
+// which is empty if they are identical.
+func jobSpecDiff(from, to presenters.JobSpecVersionResource) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from.Definition),
+		B:        difflib.SplitLines(to.Definition),
+		FromFile: fmt.Sprintf("version %d", from.Version),
+		FromDate: from.CreatedAt.Format(time.RFC3339),
+		ToFile:   fmt.Sprintf("version %d", to.Version),
+		ToDate:   to.CreatedAt.Format(time.RFC3339),
+		Context:  3,
+	})
+}
+
+// parseKeyValues parses values of the form key=value.
+func parseKeyValues(kvs []string) (map[string]string, error) {
+	m := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, errors.Errorf("expected key=value, got %q", kv)
+		}
+		if _, dup := m[k]; dup {
+			return nil, errors.Errorf("duplicate key %q", k)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// JobTemplatePresenter wraps the JSONAPI job template resource and adds rendering functionality
+type JobTemplatePresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobTemplateResource
+}
+
+// ToRow presents the template as a row
+func (p JobTemplatePresenter) ToRow() []string {
+	var params []string
+	for _, param := range p.Parameters {
+		params = append(params, param.Name+"="+string(param.Type))
+	}
+	return []string{
+		p.Name,
+		strconv.Itoa(int(p.Version)),
+		strings.Join(params, "\n"),
+		p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *JobTemplatePresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Name", "Version", "Parameters", "Created At"})
+	table.Append(p.ToRow())
+	render("Job Template", table)
+
+	_, err := fmt.Fprintf(rt, "\n%s\n", p.Definition)
+	return err
+}
+
+type JobTemplatePresenters []JobTemplatePresenter
+
+// RenderTable implements TableRenderer
+func (ps JobTemplatePresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Name", "Version", "Parameters", "Created At"})
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+
+	render("Job Templates", table)
+	return nil
+}
+
+// JobTemplateJobPresenter wraps the JSONAPI resource of a job created from a job template and
+// adds rendering functionality
+type JobTemplateJobPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobTemplateJobResource
+}
+
+// ToRow presents the job as a row
+func (p JobTemplateJobPresenter) ToRow() []string {
+	return []string{
+		p.ID,
+		p.Name,
+		strconv.Itoa(int(p.TemplateVersion)),
+		strconv.FormatBool(p.Outdated),
+	}
+}
+
+type JobTemplateJobPresenters []JobTemplateJobPresenter
+
+// RenderTable implements TableRenderer
+func (ps JobTemplateJobPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"ID", "Name", "Template Version", "Outdated"})
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+
+	render("Jobs", table)
+	return nil
+}
+
+// ListJobTemplates lists the latest version of every job template
+func (s *Shell) ListJobTemplates(c *cli.Context) (err error) {
+	resp, err := s.HTTP.Get("/v2/job_templates")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobTemplatePresenters{})
+}
+
+// ShowJobTemplate shows the latest version of a job template
+func (s *Shell) ShowJobTemplate(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the template name"))
+	}
+	resp, err := s.HTTP.Get("/v2/job_templates/" + url.PathEscape(c.Args().First()))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobTemplatePresenter{})
+}
+
+// CreateJobTemplate creates a job template, or a new version of it. If jobs were created from
+// previous versions, they are listed.
+func (s *Shell) CreateJobTemplate(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return s.errorOut(errors.New("must pass the template name and the path to its definition"))
+	}
+	definition, err := os.ReadFile(c.Args().Get(1))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	params, err := parseKeyValues(c.StringSlice("param"))
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "invalid param"))
+	}
+	templateRequest := web.CreateJobTemplateRequest{
+		Name:       c.Args().First(),
+		Definition: string(definition),
+		Parameters: job.TemplateParameters{},
+	}
+	// keep the order of the flags
+	for _, kv := range c.StringSlice("param") {
+		name, _, _ := strings.Cut(kv, "=")
+		templateRequest.Parameters = append(templateRequest.Parameters, job.TemplateParameter{
+			Name: name,
+			Type: job.TemplateParameterType(params[name]),
+		})
+	}
+
+	request, err := json.Marshal(templateRequest)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	resp, err := s.HTTP.Post("/v2/job_templates", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var template JobTemplatePresenter
+	if err = s.renderAPIResponse(resp, &template, "Job template created"); err != nil || template.Version == 1 {
+		return err
+	}
+	return s.ListJobTemplateJobs(c)
+}
+
+// ListJobTemplateJobs lists the jobs created from a job template, and whether they were created
+// from a previous version of it
+func (s *Shell) ListJobTemplateJobs(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the template name"))
+	}
+	resp, err := s.HTTP.Get("/v2/job_templates/" + url.PathEscape(c.Args().First()) + "/jobs")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobTemplateJobPresenters{})
+}
+
+// WebhookSecretPresenter wraps the JSONAPI webhook secret resource and adds rendering
+// functionality
+type WebhookSecretPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.WebhookSecretResource
+}
+
+// RenderTable implements TableRenderer
+func (p *WebhookSecretPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Job ID", "Secret"})
+	table.Append([]string{p.ID, p.Secret})
+	render("Webhook Secret", table)
+
+	_, err := fmt.Fprintf(rt, "Sign requests with HMAC-SHA256 of \"<%s>.<%s>.<body>\" as %s, the secret will not be shown again\n",
+		webhook.TimestampHeader, webhook.NonceHeader, webhook.SignatureHeader)
+	return err
+}
+
+// GenerateWebhookSecret generates a new secret for a webhook job, and shows it
+func (s *Shell) GenerateWebhookSecret(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the job id"))
+	}
+	resp, err := s.HTTP.Post("/v2/jobs/"+c.Args().First()+"/webhook_secret", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &WebhookSecretPresenter{})
+}
+
+// RemoveWebhookSecret removes the secret of a webhook job
+func (s *Shell) RemoveWebhookSecret(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the job id"))
+	}
+	resp, err := s.HTTP.Delete("/v2/jobs/" + c.Args().First() + "/webhook_secret")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	_, err = s.parseResponse(resp)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	fmt.Printf("Webhook secret of job %v removed\n", c.Args().First())
+	return nil
+}
+
+// JobShadowReportPresenter wraps the JSONAPI job shadow report resource and adds rendering
+// functionality
+type JobShadowReportPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobShadowReportResource
+}
+
+// ToRows presents the report as rows of fields and values
+func (p *JobShadowReportPresenter) ToRows() [][]string {
+	return [][]string{
+		{"Job ID", p.ID},
+		{"Shadow Pipeline Spec ID", strconv.Itoa(int(p.ShadowPipelineSpecID))},
+		{"Runs", strconv.Itoa(p.Runs)},
+		{"Live Errors", fmt.Sprintf("%d (%.2f%%)", p.LiveErrors, p.LiveErrorRate*100)},
+		{"Shadow Errors", fmt.Sprintf("%d (%.2f%%)", p.ShadowErrors, p.ShadowErrorRate*100)},
+		{"Compared", strconv.Itoa(p.Compared)},
+		{"Mean Deviation", p.MeanDeviation},
+		{"Max Deviation", p.MaxDeviation},
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *JobShadowReportPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Field", "Value"})
+	table.AppendBulk(p.ToRows())
+
+	render("Job Shadow Report", table)
+	return nil
+}
+
+// SetJobShadow sets the candidate pipeline a job runs in shadow mode
+func (s *Shell) SetJobShadow(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return s.errorOut(errors.New("must pass the job id and the path to the observation source"))
+	}
+	source, err := os.ReadFile(c.Args().Get(1))
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	request, err := json.Marshal(web.SetShadowRequest{
+		ObservationSource: string(source),
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Put("/v2/jobs/"+c.Args().First()+"/shadow", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobPresenter{}, "Job shadow set")
+}
+
+// RemoveJobShadow removes the shadow pipeline of a job
+func (s *Shell) RemoveJobShadow(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the job id"))
+	}
+	resp, err := s.HTTP.Delete("/v2/jobs/" + c.Args().First() + "/shadow")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobPresenter{}, "Job shadow removed")
+}
+
+// JobShadowReport compares the results of the shadow runs of a job to those of its live runs
+func (s *Shell) JobShadowReport(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the job id"))
+	}
+	resp, err := s.HTTP.Get(fmt.Sprintf("/v2/jobs/%s/shadow/report?limit=%d", c.Args().First(), c.Int("limit")))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobShadowReportPresenter{})
+}
+
 // TriggerPipelineRun triggers a job run based on a job ID
 func (s *Shell) TriggerPipelineRun(c *cli.Context) error {
 	if !c.Args().Present() {
@@ -305,3 +1003,164 @@ func (s *Shell) TriggerPipelineRun(c *cli.Context) error {
 	err = s.renderAPIResponse(resp, &run, "Pipeline run successfully triggered")
 	return err
 }
+
+// PipelineRunReplayPresenter wraps the JSONAPI pipeline run replay resource and adds rendering
+// functionality
+type PipelineRunReplayPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.PipelineRunReplayResource
+}
+
+// ToRows returns a row per task
+func (p PipelineRunReplayPresenter) ToRows() [][]string {
+	var rows [][]string
+	for _, t := range p.Tasks {
+		source := "executed"
+		if t.Substituted {
+			source = "recorded"
+		}
+		rows = append(rows, []string{
+			t.DotID,
+			string(t.Type),
+			source,
+			strconv.FormatBool(t.Changed),
+			replayTaskOutputString(t.OriginalRan, t.OriginalOutput, t.OriginalError),
+			replayTaskOutputString(t.ReplayedRan, t.ReplayedOutput, t.ReplayedError),
+		})
+	}
+	return rows
+}
+
+func replayTaskOutputString(ran bool, output, errString *string) string {
+	switch {
+	case !ran:
+		return "(not run)"
+	case errString != nil:
+		return "error: " + *errString
+	case output != nil:
+		return *output
+	}
+	return "null"
+}
+
+// RenderTable implements TableRenderer
+func (p *PipelineRunReplayPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Task", "Type", "Result", "Changed", "Original Output", "Replayed Output"})
+	for _, r := range p.ToRows() {
+		table.Append(r)
+	}
+
+	render(fmt.Sprintf("Replay of run %s", p.GetID()), table)
+	_, err := fmt.Fprintf(rt.Writer, "%d of %d tasks changed\n", p.Changed, len(p.Tasks))
+	return err
+}
+
+// ReplayPipelineRun re-executes a stored run and renders the per-task diff with the original run
+func (s *Shell) ReplayPipelineRun(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the id of the run to replay"))
+	}
+	request, err := json.Marshal(pipeline.ReplayOptions{Refetch: c.StringSlice("refetch")})
+	if err != nil {
+		return s.errorOut(err)
+	}
+	resp, err := s.HTTP.Post("/v2/pipeline/runs/"+c.Args().First()+"/replay", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &PipelineRunReplayPresenter{})
+}
+
+// ArchivedPipelineRunPresenter wraps the JSONAPI pipeline run resource and adds rendering
+// functionality
+type ArchivedPipelineRunPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.PipelineRunResource
+}
+
+// ToRow presents the run as a row
+func (p ArchivedPipelineRunPresenter) ToRow() []string {
+	finishedAt := ""
+	if p.FinishedAt.Valid {
+		finishedAt = p.FinishedAt.Time.Format(time.RFC3339)
+	}
+	var errs []string
+	for _, e := range p.AllErrors {
+		if e != nil {
+			errs = append(errs, *e)
+		}
+	}
+	return []string{
+		p.GetID(),
+		strconv.Itoa(int(p.PipelineSpec.JobID)),
+		finishedAt,
+		strings.Join(errs, "; "),
+	}
+}
+
+type ArchivedPipelineRunPresenters []ArchivedPipelineRunPresenter
+
+// RenderTable implements TableRenderer
+func (ps ArchivedPipelineRunPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"ID", "Job ID", "Finished At", "Errors"})
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+
+	render("Archived Job Runs", table)
+	return nil
+}
+
+func archiveFilterQuery(c *cli.Context) string {
+	q := url.Values{}
+	if c.IsSet("job-id") {
+		q.Set("jobID", strconv.FormatInt(c.Int64("job-id"), 10))
+	}
+	if from := c.String("from"); from != "" {
+		q.Set("from", from)
+	}
+	if to := c.String("to"); to != "" {
+		q.Set("to", to)
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// ListArchivedPipelineRuns lists the archived job runs matching the filter flags
+func (s *Shell) ListArchivedPipelineRuns(c *cli.Context) (err error) {
+	resp, err := s.HTTP.Get("/v2/pipeline/archive/runs" + archiveFilterQuery(c))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &ArchivedPipelineRunPresenters{})
+}
+
+// ImportArchivedPipelineRuns imports the archived job runs matching the filter flags back into
+// the database
+func (s *Shell) ImportArchivedPipelineRuns(c *cli.Context) (err error) {
+	resp, err := s.HTTP.Post("/v2/pipeline/archive/import"+archiveFilterQuery(c), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &ArchivedPipelineRunPresenters{}, "Imported job runs")
+}
@@ -3,6 +3,7 @@ package cmd_test
 import (
 	"bytes"
 	"flag"
+	"strconv"
 	"testing"
 	"time"
 
@@ -12,8 +13,10 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/cmd"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
@@ -432,8 +435,102 @@ func TestShell_DeleteJob(t *testing.T) {
 	requireJobsCount(t, app.JobORM(), 0)
 }
 
+func TestShell_JobHistoryDiffRollback(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].Enabled = ptr(true)
+	})
+	client, r := app.NewShellAndRenderer()
+
+	// Create the job
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	cltest.FlagSetApplyFromAction(client.CreateJob, fs, "")
+
+	require.NoError(t, fs.Parse([]string{"../testdata/tomlspecs/direct-request-spec.toml"}))
+
+	err := client.CreateJob(cli.NewContext(nil, fs, nil))
+	require.NoError(t, err)
+	createOutput := *r.Renders[0].(*cmd.JobPresenter)
+
+	set := flag.NewFlagSet("test", 0)
+	require.NoError(t, set.Parse([]string{createOutput.ID}))
+	require.NoError(t, client.JobHistory(cli.NewContext(nil, set, nil)))
+	versions := *r.Renders[1].(*cmd.JobSpecVersionPresenters)
+	require.Len(t, versions, 1)
+	assert.Equal(t, int32(1), versions[0].Version)
+
+	// rolling back records the previous definition as a new version
+	set = flag.NewFlagSet("test", 0)
+	require.NoError(t, set.Parse([]string{createOutput.ID, "1"}))
+	require.NoError(t, client.RollbackJob(cli.NewContext(nil, set, nil)))
+	rollbackOutput := *r.Renders[2].(*cmd.JobPresenter)
+	assert.Equal(t, createOutput.ID, rollbackOutput.ID)
+
+	set = flag.NewFlagSet("test", 0)
+	require.NoError(t, set.Parse([]string{createOutput.ID}))
+	require.NoError(t, client.JobHistory(cli.NewContext(nil, set, nil)))
+	versions = *r.Renders[3].(*cmd.JobSpecVersionPresenters)
+	require.Len(t, versions, 2)
+	assert.Equal(t, versions[0].Definition, versions[1].Definition)
+
+	set = flag.NewFlagSet("test", 0)
+	require.NoError(t, set.Parse([]string{createOutput.ID, "1"}))
+	require.NoError(t, client.DiffJobVersions(cli.NewContext(nil, set, nil)))
+	diff := *r.Renders[4].(*cmd.JobSpecDiffPresenter)
+	assert.Equal(t, int32(1), diff.From)
+	assert.Equal(t, int32(2), diff.To)
+	assert.Empty(t, diff.Diff)
+
+	set = flag.NewFlagSet("test", 0)
+	require.NoError(t, set.Parse([]string{createOutput.ID, "3"}))
+	require.EqualError(t, client.DiffJobVersions(cli.NewContext(nil, set, nil)), "job "+createOutput.ID+" has no version 3")
+}
+
 func requireJobsCount(t *testing.T, orm job.ORM, expected int) {
 	jobs, _, err := orm.FindJobs(0, 1000)
 	require.NoError(t, err)
 	require.Len(t, jobs, expected)
 }
+
+func TestShell_ReplayPipelineRun(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, nil)
+	client, r := app.NewShellAndRenderer()
+
+	jb, err := webhook.ValidatedWebhookSpec(`
+type            = "webhook"
+schemaVersion   = 1
+observationSource   = """
+ds          [type=memo value="12.5"];
+ds_multiply [type=multiply times=100];
+ds -> ds_multiply;
+"""
+`, nil)
+	require.NoError(t, err)
+	require.NoError(t, app.AddJobV2(testutils.Context(t), &jb))
+	runID, err := app.RunJobV2(testutils.Context(t), jb.ID, nil)
+	require.NoError(t, err)
+
+	// Must supply run id
+	set := flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.ReplayPipelineRun, set, "")
+	require.EqualError(t, client.ReplayPipelineRun(cli.NewContext(nil, set, nil)), "must pass the id of the run to replay")
+
+	set = flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.ReplayPipelineRun, set, "")
+	require.NoError(t, set.Parse([]string{strconv.FormatInt(runID, 10)}))
+	require.NoError(t, client.ReplayPipelineRun(cli.NewContext(nil, set, nil)))
+
+	require.Len(t, r.Renders, 1)
+	replay, ok := r.Renders[0].(*cmd.PipelineRunReplayPresenter)
+	require.True(t, ok, "Expected Renders[0] to be *cmd.PipelineRunReplayPresenter, got %T", r.Renders[0])
+	assert.Equal(t, strconv.FormatInt(runID, 10), replay.ID)
+	assert.Equal(t, 0, replay.Changed)
+	require.Len(t, replay.ToRows(), 2)
+	for _, row := range replay.ToRows() {
+		assert.Equal(t, "executed", row[2])
+		assert.Equal(t, "false", row[3])
+	}
+}
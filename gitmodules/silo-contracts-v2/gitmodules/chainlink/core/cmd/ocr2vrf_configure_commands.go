@@ -242,7 +242,7 @@ func (s *Shell) ConfigureOCR2VRFNode(c *cli.Context, owner *bind.TransactOpts, e
 
 		// Create forwarder for management in forwarder_manager.go.
 		orm := forwarders.NewORM(ldb.DB(), lggr, s.Config.Database())
-		_, err = orm.CreateForwarder(common.HexToAddress(forwarderAddress), *utils.NewBigI(chainID))
+		_, err = orm.CreateForwarder(common.HexToAddress(forwarderAddress), *utils.NewBigI(chainID), forwarders.TypeAuthorizedForwarder, nil, false)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initSubpipelinesSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List all versions of all subpipelines",
+			Action: s.ListSubpipelines,
+		},
+		{
+			Name:   "show",
+			Usage:  "Show a subpipeline",
+			Action: s.ShowSubpipeline,
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "version, v",
+					Usage: "The version to show, defaults to the latest",
+				},
+			},
+		},
+		{
+			Name:   "create",
+			Usage:  "Create a new version of a subpipeline from a DOT file",
+			Action: s.CreateSubpipeline,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "name, n",
+					Usage: "The name of the subpipeline",
+				},
+			},
+		},
+		{
+			Name:   "delete",
+			Usage:  "Delete all versions of a subpipeline",
+			Action: s.DeleteSubpipeline,
+		},
+	}
+}
+
+type SubpipelinePresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.SubpipelineResource
+}
+
+var subpipelineHeaders = []string{"Name", "Version", "Created At"}
+
+// ToRow presents the SubpipelineResource as a slice of strings.
+func (p *SubpipelinePresenter) ToRow() []string {
+	return []string{
+		p.Name,
+		strconv.Itoa(int(p.Version)),
+		p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *SubpipelinePresenter) RenderTable(rt RendererTable) error {
+	renderList(subpipelineHeaders, [][]string{p.ToRow()}, rt.Writer)
+	_, err := fmt.Fprintln(rt.Writer, p.DotDagSource)
+	return err
+}
+
+// SubpipelinePresenters implements TableRenderer for a slice of SubpipelinePresenter.
+type SubpipelinePresenters []SubpipelinePresenter
+
+// RenderTable implements TableRenderer
+func (ps SubpipelinePresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+	renderList(subpipelineHeaders, rows, rt.Writer)
+
+	return nil
+}
+
+// ListSubpipelines lists all versions of all subpipelines.
+func (s *Shell) ListSubpipelines(c *cli.Context) (err error) {
+	resp, err := s.HTTP.Get("/v2/subpipelines")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &SubpipelinePresenters{})
+}
+
+// ShowSubpipeline shows a version of a subpipeline, the latest one by default.
+func (s *Shell) ShowSubpipeline(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the name of the subpipeline to be shown"))
+	}
+	path := "/v2/subpipelines/" + url.PathEscape(c.Args().First())
+	if version := c.Int("version"); version != 0 {
+		path += "?version=" + strconv.Itoa(version)
+	}
+	resp, err := s.HTTP.Get(path)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &SubpipelinePresenter{})
+}
+
+// CreateSubpipeline stores the DOT source in the given file as the next version of a subpipeline.
+func (s *Shell) CreateSubpipeline(c *cli.Context) (err error) {
+	name := c.String("name")
+	if name == "" {
+		return s.errorOut(errors.New("must pass the name of the subpipeline with --name"))
+	}
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the path of a DOT file"))
+	}
+	source, err := fromFile(c.Args().First())
+	if err != nil {
+		return s.errorOut(errors.Wrapf(err, "error reading from file '%s'", c.Args().First()))
+	}
+
+	request, err := json.Marshal(web.CreateSubpipelineRequest{
+		Name:   name,
+		Source: source.String(),
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post("/v2/subpipelines", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &SubpipelinePresenter{}, "Subpipeline created")
+}
+
+// DeleteSubpipeline deletes all versions of a subpipeline.
+func (s *Shell) DeleteSubpipeline(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the name of the subpipeline to be deleted"))
+	}
+	resp, err := s.HTTP.Delete("/v2/subpipelines/" + url.PathEscape(c.Args().First()))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	if _, err = s.parseResponse(resp); err != nil {
+		return s.errorOut(err)
+	}
+
+	fmt.Printf("Subpipeline %v deleted\n", c.Args().First())
+	return nil
+}
@@ -1,11 +1,26 @@
 package config
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 )
 
+type JobPipelineArchive interface {
+	Enabled() bool
+	Dir() string
+	Threshold() time.Duration
+}
+
+type JobPipelineTracing interface {
+	Enabled() bool
+	Exporter() string
+	CollectorURL() *url.URL
+	FilePath() string
+	SamplingRatio() float64
+}
+
 type JobPipeline interface {
 	DefaultHTTPLimit() int64
 	DefaultHTTPTimeout() models.Duration
@@ -15,4 +30,7 @@ type JobPipeline interface {
 	ReaperThreshold() time.Duration
 	ResultWriteQueueDepth() uint64
 	ExternalInitiatorsEnabled() bool
+	SignAllowedJobTypes() []string
+	Archive() JobPipelineArchive
+	Tracing() JobPipelineTracing
 }
@@ -728,8 +728,11 @@ type JobPipeline struct {
 	ReaperInterval            *models.Duration
 	ReaperThreshold           *models.Duration
 	ResultWriteQueueDepth     *uint32
+	SignAllowedJobTypes       *[]string
 
+	Archive     JobPipelineArchive     `toml:",omitempty"`
 	HTTPRequest JobPipelineHTTPRequest `toml:",omitempty"`
+	Tracing     JobPipelineTracing     `toml:",omitempty"`
 }
 
 func (j *JobPipeline) setFrom(f *JobPipeline) {
@@ -751,8 +754,38 @@ func (j *JobPipeline) setFrom(f *JobPipeline) {
 	if v := f.ResultWriteQueueDepth; v != nil {
 		j.ResultWriteQueueDepth = v
 	}
+	if v := f.SignAllowedJobTypes; v != nil {
+		j.SignAllowedJobTypes = v
+	}
+	j.Archive.setFrom(&f.Archive)
 	j.HTTPRequest.setFrom(&f.HTTPRequest)
+	j.Tracing.setFrom(&f.Tracing)
+
+}
 
+type JobPipelineArchive struct {
+	Enabled   *bool
+	Dir       *string
+	Threshold *models.Duration
+}
+
+func (a *JobPipelineArchive) setFrom(f *JobPipelineArchive) {
+	if v := f.Enabled; v != nil {
+		a.Enabled = v
+	}
+	if v := f.Dir; v != nil {
+		a.Dir = v
+	}
+	if v := f.Threshold; v != nil {
+		a.Threshold = v
+	}
+}
+
+func (a *JobPipelineArchive) ValidateConfig() (err error) {
+	if a.Enabled != nil && *a.Enabled && (a.Dir == nil || *a.Dir == "") {
+		err = multierr.Append(err, configutils.ErrMissing{Name: "Dir", Msg: "must be set when Enabled"})
+	}
+	return
 }
 
 type JobPipelineHTTPRequest struct {
@@ -769,6 +802,54 @@ func (j *JobPipelineHTTPRequest) setFrom(f *JobPipelineHTTPRequest) {
 	}
 }
 
+type JobPipelineTracing struct {
+	Enabled       *bool
+	Exporter      *string
+	CollectorURL  *models.URL
+	FilePath      *string
+	SamplingRatio *float64
+}
+
+func (t *JobPipelineTracing) setFrom(f *JobPipelineTracing) {
+	if v := f.Enabled; v != nil {
+		t.Enabled = v
+	}
+	if v := f.Exporter; v != nil {
+		t.Exporter = v
+	}
+	if v := f.CollectorURL; v != nil {
+		t.CollectorURL = v
+	}
+	if v := f.FilePath; v != nil {
+		t.FilePath = v
+	}
+	if v := f.SamplingRatio; v != nil {
+		t.SamplingRatio = v
+	}
+}
+
+func (t *JobPipelineTracing) ValidateConfig() (err error) {
+	if t.SamplingRatio != nil && (*t.SamplingRatio < 0 || *t.SamplingRatio > 1) {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "SamplingRatio", Value: *t.SamplingRatio, Msg: "must be between 0 and 1"})
+	}
+	if t.Enabled == nil || !*t.Enabled || t.Exporter == nil {
+		return
+	}
+	switch *t.Exporter {
+	case "otlp":
+		if t.CollectorURL == nil {
+			err = multierr.Append(err, configutils.ErrMissing{Name: "CollectorURL", Msg: "must be set when Exporter is otlp"})
+		}
+	case "file":
+		if t.FilePath == nil || *t.FilePath == "" {
+			err = multierr.Append(err, configutils.ErrMissing{Name: "FilePath", Msg: "must be set when Exporter is file"})
+		}
+	default:
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "Exporter", Value: *t.Exporter, Msg: "must be otlp or file"})
+	}
+	return
+}
+
 type FluxMonitor struct {
 	DefaultTransactionQueueDepth *uint32
 	SimulateTransactions         *bool
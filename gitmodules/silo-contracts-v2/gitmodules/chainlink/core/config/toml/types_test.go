@@ -174,3 +174,42 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestJobPipelineTracing_ValidateConfig(t *testing.T) {
+	collectorURL := models.MustParseURL("http://localhost:4318/v1/traces")
+
+	tests := []struct {
+		name    string
+		input   JobPipelineTracing
+		wantErr string
+	}{
+		{"disabled", JobPipelineTracing{Enabled: testutils.Ptr(false), Exporter: testutils.Ptr("bogus")}, ""},
+		{"otlp", JobPipelineTracing{Enabled: testutils.Ptr(true), Exporter: testutils.Ptr("otlp"), CollectorURL: collectorURL}, ""},
+		{"file", JobPipelineTracing{Enabled: testutils.Ptr(true), Exporter: testutils.Ptr("file"), FilePath: testutils.Ptr("traces.jsonl")}, ""},
+		{"otlp without collector", JobPipelineTracing{Enabled: testutils.Ptr(true), Exporter: testutils.Ptr("otlp")}, "CollectorURL: missing: must be set when Exporter is otlp"},
+		{"file without path", JobPipelineTracing{Enabled: testutils.Ptr(true), Exporter: testutils.Ptr("file"), FilePath: testutils.Ptr("")}, "FilePath: missing: must be set when Exporter is file"},
+		{"unknown exporter", JobPipelineTracing{Enabled: testutils.Ptr(true), Exporter: testutils.Ptr("jaeger")}, "Exporter: invalid value (jaeger): must be otlp or file"},
+		{"sampling ratio", JobPipelineTracing{SamplingRatio: testutils.Ptr(1.5)}, "SamplingRatio: invalid value (1.5): must be between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.ValidateConfig()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJobPipelineArchive_ValidateConfig(t *testing.T) {
+	assert.NoError(t, (&JobPipelineArchive{Enabled: testutils.Ptr(false)}).ValidateConfig())
+	assert.NoError(t, (&JobPipelineArchive{Enabled: testutils.Ptr(true), Dir: testutils.Ptr("archive")}).ValidateConfig())
+
+	err := (&JobPipelineArchive{Enabled: testutils.Ptr(true)}).ValidateConfig()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Dir: missing: must be set when Enabled")
+	}
+}
@@ -827,7 +827,7 @@ func setupForwarderEnabledNode(
 	// add forwarder address to be tracked in db
 	forwarderORM := forwarders.NewORM(app.GetSqlxDB(), logger.TestLogger(t), config.Database())
 	chainID := utils.Big(*b.Blockchain().Config().ChainID)
-	_, err = forwarderORM.CreateForwarder(forwarder, chainID)
+	_, err = forwarderORM.CreateForwarder(forwarder, chainID, forwarders.TypeAuthorizedForwarder, nil, false)
 	require.NoError(t, err)
 
 	return app, p2pKey.PeerID().Raw(), transmitter, forwarder, key
@@ -170,7 +170,7 @@ func setupNodeOCR2(
 		// add forwarder address to be tracked in db
 		forwarderORM := forwarders.NewORM(app.GetSqlxDB(), logger.TestLogger(t), config.Database())
 		chainID := utils.Big(*b.Blockchain().Config().ChainID)
-		_, err = forwarderORM.CreateForwarder(faddr, chainID)
+		_, err = forwarderORM.CreateForwarder(faddr, chainID, forwarders.TypeAuthorizedForwarder, nil, false)
 		require.NoError(t, err)
 
 		effectiveTransmitter = faddr
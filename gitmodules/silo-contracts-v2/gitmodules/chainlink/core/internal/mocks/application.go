@@ -65,6 +65,32 @@ func (_m *Application) AddJobV2(ctx context.Context, _a1 *job.Job) error {
 	return r0
 }
 
+// ArchivedJobRunsV2 provides a mock function with given fields: filter
+func (_m *Application) ArchivedJobRunsV2(filter pipeline.ArchiveFilter) ([]pipeline.Run, error) {
+	ret := _m.Called(filter)
+
+	var r0 []pipeline.Run
+	var r1 error
+	if rf, ok := ret.Get(0).(func(pipeline.ArchiveFilter) ([]pipeline.Run, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(pipeline.ArchiveFilter) []pipeline.Run); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(pipeline.ArchiveFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BridgeORM provides a mock function with given fields:
 func (_m *Application) BridgeORM() bridges.ORM {
 	ret := _m.Called()
@@ -317,6 +343,32 @@ func (_m *Application) ID() uuid.UUID {
 	return r0
 }
 
+// ImportArchivedJobRunsV2 provides a mock function with given fields: filter
+func (_m *Application) ImportArchivedJobRunsV2(filter pipeline.ArchiveFilter) ([]pipeline.Run, error) {
+	ret := _m.Called(filter)
+
+	var r0 []pipeline.Run
+	var r1 error
+	if rf, ok := ret.Get(0).(func(pipeline.ArchiveFilter) ([]pipeline.Run, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(pipeline.ArchiveFilter) []pipeline.Run); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(pipeline.ArchiveFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // JobORM provides a mock function with given fields:
 func (_m *Application) JobORM() job.ORM {
 	ret := _m.Called()
@@ -349,6 +401,20 @@ func (_m *Application) JobSpawner() job.Spawner {
 	return r0
 }
 
+// PauseJob provides a mock function with given fields: ctx, jobID
+func (_m *Application) PauseJob(ctx context.Context, jobID int32) error {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PipelineORM provides a mock function with given fields:
 func (_m *Application) PipelineORM() pipeline.ORM {
 	ret := _m.Called()
@@ -365,6 +431,20 @@ func (_m *Application) PipelineORM() pipeline.ORM {
 	return r0
 }
 
+// RemoveJobShadow provides a mock function with given fields: ctx, jobID
+func (_m *Application) RemoveJobShadow(ctx context.Context, jobID int32) error {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReplayFromBlock provides a mock function with given fields: chainID, number, forceBroadcast
 func (_m *Application) ReplayFromBlock(chainID *big.Int, number uint64, forceBroadcast bool) error {
 	ret := _m.Called(chainID, number, forceBroadcast)
@@ -379,6 +459,44 @@ func (_m *Application) ReplayFromBlock(chainID *big.Int, number uint64, forceBro
 	return r0
 }
 
+// ReplayJobRunV2 provides a mock function with given fields: ctx, runID, opts
+func (_m *Application) ReplayJobRunV2(ctx context.Context, runID int64, opts pipeline.ReplayOptions) (pipeline.ReplayResult, error) {
+	ret := _m.Called(ctx, runID, opts)
+
+	var r0 pipeline.ReplayResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.ReplayOptions) (pipeline.ReplayResult, error)); ok {
+		return rf(ctx, runID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.ReplayOptions) pipeline.ReplayResult); ok {
+		r0 = rf(ctx, runID, opts)
+	} else {
+		r0 = ret.Get(0).(pipeline.ReplayResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, pipeline.ReplayOptions) error); ok {
+		r1 = rf(ctx, runID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResumePausedJob provides a mock function with given fields: ctx, jobID
+func (_m *Application) ResumePausedJob(ctx context.Context, jobID int32) error {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumeJobV2 provides a mock function with given fields: ctx, taskID, result
 func (_m *Application) ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error {
 	ret := _m.Called(ctx, taskID, result)
@@ -473,6 +591,20 @@ func (_m *Application) SessionORM() sessions.ORM {
 	return r0
 }
 
+// SetJobShadow provides a mock function with given fields: ctx, jobID, p
+func (_m *Application) SetJobShadow(ctx context.Context, jobID int32, p pipeline.Pipeline) error {
+	ret := _m.Called(ctx, jobID, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, pipeline.Pipeline) error); ok {
+		r0 = rf(ctx, jobID, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetLogLevel provides a mock function with given fields: lvl
 func (_m *Application) SetLogLevel(lvl zapcore.Level) error {
 	ret := _m.Called(lvl)
@@ -531,6 +663,20 @@ func (_m *Application) TxmStorageService() txmgr.EvmTxStore {
 	return r0
 }
 
+// UpdateJobV2 provides a mock function with given fields: ctx, _a1
+func (_m *Application) UpdateJobV2(ctx context.Context, _a1 *job.Job) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *job.Job) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WakeSessionReaper provides a mock function with given fields:
 func (_m *Application) WakeSessionReaper() {
 	_m.Called()
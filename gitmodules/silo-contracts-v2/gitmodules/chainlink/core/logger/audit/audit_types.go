@@ -52,8 +52,18 @@ const (
 	CosmosTransactionCreated EventID = "COSMOS_TRANSACTION_CREATED"
 	SolanaTransactionCreated EventID = "SOLANA_TRANSACTION_CREATED"
 
-	JobCreated EventID = "JOB_CREATED"
-	JobDeleted EventID = "JOB_DELETED"
+	JobCreated       EventID = "JOB_CREATED"
+	JobDeleted       EventID = "JOB_DELETED"
+	JobPaused        EventID = "JOB_PAUSED"
+	JobResumed       EventID = "JOB_RESUMED"
+	JobRolledBack    EventID = "JOB_ROLLED_BACK"
+	JobShadowSet     EventID = "JOB_SHADOW_SET"
+	JobShadowRemoved EventID = "JOB_SHADOW_REMOVED"
+
+	JobTemplateCreated EventID = "JOB_TEMPLATE_CREATED"
+
+	WebhookSecretGenerated EventID = "WEBHOOK_SECRET_GENERATED"
+	WebhookSecretDeleted   EventID = "WEBHOOK_SECRET_DELETED"
 
 	ChainAdded       EventID = "CHAIN_ADDED"
 	ChainSpecUpdated EventID = "CHAIN_SPEC_UPDATED"
@@ -67,8 +77,12 @@ const (
 	BridgeDeleted EventID = "BRIDGE_DELETED"
 
 	ForwarderCreated EventID = "FORWARDER_CREATED"
+	ForwarderUpdated EventID = "FORWARDER_UPDATED"
 	ForwarderDeleted EventID = "FORWARDER_DELETED"
 
+	SubpipelineCreated EventID = "SUBPIPELINE_CREATED"
+	SubpipelineDeleted EventID = "SUBPIPELINE_DELETED"
+
 	ExternalInitiatorCreated EventID = "EXTERNAL_INITIATOR_CREATED"
 	ExternalInitiatorDeleted EventID = "EXTERNAL_INITIATOR_DELETED"
 
@@ -84,6 +98,8 @@ const (
 
 	JobErrorDismissed EventID = "JOB_ERROR_DISMISSED"
 	JobRunSet         EventID = "JOB_RUN_SET"
+	JobRunReplayed    EventID = "JOB_RUN_REPLAYED"
+	JobRunsImported   EventID = "JOB_RUNS_IMPORTED"
 
 	EnvNoncriticalEnvDumped EventID = "ENV_NONCRITICAL_ENV_DUMPED"
 
@@ -13,6 +13,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/pyroscope-io/client/pyroscope"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 
@@ -32,6 +35,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/blockheaderfeeder"
 	"github.com/smartcontractkit/chainlink/v2/core/services/cron"
 	"github.com/smartcontractkit/chainlink/v2/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/evmlog"
 	"github.com/smartcontractkit/chainlink/v2/core/services/feeds"
 	"github.com/smartcontractkit/chainlink/v2/core/services/fluxmonitorv2"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
@@ -87,8 +91,18 @@ type Application interface {
 	TxmStorageService() txmgr.EvmTxStore
 	AddJobV2(ctx context.Context, job *job.Job) error
 	DeleteJob(ctx context.Context, jobID int32) error
+	UpdateJobV2(ctx context.Context, job *job.Job) error
+	PauseJob(ctx context.Context, jobID int32) error
+	// ResumePausedJob restarts a job paused by PauseJob, unlike ResumeJobV2 which resumes a
+	// pipeline run waiting on an async task.
+	ResumePausedJob(ctx context.Context, jobID int32) error
+	SetJobShadow(ctx context.Context, jobID int32, p pipeline.Pipeline) error
+	RemoveJobShadow(ctx context.Context, jobID int32) error
 	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error)
 	ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error
+	ReplayJobRunV2(ctx context.Context, runID int64, opts pipeline.ReplayOptions) (pipeline.ReplayResult, error)
+	ArchivedJobRunsV2(filter pipeline.ArchiveFilter) ([]pipeline.Run, error)
+	ImportArchivedJobRunsV2(filter pipeline.ArchiveFilter) ([]pipeline.Run, error)
 	// Testing only
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
 
@@ -135,6 +149,7 @@ type ChainlinkApplication struct {
 	sqlxDB                   *sqlx.DB
 	secretGenerator          SecretGenerator
 	profiler                 *pyroscope.Profiler
+	tracerProvider           *sdktrace.TracerProvider
 	loopRegistry             *plugins.LoopRegistry
 
 	started     bool
@@ -205,6 +220,18 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		globalLogger.Debug("Pyroscope (automatic pprof profiling) is disabled")
 	}
 
+	var tracerProvider *sdktrace.TracerProvider
+	if tracing := cfg.JobPipeline().Tracing(); tracing.Enabled() {
+		globalLogger.Debugw("Pipeline tracing is enabled", "exporter", tracing.Exporter())
+		var err error
+		tracerProvider, err = pipeline.NewTracerProvider(tracing)
+		if err != nil {
+			return nil, errors.Wrap(err, "starting pipeline tracing failed")
+		}
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	}
+
 	ap := cfg.AutoPprof()
 	var nurse *services.Nurse
 	if ap.Enabled() {
@@ -281,10 +308,13 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		bridgeORM      = bridges.NewORM(db, globalLogger, cfg.Database())
 		sessionORM     = sessions.NewORM(db, cfg.WebServer().SessionTimeout().Duration(), globalLogger, cfg.Database(), auditLogger)
 		mercuryORM     = mercury.NewORM(db, globalLogger, cfg.Database())
-		pipelineRunner = pipeline.NewRunner(pipelineORM, bridgeORM, cfg.JobPipeline(), cfg.WebServer(), legacyEVMChains, keyStore.Eth(), keyStore.VRF(), globalLogger, restrictedHTTPClient, unrestrictedHTTPClient)
+		pipelineRunner = pipeline.NewRunner(pipelineORM, bridgeORM, cfg.JobPipeline(), cfg.WebServer(), legacyEVMChains, keyStore.Eth(), keyStore.VRF(), keyStore.OCR2(), globalLogger, restrictedHTTPClient, unrestrictedHTTPClient)
 		jobORM         = job.NewORM(db, legacyEVMChains, pipelineORM, bridgeORM, keyStore, globalLogger, cfg.Database())
 		txmORM         = txmgr.NewTxStore(db, globalLogger, cfg.Database())
 	)
+	if cfg.JobPipeline().Archive().Enabled() {
+		pipelineORM.EnableArchive()
+	}
 
 	for _, chain := range legacyEVMChains.Slice() {
 		chain.HeadBroadcaster().Subscribe(promReporter)
@@ -299,6 +329,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				globalLogger,
 				pipelineRunner,
 				pipelineORM,
+				keyStore.Eth(),
 				legacyEVMChains,
 				mailMon),
 			job.Keeper: keeper.NewDelegate(
@@ -323,6 +354,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				globalLogger),
 			job.Cron: cron.NewDelegate(
 				pipelineRunner,
+				cron.NewORM(db, globalLogger, cfg.Database()),
 				globalLogger),
 			job.BlockhashStore: blockhashstore.NewDelegate(
 				globalLogger,
@@ -336,6 +368,12 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				legacyEVMChains,
 				keyStore.Eth(),
 				globalLogger),
+			job.EVMLog: evmlog.NewDelegate(
+				globalLogger,
+				pipelineRunner,
+				legacyEVMChains,
+				db,
+				cfg.Database()),
 		}
 		webhookJobRunner = delegates[job.Webhook].(*webhook.Delegate).WebhookJobRunner()
 	)
@@ -423,7 +461,10 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		lbs = append(lbs, c.LogBroadcaster())
 	}
 	jobSpawner := job.NewSpawner(jobORM, cfg.Database(), delegates, db, globalLogger, lbs)
-	srvcs = append(srvcs, jobSpawner, pipelineRunner)
+	// The trigger dispatcher is started before the jobs, so it does not miss their first runs.
+	triggerDispatcher := webhook.NewTriggerDispatcher(pipelineRunner, webhookJobRunner, globalLogger)
+	jobSpawner.AddJobListener(triggerDispatcher)
+	srvcs = append(srvcs, triggerDispatcher, jobSpawner, pipelineRunner)
 
 	// We start the log poller after the job spawner
 	// so jobs have a chance to apply their initial log filters.
@@ -479,6 +520,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		closeLogger:              opts.CloseLogger,
 		secretGenerator:          opts.SecretGenerator,
 		profiler:                 profiler,
+		tracerProvider:           tracerProvider,
 		loopRegistry:             loopRegistry,
 
 		sqlxDB: opts.SqlxDB,
@@ -617,6 +659,11 @@ func (app *ChainlinkApplication) stop() (err error) {
 			err = multierr.Append(err, app.profiler.Stop())
 		}
 
+		if app.tracerProvider != nil {
+			// flushes the spans of the last runs
+			err = multierr.Append(err, app.tracerProvider.Shutdown(context.Background()))
+		}
+
 		app.logger.Info("Exited all services")
 
 		app.started = false
@@ -704,6 +751,40 @@ func (app *ChainlinkApplication) DeleteJob(ctx context.Context, jobID int32) err
 	return app.jobSpawner.DeleteJob(jobID, pg.WithParentCtx(ctx))
 }
 
+// UpdateJobV2 replaces the spec of the job with ID j.ID, keeping its ID and run history.
+func (app *ChainlinkApplication) UpdateJobV2(ctx context.Context, j *job.Job) error {
+	// Do not allow the job to be updated if it is managed by the Feeds Manager
+	isManaged, err := app.FeedsService.IsJobManaged(ctx, int64(j.ID))
+	if err != nil {
+		return err
+	}
+
+	if isManaged {
+		return errors.New("job must be updated in the feeds manager")
+	}
+
+	return app.jobSpawner.UpdateJob(j, pg.WithParentCtx(ctx))
+}
+
+// PauseJob stops the services of a job without deleting it.
+func (app *ChainlinkApplication) PauseJob(ctx context.Context, jobID int32) error {
+	return app.jobSpawner.PauseJob(jobID, pg.WithParentCtx(ctx))
+}
+
+func (app *ChainlinkApplication) ResumePausedJob(ctx context.Context, jobID int32) error {
+	return app.jobSpawner.ResumeJob(jobID, pg.WithParentCtx(ctx))
+}
+
+// SetJobShadow makes p the shadow pipeline of a job, which is run alongside the job without
+// writing on-chain so that its results can be compared to the live ones.
+func (app *ChainlinkApplication) SetJobShadow(ctx context.Context, jobID int32, p pipeline.Pipeline) error {
+	return app.jobSpawner.SetShadow(jobID, p, pg.WithParentCtx(ctx))
+}
+
+func (app *ChainlinkApplication) RemoveJobShadow(ctx context.Context, jobID int32) error {
+	return app.jobSpawner.RemoveShadow(jobID, pg.WithParentCtx(ctx))
+}
+
 func (app *ChainlinkApplication) RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error) {
 	return app.webhookJobRunner.RunJob(ctx, jobUUID, requestBody, meta)
 }
@@ -780,6 +861,49 @@ func (app *ChainlinkApplication) ResumeJobV2(
 	return app.pipelineRunner.ResumeRun(taskID, result.Value, result.Error)
 }
 
+// ReplayJobRunV2 re-executes a stored pipeline run without saving it, substituting recorded
+// responses, and compares its task outputs to the stored ones.
+func (app *ChainlinkApplication) ReplayJobRunV2(
+	ctx context.Context,
+	runID int64,
+	opts pipeline.ReplayOptions,
+) (pipeline.ReplayResult, error) {
+	return app.pipelineRunner.ReplayRun(ctx, runID, opts, app.logger)
+}
+
+// runArchive returns the archive of the pipeline runner, so that reads are serialized with the
+// writes of its reaper.
+func (app *ChainlinkApplication) runArchive() (*pipeline.RunArchive, error) {
+	archive := app.pipelineRunner.Archive()
+	if archive == nil {
+		return nil, pipeline.ErrArchiveDisabled
+	}
+	return archive, nil
+}
+
+// ArchivedJobRunsV2 returns the archived pipeline runs matching filter.
+func (app *ChainlinkApplication) ArchivedJobRunsV2(filter pipeline.ArchiveFilter) ([]pipeline.Run, error) {
+	archive, err := app.runArchive()
+	if err != nil {
+		return nil, err
+	}
+	return archive.Query(filter)
+}
+
+// ImportArchivedJobRunsV2 inserts the archived pipeline runs matching filter back into the
+// database, and returns the runs that were imported.
+func (app *ChainlinkApplication) ImportArchivedJobRunsV2(filter pipeline.ArchiveFilter) ([]pipeline.Run, error) {
+	archive, err := app.runArchive()
+	if err != nil {
+		return nil, err
+	}
+	runs, err := archive.Query(filter)
+	if err != nil {
+		return nil, err
+	}
+	return app.pipelineORM.ImportRuns(runs)
+}
+
 func (app *ChainlinkApplication) GetFeedsService() feeds.Service {
 	return app.FeedsService
 }
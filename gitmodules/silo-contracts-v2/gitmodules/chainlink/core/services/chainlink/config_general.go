@@ -305,7 +305,7 @@ func (g *generalConfig) EVMEnabled() bool {
 func (g *generalConfig) EVMRPCEnabled() bool {
 	for _, c := range g.c.EVM {
 		if c.IsEnabled() {
-			if len(c.Nodes) > 0 {
+			if len(c.Nodes) > 0 || c.SimulatedFork.IsEnabled() {
 				return true
 			}
 		}
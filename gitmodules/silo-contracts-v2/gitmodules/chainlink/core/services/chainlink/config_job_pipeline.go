@@ -1,6 +1,7 @@
 package chainlink
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/v2/core/config"
@@ -45,3 +46,64 @@ func (j *jobPipelineConfig) ResultWriteQueueDepth() uint64 {
 func (j *jobPipelineConfig) ExternalInitiatorsEnabled() bool {
 	return *j.c.ExternalInitiatorsEnabled
 }
+
+func (j *jobPipelineConfig) SignAllowedJobTypes() []string {
+	if j.c.SignAllowedJobTypes == nil {
+		return nil
+	}
+	return *j.c.SignAllowedJobTypes
+}
+
+func (j *jobPipelineConfig) Archive() config.JobPipelineArchive {
+	return &jobPipelineArchiveConfig{c: j.c.Archive}
+}
+
+type jobPipelineArchiveConfig struct {
+	c toml.JobPipelineArchive
+}
+
+func (a *jobPipelineArchiveConfig) Enabled() bool {
+	return *a.c.Enabled
+}
+
+func (a *jobPipelineArchiveConfig) Dir() string {
+	if a.c.Dir == nil {
+		return ""
+	}
+	return *a.c.Dir
+}
+
+func (a *jobPipelineArchiveConfig) Threshold() time.Duration {
+	return a.c.Threshold.Duration()
+}
+
+func (j *jobPipelineConfig) Tracing() config.JobPipelineTracing {
+	return &jobPipelineTracingConfig{c: j.c.Tracing}
+}
+
+type jobPipelineTracingConfig struct {
+	c toml.JobPipelineTracing
+}
+
+func (t *jobPipelineTracingConfig) Enabled() bool {
+	return *t.c.Enabled
+}
+
+func (t *jobPipelineTracingConfig) Exporter() string {
+	return *t.c.Exporter
+}
+
+func (t *jobPipelineTracingConfig) CollectorURL() *url.URL {
+	return t.c.CollectorURL.URL()
+}
+
+func (t *jobPipelineTracingConfig) FilePath() string {
+	if t.c.FilePath == nil {
+		return ""
+	}
+	return *t.c.FilePath
+}
+
+func (t *jobPipelineTracingConfig) SamplingRatio() float64 {
+	return *t.c.SamplingRatio
+}
@@ -30,4 +30,17 @@ func TestJobPipelineConfigTest(t *testing.T) {
 	assert.Equal(t, 168*time.Hour, jp.ReaperThreshold())
 	assert.Equal(t, uint64(10), jp.ResultWriteQueueDepth())
 	assert.True(t, jp.ExternalInitiatorsEnabled())
+	assert.Equal(t, []string{"webhook", "cron"}, jp.SignAllowedJobTypes())
+
+	archive := jp.Archive()
+	assert.True(t, archive.Enabled())
+	assert.Equal(t, "/tmp/pipeline-archive", archive.Dir())
+	assert.Equal(t, 72*time.Hour, archive.Threshold())
+
+	tracing := jp.Tracing()
+	assert.True(t, tracing.Enabled())
+	assert.Equal(t, "file", tracing.Exporter())
+	assert.Equal(t, "https://collector.test/v1/traces", tracing.CollectorURL().String())
+	assert.Equal(t, "/tmp/traces.jsonl", tracing.FilePath())
+	assert.Equal(t, 0.25, tracing.SamplingRatio())
 }
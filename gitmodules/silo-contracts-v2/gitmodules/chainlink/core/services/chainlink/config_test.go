@@ -329,10 +329,23 @@ func TestConfig_Marshal(t *testing.T) {
 		ReaperInterval:            models.MustNewDuration(4 * time.Hour),
 		ReaperThreshold:           models.MustNewDuration(7 * 24 * time.Hour),
 		ResultWriteQueueDepth:     ptr[uint32](10),
+		SignAllowedJobTypes:       &[]string{"webhook", "cron"},
+		Archive: toml.JobPipelineArchive{
+			Enabled:   ptr(true),
+			Dir:       ptr("/tmp/pipeline-archive"),
+			Threshold: models.MustNewDuration(72 * time.Hour),
+		},
 		HTTPRequest: toml.JobPipelineHTTPRequest{
 			MaxSize:        ptr[utils.FileSize](100 * utils.MB),
 			DefaultTimeout: models.MustNewDuration(time.Minute),
 		},
+		Tracing: toml.JobPipelineTracing{
+			Enabled:       ptr(true),
+			Exporter:      ptr("file"),
+			CollectorURL:  models.MustParseURL("https://collector.test/v1/traces"),
+			FilePath:      ptr("/tmp/traces.jsonl"),
+			SamplingRatio: ptr(0.25),
+		},
 	}
 	full.FluxMonitor = toml.FluxMonitor{
 		DefaultTransactionQueueDepth: ptr[uint32](100),
@@ -542,6 +555,12 @@ func TestConfig_Marshal(t *testing.T) {
 					},
 				},
 			},
+			SimulatedFork: evmcfg.SimulatedFork{
+				Enabled:      ptr(false),
+				SnapshotFile: ptr("snapshot.json"),
+				BlockTime:    &second,
+				GasLimit:     ptr[uint64](30_000_000),
+			},
 			Nodes: []*evmcfg.Node{
 				{
 					Name:    ptr("foo"),
@@ -746,10 +765,23 @@ MaxSuccessfulRuns = 123456
 ReaperInterval = '4h0m0s'
 ReaperThreshold = '168h0m0s'
 ResultWriteQueueDepth = 10
+SignAllowedJobTypes = ['webhook', 'cron']
+
+[JobPipeline.Archive]
+Enabled = true
+Dir = '/tmp/pipeline-archive'
+Threshold = '72h0m0s'
 
 [JobPipeline.HTTPRequest]
 DefaultTimeout = '1m0s'
 MaxSize = '100.00mb'
+
+[JobPipeline.Tracing]
+Enabled = true
+Exporter = 'file'
+CollectorURL = 'https://collector.test/v1/traces'
+FilePath = '/tmp/traces.jsonl'
+SamplingRatio = 0.25
 `},
 		{"OCR", Config{Core: toml.Core{OCR: full.OCR}}, `[OCR]
 Enabled = true
@@ -939,6 +971,12 @@ ObservationGracePeriod = '1s'
 [EVM.OCR2.Automation]
 GasLimit = 540
 
+[EVM.SimulatedFork]
+Enabled = false
+SnapshotFile = 'snapshot.json'
+BlockTime = '1s'
+GasLimit = 30000000
+
 [[EVM.Nodes]]
 Name = 'foo'
 WSURL = 'wss://web.socket/test/foo'
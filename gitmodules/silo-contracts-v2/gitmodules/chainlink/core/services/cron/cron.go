@@ -2,34 +2,48 @@ package cron
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	mrand "math/rand"
+	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/robfig/cron/v3"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
+// maxCatchUpRuns is the maximum number of missed runs which are run on startup with the
+// run-all catch-up policy. The latest ones are run.
+const maxCatchUpRuns = 100
+
 // Cron runs a cron jobSpec from a CronSpec
 type Cron struct {
 	cronRunner     *cron.Cron
 	logger         logger.Logger
 	jobSpec        job.Job
 	pipelineRunner pipeline.Runner
+	orm            ORM
+	entryID        cron.EntryID
 	chStop         utils.StopChan
+	wg             sync.WaitGroup
 }
 
 // NewCronFromJobSpec instantiates a job that executes on a predefined schedule.
 func NewCronFromJobSpec(
 	jobSpec job.Job,
 	pipelineRunner pipeline.Runner,
+	orm ORM,
 	logger logger.Logger,
 ) (*Cron, error) {
 	cronLogger := logger.Named("Cron").With(
 		"jobID", jobSpec.ID,
-		"schedule", jobSpec.CronSpec.CronSchedule,
+		"schedule", jobSpec.CronSpec.Schedule(),
 	)
 
 	return &Cron{
@@ -37,6 +51,7 @@ func NewCronFromJobSpec(
 		logger:         cronLogger,
 		jobSpec:        jobSpec,
 		pipelineRunner: pipelineRunner,
+		orm:            orm,
 		chStop:         make(chan struct{}),
 	}, nil
 }
@@ -45,12 +60,20 @@ func NewCronFromJobSpec(
 func (cr *Cron) Start(context.Context) error {
 	cr.logger.Debug("Starting")
 
-	_, err := cr.cronRunner.AddFunc(cr.jobSpec.CronSpec.CronSchedule, cr.runPipeline)
+	var err error
+	cr.entryID, err = cr.cronRunner.AddFunc(cr.jobSpec.CronSpec.Schedule(), cr.runScheduled)
 	if err != nil {
-		cr.logger.Errorw(fmt.Sprintf("Error running cron job %d", cr.jobSpec.ID), "err", err, "schedule", cr.jobSpec.CronSpec.CronSchedule, "jobID", cr.jobSpec.ID)
+		cr.logger.Errorw(fmt.Sprintf("Error running cron job %d", cr.jobSpec.ID), "err", err, "schedule", cr.jobSpec.CronSpec.Schedule(), "jobID", cr.jobSpec.ID)
 		return err
 	}
+	now := time.Now()
 	cr.cronRunner.Start()
+
+	cr.wg.Add(1)
+	go func() {
+		defer cr.wg.Done()
+		cr.catchUp(now)
+	}()
 	return nil
 }
 
@@ -58,11 +81,89 @@ func (cr *Cron) Start(context.Context) error {
 // running and cleans up resources.
 func (cr *Cron) Close() error {
 	cr.logger.Debug("Closing")
-	cr.cronRunner.Stop()
+	close(cr.chStop)
+	<-cr.cronRunner.Stop().Done()
+	cr.wg.Wait()
 	return nil
 }
 
-func (cr *Cron) runPipeline() {
+// runScheduled runs the pipeline after a random delay of up to the jitter of the spec, so the
+// nodes running the same job do not all run it at once, and records the time it was due at.
+func (cr *Cron) runScheduled() {
+	scheduledAt := cr.cronRunner.Entry(cr.entryID).Prev
+	if jitter := cr.jobSpec.CronSpec.Jitter.Duration(); jitter > 0 {
+		select {
+		case <-time.After(time.Duration(mrand.Int63n(int64(jitter)))):
+		case <-cr.chStop:
+			return
+		}
+	}
+	cr.runPipeline(map[string]interface{}{})
+	cr.setLastScheduledAt(scheduledAt)
+}
+
+// catchUp runs the pipeline for the scheduled times between the latest recorded one and now,
+// according to the catch-up policy of the spec. Jobs which were never started before have
+// nothing to catch up, and start recording from now.
+func (cr *Cron) catchUp(now time.Time) {
+	ctx, cancel := cr.chStop.NewCtx()
+	defer cancel()
+
+	last, err := cr.orm.LastScheduledAt(cr.jobSpec.ID, pg.WithParentCtx(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		cr.setLastScheduledAt(now)
+		return
+	} else if err != nil {
+		cr.logger.Errorw("Failed to load the last scheduled time, not catching up missed runs", "err", err)
+		return
+	}
+	if cr.jobSpec.CronSpec.CatchUp != job.CronCatchUpRunOnce && cr.jobSpec.CronSpec.CatchUp != job.CronCatchUpRunAll {
+		return
+	}
+
+	missed, total, err := missedRuns(cr.jobSpec.CronSpec.Schedule(), last, now, maxCatchUpRuns)
+	if err != nil {
+		cr.logger.Errorw("Failed to compute missed runs", "err", err)
+		return
+	}
+	if total == 0 {
+		return
+	}
+	cr.logger.Infow("Catching up missed runs", "missed", total, "lastScheduledAt", last, "catchUp", cr.jobSpec.CronSpec.CatchUp)
+
+	if cr.jobSpec.CronSpec.CatchUp == job.CronCatchUpRunOnce {
+		missed = missed[len(missed)-1:]
+	} else if total > len(missed) {
+		cr.logger.Warnw(fmt.Sprintf("Missed more runs than can be caught up, only running the latest %d", len(missed)), "missed", total)
+	}
+	for _, scheduledAt := range missed {
+		select {
+		case <-cr.chStop:
+			return
+		default:
+		}
+		cr.runPipeline(map[string]interface{}{
+			"catchUp":     true,
+			"scheduledAt": scheduledAt.UTC().Format(time.RFC3339),
+		})
+		cr.setLastScheduledAt(scheduledAt)
+	}
+}
+
+func (cr *Cron) setLastScheduledAt(scheduledAt time.Time) {
+	if scheduledAt.IsZero() {
+		// the entry was already removed by Close
+		return
+	}
+	ctx, cancel := cr.chStop.NewCtx()
+	defer cancel()
+
+	if err := cr.orm.SetLastScheduledAt(cr.jobSpec.ID, scheduledAt, pg.WithParentCtx(ctx)); err != nil && ctx.Err() == nil {
+		cr.logger.Errorw("Failed to record the last scheduled time", "err", err, "scheduledAt", scheduledAt)
+	}
+}
+
+func (cr *Cron) runPipeline(meta map[string]interface{}) {
 	ctx, cancel := cr.chStop.NewCtx()
 	defer cancel()
 
@@ -73,7 +174,7 @@ func (cr *Cron) runPipeline() {
 			"name":          cr.jobSpec.Name.ValueOrZero(),
 		},
 		"jobRun": map[string]interface{}{
-			"meta": map[string]interface{}{},
+			"meta": meta,
 		},
 	})
 
@@ -85,6 +186,27 @@ func (cr *Cron) runPipeline() {
 	}
 }
 
+// missedRuns returns the latest (up to limit) times the schedule was due after last and up to
+// now, oldest first, and how many there were in total.
+func missedRuns(schedule string, last time.Time, now time.Time, limit int) (missed []time.Time, total int, err error) {
+	sched, err := cronParser().Parse(schedule)
+	if err != nil {
+		return nil, 0, err
+	}
+	for t := sched.Next(last); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+		total++
+		missed = append(missed, t)
+		if len(missed) > limit {
+			missed = missed[1:]
+		}
+	}
+	return missed, total, nil
+}
+
+func cronParser() cron.Parser {
+	return cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+}
+
 func cronRunner() *cron.Cron {
 	return cron.New(cron.WithSeconds())
 }
@@ -1,9 +1,14 @@
 package cron_test
 
 import (
+	"database/sql"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -17,11 +22,47 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/cron"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
 	evmrelay "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
 )
 
+// memORM is an in-memory ORM.
+type memORM struct {
+	mu          sync.Mutex
+	scheduledAt map[int32]time.Time
+}
+
+func newMemORM() *memORM {
+	return &memORM{scheduledAt: make(map[int32]time.Time)}
+}
+
+func (o *memORM) LastScheduledAt(jobID int32, qopts ...pg.QOpt) (time.Time, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	scheduledAt, ok := o.scheduledAt[jobID]
+	if !ok {
+		return time.Time{}, errors.Wrap(sql.ErrNoRows, "LastScheduledAt failed")
+	}
+	return scheduledAt, nil
+}
+
+func (o *memORM) SetLastScheduledAt(jobID int32, scheduledAt time.Time, qopts ...pg.QOpt) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if scheduledAt.After(o.scheduledAt[jobID]) {
+		o.scheduledAt[jobID] = scheduledAt
+	}
+	return nil
+}
+
+func (o *memORM) get(jobID int32) time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.scheduledAt[jobID]
+}
+
 func TestCronV2Pipeline(t *testing.T) {
 	runner := pipelinemocks.NewRunner(t)
 	cfg := configtest.NewTestGeneralConfig(t)
@@ -43,7 +84,7 @@ func TestCronV2Pipeline(t *testing.T) {
 		PipelineSpec:  &pipeline.Spec{},
 		ExternalJobID: uuid.New(),
 	}
-	delegate := cron.NewDelegate(runner, lggr)
+	delegate := cron.NewDelegate(runner, cron.NewORM(db, lggr, cfg.Database()), lggr)
 
 	err = jobORM.CreateJob(jb)
 	require.NoError(t, err)
@@ -61,6 +102,7 @@ func TestCronV2Schedule(t *testing.T) {
 	t.Parallel()
 
 	spec := job.Job{
+		ID:            1,
 		Type:          job.Cron,
 		SchemaVersion: 1,
 		CronSpec:      &job.CronSpec{CronSchedule: "@every 1s"},
@@ -73,11 +115,102 @@ func TestCronV2Schedule(t *testing.T) {
 		Return(false, nil).
 		Once()
 
-	service, err := cron.NewCronFromJobSpec(spec, runner, logger.TestLogger(t))
+	orm := newMemORM()
+	startedAt := time.Now()
+	service, err := cron.NewCronFromJobSpec(spec, runner, orm, logger.TestLogger(t))
 	require.NoError(t, err)
 	err = service.Start(testutils.Context(t))
 	require.NoError(t, err)
 	defer func() { assert.NoError(t, service.Close()) }()
 
 	awaiter.AwaitOrFail(t)
+	// the time the schedule was due at is recorded after the run
+	gomega.NewWithT(t).Eventually(func() bool {
+		return orm.get(spec.ID).After(startedAt.Add(time.Second / 2))
+	}, testutils.WaitTimeout(t)).Should(gomega.BeTrue())
+}
+
+func TestCronV2CatchUp(t *testing.T) {
+	t.Parallel()
+
+	newSpec := func(catchUp job.CronCatchUp) job.Job {
+		return job.Job{
+			ID:             1,
+			Type:           job.Cron,
+			SchemaVersion:  1,
+			CronSpec:       &job.CronSpec{CronSchedule: "CRON_TZ=UTC 0 0 * * * *", CatchUp: catchUp},
+			PipelineSpecID: 1,
+			PipelineSpec:   &pipeline.Spec{},
+		}
+	}
+	// the hourly schedule was due three times since it was last handled
+	latest := time.Now().Truncate(time.Hour)
+	last := latest.Add(-3*time.Hour + time.Second)
+
+	for _, tc := range []struct {
+		catchUp job.CronCatchUp
+		runs    int
+	}{
+		{job.CronCatchUpRunOnce, 1},
+		{job.CronCatchUpRunAll, 3},
+	} {
+		tc := tc
+		t.Run(string(tc.catchUp), func(t *testing.T) {
+			t.Parallel()
+
+			orm := newMemORM()
+			require.NoError(t, orm.SetLastScheduledAt(1, last))
+			runner := pipelinemocks.NewRunner(t)
+			runs := make(chan *pipeline.Run, tc.runs)
+			runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { runs <- args.Get(1).(*pipeline.Run) }).
+				Return(false, nil).
+				Times(tc.runs)
+
+			service, err := cron.NewCronFromJobSpec(newSpec(tc.catchUp), runner, orm, logger.TestLogger(t))
+			require.NoError(t, err)
+			require.NoError(t, service.Start(testutils.Context(t)))
+			defer func() { assert.NoError(t, service.Close()) }()
+
+			for i := 0; i < tc.runs; i++ {
+				select {
+				case run := <-runs:
+					meta := run.Inputs.Val.(map[string]interface{})["jobRun"].(map[string]interface{})["meta"].(map[string]interface{})
+					assert.Equal(t, true, meta["catchUp"])
+					assert.NotEmpty(t, meta["scheduledAt"])
+				case <-time.After(testutils.WaitTimeout(t)):
+					t.Fatal("timed out waiting for missed runs")
+				}
+			}
+			gomega.NewWithT(t).Eventually(func() time.Time {
+				return orm.get(1)
+			}, testutils.WaitTimeout(t)).Should(gomega.BeTemporally("==", latest))
+		})
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		t.Parallel()
+
+		orm := newMemORM()
+		require.NoError(t, orm.SetLastScheduledAt(1, last))
+		service, err := cron.NewCronFromJobSpec(newSpec(job.CronCatchUpSkip), pipelinemocks.NewRunner(t), orm, logger.TestLogger(t))
+		require.NoError(t, err)
+		require.NoError(t, service.Start(testutils.Context(t)))
+		assert.NoError(t, service.Close())
+	})
+
+	t.Run("new job", func(t *testing.T) {
+		t.Parallel()
+
+		// nothing is caught up, and missed runs are counted from the start of the job
+		orm := newMemORM()
+		startedAt := time.Now()
+		service, err := cron.NewCronFromJobSpec(newSpec(job.CronCatchUpRunAll), pipelinemocks.NewRunner(t), orm, logger.TestLogger(t))
+		require.NoError(t, err)
+		require.NoError(t, service.Start(testutils.Context(t)))
+		gomega.NewWithT(t).Eventually(func() time.Time {
+			return orm.get(1)
+		}, testutils.WaitTimeout(t)).Should(gomega.BeTemporally(">=", startedAt))
+		assert.NoError(t, service.Close())
+	})
 }
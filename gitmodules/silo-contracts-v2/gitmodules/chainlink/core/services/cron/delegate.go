@@ -11,14 +11,16 @@ import (
 
 type Delegate struct {
 	pipelineRunner pipeline.Runner
+	orm            ORM
 	lggr           logger.Logger
 }
 
 var _ job.Delegate = (*Delegate)(nil)
 
-func NewDelegate(pipelineRunner pipeline.Runner, lggr logger.Logger) *Delegate {
+func NewDelegate(pipelineRunner pipeline.Runner, orm ORM, lggr logger.Logger) *Delegate {
 	return &Delegate{
 		pipelineRunner: pipelineRunner,
+		orm:            orm,
 		lggr:           lggr,
 	}
 }
@@ -38,7 +40,7 @@ func (d *Delegate) ServicesForSpec(spec job.Job, qopts ...pg.QOpt) (services []j
 		return nil, errors.Errorf("services.Delegate expects a *jobSpec.CronSpec to be present, got %v", spec)
 	}
 
-	cron, err := NewCronFromJobSpec(spec, d.pipelineRunner, d.lggr)
+	cron, err := NewCronFromJobSpec(spec, d.pipelineRunner, d.orm, d.lggr)
 	if err != nil {
 		return nil, err
 	}
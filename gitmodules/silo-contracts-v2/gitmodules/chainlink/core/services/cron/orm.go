@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+type ORM interface {
+	// LastScheduledAt returns the latest time the schedule of a cron job was due at and handled,
+	// or sql.ErrNoRows if there is none.
+	LastScheduledAt(jobID int32, qopts ...pg.QOpt) (time.Time, error)
+	// SetLastScheduledAt records that the schedule of a cron job was due at scheduledAt and
+	// handled. It is ignored if a later time is already recorded.
+	SetLastScheduledAt(jobID int32, scheduledAt time.Time, qopts ...pg.QOpt) error
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("CronORM"), cfg)}
+}
+
+func (o *orm) LastScheduledAt(jobID int32, qopts ...pg.QOpt) (scheduledAt time.Time, err error) {
+	err = o.q.WithOpts(qopts...).Get(&scheduledAt, `SELECT last_scheduled_at FROM cron_job_progress WHERE job_id = $1`, jobID)
+	return scheduledAt, errors.Wrap(err, "LastScheduledAt failed")
+}
+
+func (o *orm) SetLastScheduledAt(jobID int32, scheduledAt time.Time, qopts ...pg.QOpt) error {
+	_, err := o.q.WithOpts(qopts...).Exec(`INSERT INTO cron_job_progress (job_id, last_scheduled_at, updated_at) VALUES ($1, $2, NOW())
+	ON CONFLICT (job_id) DO UPDATE SET last_scheduled_at = EXCLUDED.last_scheduled_at, updated_at = EXCLUDED.updated_at
+	WHERE cron_job_progress.last_scheduled_at < EXCLUDED.last_scheduled_at`, jobID, scheduledAt)
+	return errors.Wrap(err, "SetLastScheduledAt failed")
+}
@@ -1,6 +1,9 @@
 package cron
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
@@ -34,8 +37,26 @@ func ValidatedCronSpec(tomlString string) (job.Job, error) {
 	if jb.Type != job.Cron {
 		return jb, errors.Errorf("unsupported type %s", jb.Type)
 	}
-	if err := utils.ValidateCronSchedule(spec.CronSchedule); err != nil {
-		return jb, errors.Wrapf(err, "while validating cron schedule '%v'", spec.CronSchedule)
+	if spec.TimeZone != "" {
+		if strings.HasPrefix(spec.CronSchedule, "CRON_TZ=") || strings.HasPrefix(spec.CronSchedule, "TZ=") {
+			return jb, errors.New("the time zone must be set either by timeZone or in the schedule, not both")
+		}
+		if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+			return jb, errors.Wrapf(err, "invalid time zone '%v'", spec.TimeZone)
+		}
+	}
+	if err := utils.ValidateCronSchedule(spec.Schedule()); err != nil {
+		return jb, errors.Wrapf(err, "while validating cron schedule '%v'", spec.Schedule())
+	}
+	if spec.Jitter.Duration() < 0 {
+		return jb, errors.Errorf("jitter must not be negative, got %v", spec.Jitter.Duration())
+	}
+	switch spec.CatchUp {
+	case "":
+		spec.CatchUp = job.CronCatchUpSkip
+	case job.CronCatchUpSkip, job.CronCatchUpRunOnce, job.CronCatchUpRunAll:
+	default:
+		return jb, errors.Errorf("catchUp must be one of %s, %s or %s, got '%v'", job.CronCatchUpSkip, job.CronCatchUpRunOnce, job.CronCatchUpRunAll, spec.CatchUp)
 	}
 
 	return jb, nil
@@ -3,6 +3,7 @@ package cron_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
@@ -77,6 +78,105 @@ ds -> ds_parse -> ds_multiply;
 				assert.True(t, strings.Contains(err.Error(), "invalid cron schedule"))
 			},
 		},
+		{
+			name: "time zone, jitter and catch-up",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "0 0 1 1 * *"
+timeZone        = "America/New_York"
+jitter          = "30s"
+catchUp         = "run-all"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+ds_parse    [type=jsonparse path="data,price"];
+ds_multiply [type=multiply times=100];
+ds -> ds_parse -> ds_multiply;
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, s.CronSpec)
+				assert.Equal(t, "CRON_TZ=America/New_York 0 0 1 1 * *", s.CronSpec.Schedule())
+				assert.Equal(t, 30*time.Second, s.CronSpec.Jitter.Duration())
+				assert.Equal(t, job.CronCatchUpRunAll, s.CronSpec.CatchUp)
+			},
+		},
+		{
+			name: "default catch-up",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "CRON_TZ=UTC 0 0 1 1 * *"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+ds_parse    [type=jsonparse path="data,price"];
+ds_multiply [type=multiply times=100];
+ds -> ds_parse -> ds_multiply;
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, job.CronCatchUpSkip, s.CronSpec.CatchUp)
+			},
+		},
+		{
+			name: "time zone set twice",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "CRON_TZ=UTC 0 0 1 1 * *"
+timeZone        = "UTC"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+ds_parse    [type=jsonparse path="data,price"];
+ds_multiply [type=multiply times=100];
+ds -> ds_parse -> ds_multiply;
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "either by timeZone or in the schedule")
+			},
+		},
+		{
+			name: "invalid time zone",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "0 0 1 1 * *"
+timeZone        = "Mars/Olympus_Mons"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+ds_parse    [type=jsonparse path="data,price"];
+ds_multiply [type=multiply times=100];
+ds -> ds_parse -> ds_multiply;
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid time zone")
+			},
+		},
+		{
+			name: "invalid catch-up",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "CRON_TZ=UTC 0 0 1 1 * *"
+catchUp         = "sometimes"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+ds_parse    [type=jsonparse path="data,price"];
+ds_multiply [type=multiply times=100];
+ds -> ds_parse -> ds_multiply;
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "catchUp must be one of")
+			},
+		},
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
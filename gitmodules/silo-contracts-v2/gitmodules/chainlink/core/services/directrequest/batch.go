@@ -0,0 +1,410 @@
+package directrequest
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	txmgrcommon "github.com/smartcontractkit/chainlink/v2/common/txmgr"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/operator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+const (
+	// maxFulfillmentAttempts is the number of times a request is submitted before giving up.
+	maxFulfillmentAttempts = 3
+	// fulfillmentRetention is how long finished fulfillments are kept.
+	fulfillmentRetention = 24 * time.Hour
+)
+
+var (
+	operatorABI = evmtypes.MustGetABI(operator_wrapper.OperatorABI)
+	// batchOperatorABI is the batched counterpart of the fulfillOracleRequest2 method, which is
+	// not implemented by the Operator contract of this repo. It is only used by jobs setting
+	// batchFulfillmentUseBatchMethod. Requests which cannot be fulfilled are skipped rather than
+	// reverting the batch, and are retried individually.
+	batchOperatorABI = evmtypes.MustGetABI(`[{"inputs":[{"internalType":"bytes32[]","name":"requestIds","type":"bytes32[]"},{"internalType":"uint256[]","name":"payments","type":"uint256[]"},{"internalType":"address[]","name":"callbackAddresses","type":"address[]"},{"internalType":"bytes4[]","name":"callbackFunctionIds","type":"bytes4[]"},{"internalType":"uint256[]","name":"expirations","type":"uint256[]"},{"internalType":"bytes[]","name":"data","type":"bytes[]"}],"name":"fulfillOracleRequests","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+)
+
+// ETHKeyStore is the subset of the eth keystore used to pick the sending key of fulfillments.
+type ETHKeyStore interface {
+	GetRoundRobinAddress(chainID *big.Int, addrs ...common.Address) (common.Address, error)
+}
+
+type fulfillmentStatus string
+
+const (
+	fulfillmentPending   fulfillmentStatus = "pending"
+	fulfillmentSubmitted fulfillmentStatus = "submitted"
+	fulfillmentFulfilled fulfillmentStatus = "fulfilled"
+	fulfillmentCancelled fulfillmentStatus = "cancelled"
+	fulfillmentFailed    fulfillmentStatus = "failed"
+)
+
+// fulfillment is the response to an oracle request, waiting to be submitted or confirmed.
+type fulfillment struct {
+	ID                 int64
+	ExternalJobID      uuid.UUID
+	RequestID          common.Hash
+	Payment            *utils.Big
+	CallbackAddress    common.Address
+	CallbackFunctionID []byte
+	Expiration         *utils.Big
+	Data               []byte
+	Status             fulfillmentStatus
+	Attempts           int32
+	Error              *string
+	SubmittedAt        *time.Time
+	EthTxID            *int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func newFulfillment(externalJobID uuid.UUID, request *operator_wrapper.OperatorOracleRequest, data []byte) fulfillment {
+	return fulfillment{
+		ExternalJobID:      externalJobID,
+		RequestID:          request.RequestId,
+		Payment:            utils.NewBig(request.Payment),
+		CallbackAddress:    request.CallbackAddr,
+		CallbackFunctionID: request.CallbackFunctionId[:],
+		Expiration:         utils.NewBig(request.CancelExpiration),
+		Data:               data,
+	}
+}
+
+// fulfillmentORM stores the fulfillments of the requests of a job with batch fulfillment enabled.
+type fulfillmentORM struct {
+	q             pg.Q
+	externalJobID uuid.UUID
+}
+
+// Queue stores the response to a request, to be submitted with the next batch.
+func (o *fulfillmentORM) Queue(f fulfillment, qopts ...pg.QOpt) error {
+	err := o.q.WithOpts(qopts...).ExecQNamed(`
+INSERT INTO direct_request_fulfillments (external_job_id, request_id, payment, callback_address, callback_function_id, expiration, data, status, created_at, updated_at)
+VALUES (:external_job_id, :request_id, :payment, :callback_address, :callback_function_id, :expiration, :data, 'pending', NOW(), NOW())
+ON CONFLICT (external_job_id, request_id) DO NOTHING`, f)
+	return errors.Wrap(err, "failed to queue fulfillment")
+}
+
+// SetStatus sets the status of the fulfillment of a request, unless it is already finished.
+func (o *fulfillmentORM) SetStatus(requestID common.Hash, status fulfillmentStatus, qopts ...pg.QOpt) error {
+	err := o.q.WithOpts(qopts...).ExecQ(`
+UPDATE direct_request_fulfillments SET status = $3, updated_at = NOW()
+WHERE external_job_id = $1 AND request_id = $2 AND status IN ('pending', 'submitted')`, o.externalJobID, requestID, status)
+	return errors.Wrapf(err, "failed to mark fulfillment %s", status)
+}
+
+// FindPending returns the oldest fulfillments which were not submitted yet.
+func (o *fulfillmentORM) FindPending(limit uint32, qopts ...pg.QOpt) (fulfillments []fulfillment, err error) {
+	err = o.q.WithOpts(qopts...).Select(&fulfillments, `
+SELECT * FROM direct_request_fulfillments
+WHERE external_job_id = $1 AND status = 'pending'
+ORDER BY id ASC LIMIT $2`, o.externalJobID, limit)
+	return fulfillments, errors.Wrap(err, "failed to load pending fulfillments")
+}
+
+// submission is a submitted fulfillment with the state of the transaction it was submitted
+// with, and its receipt once it is confirmed. Both are empty if the transaction is not found.
+type submission struct {
+	fulfillment
+	TxState *string
+	Receipt *evmtypes.Receipt
+}
+
+// FindFinishedSubmissions returns the fulfillments whose requests were not fulfilled yet and
+// whose transactions are finished: confirmed, fatally errored or not found.
+func (o *fulfillmentORM) FindFinishedSubmissions(qopts ...pg.QOpt) (submissions []submission, err error) {
+	err = o.q.WithOpts(qopts...).Select(&submissions, `
+SELECT f.*, t.state AS tx_state, (
+	SELECT r.receipt FROM eth_tx_attempts a JOIN eth_receipts r ON r.tx_hash = a.hash
+	WHERE a.eth_tx_id = t.id ORDER BY r.block_number DESC LIMIT 1
+) AS receipt
+FROM direct_request_fulfillments f
+LEFT JOIN eth_txes t ON t.id = f.eth_tx_id
+WHERE f.external_job_id = $1 AND f.status = 'submitted' AND (t.id IS NULL OR t.state IN ('confirmed', 'fatal_error'))
+ORDER BY f.id ASC`, o.externalJobID)
+	return submissions, errors.Wrap(err, "failed to load finished submissions")
+}
+
+// MarkSubmitted records a submission of the fulfillments with the given transaction.
+func (o *fulfillmentORM) MarkSubmitted(ids []int64, ethTxID int64, qopts ...pg.QOpt) error {
+	err := o.q.WithOpts(qopts...).ExecQ(`
+UPDATE direct_request_fulfillments SET status = 'submitted', attempts = attempts + 1, eth_tx_id = $2, submitted_at = NOW(), updated_at = NOW()
+WHERE id = ANY($1)`, pq.Array(ids), ethTxID)
+	return errors.Wrap(err, "failed to mark fulfillments submitted")
+}
+
+// MarkFailed gives up on the fulfillment of a request.
+func (o *fulfillmentORM) MarkFailed(id int64, reason string, qopts ...pg.QOpt) error {
+	err := o.q.WithOpts(qopts...).ExecQ(`
+UPDATE direct_request_fulfillments SET status = 'failed', error = $2, updated_at = NOW()
+WHERE id = $1`, id, reason)
+	return errors.Wrap(err, "failed to mark fulfillment failed")
+}
+
+// Prune deletes the finished fulfillments last updated before the given time.
+func (o *fulfillmentORM) Prune(updatedBefore time.Time, qopts ...pg.QOpt) error {
+	err := o.q.WithOpts(qopts...).ExecQ(`
+DELETE FROM direct_request_fulfillments
+WHERE external_job_id = $1 AND status IN ('fulfilled', 'cancelled', 'failed') AND updated_at < $2`, o.externalJobID, updatedBefore)
+	return errors.Wrap(err, "failed to prune fulfillments")
+}
+
+var _ job.ServiceCtx = &fulfillmentBatcher{}
+
+// fulfillmentBatcher submits the fulfillments queued by the listener of a job every
+// batchFulfillmentWindow, in transactions of up to batchFulfillmentMaxSize requests if
+// batchFulfillmentUseBatchMethod is set, or one by one otherwise. Transactions are sent from the
+// keys of the node authorized by the Operator. The listener marks a fulfillment
+// fulfilled when it receives the OracleResponse log of its request. Once the transaction of a
+// fulfillment is finished without an OracleResponse log for its request, because it failed,
+// reverted or skipped the request, the request is retried individually.
+type fulfillmentBatcher struct {
+	utils.StartStopOnce
+	lggr        logger.Logger
+	orm         *fulfillmentORM
+	q           pg.Q
+	oracle      operator_wrapper.OperatorInterface
+	txm         txmgr.TxManager
+	ethKeyStore ETHKeyStore
+	chainID     *big.Int
+	contract    common.Address
+	jobID       int32
+	window      time.Duration
+	maxSize     uint32
+	gasLimit    uint32
+	gasLimitMax uint32
+	// useBatchMethod submits fulfillments with fulfillOracleRequests
+	useBatchMethod bool
+	chStop         utils.StopChan
+	wg             sync.WaitGroup
+}
+
+func (b *fulfillmentBatcher) Start(context.Context) error {
+	return b.StartOnce("DirectRequestFulfillmentBatcher", func() error {
+		b.wg.Add(1)
+		go b.run()
+		return nil
+	})
+}
+
+func (b *fulfillmentBatcher) Close() error {
+	return b.StopOnce("DirectRequestFulfillmentBatcher", func() error {
+		close(b.chStop)
+		b.wg.Wait()
+		return nil
+	})
+}
+
+func (b *fulfillmentBatcher) run() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.chStop:
+			return
+		case <-ticker.C:
+			ctx, cancel := b.chStop.NewCtx()
+			b.processFulfillments(ctx)
+			cancel()
+		}
+	}
+}
+
+func (b *fulfillmentBatcher) processFulfillments(ctx context.Context) {
+	b.submitPending(ctx)
+	b.retryFinishedSubmissions(ctx)
+
+	if err := b.orm.Prune(time.Now().Add(-fulfillmentRetention), pg.WithParentCtx(ctx)); err != nil {
+		b.lggr.Errorw("Failed to prune fulfillments", "err", err)
+	}
+}
+
+func (b *fulfillmentBatcher) submitPending(ctx context.Context) {
+	for {
+		pending, err := b.orm.FindPending(b.maxSize, pg.WithParentCtx(ctx))
+		if err != nil {
+			b.lggr.Errorw("Failed to load pending fulfillments", "err", err)
+			return
+		}
+		if len(pending) == 0 {
+			return
+		}
+		if b.useBatchMethod {
+			if err = b.submit(ctx, "fulfillOracleRequests", pending); err != nil {
+				b.lggr.Errorw("Failed to submit batch of fulfillments", "err", err, "numRequests", len(pending))
+				return
+			}
+		} else {
+			for _, f := range pending {
+				if err = b.submit(ctx, "fulfillOracleRequest2", []fulfillment{f}); err != nil {
+					b.lggr.Errorw("Failed to submit fulfillment", "err", err, "requestID", formatRequestId(f.RequestID))
+					return
+				}
+			}
+		}
+		if uint32(len(pending)) < b.maxSize {
+			return
+		}
+	}
+}
+
+// fromAddress returns the next key of the node authorized by the Operator to fulfill requests.
+func (b *fulfillmentBatcher) fromAddress(ctx context.Context) (common.Address, error) {
+	senders, err := b.oracle.GetAuthorizedSenders(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to get the authorized senders of the operator")
+	}
+	if len(senders) == 0 {
+		return common.Address{}, errors.New("operator has no authorized senders")
+	}
+	fromAddress, err := b.ethKeyStore.GetRoundRobinAddress(b.chainID, senders...)
+	return fromAddress, errors.Wrap(err, "failed to get a sending key authorized by the operator")
+}
+
+// retryFinishedSubmissions checks the outcome of the transactions of the submitted fulfillments
+// once they are finished, so a request is never submitted again while a transaction fulfilling
+// it may still be mined.
+func (b *fulfillmentBatcher) retryFinishedSubmissions(ctx context.Context) {
+	submissions, err := b.orm.FindFinishedSubmissions(pg.WithParentCtx(ctx))
+	if err != nil {
+		b.lggr.Errorw("Failed to load finished submissions", "err", err)
+		return
+	}
+	for _, s := range submissions {
+		lggr := b.lggr.With("requestID", formatRequestId(s.RequestID), "attempts", s.Attempts, "ethTxID", s.EthTxID)
+		fulfilled, reason := s.outcome(b.contract)
+		if fulfilled {
+			// the OracleResponse log may not be received by the listener yet
+			if err = b.orm.SetStatus(s.RequestID, fulfillmentFulfilled, pg.WithParentCtx(ctx)); err != nil {
+				lggr.Errorw("Failed to mark request fulfilled", "err", err)
+			}
+			continue
+		}
+		if s.Attempts >= maxFulfillmentAttempts {
+			lggr.Errorw("Giving up on fulfillment, request was not fulfilled", "reason", reason)
+			if err = b.orm.MarkFailed(s.ID, reason, pg.WithParentCtx(ctx)); err != nil {
+				lggr.Errorw("Failed to mark fulfillment failed", "err", err)
+			}
+			continue
+		}
+		lggr.Warnw("Request was not fulfilled, retrying", "reason", reason)
+		if err = b.submit(ctx, "fulfillOracleRequest2", []fulfillment{s.fulfillment}); err != nil {
+			lggr.Errorw("Failed to submit fulfillment", "err", err)
+		}
+	}
+}
+
+// outcome returns whether the finished transaction of the submission fulfilled its request, or
+// why it did not.
+func (s submission) outcome(contract common.Address) (fulfilled bool, reason string) {
+	switch {
+	case s.TxState == nil:
+		return false, "transaction not found"
+	case *s.TxState == string(txmgrcommon.TxFatalError):
+		return false, "transaction failed"
+	case s.Receipt == nil:
+		return false, "transaction receipt not found"
+	case s.Receipt.Status == 0:
+		return false, "transaction reverted"
+	}
+	topic := operator_wrapper.OperatorOracleResponse{}.Topic()
+	for _, lg := range s.Receipt.Logs {
+		if lg.Address == contract && len(lg.Topics) == 2 && lg.Topics[0] == topic && lg.Topics[1] == s.RequestID {
+			return true, ""
+		}
+	}
+	return false, "request was skipped"
+}
+
+// submit creates a transaction fulfilling the requests with the given method, either the
+// batched fulfillOracleRequests or fulfillOracleRequest2 for a single request.
+func (b *fulfillmentBatcher) submit(ctx context.Context, method string, fulfillments []fulfillment) error {
+	payload, err := packFulfillments(method, fulfillments)
+	if err != nil {
+		return err
+	}
+	fromAddress, err := b.fromAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int64, len(fulfillments))
+	requestIDs := make([]common.Hash, len(fulfillments))
+	for i, f := range fulfillments {
+		ids[i] = f.ID
+		requestIDs[i] = f.RequestID
+	}
+	gasLimit := uint64(b.gasLimit) * uint64(len(fulfillments))
+	if gasLimit > uint64(b.gasLimitMax) {
+		gasLimit = uint64(b.gasLimitMax)
+	}
+	jobID := b.jobID
+
+	err = b.q.WithOpts(pg.WithParentCtx(ctx)).Transaction(func(tx pg.Queryer) error {
+		etx, err := b.txm.CreateTransaction(txmgr.TxRequest{
+			FromAddress:    fromAddress,
+			ToAddress:      b.contract,
+			EncodedPayload: payload,
+			FeeLimit:       uint32(gasLimit),
+			Strategy:       txmgrcommon.NewSendEveryStrategy(),
+			Meta: &txmgr.TxMeta{
+				JobID:      &jobID,
+				RequestIDs: requestIDs,
+			},
+		}, pg.WithQueryer(tx))
+		if err != nil {
+			return errors.Wrap(err, "failed to create fulfillment transaction")
+		}
+		return b.orm.MarkSubmitted(ids, etx.ID, pg.WithQueryer(tx))
+	})
+	if err != nil {
+		return err
+	}
+	b.lggr.Infow("Submitted fulfillments", "method", method, "numRequests", len(fulfillments), "fromAddress", fromAddress, "gasLimit", gasLimit)
+	return nil
+}
+
+func packFulfillments(method string, fulfillments []fulfillment) ([]byte, error) {
+	var (
+		requestIDs          = make([][32]byte, len(fulfillments))
+		payments            = make([]*big.Int, len(fulfillments))
+		callbackAddresses   = make([]common.Address, len(fulfillments))
+		callbackFunctionIDs = make([][4]byte, len(fulfillments))
+		expirations         = make([]*big.Int, len(fulfillments))
+		data                = make([][]byte, len(fulfillments))
+	)
+	for i, f := range fulfillments {
+		requestIDs[i] = f.RequestID
+		payments[i] = f.Payment.ToInt()
+		callbackAddresses[i] = f.CallbackAddress
+		copy(callbackFunctionIDs[i][:], f.CallbackFunctionID)
+		expirations[i] = f.Expiration.ToInt()
+		data[i] = f.Data
+	}
+
+	var (
+		payload []byte
+		err     error
+	)
+	if method == "fulfillOracleRequests" {
+		payload, err = batchOperatorABI.Pack(method, requestIDs, payments, callbackAddresses, callbackFunctionIDs, expirations, data)
+	} else {
+		payload, err = operatorABI.Pack(method, requestIDs[0], payments[0], callbackAddresses[0], callbackFunctionIDs[0], expirations[0], data[0])
+	}
+	return payload, errors.Wrapf(err, "failed to pack %s payload", method)
+}
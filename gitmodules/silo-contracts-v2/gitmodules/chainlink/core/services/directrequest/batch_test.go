@@ -0,0 +1,278 @@
+package directrequest
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	txmgrcommon "github.com/smartcontractkit/chainlink/v2/common/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink/v2/common/txmgr/types"
+	logmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/log/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/operator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func newTestOracleRequest(requestID common.Hash) *operator_wrapper.OperatorOracleRequest {
+	return &operator_wrapper.OperatorOracleRequest{
+		RequestId:          requestID,
+		Payment:            big.NewInt(100),
+		CallbackAddr:       testutils.NewAddress(),
+		CallbackFunctionId: [4]byte{1, 2, 3, 4},
+		CancelExpiration:   big.NewInt(1000),
+	}
+}
+
+func TestFulfillmentORM(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	orm := &fulfillmentORM{q: pg.NewQ(db, logger.TestLogger(t), pgtest.NewQConfig(true)), externalJobID: uuid.New()}
+
+	requestIDs := []common.Hash{testutils.Random32Byte(), testutils.Random32Byte(), testutils.Random32Byte()}
+	for _, id := range requestIDs {
+		require.NoError(t, orm.Queue(newFulfillment(orm.externalJobID, newTestOracleRequest(id), []byte{0xaa})))
+	}
+	// queueing the response to a request again is a no-op
+	require.NoError(t, orm.Queue(newFulfillment(orm.externalJobID, newTestOracleRequest(requestIDs[0]), []byte{0xbb})))
+
+	pending, err := orm.FindPending(2)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, requestIDs[0], pending[0].RequestID)
+	assert.Equal(t, []byte{0xaa}, pending[0].Data)
+	assert.Equal(t, []byte{1, 2, 3, 4}, pending[0].CallbackFunctionID)
+	assert.Equal(t, int64(100), pending[0].Payment.ToInt().Int64())
+
+	// the transaction is not found, as if it was reaped
+	require.NoError(t, orm.MarkSubmitted([]int64{pending[0].ID, pending[1].ID}, 1<<40))
+	require.NoError(t, orm.SetStatus(requestIDs[0], fulfillmentFulfilled))
+	require.NoError(t, orm.SetStatus(requestIDs[2], fulfillmentCancelled))
+
+	pending, err = orm.FindPending(10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	submissions, err := orm.FindFinishedSubmissions()
+	require.NoError(t, err)
+	require.Len(t, submissions, 1)
+	assert.Equal(t, requestIDs[1], submissions[0].RequestID)
+	assert.Equal(t, int32(1), submissions[0].Attempts)
+	assert.Equal(t, int64(1<<40), *submissions[0].EthTxID)
+	assert.Nil(t, submissions[0].TxState)
+	assert.Nil(t, submissions[0].Receipt)
+
+	// finished fulfillments are not reopened
+	require.NoError(t, orm.SetStatus(requestIDs[0], fulfillmentCancelled))
+	require.NoError(t, orm.MarkFailed(submissions[0].ID, "transaction not found"))
+
+	require.NoError(t, orm.Prune(time.Now().Add(time.Minute)))
+	assert.Zero(t, pgtest.MustCount(t, db, `SELECT count(*) FROM direct_request_fulfillments WHERE external_job_id = $1`, orm.externalJobID))
+}
+
+func TestListener_QueueFulfillment(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	orm := &fulfillmentORM{q: pg.NewQ(db, logger.TestLogger(t), pgtest.NewQConfig(true)), externalJobID: uuid.New()}
+	completed := pipeline.Run{State: pipeline.RunStatusCompleted, Outputs: pipeline.JSONSerializable{Valid: true, Val: []interface{}{"0xaa"}}}
+	countQueued := func() int {
+		return pgtest.MustCount(t, db, `SELECT count(*) FROM direct_request_fulfillments WHERE external_job_id = $1`, orm.externalJobID)
+	}
+
+	t.Run("queues the response and consumes the log", func(t *testing.T) {
+		lb := logmocks.NewBroadcast(t)
+		broadcaster := logmocks.NewBroadcaster(t)
+		broadcaster.On("MarkConsumed", lb, mock.Anything).Return(nil).Once()
+		l := &listener{logger: logger.TestLogger(t), logBroadcaster: broadcaster, fulfillments: orm, job: job.Job{ExternalJobID: orm.externalJobID}}
+
+		l.queueFulfillment(newTestOracleRequest(testutils.Random32Byte()), completed, lb)
+		assert.Equal(t, 1, countQueued())
+	})
+
+	t.Run("consumes the log of failed runs", func(t *testing.T) {
+		lb := logmocks.NewBroadcast(t)
+		broadcaster := logmocks.NewBroadcaster(t)
+		broadcaster.On("MarkConsumed", lb, mock.Anything).Return(nil).Once()
+		l := &listener{logger: logger.TestLogger(t), logBroadcaster: broadcaster, fulfillments: orm, job: job.Job{ExternalJobID: orm.externalJobID}}
+
+		l.queueFulfillment(newTestOracleRequest(testutils.Random32Byte()), pipeline.Run{State: pipeline.RunStatusErrored}, lb)
+		assert.Equal(t, 1, countQueued())
+	})
+
+	t.Run("does not queue the response if the log is not consumed", func(t *testing.T) {
+		lb := logmocks.NewBroadcast(t)
+		broadcaster := logmocks.NewBroadcaster(t)
+		broadcaster.On("MarkConsumed", lb, mock.Anything).Return(errors.New("boom")).Once()
+		l := &listener{logger: logger.TestLogger(t), logBroadcaster: broadcaster, fulfillments: orm, job: job.Job{ExternalJobID: orm.externalJobID}}
+
+		l.queueFulfillment(newTestOracleRequest(testutils.Random32Byte()), completed, lb)
+		assert.Equal(t, 1, countQueued())
+	})
+}
+
+func TestPackFulfillments(t *testing.T) {
+	t.Parallel()
+
+	fulfillments := []fulfillment{
+		newFulfillment(uuid.New(), newTestOracleRequest(testutils.Random32Byte()), []byte{0xaa}),
+		newFulfillment(uuid.New(), newTestOracleRequest(testutils.Random32Byte()), []byte{0xbb, 0xcc}),
+	}
+
+	payload, err := packFulfillments("fulfillOracleRequests", fulfillments)
+	require.NoError(t, err)
+	method := batchOperatorABI.Methods["fulfillOracleRequests"]
+	assert.Equal(t, method.ID, payload[:4])
+	args, err := method.Inputs.Unpack(payload[4:])
+	require.NoError(t, err)
+	assert.Equal(t, [][32]byte{fulfillments[0].RequestID, fulfillments[1].RequestID}, args[0])
+	assert.Equal(t, [][]byte{{0xaa}, {0xbb, 0xcc}}, args[5])
+
+	payload, err = packFulfillments("fulfillOracleRequest2", fulfillments[1:])
+	require.NoError(t, err)
+	method = operatorABI.Methods["fulfillOracleRequest2"]
+	assert.Equal(t, method.ID, payload[:4])
+	args, err = method.Inputs.Unpack(payload[4:])
+	require.NoError(t, err)
+	assert.Equal(t, [32]byte(fulfillments[1].RequestID), args[0])
+	assert.Equal(t, [4]byte{1, 2, 3, 4}, args[3])
+	assert.Equal(t, []byte{0xbb, 0xcc}, args[5])
+}
+
+func TestSubmission_Outcome(t *testing.T) {
+	t.Parallel()
+
+	contract := testutils.NewAddress()
+	requestID := testutils.Random32Byte()
+	responseLog := func(address common.Address, requestID common.Hash) *evmtypes.Log {
+		return &evmtypes.Log{Address: address, Topics: []common.Hash{operator_wrapper.OperatorOracleResponse{}.Topic(), requestID}}
+	}
+	state := func(state txmgrtypes.TxState) *string {
+		s := string(state)
+		return &s
+	}
+
+	for _, tt := range []struct {
+		name      string
+		txState   *string
+		receipt   *evmtypes.Receipt
+		fulfilled bool
+		reason    string
+	}{
+		{"not found", nil, nil, false, "transaction not found"},
+		{"fatal error", state(txmgrcommon.TxFatalError), nil, false, "transaction failed"},
+		{"reverted", state(txmgrcommon.TxConfirmed), &evmtypes.Receipt{Status: 0}, false, "transaction reverted"},
+		{"skipped", state(txmgrcommon.TxConfirmed), &evmtypes.Receipt{Status: 1, Logs: []*evmtypes.Log{
+			responseLog(contract, testutils.Random32Byte()),
+			responseLog(testutils.NewAddress(), requestID),
+		}}, false, "request was skipped"},
+		{"fulfilled", state(txmgrcommon.TxConfirmed), &evmtypes.Receipt{Status: 1, Logs: []*evmtypes.Log{
+			responseLog(contract, requestID),
+		}}, true, ""},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := submission{fulfillment: fulfillment{RequestID: requestID}, TxState: tt.txState, Receipt: tt.receipt}
+			fulfilled, reason := s.outcome(contract)
+			assert.Equal(t, tt.fulfilled, fulfilled)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+// fakeETHKeyStore picks the first of its keys among the given addresses.
+type fakeETHKeyStore []common.Address
+
+func (ks fakeETHKeyStore) GetRoundRobinAddress(_ *big.Int, addrs ...common.Address) (common.Address, error) {
+	for _, key := range ks {
+		for _, addr := range addrs {
+			if key == addr {
+				return key, nil
+			}
+		}
+	}
+	return common.Address{}, errors.New("no sending keys available")
+}
+
+// fakeOperator answers GetAuthorizedSenders with senders.
+type fakeOperator struct {
+	operator_wrapper.OperatorInterface
+	senders []common.Address
+}
+
+func (o fakeOperator) GetAuthorizedSenders(*bind.CallOpts) ([]common.Address, error) {
+	return o.senders, nil
+}
+
+func TestFulfillmentBatcher_FromAddress(t *testing.T) {
+	t.Parallel()
+
+	key, otherKey, sender := testutils.NewAddress(), testutils.NewAddress(), testutils.NewAddress()
+	for _, tt := range []struct {
+		name    string
+		senders []common.Address
+		want    common.Address
+		wantErr bool
+	}{
+		{"authorized key", []common.Address{sender, key}, key, false},
+		{"no authorized senders", nil, common.Address{}, true},
+		{"no authorized key", []common.Address{sender}, common.Address{}, true},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := &fulfillmentBatcher{
+				oracle:      fakeOperator{senders: tt.senders},
+				ethKeyStore: fakeETHKeyStore{otherKey, key},
+				chainID:     big.NewInt(1),
+			}
+
+			from, err := b.fromAddress(testutils.Context(t))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, from)
+		})
+	}
+}
+
+func TestFulfillmentData(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name    string
+		outputs interface{}
+		want    []byte
+		wantErr bool
+	}{
+		{"hex string", []interface{}{"0xaabb"}, []byte{0xaa, 0xbb}, false},
+		{"bytes", []interface{}{[]byte{0xaa}}, []byte{0xaa}, false},
+		{"multiple outputs", []interface{}{"0xaa", "0xbb"}, nil, true},
+		{"number", []interface{}{42}, nil, true},
+		{"invalid hex", []interface{}{"aabb"}, nil, true},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data, err := fulfillmentData(pipeline.Run{Outputs: pipeline.JSONSerializable{Valid: true, Val: tt.outputs}})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, data)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/assets"
@@ -28,6 +29,7 @@ type (
 		logger         logger.Logger
 		pipelineRunner pipeline.Runner
 		pipelineORM    pipeline.ORM
+		ethKeyStore    ETHKeyStore
 		chHeads        chan *evmtypes.Head
 		legacyChains   evm.LegacyChainContainer
 		mailMon        *utils.MailboxMonitor
@@ -45,6 +47,7 @@ func NewDelegate(
 	logger logger.Logger,
 	pipelineRunner pipeline.Runner,
 	pipelineORM pipeline.ORM,
+	ethKeyStore ETHKeyStore,
 	legacyChains evm.LegacyChainContainer,
 	mailMon *utils.MailboxMonitor,
 ) *Delegate {
@@ -52,6 +55,7 @@ func NewDelegate(
 		logger:         logger.Named("DirectRequest"),
 		pipelineRunner: pipelineRunner,
 		pipelineORM:    pipelineORM,
+		ethKeyStore:    ethKeyStore,
 		chHeads:        make(chan *evmtypes.Head, 1),
 		legacyChains:   legacyChains,
 		mailMon:        mailMon,
@@ -67,7 +71,8 @@ func (d *Delegate) AfterJobCreated(spec job.Job)                 {}
 func (d *Delegate) BeforeJobDeleted(spec job.Job)                {}
 func (d *Delegate) OnDeleteJob(spec job.Job, q pg.Queryer) error { return nil }
 
-// ServicesForSpec returns the log listener service for a direct request job
+// ServicesForSpec returns the log listener service for a direct request job, preceded by the
+// service submitting its fulfillments if batch fulfillment is enabled
 func (d *Delegate) ServicesForSpec(jb job.Job, qopts ...pg.QOpt) ([]job.ServiceCtx, error) {
 	if jb.DirectRequestSpec == nil {
 		return nil, errors.Errorf("DirectRequest: directrequest.Delegate expects a *job.DirectRequestSpec to be present, got %v", jb)
@@ -102,12 +107,33 @@ func (d *Delegate) ServicesForSpec(jb job.Job, qopts ...pg.QOpt) ([]job.ServiceC
 		job:                      jb,
 		mbOracleRequests:         utils.NewHighCapacityMailbox[log.Broadcast](),
 		mbOracleCancelRequests:   utils.NewHighCapacityMailbox[log.Broadcast](),
+		mbOracleResponses:        utils.NewHighCapacityMailbox[log.Broadcast](),
 		minIncomingConfirmations: concreteSpec.MinIncomingConfirmations.Uint32,
 		requesters:               concreteSpec.Requesters,
 		minContractPayment:       concreteSpec.MinContractPayment,
 		chStop:                   make(chan struct{}),
 	}
 	var services []job.ServiceCtx
+	if concreteSpec.BatchFulfillmentEnabled {
+		logListener.fulfillments = &fulfillmentORM{q: d.pipelineORM.GetQ(), externalJobID: jb.ExternalJobID}
+		services = append(services, &fulfillmentBatcher{
+			lggr:           svcLogger.Named("FulfillmentBatcher"),
+			orm:            logListener.fulfillments,
+			q:              d.pipelineORM.GetQ(),
+			oracle:         oracle,
+			txm:            chain.TxManager(),
+			ethKeyStore:    d.ethKeyStore,
+			chainID:        chain.ID(),
+			contract:       concreteSpec.ContractAddress.Address(),
+			jobID:          jb.ID,
+			window:         concreteSpec.BatchFulfillmentWindow.Duration(),
+			maxSize:        concreteSpec.BatchFulfillmentMaxSize,
+			gasLimit:       chain.Config().EVM().GasEstimator().LimitDefault(),
+			gasLimitMax:    chain.Config().EVM().GasEstimator().LimitMax(),
+			useBatchMethod: concreteSpec.BatchFulfillmentUseBatchMethod,
+			chStop:         make(chan struct{}),
+		})
+	}
 	services = append(services, logListener)
 
 	return services, nil
@@ -131,9 +157,11 @@ type listener struct {
 	shutdownWaitGroup        sync.WaitGroup
 	mbOracleRequests         *utils.Mailbox[log.Broadcast]
 	mbOracleCancelRequests   *utils.Mailbox[log.Broadcast]
+	mbOracleResponses        *utils.Mailbox[log.Broadcast]
 	minIncomingConfirmations uint32
 	requesters               models.AddressCollection
 	minContractPayment       *assets.Link
+	fulfillments             *fulfillmentORM // set when batch fulfillment is enabled
 	chStop                   chan struct{}
 	utils.StartStopOnce
 }
@@ -141,18 +169,24 @@ type listener struct {
 // Start complies with job.Service
 func (l *listener) Start(context.Context) error {
 	return l.StartOnce("DirectRequestListener", func() error {
+		logsWithTopics := map[common.Hash][][]log.Topic{
+			operator_wrapper.OperatorOracleRequest{}.Topic():       {{log.Topic(l.job.ExternalIDEncodeBytesToTopic()), log.Topic(l.job.ExternalIDEncodeStringToTopic())}},
+			operator_wrapper.OperatorCancelOracleRequest{}.Topic(): {{log.Topic(l.job.ExternalIDEncodeBytesToTopic()), log.Topic(l.job.ExternalIDEncodeStringToTopic())}},
+		}
+		if l.fulfillments != nil {
+			// responses are not tied to a job, the request IDs are matched against the queued fulfillments
+			logsWithTopics[operator_wrapper.OperatorOracleResponse{}.Topic()] = nil
+		}
 		unsubscribeLogs := l.logBroadcaster.Register(l, log.ListenerOpts{
-			Contract: l.oracle.Address(),
-			ParseLog: l.oracle.ParseLog,
-			LogsWithTopics: map[common.Hash][][]log.Topic{
-				operator_wrapper.OperatorOracleRequest{}.Topic():       {{log.Topic(l.job.ExternalIDEncodeBytesToTopic()), log.Topic(l.job.ExternalIDEncodeStringToTopic())}},
-				operator_wrapper.OperatorCancelOracleRequest{}.Topic(): {{log.Topic(l.job.ExternalIDEncodeBytesToTopic()), log.Topic(l.job.ExternalIDEncodeStringToTopic())}},
-			},
+			Contract:                 l.oracle.Address(),
+			ParseLog:                 l.oracle.ParseLog,
+			LogsWithTopics:           logsWithTopics,
 			MinIncomingConfirmations: l.minIncomingConfirmations,
 		})
-		l.shutdownWaitGroup.Add(3)
+		l.shutdownWaitGroup.Add(4)
 		go l.processOracleRequests()
 		go l.processCancelOracleRequests()
+		go l.processOracleResponses()
 
 		go func() {
 			<-l.chStop
@@ -162,6 +196,7 @@ func (l *listener) Start(context.Context) error {
 
 		l.mailMon.Monitor(l.mbOracleRequests, "DirectRequest", "Requests", fmt.Sprint(l.job.PipelineSpec.JobID))
 		l.mailMon.Monitor(l.mbOracleCancelRequests, "DirectRequest", "Cancel", fmt.Sprint(l.job.PipelineSpec.JobID))
+		l.mailMon.Monitor(l.mbOracleResponses, "DirectRequest", "Responses", fmt.Sprint(l.job.PipelineSpec.JobID))
 
 		return nil
 	})
@@ -180,7 +215,7 @@ func (l *listener) Close() error {
 		close(l.chStop)
 		l.shutdownWaitGroup.Wait()
 
-		return services.CloseAll(l.mbOracleRequests, l.mbOracleCancelRequests)
+		return services.CloseAll(l.mbOracleRequests, l.mbOracleCancelRequests, l.mbOracleResponses)
 	})
 }
 
@@ -202,6 +237,11 @@ func (l *listener) HandleLog(lb log.Broadcast) {
 		if wasOverCapacity {
 			l.logger.Error("CancelOracleRequest log mailbox is over capacity - dropped the oldest log")
 		}
+	case *operator_wrapper.OperatorOracleResponse:
+		wasOverCapacity := l.mbOracleResponses.Deliver(lb)
+		if wasOverCapacity {
+			l.logger.Error("OracleResponse log mailbox is over capacity - dropped the oldest log")
+		}
 	default:
 		l.logger.Warnf("Unexpected log type %T", log)
 	}
@@ -231,6 +271,18 @@ func (l *listener) processCancelOracleRequests() {
 	}
 }
 
+func (l *listener) processOracleResponses() {
+	for {
+		select {
+		case <-l.chStop:
+			l.shutdownWaitGroup.Done()
+			return
+		case <-l.mbOracleResponses.Notify():
+			l.handleReceivedLogs(l.mbOracleResponses)
+		}
+	}
+}
+
 func (l *listener) handleReceivedLogs(mailbox *utils.Mailbox[log.Broadcast]) {
 	for {
 		select {
@@ -250,6 +302,12 @@ func (l *listener) handleReceivedLogs(mailbox *utils.Mailbox[log.Broadcast]) {
 			continue
 		}
 
+		// the topic of responses is the request ID rather than the job ID
+		if response, ok := lb.DecodedLog().(*operator_wrapper.OperatorOracleResponse); ok && response != nil {
+			l.handleOracleResponse(response, lb)
+			continue
+		}
+
 		logJobSpecID := lb.RawLog().Topics[1]
 		if logJobSpecID == (common.Hash{}) || (logJobSpecID != l.job.ExternalIDEncodeStringToTopic() && logJobSpecID != l.job.ExternalIDEncodeBytesToTopic()) {
 			l.logger.Debugw("Skipping Run for Log with wrong Job ID", "logJobSpecID", logJobSpecID)
@@ -362,17 +420,72 @@ func (l *listener) handleOracleRequest(request *operator_wrapper.OperatorOracleR
 		},
 	})
 	run := pipeline.NewRun(*l.job.PipelineSpec, vars)
-	_, err := l.pipelineRunner.Run(ctx, &run, l.logger, true, func(tx pg.Queryer) error {
-		l.markLogConsumed(lb, pg.WithQueryer(tx))
-		return nil
-	})
+	// With batch fulfillment, the log is only consumed once the response is queued, so that the
+	// request is run again if the node stops before.
+	var consumeLog func(tx pg.Queryer) error
+	if l.fulfillments == nil {
+		consumeLog = func(tx pg.Queryer) error {
+			l.markLogConsumed(lb, pg.WithQueryer(tx))
+			return nil
+		}
+	}
+	_, err := l.pipelineRunner.Run(ctx, &run, l.logger, true, consumeLog)
 	if ctx.Err() != nil {
 		return
 	} else if err != nil {
 		l.logger.Errorw("Failed executing run", "err", err)
+		return
+	}
+
+	if l.fulfillments != nil {
+		l.queueFulfillment(request, run, lb)
+	}
+}
+
+// queueFulfillment queues the final output of a completed run, the response data passed to
+// fulfillOracleRequest2, to be submitted with the next batch of fulfillments. The log of the
+// request is consumed in the same transaction.
+func (l *listener) queueFulfillment(request *operator_wrapper.OperatorOracleRequest, run pipeline.Run, lb log.Broadcast) {
+	err := l.fulfillments.q.Transaction(func(tx pg.Queryer) error {
+		if run.State == pipeline.RunStatusCompleted {
+			data, err := fulfillmentData(run)
+			if err != nil {
+				l.logger.Errorw("Not fulfilling request, invalid pipeline output", "err", err, "requestId", formatRequestId(request.RequestId))
+			} else if err = l.fulfillments.Queue(newFulfillment(l.job.ExternalJobID, request, data), pg.WithQueryer(tx)); err != nil {
+				return err
+			}
+		}
+		return errors.Wrap(l.logBroadcaster.MarkConsumed(lb, pg.WithQueryer(tx)), "failed to mark log consumed")
+	})
+	if err != nil {
+		l.logger.Errorw("Failed to queue fulfillment", "err", err, "requestId", formatRequestId(request.RequestId))
+	}
+}
+
+func fulfillmentData(run pipeline.Run) ([]byte, error) {
+	outputs, ok := run.Outputs.Val.([]interface{})
+	if !ok || len(outputs) != 1 {
+		return nil, errors.Errorf("expected a single final output, got %v", run.Outputs.Val)
+	}
+	switch data := outputs[0].(type) {
+	case []byte:
+		return data, nil
+	case string:
+		return hexutil.Decode(data)
+	default:
+		return nil, errors.Errorf("expected the response data as bytes or a hex string, got %T", data)
 	}
 }
 
+// handleOracleResponse marks the fulfillment of the request fulfilled, if it is queued by the job.
+func (l *listener) handleOracleResponse(response *operator_wrapper.OperatorOracleResponse, lb log.Broadcast) {
+	if err := l.fulfillments.SetStatus(response.RequestId, fulfillmentFulfilled); err != nil {
+		l.logger.Errorw("Failed to mark request fulfilled", "err", err, "requestId", formatRequestId(response.RequestId))
+		return
+	}
+	l.markLogConsumed(lb)
+}
+
 func (l *listener) allowRequester(requester common.Address) bool {
 	if len(l.requesters) == 0 {
 		return true
@@ -391,6 +504,11 @@ func (l *listener) handleCancelOracleRequest(request *operator_wrapper.OperatorC
 	if loaded {
 		close(runCloserChannelIf.(utils.StopChan))
 	}
+	if l.fulfillments != nil {
+		if err := l.fulfillments.SetStatus(request.RequestId, fulfillmentCancelled); err != nil {
+			l.logger.Errorw("Failed to mark request cancelled", "err", err, "requestId", formatRequestId(request.RequestId))
+		}
+	}
 	l.markLogConsumed(lb)
 }
 
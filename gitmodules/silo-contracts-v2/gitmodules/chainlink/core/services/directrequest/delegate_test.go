@@ -49,7 +49,7 @@ func TestDelegate_ServicesForSpec(t *testing.T) {
 	lggr := logger.TestLogger(t)
 	legacyChains, err := evmrelay.NewLegacyChainsFromRelayerExtenders(relayerExtenders)
 	require.NoError(t, err)
-	delegate := directrequest.NewDelegate(lggr, runner, nil, legacyChains, mailMon)
+	delegate := directrequest.NewDelegate(lggr, runner, nil, keyStore.Eth(), legacyChains, mailMon)
 
 	t.Run("Spec without DirectRequestSpec", func(t *testing.T) {
 		spec := job.Job{}
@@ -92,7 +92,7 @@ func NewDirectRequestUniverseWithConfig(t *testing.T, cfg chainlink.GeneralConfi
 	legacyChains, err := evmrelay.NewLegacyChainsFromRelayerExtenders(relayExtenders)
 	require.NoError(t, err)
 	jobORM := job.NewORM(db, legacyChains, orm, btORM, keyStore, lggr, cfg.Database())
-	delegate := directrequest.NewDelegate(lggr, runner, orm, legacyChains, mailMon)
+	delegate := directrequest.NewDelegate(lggr, runner, orm, keyStore.Eth(), legacyChains, mailMon)
 
 	jb := cltest.MakeDirectRequestJobSpec(t)
 	jb.ExternalJobID = uuid.New()
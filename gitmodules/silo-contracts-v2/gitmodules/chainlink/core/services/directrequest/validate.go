@@ -1,6 +1,8 @@
 package directrequest
 
 import (
+	"time"
+
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
 
@@ -8,18 +10,28 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/null"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 type DirectRequestToml struct {
-	ContractAddress          ethkey.EIP55Address      `toml:"contractAddress"`
-	Requesters               models.AddressCollection `toml:"requesters"`
-	MinContractPayment       *assets.Link             `toml:"minContractPaymentLinkJuels"`
-	EVMChainID               *utils.Big               `toml:"evmChainID"`
-	MinIncomingConfirmations null.Uint32              `toml:"minIncomingConfirmations"`
+	ContractAddress                ethkey.EIP55Address      `toml:"contractAddress"`
+	Requesters                     models.AddressCollection `toml:"requesters"`
+	MinContractPayment             *assets.Link             `toml:"minContractPaymentLinkJuels"`
+	EVMChainID                     *utils.Big               `toml:"evmChainID"`
+	MinIncomingConfirmations       null.Uint32              `toml:"minIncomingConfirmations"`
+	BatchFulfillmentEnabled        bool                     `toml:"batchFulfillmentEnabled"`
+	BatchFulfillmentWindow         models.Interval          `toml:"batchFulfillmentWindow"`
+	BatchFulfillmentMaxSize        uint32                   `toml:"batchFulfillmentMaxSize"`
+	BatchFulfillmentUseBatchMethod bool                     `toml:"batchFulfillmentUseBatchMethod"`
 }
 
+const (
+	defaultBatchFulfillmentWindow  = 5 * time.Second
+	defaultBatchFulfillmentMaxSize = 20
+)
+
 func ValidatedDirectRequestSpec(tomlString string) (job.Job, error) {
 	var jb = job.Job{}
 	tree, err := toml.Load(tomlString)
@@ -36,15 +48,46 @@ func ValidatedDirectRequestSpec(tomlString string) (job.Job, error) {
 		return jb, err
 	}
 	jb.DirectRequestSpec = &job.DirectRequestSpec{
-		ContractAddress:          spec.ContractAddress,
-		Requesters:               spec.Requesters,
-		MinContractPayment:       spec.MinContractPayment,
-		EVMChainID:               spec.EVMChainID,
-		MinIncomingConfirmations: spec.MinIncomingConfirmations,
+		ContractAddress:                spec.ContractAddress,
+		Requesters:                     spec.Requesters,
+		MinContractPayment:             spec.MinContractPayment,
+		EVMChainID:                     spec.EVMChainID,
+		MinIncomingConfirmations:       spec.MinIncomingConfirmations,
+		BatchFulfillmentEnabled:        spec.BatchFulfillmentEnabled,
+		BatchFulfillmentWindow:         spec.BatchFulfillmentWindow,
+		BatchFulfillmentMaxSize:        spec.BatchFulfillmentMaxSize,
+		BatchFulfillmentUseBatchMethod: spec.BatchFulfillmentUseBatchMethod,
 	}
 
 	if jb.Type != job.DirectRequest {
 		return jb, errors.Errorf("unsupported type %s", jb.Type)
 	}
+	if err = validateBatchFulfillment(jb); err != nil {
+		return jb, err
+	}
 	return jb, nil
 }
+
+// validateBatchFulfillment checks the batch fulfillment settings of the spec, and sets their
+// defaults when batch fulfillment is enabled.
+func validateBatchFulfillment(jb job.Job) error {
+	spec := jb.DirectRequestSpec
+	if !spec.BatchFulfillmentEnabled {
+		if spec.BatchFulfillmentWindow != 0 || spec.BatchFulfillmentMaxSize != 0 || spec.BatchFulfillmentUseBatchMethod {
+			return errors.New("batchFulfillmentWindow, batchFulfillmentMaxSize and batchFulfillmentUseBatchMethod require batchFulfillmentEnabled")
+		}
+		return nil
+	}
+	for _, t := range jb.Pipeline.Tasks {
+		if t.Type() == pipeline.TaskTypeETHTx {
+			return errors.Errorf("task %s: requests are fulfilled by the node when batchFulfillmentEnabled is set, the pipeline must output the response data instead of submitting it", t.DotID())
+		}
+	}
+	if spec.BatchFulfillmentWindow == 0 {
+		spec.BatchFulfillmentWindow = models.Interval(defaultBatchFulfillmentWindow)
+	}
+	if spec.BatchFulfillmentMaxSize == 0 {
+		spec.BatchFulfillmentMaxSize = defaultBatchFulfillmentMaxSize
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package directrequest
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -72,3 +73,52 @@ func TestValidatedDirectRequestSpec_MinIncomingConfirmations(t *testing.T) {
 		assert.Equal(t, uint32(100), s.DirectRequestSpec.MinIncomingConfirmations.Uint32)
 	})
 }
+
+func TestValidatedDirectRequestSpec_BatchFulfillment(t *testing.T) {
+	t.Parallel()
+
+	const spec = `
+type                = "directrequest"
+schemaVersion       = 1
+name                = "example eth request event spec"
+contractAddress     = "0x613a38AC1659769640aaE063C651F48E0250454C"
+%s
+observationSource   = """
+    %s
+"""
+`
+	const encodeData = `encode_data [type=ethabiencode abi="(bytes32 requestId, bytes value)" data="$(decode_cbor)"];`
+
+	t.Run("defaults", func(t *testing.T) {
+		s, err := ValidatedDirectRequestSpec(fmt.Sprintf(spec, "batchFulfillmentEnabled = true", encodeData))
+		require.NoError(t, err)
+		assert.True(t, s.DirectRequestSpec.BatchFulfillmentEnabled)
+		assert.Equal(t, 5*time.Second, s.DirectRequestSpec.BatchFulfillmentWindow.Duration())
+		assert.Equal(t, uint32(20), s.DirectRequestSpec.BatchFulfillmentMaxSize)
+		assert.False(t, s.DirectRequestSpec.BatchFulfillmentUseBatchMethod)
+	})
+
+	t.Run("window and max size", func(t *testing.T) {
+		s, err := ValidatedDirectRequestSpec(fmt.Sprintf(spec, `
+batchFulfillmentEnabled = true
+batchFulfillmentWindow = "30s"
+batchFulfillmentMaxSize = 50
+batchFulfillmentUseBatchMethod = true`, encodeData))
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, s.DirectRequestSpec.BatchFulfillmentWindow.Duration())
+		assert.Equal(t, uint32(50), s.DirectRequestSpec.BatchFulfillmentMaxSize)
+		assert.True(t, s.DirectRequestSpec.BatchFulfillmentUseBatchMethod)
+	})
+
+	t.Run("window without batch fulfillment", func(t *testing.T) {
+		_, err := ValidatedDirectRequestSpec(fmt.Sprintf(spec, `batchFulfillmentWindow = "30s"`, encodeData))
+		require.ErrorContains(t, err, "require batchFulfillmentEnabled")
+	})
+
+	t.Run("pipeline submitting the response", func(t *testing.T) {
+		_, err := ValidatedDirectRequestSpec(fmt.Sprintf(spec, "batchFulfillmentEnabled = true", encodeData+`
+    submit_tx [type=ethtx to="0x613a38AC1659769640aaE063C651F48E0250454C" data="$(encode_data)"];
+    encode_data -> submit_tx;`))
+		require.ErrorContains(t, err, "task submit_tx")
+	})
+}
@@ -0,0 +1,107 @@
+package evmlog
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+type Delegate struct {
+	logger         logger.Logger
+	pipelineRunner pipeline.Runner
+	legacyChains   evm.LegacyChainContainer
+	orm            ORM
+}
+
+var _ job.Delegate = (*Delegate)(nil)
+
+func NewDelegate(
+	logger logger.Logger,
+	pipelineRunner pipeline.Runner,
+	legacyChains evm.LegacyChainContainer,
+	db *sqlx.DB,
+	cfg pg.QConfig,
+) *Delegate {
+	return &Delegate{
+		logger:         logger.Named("EVMLog"),
+		pipelineRunner: pipelineRunner,
+		legacyChains:   legacyChains,
+		orm:            NewORM(db, logger, cfg),
+	}
+}
+
+// JobType satisfies the job.Delegate interface.
+func (d *Delegate) JobType() job.Type {
+	return job.EVMLog
+}
+
+func (d *Delegate) BeforeJobCreated(spec job.Job) {}
+func (d *Delegate) AfterJobCreated(spec job.Job)  {}
+func (d *Delegate) BeforeJobDeleted(spec job.Job) {}
+
+// OnDeleteJob removes the log poller filter of the job, in the same transaction as the job.
+func (d *Delegate) OnDeleteJob(jb job.Job, q pg.Queryer) error {
+	if jb.EVMLogSpec == nil {
+		return nil
+	}
+	chain, err := d.legacyChains.Get(jb.EVMLogSpec.EVMChainID.String())
+	if err != nil {
+		// The chain is gone, and so is its log poller.
+		d.logger.Warnw("Could not unregister log poller filter", "jobID", jb.ID, "err", err)
+		return nil
+	}
+	return chain.LogPoller().UnregisterFilter(filterName(jb), pg.WithQueryer(q))
+}
+
+// ServicesForSpec returns the log listener to be used for running evmlog jobs
+func (d *Delegate) ServicesForSpec(jb job.Job, qopts ...pg.QOpt) ([]job.ServiceCtx, error) {
+	if jb.EVMLogSpec == nil {
+		return nil, errors.Errorf("evmlog.Delegate expects a *job.EVMLogSpec to be present, got %v", jb)
+	}
+	spec := jb.EVMLogSpec
+
+	chain, err := d.legacyChains.Get(spec.EVMChainID.String())
+	if err != nil {
+		return nil, fmt.Errorf("getting chain ID %d: %w", spec.EVMChainID.ToInt(), err)
+	}
+	if !chain.Config().Feature().LogPoller() {
+		return nil, errors.New("log poller must be enabled to run evmlog jobs")
+	}
+
+	event, err := ParseEvent(spec.EventABI)
+	if err != nil {
+		return nil, errors.Wrap(err, `invalid "eventABI"`)
+	}
+
+	lp := chain.LogPoller()
+	err = lp.RegisterFilter(logpoller.Filter{
+		Name:      filterName(jb),
+		EventSigs: evmtypes.HashArray{event.ID},
+		Addresses: evmtypes.AddressArray{spec.ContractAddress.Address()},
+	}, qopts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to register log poller filter")
+	}
+
+	lggr := d.logger.With(
+		"jobID", jb.ID,
+		"externalJobID", jb.ExternalJobID,
+		"contractAddress", spec.ContractAddress,
+		"event", event.Name,
+	)
+
+	return []job.ServiceCtx{newListener(lggr, jb, event, lp, d.pipelineRunner, d.orm, int64(chain.Config().EVM().FinalityDepth()))}, nil
+}
+
+func filterName(jb job.Job) string {
+	return logpoller.FilterName("EVMLog", jb.ExternalJobID)
+}
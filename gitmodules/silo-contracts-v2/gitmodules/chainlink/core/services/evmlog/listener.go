@@ -0,0 +1,292 @@
+package evmlog
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+var _ job.ServiceCtx = &listener{}
+
+type logKey struct {
+	blockHash common.Hash
+	logIndex  int64
+}
+
+// listener polls the log poller for the logs matching an evmlog job and
+// starts a pipeline run for each of them.
+//
+// Every log a run was started for is recorded together with the run, so that
+// logs are not processed twice across restarts. Since the log poller only
+// returns logs on the canonical chain, logs are re-scanned for lookback blocks,
+// and recorded logs which are no longer returned were reorged out.
+type listener struct {
+	utils.StartStopOnce
+	logger         logger.Logger
+	job            job.Job
+	event          abi.Event
+	lp             logpoller.LogPoller
+	pipelineRunner pipeline.Runner
+	orm            ORM
+	lookback       int64
+
+	// nextBlock is the first block which has not been processed yet, 0 until
+	// the progress of the job has been loaded.
+	nextBlock int64
+
+	parentCtx context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func newListener(
+	lggr logger.Logger,
+	jb job.Job,
+	event abi.Event,
+	lp logpoller.LogPoller,
+	pipelineRunner pipeline.Runner,
+	orm ORM,
+	lookback int64,
+) *listener {
+	return &listener{
+		logger:         lggr,
+		job:            jb,
+		event:          event,
+		lp:             lp,
+		pipelineRunner: pipelineRunner,
+		orm:            orm,
+		lookback:       lookback,
+		done:           make(chan struct{}),
+	}
+}
+
+// Start complies with job.Service
+func (l *listener) Start(context.Context) error {
+	return l.StartOnce("EVMLogListener", func() error {
+		l.parentCtx, l.cancel = context.WithCancel(context.Background())
+		go func() {
+			defer close(l.done)
+			ticker := time.NewTicker(utils.WithJitter(l.job.EVMLogSpec.PollPeriod))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if err := l.poll(l.parentCtx); err != nil && l.parentCtx.Err() == nil {
+						l.logger.Errorw("Failed to process logs", "err", err)
+					}
+				case <-l.parentCtx.Done():
+					return
+				}
+			}
+		}()
+		return nil
+	})
+}
+
+// Close complies with job.Service
+func (l *listener) Close() error {
+	return l.StopOnce("EVMLogListener", func() error {
+		l.cancel()
+		<-l.done
+		return nil
+	})
+}
+
+func (l *listener) poll(ctx context.Context) error {
+	spec := l.job.EVMLogSpec
+	q := pg.WithParentCtx(ctx)
+
+	latest, err := l.lp.LatestBlock(q)
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest block")
+	}
+	to := latest - int64(spec.MinConfirmations)
+	if to < 0 {
+		return nil
+	}
+
+	if l.nextBlock == 0 {
+		var processed int64
+		processed, err = l.orm.LatestProcessedBlock(l.job.ID, q)
+		if errors.Is(err, sql.ErrNoRows) {
+			// A new job only processes logs emitted after it was created.
+			if err = l.orm.SetLatestProcessedBlock(l.job.ID, to, q); err != nil {
+				return err
+			}
+			l.nextBlock = to + 1
+			return nil
+		} else if err != nil {
+			return err
+		}
+		l.nextBlock = processed + 1
+	}
+	if to < l.nextBlock {
+		return nil
+	}
+
+	from := l.nextBlock - l.lookback
+	if from < 0 {
+		from = 0
+	}
+	processed, err := l.orm.ProcessedLogs(l.job.ID, from, q)
+	if err != nil {
+		return err
+	}
+	seen := make(map[logKey]struct{}, len(processed))
+	for _, p := range processed {
+		seen[logKey{p.BlockHash, p.LogIndex}] = struct{}{}
+	}
+
+	logs, err := l.lp.Logs(from, to, l.event.ID, spec.ContractAddress.Address(), q)
+	if err != nil {
+		return errors.Wrap(err, "failed to get logs")
+	}
+	current := make(map[logKey]struct{}, len(logs))
+	for _, lg := range logs {
+		if !l.matches(lg) {
+			continue
+		}
+		key := logKey{lg.BlockHash, lg.LogIndex}
+		current[key] = struct{}{}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		if err = l.run(ctx, lg); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// The log is run again by the next poll, so progress only
+			// covers the blocks before it.
+			if lg.BlockNumber > l.nextBlock {
+				if err2 := l.orm.SetLatestProcessedBlock(l.job.ID, lg.BlockNumber-1, q); err2 != nil {
+					return err2
+				}
+				l.nextBlock = lg.BlockNumber
+			}
+			return err
+		}
+	}
+
+	for _, p := range processed {
+		if p.BlockNumber > to {
+			continue
+		}
+		if _, ok := current[logKey{p.BlockHash, p.LogIndex}]; ok {
+			continue
+		}
+		l.logger.Warnw("Processed log was reorged out", "blockHash", p.BlockHash, "blockNumber", p.BlockNumber, "logIndex", p.LogIndex)
+		if err = l.orm.DeleteProcessedLog(l.job.ID, p.BlockHash, p.LogIndex, q); err != nil {
+			return err
+		}
+	}
+
+	if err = l.orm.SetLatestProcessedBlock(l.job.ID, to, q); err != nil {
+		return err
+	}
+	if err = l.orm.PruneProcessedLogs(l.job.ID, from, q); err != nil {
+		return err
+	}
+	l.nextBlock = to + 1
+	return nil
+}
+
+// matches reports whether the log passes the topic filters of the job.
+func (l *listener) matches(lg logpoller.Log) bool {
+	topics := lg.GetTopics()
+	for i, values := range []job.EVMLogTopicValues{l.job.EVMLogSpec.Topic1, l.job.EVMLogSpec.Topic2, l.job.EVMLogSpec.Topic3} {
+		if len(values) == 0 {
+			continue
+		}
+		if len(topics) <= i+1 || !values.Contains(topics[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// run starts a pipeline run for the log. An error is returned when no run
+// was stored for it, in which case the log is not recorded as processed.
+func (l *listener) run(ctx context.Context, lg logpoller.Log) error {
+	lggr := l.logger.With("txHash", lg.TxHash, "blockNumber", lg.BlockNumber, "logIndex", lg.LogIndex)
+
+	decoded, err := l.decode(lg)
+	if err != nil {
+		// The log is recorded anyway, there is no point in retrying.
+		lggr.Errorw("Failed to decode log", "err", err)
+	}
+
+	vars := pipeline.NewVarsFrom(map[string]interface{}{
+		"jobSpec": map[string]interface{}{
+			"databaseID":    l.job.ID,
+			"externalJobID": l.job.ExternalJobID,
+			"name":          l.job.Name.ValueOrZero(),
+			"evmChainID":    l.job.EVMLogSpec.EVMChainID.String(),
+			"pipelineSpec": &pipeline.Spec{
+				ForwardingAllowed: l.job.ForwardingAllowed,
+			},
+		},
+		"jobRun": map[string]interface{}{
+			"log":            decoded,
+			"logBlockHash":   lg.BlockHash,
+			"logBlockNumber": lg.BlockNumber,
+			"logTxHash":      lg.TxHash,
+			"logIndex":       lg.LogIndex,
+			"logAddress":     lg.Address,
+			"logTopics":      lg.GetTopics(),
+			"logData":        lg.Data,
+		},
+	})
+	run := pipeline.NewRun(*l.job.PipelineSpec, vars)
+	_, err = l.pipelineRunner.Run(ctx, &run, lggr, true, func(tx pg.Queryer) error {
+		return l.orm.MarkLogProcessed(l.job.ID, lg, pg.WithQueryer(tx))
+	})
+	if err == nil || ctx.Err() != nil {
+		return nil
+	}
+	lggr.Errorw("Failed executing run", "err", err)
+	if run.ID != 0 {
+		// The run was stored before it failed, it is resumed with the other
+		// unfinished runs.
+		return nil
+	}
+	// The log is recorded in the same transaction the run is started in, but
+	// a run without async tasks is only stored once it has finished.
+	if err2 := l.orm.DeleteProcessedLog(l.job.ID, lg.BlockHash, lg.LogIndex); err2 != nil {
+		return errors.Wrap(err2, "failed to forget log of failed run")
+	}
+	return errors.Wrap(err, "failed executing run")
+}
+
+// decode unpacks the arguments of the event, both indexed and not, into a map
+// keyed by argument name.
+func (l *listener) decode(lg logpoller.Log) (map[string]interface{}, error) {
+	decoded := make(map[string]interface{})
+	if err := l.event.Inputs.NonIndexed().UnpackIntoMap(decoded, lg.Data); err != nil {
+		return decoded, errors.Wrap(err, "failed to unpack log data")
+	}
+	var indexed abi.Arguments
+	for _, arg := range l.event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	topics := lg.GetTopics()
+	if len(topics) == 0 {
+		return decoded, errors.New("log has no topics")
+	}
+	if err := abi.ParseTopicsIntoMap(decoded, indexed, topics[1:]); err != nil {
+		return decoded, errors.Wrap(err, "failed to unpack log topics")
+	}
+	return decoded, nil
+}
@@ -0,0 +1,217 @@
+package evmlog
+
+import (
+	"database/sql"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// memORM is an in-memory ORM.
+type memORM struct {
+	mu       sync.Mutex
+	logs     map[logKey]ProcessedLog
+	progress map[int32]int64
+}
+
+func newMemORM() *memORM {
+	return &memORM{logs: make(map[logKey]ProcessedLog), progress: make(map[int32]int64)}
+}
+
+func (o *memORM) MarkLogProcessed(jobID int32, lg logpoller.Log, qopts ...pg.QOpt) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.logs[logKey{lg.BlockHash, lg.LogIndex}] = ProcessedLog{JobID: jobID, BlockHash: lg.BlockHash, BlockNumber: lg.BlockNumber, LogIndex: lg.LogIndex}
+	return nil
+}
+
+func (o *memORM) ProcessedLogs(jobID int32, fromBlock int64, qopts ...pg.QOpt) (logs []ProcessedLog, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, p := range o.logs {
+		if p.JobID == jobID && p.BlockNumber >= fromBlock {
+			logs = append(logs, p)
+		}
+	}
+	return logs, nil
+}
+
+func (o *memORM) DeleteProcessedLog(jobID int32, blockHash common.Hash, logIndex int64, qopts ...pg.QOpt) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.logs, logKey{blockHash, logIndex})
+	return nil
+}
+
+func (o *memORM) PruneProcessedLogs(jobID int32, beforeBlock int64, qopts ...pg.QOpt) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for k, p := range o.logs {
+		if p.JobID == jobID && p.BlockNumber < beforeBlock {
+			delete(o.logs, k)
+		}
+	}
+	return nil
+}
+
+func (o *memORM) LatestProcessedBlock(jobID int32, qopts ...pg.QOpt) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n, ok := o.progress[jobID]
+	if !ok {
+		return 0, errors.Wrap(sql.ErrNoRows, "LatestProcessedBlock failed")
+	}
+	return n, nil
+}
+
+func (o *memORM) SetLatestProcessedBlock(jobID int32, blockNumber int64, qopts ...pg.QOpt) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.progress[jobID] = blockNumber
+	return nil
+}
+
+func TestListener_Poll(t *testing.T) {
+	contract := ethkey.EIP55Address("0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17")
+	from := common.HexToAddress("0x469aA2CD13e037DC5236320783dCfd0e641c0559")
+	to := common.HexToAddress("0x2be990eE17832b59E0086534c5ea2459Aa75E38F")
+	other := common.HexToAddress("0x92B5e28Ac583812874e4271380c7d070C5FB6E6b")
+
+	event, err := ParseEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+	require.NoError(t, err)
+
+	jb := job.Job{
+		ID:   1,
+		Type: job.EVMLog,
+		EVMLogSpec: &job.EVMLogSpec{
+			EVMChainID:       utils.NewBigI(4),
+			ContractAddress:  contract,
+			EventABI:         "Transfer(address indexed from, address indexed to, uint256 value)",
+			Topic2:           job.EVMLogTopicValues{common.BytesToHash(to.Bytes())},
+			MinConfirmations: 2,
+			PollPeriod:       time.Second,
+		},
+		PipelineSpec: &pipeline.Spec{},
+	}
+
+	newLog := func(blockHash common.Hash, blockNumber int64, logIndex int64, recipient common.Address, value int64) logpoller.Log {
+		data, err := abi.Arguments{event.Inputs[2]}.Pack(big.NewInt(value))
+		require.NoError(t, err)
+		return logpoller.Log{
+			BlockHash:   blockHash,
+			BlockNumber: blockNumber,
+			LogIndex:    logIndex,
+			Address:     contract.Address(),
+			EventSig:    event.ID,
+			Topics: pq.ByteaArray{
+				event.ID.Bytes(),
+				common.BytesToHash(from.Bytes()).Bytes(),
+				common.BytesToHash(recipient.Bytes()).Bytes(),
+			},
+			Data: data,
+		}
+	}
+	matching := newLog(common.HexToHash("0x12"), 12, 0, to, 5)
+	filtered := newLog(common.HexToHash("0x12"), 12, 1, other, 6)
+	reorged := newLog(common.HexToHash("0x1212"), 12, 0, to, 7)
+
+	orm := newMemORM()
+	lp := lpmocks.NewLogPoller(t)
+	runner := pipelinemocks.NewRunner(t)
+	ctx := testutils.Context(t)
+	lggr := logger.TestLogger(t)
+
+	expectRun := func(value int64) {
+		runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+			Run(func(args mock.Arguments) {
+				run := args.Get(1).(*pipeline.Run)
+				jobRun := run.Inputs.Val.(map[string]interface{})["jobRun"].(map[string]interface{})
+				decoded := jobRun["log"].(map[string]interface{})
+				assert.Equal(t, from, decoded["from"])
+				assert.Equal(t, to, decoded["to"])
+				assert.Equal(t, big.NewInt(value), decoded["value"])
+				fn := args.Get(4).(func(pg.Queryer) error)
+				require.NoError(t, fn(nil))
+			}).
+			Return(false, nil).Once()
+	}
+
+	l := newListener(lggr, jb, event, lp, runner, orm, 5)
+
+	// A new job starts at the latest confirmed block.
+	lp.On("LatestBlock", mock.Anything).Return(int64(12), nil).Once()
+	require.NoError(t, l.poll(ctx))
+	assert.Equal(t, int64(11), l.nextBlock)
+
+	// Only the log matching the topic filters is run.
+	lp.On("LatestBlock", mock.Anything).Return(int64(14), nil).Once()
+	lp.On("Logs", int64(6), int64(12), event.ID, contract.Address(), mock.Anything).
+		Return([]logpoller.Log{matching, filtered}, nil).Once()
+	expectRun(5)
+	require.NoError(t, l.poll(ctx))
+	assert.Equal(t, int64(13), l.nextBlock)
+	assert.Len(t, orm.logs, 1)
+
+	// After a restart, processed logs are not run again.
+	l = newListener(lggr, jb, event, lp, runner, orm, 5)
+	lp.On("LatestBlock", mock.Anything).Return(int64(15), nil).Once()
+	lp.On("Logs", int64(8), int64(13), event.ID, contract.Address(), mock.Anything).
+		Return([]logpoller.Log{matching, filtered}, nil).Once()
+	require.NoError(t, l.poll(ctx))
+	assert.Equal(t, int64(14), l.nextBlock)
+	runner.AssertNumberOfCalls(t, "Run", 1)
+
+	// A log replaced by a reorg is run again, and the old one is forgotten.
+	lp.On("LatestBlock", mock.Anything).Return(int64(16), nil).Once()
+	lp.On("Logs", int64(9), int64(14), event.ID, contract.Address(), mock.Anything).
+		Return([]logpoller.Log{reorged}, nil).Once()
+	expectRun(7)
+	require.NoError(t, l.poll(ctx))
+	require.Len(t, orm.logs, 1)
+	assert.Contains(t, orm.logs, logKey{reorged.BlockHash, reorged.LogIndex})
+	assert.Equal(t, int64(14), orm.progress[jb.ID])
+
+	// A log whose run failed is forgotten and progress stops before its block,
+	// so that it is run again.
+	failing := newLog(common.HexToHash("0x16"), 16, 0, to, 8)
+	lp.On("LatestBlock", mock.Anything).Return(int64(18), nil).Twice()
+	lp.On("Logs", int64(10), int64(16), event.ID, contract.Address(), mock.Anything).
+		Return([]logpoller.Log{reorged, failing}, nil).Once()
+	runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(4).(func(pg.Queryer) error)
+			require.NoError(t, fn(nil))
+		}).
+		Return(false, errors.New("failed to store run")).Once()
+	require.Error(t, l.poll(ctx))
+	assert.Equal(t, int64(16), l.nextBlock)
+	assert.Equal(t, int64(15), orm.progress[jb.ID])
+	assert.NotContains(t, orm.logs, logKey{failing.BlockHash, failing.LogIndex})
+
+	lp.On("Logs", int64(11), int64(16), event.ID, contract.Address(), mock.Anything).
+		Return([]logpoller.Log{reorged, failing}, nil).Once()
+	expectRun(8)
+	require.NoError(t, l.poll(ctx))
+	assert.Contains(t, orm.logs, logKey{failing.BlockHash, failing.LogIndex})
+	assert.Equal(t, int64(16), orm.progress[jb.ID])
+}
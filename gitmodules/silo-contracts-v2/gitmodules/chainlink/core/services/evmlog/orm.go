@@ -0,0 +1,82 @@
+package evmlog
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+// ProcessedLog is a log an evmlog job started a pipeline run for.
+type ProcessedLog struct {
+	JobID       int32
+	BlockHash   common.Hash
+	BlockNumber int64
+	LogIndex    int64
+	CreatedAt   time.Time
+}
+
+type ORM interface {
+	// MarkLogProcessed records that a pipeline run was started for the log.
+	MarkLogProcessed(jobID int32, lg logpoller.Log, qopts ...pg.QOpt) error
+	// ProcessedLogs returns the processed logs of a job in blocks from fromBlock on.
+	ProcessedLogs(jobID int32, fromBlock int64, qopts ...pg.QOpt) ([]ProcessedLog, error)
+	// DeleteProcessedLog forgets a processed log, which is no longer part of the chain.
+	DeleteProcessedLog(jobID int32, blockHash common.Hash, logIndex int64, qopts ...pg.QOpt) error
+	// PruneProcessedLogs forgets the processed logs of a job in blocks before beforeBlock.
+	PruneProcessedLogs(jobID int32, beforeBlock int64, qopts ...pg.QOpt) error
+	// LatestProcessedBlock returns the last block whose logs were processed by a job,
+	// or sql.ErrNoRows if there is none.
+	LatestProcessedBlock(jobID int32, qopts ...pg.QOpt) (int64, error)
+	// SetLatestProcessedBlock records the last block whose logs were processed by a job.
+	SetLatestProcessedBlock(jobID int32, blockNumber int64, qopts ...pg.QOpt) error
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("EVMLogORM"), cfg)}
+}
+
+func (o *orm) MarkLogProcessed(jobID int32, lg logpoller.Log, qopts ...pg.QOpt) error {
+	_, err := o.q.WithOpts(qopts...).Exec(`INSERT INTO evm_log_job_logs (job_id, block_hash, block_number, log_index, created_at)
+	VALUES ($1, $2, $3, $4, NOW()) ON CONFLICT DO NOTHING`, jobID, lg.BlockHash, lg.BlockNumber, lg.LogIndex)
+	return errors.Wrap(err, "MarkLogProcessed failed")
+}
+
+func (o *orm) ProcessedLogs(jobID int32, fromBlock int64, qopts ...pg.QOpt) (logs []ProcessedLog, err error) {
+	err = o.q.WithOpts(qopts...).Select(&logs, `SELECT * FROM evm_log_job_logs WHERE job_id = $1 AND block_number >= $2
+	ORDER BY block_number, log_index`, jobID, fromBlock)
+	return logs, errors.Wrap(err, "ProcessedLogs failed")
+}
+
+func (o *orm) DeleteProcessedLog(jobID int32, blockHash common.Hash, logIndex int64, qopts ...pg.QOpt) error {
+	_, err := o.q.WithOpts(qopts...).Exec(`DELETE FROM evm_log_job_logs WHERE job_id = $1 AND block_hash = $2 AND log_index = $3`,
+		jobID, blockHash, logIndex)
+	return errors.Wrap(err, "DeleteProcessedLog failed")
+}
+
+func (o *orm) PruneProcessedLogs(jobID int32, beforeBlock int64, qopts ...pg.QOpt) error {
+	_, err := o.q.WithOpts(qopts...).Exec(`DELETE FROM evm_log_job_logs WHERE job_id = $1 AND block_number < $2`, jobID, beforeBlock)
+	return errors.Wrap(err, "PruneProcessedLogs failed")
+}
+
+func (o *orm) LatestProcessedBlock(jobID int32, qopts ...pg.QOpt) (blockNumber int64, err error) {
+	err = o.q.WithOpts(qopts...).Get(&blockNumber, `SELECT block_number FROM evm_log_job_progress WHERE job_id = $1`, jobID)
+	return blockNumber, errors.Wrap(err, "LatestProcessedBlock failed")
+}
+
+func (o *orm) SetLatestProcessedBlock(jobID int32, blockNumber int64, qopts ...pg.QOpt) error {
+	_, err := o.q.WithOpts(qopts...).Exec(`INSERT INTO evm_log_job_progress (job_id, block_number, updated_at) VALUES ($1, $2, NOW())
+	ON CONFLICT (job_id) DO UPDATE SET block_number = EXCLUDED.block_number, updated_at = EXCLUDED.updated_at`, jobID, blockNumber)
+	return errors.Wrap(err, "SetLatestProcessedBlock failed")
+}
@@ -0,0 +1,97 @@
+package evmlog
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/google/uuid"
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// ValidatedEVMLogSpec validates and converts the given toml string to a job.Job.
+func ValidatedEVMLogSpec(tomlString string) (job.Job, error) {
+	jb := job.Job{
+		// Default to generating a UUID, can be overwritten by the specified one in tomlString.
+		ExternalJobID: uuid.New(),
+	}
+
+	tree, err := toml.Load(tomlString)
+	if err != nil {
+		return jb, errors.Wrap(err, "loading toml")
+	}
+
+	err = tree.Unmarshal(&jb)
+	if err != nil {
+		return jb, errors.Wrap(err, "unmarshalling toml spec")
+	}
+
+	if jb.Type != job.EVMLog {
+		return jb, errors.Errorf("unsupported type %s", jb.Type)
+	}
+
+	var spec job.EVMLogSpec
+	err = tree.Unmarshal(&spec)
+	if err != nil {
+		return jb, errors.Wrap(err, "unmarshalling toml job")
+	}
+
+	// Required fields
+	if spec.EVMChainID == nil {
+		return jb, notSet("evmChainID")
+	}
+	if spec.ContractAddress == "" {
+		return jb, notSet("contractAddress")
+	}
+	if spec.EventABI == "" {
+		return jb, notSet("eventABI")
+	}
+
+	event, err := ParseEvent(spec.EventABI)
+	if err != nil {
+		return jb, errors.Wrap(err, `invalid "eventABI"`)
+	}
+	indexed := 0
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed++
+		}
+	}
+	for i, values := range []job.EVMLogTopicValues{spec.Topic1, spec.Topic2, spec.Topic3} {
+		if len(values) > 0 && i >= indexed {
+			return jb, errors.Errorf(`"topic%d" is set, but the event has %d indexed arguments`, i+1, indexed)
+		}
+	}
+
+	// Defaults
+	if spec.PollPeriod == 0 {
+		spec.PollPeriod = 5 * time.Second
+	}
+
+	jb.EVMLogSpec = &spec
+
+	return jb, nil
+}
+
+// ParseEvent parses an event signature with argument names, in the format of the
+// ethabidecodelog task, e.g. "Transfer(address indexed from, address indexed to, uint256 value)".
+func ParseEvent(eventABI string) (abi.Event, error) {
+	name, args, indexedArgs, err := pipeline.ParseETHABIString([]byte(eventABI), true)
+	if err != nil {
+		return abi.Event{}, err
+	}
+	if name == "" {
+		return abi.Event{}, errors.Errorf("missing event name: %s", eventABI)
+	}
+	if len(indexedArgs) > 3 {
+		return abi.Event{}, errors.Errorf("an event can have at most 3 indexed arguments, got %d", len(indexedArgs))
+	}
+	return abi.NewEvent(name, name, false, args), nil
+}
+
+func notSet(field string) error {
+	return errors.Errorf("%q must be set", field)
+}
@@ -0,0 +1,136 @@
+package evmlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func TestValidatedEVMLogSpec(t *testing.T) {
+	var tests = []struct {
+		name      string
+		toml      string
+		assertion func(t *testing.T, jb job.Job, err error)
+	}{
+		{
+			name: "valid",
+			toml: `
+type = "evmlog"
+schemaVersion = 1
+name = "valid-test"
+evmChainID = "4"
+contractAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+topic2 = ["0x000000000000000000000000469aa2cd13e037dc5236320783dcfd0e641c0559"]
+minConfirmations = 3
+pollPeriod = "10s"
+observationSource = """
+ds [type=memo value="$(jobRun.log.value)"]
+"""
+`,
+			assertion: func(t *testing.T, jb job.Job, err error) {
+				require.NoError(t, err)
+				require.Equal(t, job.EVMLog, jb.Type)
+				require.Equal(t, "valid-test", jb.Name.String)
+				require.Equal(t, utils.NewBigI(4), jb.EVMLogSpec.EVMChainID)
+				require.Equal(t, ethkey.EIP55Address("0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"), jb.EVMLogSpec.ContractAddress)
+				require.Empty(t, jb.EVMLogSpec.Topic1)
+				require.Equal(t, job.EVMLogTopicValues{common.HexToHash("0x469aa2cd13e037dc5236320783dcfd0e641c0559")}, jb.EVMLogSpec.Topic2)
+				require.Equal(t, uint32(3), jb.EVMLogSpec.MinConfirmations)
+				require.Equal(t, 10*time.Second, jb.EVMLogSpec.PollPeriod)
+			},
+		},
+		{
+			name: "defaults",
+			toml: `
+type = "evmlog"
+schemaVersion = 1
+evmChainID = "4"
+contractAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+eventABI = "Ping(uint256 value)"
+observationSource = """
+ds [type=memo value="$(jobRun.log.value)"]
+"""
+`,
+			assertion: func(t *testing.T, jb job.Job, err error) {
+				require.NoError(t, err)
+				require.Equal(t, uint32(0), jb.EVMLogSpec.MinConfirmations)
+				require.Equal(t, 5*time.Second, jb.EVMLogSpec.PollPeriod)
+			},
+		},
+		{
+			name: "missing contract address",
+			toml: `
+type = "evmlog"
+schemaVersion = 1
+evmChainID = "4"
+eventABI = "Ping(uint256 value)"
+`,
+			assertion: func(t *testing.T, jb job.Job, err error) {
+				require.EqualError(t, err, `"contractAddress" must be set`)
+			},
+		},
+		{
+			name: "missing chain ID",
+			toml: `
+type = "evmlog"
+schemaVersion = 1
+contractAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+eventABI = "Ping(uint256 value)"
+`,
+			assertion: func(t *testing.T, jb job.Job, err error) {
+				require.EqualError(t, err, `"evmChainID" must be set`)
+			},
+		},
+		{
+			name: "invalid event ABI",
+			toml: `
+type = "evmlog"
+schemaVersion = 1
+evmChainID = "4"
+contractAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+eventABI = "Ping(uint256)"
+`,
+			assertion: func(t *testing.T, jb job.Job, err error) {
+				require.ErrorContains(t, err, `invalid "eventABI"`)
+			},
+		},
+		{
+			name: "topic filter on non-indexed argument",
+			toml: `
+type = "evmlog"
+schemaVersion = 1
+evmChainID = "4"
+contractAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+topic3 = ["0x0000000000000000000000000000000000000000000000000000000000000001"]
+`,
+			assertion: func(t *testing.T, jb job.Job, err error) {
+				require.EqualError(t, err, `"topic3" is set, but the event has 2 indexed arguments`)
+			},
+		},
+		{
+			name: "wrong type",
+			toml: `
+type = "directrequest"
+schemaVersion = 1
+`,
+			assertion: func(t *testing.T, jb job.Job, err error) {
+				require.EqualError(t, err, "unsupported type directrequest")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := ValidatedEVMLogSpec(tc.toml)
+			tc.assertion(t, s, err)
+		})
+	}
+}
@@ -1114,6 +1114,7 @@ func (s *service) generateJob(spec string) (*job.Job, error) {
 	if err != nil {
 		return nil, err
 	}
+	js.Definition = spec
 
 	return &js, nil
 }
@@ -1,9 +1,14 @@
 package fluxmonitorv2
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/shopspring/decimal"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/fluxmonitorv2/promfm"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 )
 
 // DeviationThresholds carries parameters used by the threshold-trigger logic
@@ -16,18 +21,49 @@ type DeviationThresholds struct {
 // answer.
 type DeviationChecker struct {
 	Thresholds DeviationThresholds
-	lggr       logger.Logger
+	// Rule decides whether the next answer deviates enough to be submitted.
+	Rule DeviationRule
+	// MinSubmissionInterval is the minimum time since the latest submission for an answer
+	// deviating enough to be submitted.
+	MinSubmissionInterval time.Duration
+
+	// lastSubmission is loaded from the round stats when the flux monitor starts
+	lastSubmission time.Time
+	// jobID labels the metrics of the decisions, which are not recorded if empty
+	jobID string
+	lggr  logger.Logger
 }
 
 // NewDeviationChecker constructs a new deviation checker with thresholds.
 func NewDeviationChecker(rel, abs float64, lggr logger.Logger) *DeviationChecker {
+	lggr = lggr.Named("DeviationChecker").With("threshold", rel, "absoluteThreshold", abs)
 	return &DeviationChecker{
 		Thresholds: DeviationThresholds{
 			Rel: rel,
 			Abs: abs,
 		},
-		lggr: lggr.Named("DeviationChecker").With("threshold", rel, "absoluteThreshold", abs),
+		Rule: NewThresholdRule(rel, abs, lggr),
+		lggr: lggr,
+	}
+}
+
+// NewDeviationCheckerFromSpec constructs the deviation checker of a flux monitor job with the
+// deviation rule of its spec, recording its decisions in the flux monitor metrics.
+func NewDeviationCheckerFromSpec(spec job.FluxMonitorSpec, jobID int32, lggr logger.Logger) *DeviationChecker {
+	c := NewDeviationChecker(float64(spec.Threshold), float64(spec.AbsoluteThreshold), lggr)
+	c.jobID = fmt.Sprintf("%d", jobID)
+	if r := spec.DeviationRule; r != nil {
+		c.lggr = c.lggr.With("deviationRule", r.Type)
+		switch r.Type {
+		case DeviationRuleAsymmetric:
+			c.Rule = NewAsymmetricRule(float64(r.UpThreshold), float64(r.DownThreshold), c.Thresholds.Abs, c.lggr)
+		case DeviationRuleVolatility:
+			c.Rule = NewVolatilityRule(int(r.VolatilityWindow), float64(r.VolatilityMultiplier),
+				float64(r.MinThreshold), float64(r.MaxThreshold), c.Thresholds.Abs, c.lggr)
+		}
+		c.MinSubmissionInterval = r.MinSubmissionInterval.Duration()
 	}
+	return c
 }
 
 // NewZeroDeviationChecker constructs a new deviation checker with 0 as thresholds.
@@ -35,46 +71,37 @@ func NewZeroDeviationChecker(lggr logger.Logger) *DeviationChecker {
 	return NewDeviationChecker(0, 0, lggr)
 }
 
-// OutsideDeviation checks whether the next price is outside the threshold.
-// If both thresholds are zero (default value), always returns true.
+// OutsideDeviation checks whether the next price is outside the threshold of the rule, and the
+// minimum time between submissions has elapsed.
 func (c *DeviationChecker) OutsideDeviation(curAnswer, nextAnswer decimal.Decimal) bool {
-	loggerFields := []interface{}{
-		"currentAnswer", curAnswer,
-		"nextAnswer", nextAnswer,
-	}
-
-	if c.Thresholds.Rel == 0 && c.Thresholds.Abs == 0 {
-		c.lggr.Debugw(
-			"Deviation thresholds both zero; short-circuiting deviation checker to "+
-				"true, regardless of feed values", loggerFields...)
-		return true
-	}
-	diff := curAnswer.Sub(nextAnswer).Abs()
-	loggerFields = append(loggerFields, "absoluteDeviation", diff)
-
-	if !diff.GreaterThan(decimal.NewFromFloat(c.Thresholds.Abs)) {
-		c.lggr.Debugw("Absolute deviation threshold not met", loggerFields...)
-		return false
-	}
-
-	if curAnswer.IsZero() {
-		if nextAnswer.IsZero() {
-			c.lggr.Debugw("Relative deviation is undefined; can't satisfy threshold", loggerFields...)
-			return false
+	decision := c.Rule.OutsideDeviation(curAnswer, nextAnswer)
+	if decision.Outside && c.MinSubmissionInterval > 0 && !c.lastSubmission.IsZero() {
+		if since := time.Since(c.lastSubmission); since < c.MinSubmissionInterval {
+			c.lggr.Debugw("Minimum time between submissions not elapsed",
+				"sinceLastSubmission", since,
+				"minSubmissionInterval", c.MinSubmissionInterval,
+			)
+			decision.Outside = false
+			decision.Reason = DeviationReasonMinSubmissionInterval
 		}
-		c.lggr.Infow("Threshold met: relative deviation is ∞", loggerFields...)
-		return true
 	}
+	c.recordDecision(decision)
+	return decision.Outside
+}
 
-	// 100*|new-old|/|old|: Deviation (relative to curAnswer) as a percentage
-	percentage := diff.Div(curAnswer.Abs()).Mul(decimal.NewFromInt(100))
-
-	loggerFields = append(loggerFields, "percentage", percentage)
+// RecordSubmission records the time of a submission of the node, for MinSubmissionInterval.
+func (c *DeviationChecker) RecordSubmission(at time.Time) {
+	c.lastSubmission = at
+}
 
-	if percentage.LessThan(decimal.NewFromFloat(c.Thresholds.Rel)) {
-		c.lggr.Debugw("Relative deviation threshold not met", loggerFields...)
-		return false
+func (c *DeviationChecker) recordDecision(decision DeviationDecision) {
+	if c.jobID == "" {
+		return
+	}
+	result := "skip"
+	if decision.Outside {
+		result = "submit"
 	}
-	c.lggr.Infow("Relative and absolute deviation thresholds both met", loggerFields...)
-	return true
+	promfm.DeviationRuleDecisions.WithLabelValues(c.jobID, c.Rule.Name(), result, string(decision.Reason)).Inc()
+	promfm.DeviationThreshold.WithLabelValues(c.jobID, c.Rule.Name()).Set(decision.Threshold)
 }
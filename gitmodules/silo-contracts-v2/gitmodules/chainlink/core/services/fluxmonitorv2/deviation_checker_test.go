@@ -3,12 +3,15 @@ package fluxmonitorv2_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/fluxmonitorv2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 )
 
 type outsideDeviationRow struct {
@@ -81,3 +84,81 @@ func TestDeviationChecker_OutsideDeviation(t *testing.T) {
 		t.Run(tc.name+" max absolute threshold", func(t *testing.T) { c(test3) })
 	}
 }
+
+func TestDeviationRules(t *testing.T) {
+	t.Parallel()
+
+	i := decimal.NewFromInt
+	lggr := logger.TestLogger(t)
+
+	t.Run("asymmetric", func(t *testing.T) {
+		rule := fluxmonitorv2.NewAsymmetricRule(1, 5, 0, lggr)
+		assert.Equal(t, fluxmonitorv2.DeviationRuleAsymmetric, rule.Name())
+
+		assert.True(t, rule.OutsideDeviation(i(100), i(101)).Outside)
+		assert.False(t, rule.OutsideDeviation(i(100), i(99)).Outside)
+		decision := rule.OutsideDeviation(i(100), i(95))
+		assert.True(t, decision.Outside)
+		assert.Equal(t, fluxmonitorv2.DeviationReasonThresholdsMet, decision.Reason)
+		assert.Equal(t, float64(5), decision.Threshold)
+	})
+
+	t.Run("volatility", func(t *testing.T) {
+		rule := fluxmonitorv2.NewVolatilityRule(5, 2, 0.5, 10, 0, lggr)
+		assert.Equal(t, fluxmonitorv2.DeviationRuleVolatility, rule.Name())
+
+		// min threshold until there is enough history
+		decision := rule.OutsideDeviation(i(100), i(100))
+		assert.Equal(t, 0.5, decision.Threshold)
+		assert.False(t, decision.Outside)
+		for _, answer := range []int64{100, 100, 100} {
+			rule.OutsideDeviation(i(100), i(answer))
+		}
+		// a stable feed keeps the min threshold
+		decision = rule.OutsideDeviation(i(100), i(101))
+		assert.Equal(t, 0.5, decision.Threshold)
+		assert.True(t, decision.Outside)
+
+		// a volatile feed raises the threshold, up to the max threshold
+		for _, answer := range []int64{104, 98, 105, 97, 106} {
+			rule.OutsideDeviation(i(100), i(answer))
+		}
+		decision = rule.OutsideDeviation(i(100), i(103))
+		assert.Greater(t, decision.Threshold, float64(3))
+		assert.LessOrEqual(t, decision.Threshold, float64(10))
+		assert.False(t, decision.Outside)
+	})
+
+	t.Run("minimum time between submissions", func(t *testing.T) {
+		checker := fluxmonitorv2.NewDeviationChecker(1, 0, lggr)
+		checker.MinSubmissionInterval = time.Hour
+
+		assert.True(t, checker.OutsideDeviation(i(100), i(110)))
+		checker.RecordSubmission(time.Now())
+		assert.False(t, checker.OutsideDeviation(i(100), i(110)))
+		checker.RecordSubmission(time.Now().Add(-2 * time.Hour))
+		assert.True(t, checker.OutsideDeviation(i(100), i(110)))
+	})
+}
+
+func TestNewDeviationCheckerFromSpec(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.TestLogger(t)
+	spec := job.FluxMonitorSpec{Threshold: 0.5, AbsoluteThreshold: 0.01}
+
+	checker := fluxmonitorv2.NewDeviationCheckerFromSpec(spec, 1, lggr)
+	assert.Equal(t, fluxmonitorv2.DeviationRuleThreshold, checker.Rule.Name())
+	assert.Zero(t, checker.MinSubmissionInterval)
+
+	spec.DeviationRule = &job.FluxMonitorDeviationRule{
+		Type:                  fluxmonitorv2.DeviationRuleAsymmetric,
+		UpThreshold:           1,
+		DownThreshold:         2,
+		MinSubmissionInterval: models.Interval(time.Minute),
+	}
+	checker = fluxmonitorv2.NewDeviationCheckerFromSpec(spec, 1, lggr)
+	assert.Equal(t, fluxmonitorv2.DeviationRuleAsymmetric, checker.Rule.Name())
+	assert.Equal(t, time.Minute, checker.MinSubmissionInterval)
+	assert.True(t, checker.OutsideDeviation(decimal.NewFromInt(100), decimal.NewFromInt(101)))
+}
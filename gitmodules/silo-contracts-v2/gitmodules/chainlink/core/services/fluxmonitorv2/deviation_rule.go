@@ -0,0 +1,194 @@
+package fluxmonitorv2
+
+import (
+	"math"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// Types of the deviation rules, set in the deviationRule table of flux monitor job specs.
+const (
+	DeviationRuleThreshold  = "threshold"
+	DeviationRuleAsymmetric = "asymmetric"
+	DeviationRuleVolatility = "volatility"
+)
+
+// DeviationReason explains a decision of a deviation rule, in logs and metrics.
+type DeviationReason string
+
+const (
+	DeviationReasonThresholdsZero        DeviationReason = "thresholds_zero"
+	DeviationReasonAbsoluteNotMet        DeviationReason = "absolute_threshold_not_met"
+	DeviationReasonRelativeUndefined     DeviationReason = "relative_deviation_undefined"
+	DeviationReasonRelativeInfinite      DeviationReason = "relative_deviation_infinite"
+	DeviationReasonRelativeNotMet        DeviationReason = "relative_threshold_not_met"
+	DeviationReasonThresholdsMet         DeviationReason = "thresholds_met"
+	DeviationReasonMinSubmissionInterval DeviationReason = "min_submission_interval"
+)
+
+// DeviationDecision is the decision of a deviation rule on the next answer.
+type DeviationDecision struct {
+	// Outside is whether the next answer deviates enough to be submitted.
+	Outside bool
+	Reason  DeviationReason
+	// Threshold is the relative threshold applied to the next answer, in percent.
+	Threshold float64
+}
+
+// DeviationRule decides whether the next answer deviates enough from the current answer, the
+// latest submission, to start a new round.
+type DeviationRule interface {
+	// Name is the type of the rule, used to label metrics.
+	Name() string
+	OutsideDeviation(curAnswer, nextAnswer decimal.Decimal) DeviationDecision
+}
+
+// checkThresholds checks that the change from curAnswer to nextAnswer is greater than the
+// absolute threshold, and at least the relative threshold for the direction of the change.
+func checkThresholds(lggr logger.Logger, curAnswer, nextAnswer decimal.Decimal, abs, relUp, relDown float64) DeviationDecision {
+	loggerFields := []interface{}{
+		"currentAnswer", curAnswer,
+		"nextAnswer", nextAnswer,
+	}
+
+	rel := relUp
+	if nextAnswer.LessThan(curAnswer) {
+		rel = relDown
+	}
+	diff := curAnswer.Sub(nextAnswer).Abs()
+	loggerFields = append(loggerFields, "absoluteDeviation", diff, "relativeThreshold", rel)
+
+	if !diff.GreaterThan(decimal.NewFromFloat(abs)) {
+		lggr.Debugw("Absolute deviation threshold not met", loggerFields...)
+		return DeviationDecision{false, DeviationReasonAbsoluteNotMet, rel}
+	}
+
+	if curAnswer.IsZero() {
+		if nextAnswer.IsZero() {
+			lggr.Debugw("Relative deviation is undefined; can't satisfy threshold", loggerFields...)
+			return DeviationDecision{false, DeviationReasonRelativeUndefined, rel}
+		}
+		lggr.Infow("Threshold met: relative deviation is ∞", loggerFields...)
+		return DeviationDecision{true, DeviationReasonRelativeInfinite, rel}
+	}
+
+	// 100*|new-old|/|old|: Deviation (relative to curAnswer) as a percentage
+	percentage := diff.Div(curAnswer.Abs()).Mul(decimal.NewFromInt(100))
+
+	loggerFields = append(loggerFields, "percentage", percentage)
+
+	if percentage.LessThan(decimal.NewFromFloat(rel)) {
+		lggr.Debugw("Relative deviation threshold not met", loggerFields...)
+		return DeviationDecision{false, DeviationReasonRelativeNotMet, rel}
+	}
+	lggr.Infow("Relative and absolute deviation thresholds both met", loggerFields...)
+	return DeviationDecision{true, DeviationReasonThresholdsMet, rel}
+}
+
+type thresholdRule struct {
+	rel, abs float64
+	lggr     logger.Logger
+}
+
+// NewThresholdRule returns the rule submitting answers which deviate by more than the absolute
+// threshold and by at least the relative threshold, in percent. If both thresholds are zero,
+// every answer is submitted.
+func NewThresholdRule(rel, abs float64, lggr logger.Logger) DeviationRule {
+	return &thresholdRule{rel: rel, abs: abs, lggr: lggr}
+}
+
+func (r *thresholdRule) Name() string { return DeviationRuleThreshold }
+
+func (r *thresholdRule) OutsideDeviation(curAnswer, nextAnswer decimal.Decimal) DeviationDecision {
+	if r.rel == 0 && r.abs == 0 {
+		r.lggr.Debugw(
+			"Deviation thresholds both zero; short-circuiting deviation checker to "+
+				"true, regardless of feed values", "currentAnswer", curAnswer, "nextAnswer", nextAnswer)
+		return DeviationDecision{true, DeviationReasonThresholdsZero, 0}
+	}
+	return checkThresholds(r.lggr, curAnswer, nextAnswer, r.abs, r.rel, r.rel)
+}
+
+type asymmetricRule struct {
+	up, down, abs float64
+	lggr          logger.Logger
+}
+
+// NewAsymmetricRule returns the rule submitting answers which deviate by more than the absolute
+// threshold, and by at least the up relative threshold if they increased or the down relative
+// threshold if they decreased, in percent.
+func NewAsymmetricRule(up, down, abs float64, lggr logger.Logger) DeviationRule {
+	return &asymmetricRule{up: up, down: down, abs: abs, lggr: lggr}
+}
+
+func (r *asymmetricRule) Name() string { return DeviationRuleAsymmetric }
+
+func (r *asymmetricRule) OutsideDeviation(curAnswer, nextAnswer decimal.Decimal) DeviationDecision {
+	return checkThresholds(r.lggr, curAnswer, nextAnswer, r.abs, r.up, r.down)
+}
+
+type volatilityRule struct {
+	window     int
+	multiplier float64
+	min, max   float64
+	abs        float64
+	lggr       logger.Logger
+
+	mu      sync.Mutex
+	history []decimal.Decimal
+}
+
+// NewVolatilityRule returns the rule adapting the relative threshold to the volatility of the
+// feed: the threshold is multiplier times the standard deviation of the relative changes between
+// the latest window answers, in percent, bounded by min and max. The min threshold is used until
+// enough answers are observed. Answers must also deviate by more than the absolute threshold.
+// The observed answers are only kept in memory, so the window is rebuilt after a restart.
+func NewVolatilityRule(window int, multiplier, min, max, abs float64, lggr logger.Logger) DeviationRule {
+	return &volatilityRule{window: window, multiplier: multiplier, min: min, max: max, abs: abs, lggr: lggr}
+}
+
+func (r *volatilityRule) Name() string { return DeviationRuleVolatility }
+
+func (r *volatilityRule) OutsideDeviation(curAnswer, nextAnswer decimal.Decimal) DeviationDecision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the threshold is computed before observing the next answer, so that a spike does not raise
+	// the threshold it is checked against
+	rel := r.threshold()
+	r.history = append(r.history, nextAnswer)
+	if len(r.history) > r.window {
+		r.history = r.history[len(r.history)-r.window:]
+	}
+	return checkThresholds(r.lggr, curAnswer, nextAnswer, r.abs, rel, rel)
+}
+
+func (r *volatilityRule) threshold() float64 {
+	var changes []float64
+	for i := 1; i < len(r.history); i++ {
+		prev := r.history[i-1]
+		if prev.IsZero() {
+			continue
+		}
+		change, _ := r.history[i].Sub(prev).Div(prev.Abs()).Mul(decimal.NewFromInt(100)).Float64()
+		changes = append(changes, change)
+	}
+	if len(changes) < 2 {
+		return r.min
+	}
+
+	var mean, variance float64
+	for _, c := range changes {
+		mean += c
+	}
+	mean /= float64(len(changes))
+	for _, c := range changes {
+		variance += (c - mean) * (c - mean)
+	}
+	variance /= float64(len(changes))
+
+	return math.Max(r.min, math.Min(r.max, r.multiplier*math.Sqrt(variance)))
+}
@@ -260,11 +260,7 @@ func NewFromJobSpec(
 		paymentChecker,
 		fmSpec.ContractAddress.Address(),
 		contractSubmitter,
-		NewDeviationChecker(
-			float64(fmSpec.Threshold),
-			float64(fmSpec.AbsoluteThreshold),
-			fmLogger,
-		),
+		NewDeviationCheckerFromSpec(*fmSpec, jobSpec.ID, fmLogger),
 		NewSubmissionChecker(min, max),
 		flags,
 		fluxAggregator,
@@ -292,6 +288,22 @@ func (fm *FluxMonitor) Start(context.Context) error {
 	})
 }
 
+// loadLastSubmission seeds the deviation checker with the time of the latest
+// submission, so that the minimum time between submissions holds across restarts.
+func (fm *FluxMonitor) loadLastSubmission() {
+	if fm.deviationChecker.MinSubmissionInterval <= 0 {
+		return
+	}
+	submittedAt, err := fm.orm.MostRecentSubmissionTime(fm.contractAddress)
+	if err != nil {
+		fm.logger.Errorw("unable to load the time of the latest submission", "err", err)
+		return
+	}
+	if !submittedAt.IsZero() {
+		fm.deviationChecker.RecordSubmission(submittedAt)
+	}
+}
+
 func (fm *FluxMonitor) IsHibernating() bool {
 	if !fm.flags.ContractExists() {
 		return false
@@ -392,6 +404,8 @@ func (fm *FluxMonitor) consume() {
 		defer unsubscribe()
 	}
 
+	fm.loadLastSubmission()
+
 	fm.pollManager.Start(fm.IsHibernating(), fm.initialRoundState())
 
 	tickLogger := fm.logger.With(
@@ -782,6 +796,7 @@ func (fm *FluxMonitor) respondToNewRoundLog(log flux_aggregator_wrapper.FluxAggr
 		newRoundLogger.Errorf("unable to create job run: %v", err)
 		return
 	}
+	fm.deviationChecker.RecordSubmission(time.Now())
 }
 
 var (
@@ -814,6 +829,7 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 	l := fm.logger.With(
 		"threshold", deviationChecker.Thresholds.Rel,
 		"absoluteThreshold", deviationChecker.Thresholds.Abs,
+		"deviationRule", deviationChecker.Rule.Name(),
 	)
 	var markConsumed = true
 	defer func() {
@@ -1009,6 +1025,7 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 		l.Errorw("can't create job run", "err", err)
 		return
 	}
+	fm.deviationChecker.RecordSubmission(time.Now())
 
 	promfm.SetDecimal(promfm.ReportedValue.WithLabelValues(jobID), answer)
 	promfm.SetUint32(promfm.ReportedRound.WithLabelValues(jobID), roundState.RoundId)
@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	pg "github.com/smartcontractkit/chainlink/v2/core/services/pg"
+
+	time "time"
 )
 
 // ORM is an autogenerated mock type for the ORM type
@@ -122,6 +124,30 @@ func (_m *ORM) MostRecentFluxMonitorRoundID(aggregator common.Address) (uint32,
 	return r0, r1
 }
 
+// MostRecentSubmissionTime provides a mock function with given fields: aggregator
+func (_m *ORM) MostRecentSubmissionTime(aggregator common.Address) (time.Time, error) {
+	ret := _m.Called(aggregator)
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(common.Address) (time.Time, error)); ok {
+		return rf(aggregator)
+	}
+	if rf, ok := ret.Get(0).(func(common.Address) time.Time); ok {
+		r0 = rf(aggregator)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(aggregator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateFluxMonitorRoundStats provides a mock function with given fields: aggregator, roundID, runID, newRoundLogsAddition, qopts
 func (_m *ORM) UpdateFluxMonitorRoundStats(aggregator common.Address, roundID uint32, runID int64, newRoundLogsAddition uint, qopts ...pg.QOpt) error {
 	_va := make([]interface{}, len(qopts))
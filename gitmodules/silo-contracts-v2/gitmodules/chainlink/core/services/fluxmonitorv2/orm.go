@@ -2,6 +2,7 @@ package fluxmonitorv2
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -23,6 +24,7 @@ type transmitter interface {
 // ORM defines an interface for database commands related to Flux Monitor v2
 type ORM interface {
 	MostRecentFluxMonitorRoundID(aggregator common.Address) (uint32, error)
+	MostRecentSubmissionTime(aggregator common.Address) (time.Time, error)
 	DeleteFluxMonitorRoundsBackThrough(aggregator common.Address, roundID uint32) error
 	FindOrCreateFluxMonitorRoundStats(aggregator common.Address, roundID uint32, newRoundLogs uint) (FluxMonitorRoundStatsV2, error)
 	UpdateFluxMonitorRoundStats(aggregator common.Address, roundID uint32, runID int64, newRoundLogsAddition uint, qopts ...pg.QOpt) error
@@ -59,6 +61,24 @@ func (o *orm) MostRecentFluxMonitorRoundID(aggregator common.Address) (uint32, e
 	return stats.RoundID, errors.Wrap(err, "MostRecentFluxMonitorRoundID failed")
 }
 
+// MostRecentSubmissionTime finds the creation time of the run of the most
+// recent submission to the provided aggregator, or the zero time if there is none
+func (o *orm) MostRecentSubmissionTime(aggregator common.Address) (time.Time, error) {
+	var createdAt time.Time
+	err := o.q.Get(&createdAt, `
+        SELECT pipeline_runs.created_at FROM flux_monitor_round_stats_v2
+        JOIN pipeline_runs ON pipeline_runs.id = flux_monitor_round_stats_v2.pipeline_run_id
+        WHERE flux_monitor_round_stats_v2.aggregator = $1
+          AND flux_monitor_round_stats_v2.num_submissions > 0
+        ORDER BY pipeline_runs.created_at DESC
+        LIMIT 1
+    `, aggregator)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return createdAt, errors.Wrap(err, "MostRecentSubmissionTime failed")
+}
+
 // DeleteFluxMonitorRoundsBackThrough deletes all the RoundStat records for a
 // given oracle address starting from the most recent round back through the
 // given round
@@ -109,6 +109,10 @@ func TestORM_UpdateFluxMonitorRoundStats(t *testing.T) {
 	err = jobORM.CreateJob(jb)
 	require.NoError(t, err)
 
+	submittedAt, err := orm.MostRecentSubmissionTime(address)
+	require.NoError(t, err)
+	require.True(t, submittedAt.IsZero())
+
 	for expectedCount := uint64(1); expectedCount < 4; expectedCount++ {
 		f := time.Now()
 		run :=
@@ -142,7 +146,15 @@ func TestORM_UpdateFluxMonitorRoundStats(t *testing.T) {
 		require.Equal(t, expectedCount, stats.NumSubmissions)
 		require.True(t, stats.PipelineRunID.Valid)
 		require.Equal(t, run.ID, stats.PipelineRunID.Int64)
+
+		submittedAt, err := orm.MostRecentSubmissionTime(address)
+		require.NoError(t, err)
+		require.WithinDuration(t, run.CreatedAt, submittedAt, time.Millisecond)
 	}
+
+	submittedAt, err = orm.MostRecentSubmissionTime(testutils.NewAddress())
+	require.NoError(t, err)
+	require.True(t, submittedAt.IsZero())
 }
 
 func makeJob(t *testing.T) *job.Job {
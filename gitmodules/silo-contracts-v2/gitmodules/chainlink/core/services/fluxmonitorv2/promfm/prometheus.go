@@ -40,6 +40,22 @@ var (
 		},
 		[]string{"job_spec_id"},
 	)
+
+	DeviationRuleDecisions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flux_monitor_deviation_rule_decisions",
+			Help: "Flux monitor's decisions to submit or skip an answer, by deviation rule and reason",
+		},
+		[]string{"job_spec_id", "rule", "decision", "reason"},
+	)
+
+	DeviationThreshold = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "flux_monitor_deviation_threshold",
+			Help: "Flux monitor's relative deviation threshold applied to the last observed value, in percent",
+		},
+		[]string{"job_spec_id", "rule"},
+	)
 )
 
 // SetDecimal sets a decimal metric
@@ -75,9 +75,70 @@ func ValidatedFluxMonitorSpec(config ValidationConfig, ts string) (job.Job, erro
 		return jb, errors.Errorf("PollTimerPeriod (%v) must be equal or greater than the smallest value of MaxTaskDuration param, JobPipeline.HTTPRequest.DefaultTimeout config var, or MinTimeout of all tasks (%v)", jb.FluxMonitorSpec.PollTimerPeriod, minTimeout)
 	}
 
+	if err := validateDeviationRule(jb.FluxMonitorSpec.DeviationRule); err != nil {
+		return jb, errors.Wrap(err, "while validating deviation rule")
+	}
+
 	return jb, nil
 }
 
+const (
+	defaultVolatilityWindow     = 20
+	defaultVolatilityMultiplier = 2
+)
+
+// validateDeviationRule checks that only the parameters of the type of the rule are set, and sets
+// the defaults of the volatility rule.
+func validateDeviationRule(rule *job.FluxMonitorDeviationRule) error {
+	if rule == nil {
+		return nil
+	}
+	if rule.MinSubmissionInterval < 0 {
+		return errors.New("minSubmissionInterval must not be negative")
+	}
+	asymmetricSet := rule.UpThreshold != 0 || rule.DownThreshold != 0
+	volatilitySet := rule.VolatilityWindow != 0 || rule.VolatilityMultiplier != 0 || rule.MinThreshold != 0 || rule.MaxThreshold != 0
+
+	switch rule.Type {
+	case "":
+		rule.Type = DeviationRuleThreshold
+		fallthrough
+	case DeviationRuleThreshold:
+		if asymmetricSet || volatilitySet {
+			return errors.Errorf("the %s rule uses the threshold and absoluteThreshold of the spec, other parameters must not be set", DeviationRuleThreshold)
+		}
+	case DeviationRuleAsymmetric:
+		if volatilitySet {
+			return errors.Errorf("the %s rule only takes upThreshold and downThreshold", DeviationRuleAsymmetric)
+		}
+		if rule.UpThreshold <= 0 || rule.DownThreshold <= 0 {
+			return errors.Errorf("the %s rule requires positive upThreshold and downThreshold", DeviationRuleAsymmetric)
+		}
+	case DeviationRuleVolatility:
+		if asymmetricSet {
+			return errors.Errorf("the %s rule does not take upThreshold and downThreshold", DeviationRuleVolatility)
+		}
+		if rule.VolatilityWindow == 0 {
+			rule.VolatilityWindow = defaultVolatilityWindow
+		}
+		if rule.VolatilityMultiplier == 0 {
+			rule.VolatilityMultiplier = defaultVolatilityMultiplier
+		}
+		if rule.VolatilityWindow < 3 {
+			return errors.New("volatilityWindow must be at least 3 answers")
+		}
+		if rule.VolatilityMultiplier < 0 || rule.MinThreshold < 0 {
+			return errors.New("volatilityMultiplier and minThreshold must not be negative")
+		}
+		if rule.MaxThreshold <= 0 || rule.MaxThreshold < rule.MinThreshold {
+			return errors.New("maxThreshold must be positive and at least minThreshold")
+		}
+	default:
+		return errors.Errorf("type must be one of %s, %s or %s, got '%s'", DeviationRuleThreshold, DeviationRuleAsymmetric, DeviationRuleVolatility, rule.Type)
+	}
+	return nil
+}
+
 // validatePollTime validates the period is greater than the min timeout for an
 // enabled poll timer.
 func validatePollTimer(disabled bool, minTimeout time.Duration, period time.Duration) bool {
@@ -216,3 +216,99 @@ externalJobID = "cfa3fa6b-2850-446b-b973-8f4c3b29d519"
 		})
 	}
 }
+
+func TestValidate_DeviationRule(t *testing.T) {
+	const spec = `
+type              = "fluxmonitor"
+schemaVersion     = 1
+name              = "example flux monitor spec"
+contractAddress   = "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"
+threshold         = 0.5
+absoluteThreshold = 0.01
+idleTimerPeriod   = "1m"
+pollTimerPeriod   = "1m"
+observationSource = """
+ds1 [type=http method=GET url="https://pricesource1.com"];
+ds1_parse [type=jsonparse path="latest"];
+ds1 -> ds1_parse;
+"""
+`
+	validate := func(rule string) (*job.FluxMonitorDeviationRule, error) {
+		j, err := ValidatedFluxMonitorSpec(testcfg{}, spec+rule)
+		if err != nil {
+			return nil, err
+		}
+		return j.FluxMonitorSpec.DeviationRule, nil
+	}
+
+	t.Run("no rule", func(t *testing.T) {
+		rule, err := validate("")
+		require.NoError(t, err)
+		assert.Nil(t, rule)
+	})
+
+	t.Run("threshold with minimum time between submissions", func(t *testing.T) {
+		rule, err := validate(`
+[deviationRule]
+minSubmissionInterval = "5m"
+`)
+		require.NoError(t, err)
+		assert.Equal(t, DeviationRuleThreshold, rule.Type)
+		assert.Equal(t, 5*time.Minute, rule.MinSubmissionInterval.Duration())
+	})
+
+	t.Run("asymmetric", func(t *testing.T) {
+		rule, err := validate(`
+[deviationRule]
+type = "asymmetric"
+upThreshold = 1
+downThreshold = 0.25
+`)
+		require.NoError(t, err)
+		assert.Equal(t, tomlutils.Float64(1), rule.UpThreshold)
+		assert.Equal(t, tomlutils.Float64(0.25), rule.DownThreshold)
+
+		_, err = validate(`
+[deviationRule]
+type = "asymmetric"
+upThreshold = 1
+`)
+		assert.ErrorContains(t, err, "requires positive upThreshold and downThreshold")
+	})
+
+	t.Run("volatility", func(t *testing.T) {
+		rule, err := validate(`
+[deviationRule]
+type = "volatility"
+minThreshold = 0.1
+maxThreshold = 2
+`)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(defaultVolatilityWindow), rule.VolatilityWindow)
+		assert.Equal(t, tomlutils.Float64(defaultVolatilityMultiplier), rule.VolatilityMultiplier)
+
+		_, err = validate(`
+[deviationRule]
+type = "volatility"
+minThreshold = 1
+maxThreshold = 0.5
+`)
+		assert.ErrorContains(t, err, "maxThreshold must be positive and at least minThreshold")
+	})
+
+	t.Run("parameters of another type", func(t *testing.T) {
+		_, err := validate(`
+[deviationRule]
+upThreshold = 1
+`)
+		assert.ErrorContains(t, err, "other parameters must not be set")
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := validate(`
+[deviationRule]
+type = "median"
+`)
+		assert.ErrorContains(t, err, "type must be one of threshold, asymmetric or volatility")
+	})
+}
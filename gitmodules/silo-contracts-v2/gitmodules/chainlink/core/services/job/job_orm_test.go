@@ -240,6 +240,24 @@ func TestORM(t *testing.T) {
 		require.NoError(t, err)
 
 		cltest.AssertCount(t, db, "external_initiator_webhook_specs", 2)
+
+		// the spec is updated in place, and its external initiators are replaced
+		updated, err := webhook.ValidatedWebhookSpec(testspecs.GenerateWebhookSpec(testspecs.WebhookSpecParams{ExternalInitiators: eiWS[1:]}).Toml(), eim)
+		require.NoError(t, err)
+		updated.ID = jb.ID
+		require.NoError(t, orm.UpdateJob(&updated))
+		assert.Equal(t, *jb.WebhookSpecID, *updated.WebhookSpecID)
+		assert.Equal(t, jb.ExternalJobID, updated.ExternalJobID)
+		cltest.AssertCount(t, db, "external_initiator_webhook_specs", 1)
+
+		// the type of a job cannot be changed
+		tree, err := toml.LoadFile("../../testdata/tomlspecs/direct-request-spec.toml")
+		require.NoError(t, err)
+		dr, err := directrequest.ValidatedDirectRequestSpec(tree.String())
+		require.NoError(t, err)
+		dr.ID = jb.ID
+		dr.ExternalJobID = uuid.UUID{}
+		require.ErrorIs(t, orm.UpdateJob(&dr), job.ErrJobTypeChanged)
 	})
 
 	t.Run("it creates and deletes records for blockhash store jobs", func(t *testing.T) {
@@ -1272,6 +1290,206 @@ func Test_FindJobsByPipelineSpecIDs(t *testing.T) {
 	})
 }
 
+func Test_Templates(t *testing.T) {
+	t.Parallel()
+
+	config := configtest.NewTestGeneralConfig(t)
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db, config.Database())
+
+	pipelineORM := pipeline.NewORM(db, logger.TestLogger(t), config.Database(), config.JobPipeline().MaxSuccessfulRuns())
+	bridgesORM := bridges.NewORM(db, logger.TestLogger(t), config.Database())
+	relayExtenders := evmtest.NewChainRelayExtenders(t, evmtest.TestChainOpts{DB: db, GeneralConfig: config, KeyStore: keyStore.Eth()})
+	legacyChains, err := evmrelay.NewLegacyChainsFromRelayerExtenders(relayExtenders)
+	require.NoError(t, err)
+	orm := NewTestORM(t, db, legacyChains, pipelineORM, bridgesORM, keyStore, config.Database())
+
+	_, err = orm.FindTemplate("direct request")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	v1 := newDirectRequestTemplate()
+	require.NoError(t, orm.CreateTemplate(&v1))
+	assert.Equal(t, int32(1), v1.Version)
+
+	spec, err := v1.Render(map[string]string{
+		"name":            "ETH / USD",
+		"contractAddress": "0x613a38AC1659769640aaE063C651F48E0250454C",
+		"times":           "100",
+	})
+	require.NoError(t, err)
+	jb, err := directrequest.ValidatedDirectRequestSpec(spec)
+	require.NoError(t, err)
+	jb.JobTemplateID = &v1.ID
+	require.NoError(t, orm.CreateJob(&jb))
+	require.NotNil(t, jb.JobTemplateID)
+	assert.Equal(t, v1.ID, *jb.JobTemplateID)
+
+	v2 := newDirectRequestTemplate()
+	v2.Definition += "\n# v2\n"
+	require.NoError(t, orm.CreateTemplate(&v2))
+	assert.Equal(t, int32(2), v2.Version)
+
+	latest, err := orm.FindTemplate("direct request")
+	require.NoError(t, err)
+	assert.Equal(t, v2.ID, latest.ID)
+	assert.Equal(t, v2.Definition, latest.Definition)
+	assert.Equal(t, v2.Parameters, latest.Parameters)
+
+	other := job.Template{Name: "other", Definition: "{{ .x }}", Parameters: job.TemplateParameters{{Name: "x", Type: job.TemplateParameterString}}}
+	require.NoError(t, orm.CreateTemplate(&other))
+
+	templates, err := orm.FindTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, v2.ID, templates[0].ID)
+	assert.Equal(t, other.ID, templates[1].ID)
+
+	jobs, err := orm.FindTemplateJobs("direct request")
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, jb.ID, jobs[0].JobID)
+	assert.Equal(t, "ETH / USD", jobs[0].JobName.ValueOrZero())
+	assert.Equal(t, int32(1), jobs[0].TemplateVersion)
+
+	jobs, err = orm.FindTemplateJobs("other")
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func Test_Triggers(t *testing.T) {
+	t.Parallel()
+
+	config := configtest.NewTestGeneralConfig(t)
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db, config.Database())
+
+	pipelineORM := pipeline.NewORM(db, logger.TestLogger(t), config.Database(), config.JobPipeline().MaxSuccessfulRuns())
+	bridgesORM := bridges.NewORM(db, logger.TestLogger(t), config.Database())
+	relayExtenders := evmtest.NewChainRelayExtenders(t, evmtest.TestChainOpts{DB: db, GeneralConfig: config, KeyStore: keyStore.Eth()})
+	legacyChains, err := evmrelay.NewLegacyChainsFromRelayerExtenders(relayExtenders)
+	require.NoError(t, err)
+	orm := NewTestORM(t, db, legacyChains, pipelineORM, bridgesORM, keyStore, config.Database())
+
+	webhookSpec := func(t *testing.T, triggers string) job.Job {
+		jb, err := webhook.ValidatedWebhookSpec(testspecs.GenerateWebhookSpec(testspecs.WebhookSpecParams{}).Toml()+triggers, nil)
+		require.NoError(t, err)
+		return jb
+	}
+
+	c := webhookSpec(t, "")
+	require.NoError(t, orm.CreateJob(&c))
+
+	b := webhookSpec(t, fmt.Sprintf("\n[[triggers]]\nexternalJobID = %q\n", c.ExternalJobID))
+	require.NoError(t, orm.CreateJob(&b))
+	require.Len(t, b.Triggers, 1)
+	assert.Equal(t, c.ExternalJobID, b.Triggers[0].DownstreamExternalJobID)
+	assert.Equal(t, job.TriggerOnSuccess, b.Triggers[0].Condition)
+
+	a := webhookSpec(t, fmt.Sprintf("\n[[triggers]]\nexternalJobID = %q\ncondition = \"always\"\n", b.ExternalJobID))
+	require.NoError(t, orm.CreateJob(&a))
+
+	t.Run("loads the triggers of jobs", func(t *testing.T) {
+		found, err := orm.FindJob(testutils.Context(t), a.ID)
+		require.NoError(t, err)
+		require.Len(t, found.Triggers, 1)
+		assert.Equal(t, a.ID, found.Triggers[0].JobID)
+		assert.Equal(t, b.ExternalJobID, found.Triggers[0].DownstreamExternalJobID)
+		assert.Equal(t, job.TriggerAlways, found.Triggers[0].Condition)
+
+		jobs, _, err := orm.FindJobs(0, 10)
+		require.NoError(t, err)
+		require.Len(t, jobs, 3)
+		for _, jb := range jobs {
+			switch jb.ID {
+			case a.ID, b.ID:
+				assert.Len(t, jb.Triggers, 1)
+			default:
+				assert.Empty(t, jb.Triggers)
+			}
+		}
+	})
+
+	t.Run("rejects triggers of unknown jobs", func(t *testing.T) {
+		jb := webhookSpec(t, fmt.Sprintf("\n[[triggers]]\nexternalJobID = %q\n", uuid.New()))
+		err := orm.CreateJob(&jb)
+		require.ErrorIs(t, err, job.ErrInvalidTrigger)
+		assert.ErrorContains(t, err, "no job with external job ID")
+	})
+
+	t.Run("rejects loops", func(t *testing.T) {
+		updated := webhookSpec(t, fmt.Sprintf("\n[[triggers]]\nexternalJobID = %q\n", a.ExternalJobID))
+		updated.ID, updated.ExternalJobID = c.ID, c.ExternalJobID
+		err := orm.UpdateJob(&updated)
+		require.ErrorIs(t, err, job.ErrInvalidTrigger)
+		assert.ErrorContains(t, err, "loop")
+
+		updated = webhookSpec(t, fmt.Sprintf("\n[[triggers]]\nexternalJobID = %q\n", c.ExternalJobID))
+		updated.ID, updated.ExternalJobID = c.ID, c.ExternalJobID
+		err = orm.UpdateJob(&updated)
+		require.ErrorIs(t, err, job.ErrInvalidTrigger)
+		assert.ErrorContains(t, err, "loop")
+	})
+
+	t.Run("keeps the triggers of updated jobs and deletes those of deleted jobs", func(t *testing.T) {
+		updated := webhookSpec(t, fmt.Sprintf("\n[[triggers]]\nexternalJobID = %q\ncondition = \"error\"\n", c.ExternalJobID))
+		updated.ID, updated.ExternalJobID = b.ID, b.ExternalJobID
+		require.NoError(t, orm.UpdateJob(&updated))
+		require.Len(t, updated.Triggers, 1)
+		assert.Equal(t, job.TriggerOnError, updated.Triggers[0].Condition)
+		cltest.AssertCount(t, db, "job_triggers", 2)
+
+		require.NoError(t, orm.DeleteJob(a.ID))
+		cltest.AssertCount(t, db, "job_triggers", 1)
+	})
+}
+
+func Test_CreateJob_Subpipelines(t *testing.T) {
+	t.Parallel()
+
+	config := configtest.NewTestGeneralConfig(t)
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db, config.Database())
+
+	pipelineORM := pipeline.NewORM(db, logger.TestLogger(t), config.Database(), config.JobPipeline().MaxSuccessfulRuns())
+	bridgesORM := bridges.NewORM(db, logger.TestLogger(t), config.Database())
+	relayExtenders := evmtest.NewChainRelayExtenders(t, evmtest.TestChainOpts{DB: db, GeneralConfig: config, KeyStore: keyStore.Eth()})
+	legacyChains, err := evmrelay.NewLegacyChainsFromRelayerExtenders(relayExtenders)
+	require.NoError(t, err)
+	orm := NewTestORM(t, db, legacyChains, pipelineORM, bridgesORM, keyStore, config.Database())
+
+	_, err = pipelineORM.CreateSubpipeline("double", `mul [type=multiply input="$(inputs.value)" times=2]`)
+	require.NoError(t, err)
+
+	webhookSpec := func(t *testing.T, task string) job.Job {
+		jb, err := webhook.ValidatedWebhookSpec(fmt.Sprintf(`
+type            = "webhook"
+schemaVersion   = 1
+observationSource   = """
+fan [type=foreach input="[1, 2]" subgraph=<%s>]
+"""
+`, task), nil)
+		require.NoError(t, err)
+		return jb
+	}
+
+	used := webhookSpec(t, `sub [type=subpipeline name="double" version=1 inputs=<{"value": $(item)}>]`)
+	require.NoError(t, orm.CreateJob(&used))
+
+	jb := webhookSpec(t, `sub [type=subpipeline name="missing"]`)
+	require.ErrorContains(t, orm.CreateJob(&jb), "subpipeline missing does not exist")
+
+	jb = webhookSpec(t, `sub [type=subpipeline name="double" version=2]`)
+	require.ErrorContains(t, orm.CreateJob(&jb), "version 2 of subpipeline double does not exist")
+
+	// the subpipeline cannot be deleted while a job invokes it
+	err = pipelineORM.DeleteSubpipeline("double")
+	require.ErrorIs(t, err, pipeline.ErrSubpipelineInUse)
+	require.ErrorContains(t, err, fmt.Sprintf("double is used by job %d", used.ID))
+
+	require.NoError(t, orm.DeleteJob(used.ID))
+	require.NoError(t, pipelineORM.DeleteSubpipeline("double"))
+}
+
 func Test_FindPipelineRuns(t *testing.T) {
 	t.Parallel()
 
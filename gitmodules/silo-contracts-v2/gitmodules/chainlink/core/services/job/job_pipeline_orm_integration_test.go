@@ -154,7 +154,7 @@ func TestPipelineORM_Integration(t *testing.T) {
 		relayExtenders := evmtest.NewChainRelayExtenders(t, evmtest.TestChainOpts{Client: evmtest.NewEthClientMockWithDefaultChain(t), DB: db, GeneralConfig: config, KeyStore: ethKeyStore})
 		legacyChains, err := evmrelay.NewLegacyChainsFromRelayerExtenders(relayExtenders)
 		require.NoError(t, err)
-		runner := pipeline.NewRunner(orm, btORM, config.JobPipeline(), cfg.WebServer(), legacyChains, nil, nil, lggr, nil, nil)
+		runner := pipeline.NewRunner(orm, btORM, config.JobPipeline(), cfg.WebServer(), legacyChains, nil, nil, nil, lggr, nil, nil)
 
 		jobORM := NewTestORM(t, db, legacyChains, orm, btORM, keyStore, cfg.Database())
 
@@ -100,6 +100,27 @@ func (_m *ORM) CreateJob(jb *job.Job, qopts ...pg.QOpt) error {
 	return r0
 }
 
+// CreateTemplate provides a mock function with given fields: t, qopts
+func (_m *ORM) CreateTemplate(t *job.Template, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, t)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*job.Template, ...pg.QOpt) error); ok {
+		r0 = rf(t, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteJob provides a mock function with given fields: id, qopts
 func (_m *ORM) DeleteJob(id int32, qopts ...pg.QOpt) error {
 	_va := make([]interface{}, len(qopts))
@@ -121,6 +142,27 @@ func (_m *ORM) DeleteJob(id int32, qopts ...pg.QOpt) error {
 	return r0
 }
 
+// DeleteShadowPipeline provides a mock function with given fields: id, qopts
+func (_m *ORM) DeleteShadowPipeline(id int32, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, ...pg.QOpt) error); ok {
+		r0 = rf(id, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DismissError provides a mock function with given fields: ctx, errorID
 func (_m *ORM) DismissError(ctx context.Context, errorID int64) error {
 	ret := _m.Called(ctx, errorID)
@@ -525,6 +567,70 @@ func (_m *ORM) FindSpecErrorsByJobIDs(ids []int32, qopts ...pg.QOpt) ([]job.Spec
 	return r0, r1
 }
 
+// FindSpecVersion provides a mock function with given fields: jobID, version, qopts
+func (_m *ORM) FindSpecVersion(jobID int32, version int32, qopts ...pg.QOpt) (job.SpecVersion, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobID, version)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 job.SpecVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32, int32, ...pg.QOpt) (job.SpecVersion, error)); ok {
+		return rf(jobID, version, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(int32, int32, ...pg.QOpt) job.SpecVersion); ok {
+		r0 = rf(jobID, version, qopts...)
+	} else {
+		r0 = ret.Get(0).(job.SpecVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(int32, int32, ...pg.QOpt) error); ok {
+		r1 = rf(jobID, version, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSpecVersions provides a mock function with given fields: jobID, qopts
+func (_m *ORM) FindSpecVersions(jobID int32, qopts ...pg.QOpt) ([]job.SpecVersion, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []job.SpecVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32, ...pg.QOpt) ([]job.SpecVersion, error)); ok {
+		return rf(jobID, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(int32, ...pg.QOpt) []job.SpecVersion); ok {
+		r0 = rf(jobID, qopts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]job.SpecVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int32, ...pg.QOpt) error); ok {
+		r1 = rf(jobID, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindTaskResultByRunIDAndTaskName provides a mock function with given fields: runID, taskName, qopts
 func (_m *ORM) FindTaskResultByRunIDAndTaskName(runID int64, taskName string, qopts ...pg.QOpt) ([]byte, error) {
 	_va := make([]interface{}, len(qopts))
@@ -558,6 +664,102 @@ func (_m *ORM) FindTaskResultByRunIDAndTaskName(runID int64, taskName string, qo
 	return r0, r1
 }
 
+// FindTemplate provides a mock function with given fields: name, qopts
+func (_m *ORM) FindTemplate(name string, qopts ...pg.QOpt) (job.Template, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 job.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...pg.QOpt) (job.Template, error)); ok {
+		return rf(name, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...pg.QOpt) job.Template); ok {
+		r0 = rf(name, qopts...)
+	} else {
+		r0 = ret.Get(0).(job.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...pg.QOpt) error); ok {
+		r1 = rf(name, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTemplateJobs provides a mock function with given fields: name, qopts
+func (_m *ORM) FindTemplateJobs(name string, qopts ...pg.QOpt) ([]job.TemplateJob, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []job.TemplateJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...pg.QOpt) ([]job.TemplateJob, error)); ok {
+		return rf(name, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...pg.QOpt) []job.TemplateJob); ok {
+		r0 = rf(name, qopts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]job.TemplateJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...pg.QOpt) error); ok {
+		r1 = rf(name, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTemplates provides a mock function with given fields: qopts
+func (_m *ORM) FindTemplates(qopts ...pg.QOpt) ([]job.Template, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []job.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(...pg.QOpt) ([]job.Template, error)); ok {
+		return rf(qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(...pg.QOpt) []job.Template); ok {
+		r0 = rf(qopts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]job.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(...pg.QOpt) error); ok {
+		r1 = rf(qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertJob provides a mock function with given fields: _a0, qopts
 func (_m *ORM) InsertJob(_a0 *job.Job, qopts ...pg.QOpt) error {
 	_va := make([]interface{}, len(qopts))
@@ -654,6 +856,50 @@ func (_m *ORM) RecordError(jobID int32, description string, qopts ...pg.QOpt) er
 	return r0
 }
 
+// SetPaused provides a mock function with given fields: id, paused, qopts
+func (_m *ORM) SetPaused(id int32, paused bool, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, id)
+	_ca = append(_ca, paused)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, bool, ...pg.QOpt) error); ok {
+		r0 = rf(id, paused, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetShadowPipeline provides a mock function with given fields: id, p, qopts
+func (_m *ORM) SetShadowPipeline(id int32, p pipeline.Pipeline, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, id)
+	_ca = append(_ca, p)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, pipeline.Pipeline, ...pg.QOpt) error); ok {
+		r0 = rf(id, p, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TryRecordError provides a mock function with given fields: jobID, description, qopts
 func (_m *ORM) TryRecordError(jobID int32, description string, qopts ...pg.QOpt) {
 	_va := make([]interface{}, len(qopts))
@@ -666,6 +912,27 @@ func (_m *ORM) TryRecordError(jobID int32, description string, qopts ...pg.QOpt)
 	_m.Called(_ca...)
 }
 
+// UpdateJob provides a mock function with given fields: jb, qopts
+func (_m *ORM) UpdateJob(jb *job.Job, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jb)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*job.Job, ...pg.QOpt) error); ok {
+		r0 = rf(jb, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewORM interface {
 	mock.TestingT
 	Cleanup(func())
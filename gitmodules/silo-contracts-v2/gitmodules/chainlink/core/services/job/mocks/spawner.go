@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	pg "github.com/smartcontractkit/chainlink/v2/core/services/pg"
+
+	pipeline "github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 )
 
 // Spawner is an autogenerated mock type for the Spawner type
@@ -32,6 +34,11 @@ func (_m *Spawner) ActiveJobs() map[int32]job.Job {
 	return r0
 }
 
+// AddJobListener provides a mock function with given fields: l
+func (_m *Spawner) AddJobListener(l job.JobListener) {
+	_m.Called(l)
+}
+
 // Close provides a mock function with given fields:
 func (_m *Spawner) Close() error {
 	ret := _m.Called()
@@ -118,6 +125,27 @@ func (_m *Spawner) Name() string {
 	return r0
 }
 
+// PauseJob provides a mock function with given fields: jobID, qopts
+func (_m *Spawner) PauseJob(jobID int32, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, ...pg.QOpt) error); ok {
+		r0 = rf(jobID, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Ready provides a mock function with given fields:
 func (_m *Spawner) Ready() error {
 	ret := _m.Called()
@@ -132,6 +160,70 @@ func (_m *Spawner) Ready() error {
 	return r0
 }
 
+// RemoveShadow provides a mock function with given fields: jobID, qopts
+func (_m *Spawner) RemoveShadow(jobID int32, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, ...pg.QOpt) error); ok {
+		r0 = rf(jobID, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResumeJob provides a mock function with given fields: jobID, qopts
+func (_m *Spawner) ResumeJob(jobID int32, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, ...pg.QOpt) error); ok {
+		r0 = rf(jobID, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetShadow provides a mock function with given fields: jobID, p, qopts
+func (_m *Spawner) SetShadow(jobID int32, p pipeline.Pipeline, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobID)
+	_ca = append(_ca, p)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, pipeline.Pipeline, ...pg.QOpt) error); ok {
+		r0 = rf(jobID, p, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields: _a0
 func (_m *Spawner) Start(_a0 context.Context) error {
 	ret := _m.Called(_a0)
@@ -167,6 +259,27 @@ func (_m *Spawner) StartService(ctx context.Context, spec job.Job, qopts ...pg.Q
 	return r0
 }
 
+// UpdateJob provides a mock function with given fields: jb, qopts
+func (_m *Spawner) UpdateJob(jb *job.Job, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jb)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*job.Job, ...pg.QOpt) error); ok {
+		r0 = rf(jb, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewSpawner interface {
 	mock.TestingT
 	Cleanup(func())
@@ -42,6 +42,7 @@ const (
 	Webhook                 Type = (Type)(pipeline.WebhookJobType)
 	Bootstrap               Type = (Type)(pipeline.BootstrapJobType)
 	Gateway                 Type = (Type)(pipeline.GatewayJobType)
+	EVMLog                  Type = (Type)(pipeline.EVMLogJobType)
 )
 
 //revive:disable:redefines-builtin-id
@@ -79,6 +80,7 @@ var (
 		LegacyGasStationSidecar: false,
 		Bootstrap:               false,
 		Gateway:                 false,
+		EVMLog:                  true,
 	}
 	supportsAsync = map[Type]bool{
 		Cron:                    true,
@@ -95,6 +97,7 @@ var (
 		LegacyGasStationSidecar: false,
 		Bootstrap:               false,
 		Gateway:                 false,
+		EVMLog:                  true,
 	}
 	schemaVersions = map[Type]uint32{
 		Cron:                    1,
@@ -111,6 +114,7 @@ var (
 		LegacyGasStationSidecar: 1,
 		Bootstrap:               1,
 		Gateway:                 1,
+		EVMLog:                  1,
 	}
 )
 
@@ -145,8 +149,12 @@ type Job struct {
 	BootstrapSpecID               *int32
 	GatewaySpec                   *GatewaySpec
 	GatewaySpecID                 *int32
+	EVMLogSpec                    *EVMLogSpec
+	EVMLogSpecID                  *int32
 	PipelineSpecID                int32
 	PipelineSpec                  *pipeline.Spec
+	ShadowPipelineSpecID          *int32
+	ShadowPipelineSpec            *pipeline.Spec
 	JobSpecErrors                 []SpecError
 	Type                          Type
 	SchemaVersion                 uint32
@@ -155,6 +163,10 @@ type Job struct {
 	Name                          null.String
 	MaxTaskDuration               models.Interval
 	Pipeline                      pipeline.Pipeline `toml:"observationSource"`
+	Triggers                      []Trigger         `toml:"triggers" db:"-"`
+	Paused                        bool              `toml:"-"`
+	JobTemplateID                 *int32            `toml:"-"`        // the template version the job was created from, if any
+	Definition                    string            `toml:"-" db:"-"` // TOML source, recorded as a SpecVersion when set
 	CreatedAt                     time.Time
 }
 
@@ -190,6 +202,16 @@ func (j *Job) SetID(value string) error {
 	return nil
 }
 
+// SpecVersion is an immutable version of the TOML definition of a job. Versions
+// are linked to the external job ID, which is stable across updates of the job.
+type SpecVersion struct {
+	ID            int64
+	ExternalJobID uuid.UUID
+	Version       int32
+	Definition    string
+	CreatedAt     time.Time
+}
+
 type SpecError struct {
 	ID          int64
 	JobID       int32
@@ -446,15 +468,50 @@ type DirectRequestSpec struct {
 	Requesters                  models.AddressCollection `toml:"requesters"`
 	MinContractPayment          *assets.Link             `toml:"minContractPaymentLinkJuels"`
 	EVMChainID                  *utils.Big               `toml:"evmChainID"`
-	CreatedAt                   time.Time                `toml:"-"`
-	UpdatedAt                   time.Time                `toml:"-"`
+	// BatchFulfillmentEnabled queues the responses of the runs, the final output of the pipeline,
+	// and fulfills the requests in batches rather than with an ethtx task per request.
+	BatchFulfillmentEnabled bool `toml:"batchFulfillmentEnabled"`
+	// BatchFulfillmentWindow is how long responses are accumulated before being fulfilled.
+	BatchFulfillmentWindow models.Interval `toml:"batchFulfillmentWindow"`
+	// BatchFulfillmentMaxSize is the maximum number of requests fulfilled in a transaction.
+	BatchFulfillmentMaxSize uint32 `toml:"batchFulfillmentMaxSize"`
+	// BatchFulfillmentUseBatchMethod submits the responses with the fulfillOracleRequests method,
+	// which the Operator must implement, rather than one by one with fulfillOracleRequest2.
+	BatchFulfillmentUseBatchMethod bool      `toml:"batchFulfillmentUseBatchMethod"`
+	CreatedAt                      time.Time `toml:"-"`
+	UpdatedAt                      time.Time `toml:"-"`
 }
 
+// CronCatchUp is the policy for the runs of a cron job which were missed while it was not running.
+type CronCatchUp string
+
+const (
+	// CronCatchUpSkip does not run missed runs.
+	CronCatchUpSkip CronCatchUp = "skip"
+	// CronCatchUpRunOnce runs once on startup if any run was missed.
+	CronCatchUpRunOnce CronCatchUp = "run-once"
+	// CronCatchUpRunAll runs every missed run on startup, up to a limit.
+	CronCatchUpRunAll CronCatchUp = "run-all"
+)
+
+// CronSpec runs a job on a schedule. TimeZone is the time zone of the schedule, as set by a CRON_TZ
+// prefix, and Jitter is the maximum random delay of the scheduled runs.
 type CronSpec struct {
-	ID           int32     `toml:"-"`
-	CronSchedule string    `toml:"schedule"`
-	CreatedAt    time.Time `toml:"-"`
-	UpdatedAt    time.Time `toml:"-"`
+	ID           int32           `toml:"-"`
+	CronSchedule string          `toml:"schedule"`
+	TimeZone     string          `toml:"timeZone"`
+	Jitter       models.Interval `toml:"jitter"`
+	CatchUp      CronCatchUp     `toml:"catchUp"`
+	CreatedAt    time.Time       `toml:"-"`
+	UpdatedAt    time.Time       `toml:"-"`
+}
+
+// Schedule returns the schedule, prefixed with the time zone if set.
+func (s CronSpec) Schedule() string {
+	if s.TimeZone == "" {
+		return s.CronSchedule
+	}
+	return "CRON_TZ=" + s.TimeZone + " " + s.CronSchedule
 }
 
 func (s CronSpec) GetID() string {
@@ -487,8 +544,46 @@ type FluxMonitorSpec struct {
 	DrumbeatEnabled     bool
 	MinPayment          *assets.Link
 	EVMChainID          *utils.Big `toml:"evmChainID"`
-	CreatedAt           time.Time  `toml:"-"`
-	UpdatedAt           time.Time  `toml:"-"`
+	// DeviationRule selects the rule deciding whether an answer deviates enough from the latest
+	// submission to start a new round. The threshold and absoluteThreshold rule is used if unset.
+	DeviationRule *FluxMonitorDeviationRule `toml:"deviationRule"`
+	CreatedAt     time.Time                 `toml:"-"`
+	UpdatedAt     time.Time                 `toml:"-"`
+}
+
+// FluxMonitorDeviationRule is the deviation rule of a flux monitor job and its parameters, see
+// fluxmonitorv2.DeviationRule. Only the parameters of the selected type are set.
+type FluxMonitorDeviationRule struct {
+	// Type is one of threshold, asymmetric or volatility.
+	Type string `toml:"type" json:"type"`
+	// UpThreshold and DownThreshold are the relative thresholds of the asymmetric rule, in percent,
+	// for answers respectively above and below the latest submission.
+	UpThreshold   tomlutils.Float64 `toml:"upThreshold,float" json:"upThreshold,omitempty"`
+	DownThreshold tomlutils.Float64 `toml:"downThreshold,float" json:"downThreshold,omitempty"`
+	// The volatility rule sets the relative threshold to VolatilityMultiplier times the standard
+	// deviation of the relative changes between the latest VolatilityWindow answers, bounded by
+	// MinThreshold and MaxThreshold.
+	VolatilityWindow     uint32            `toml:"volatilityWindow" json:"volatilityWindow,omitempty"`
+	VolatilityMultiplier tomlutils.Float64 `toml:"volatilityMultiplier,float" json:"volatilityMultiplier,omitempty"`
+	MinThreshold         tomlutils.Float64 `toml:"minThreshold,float" json:"minThreshold,omitempty"`
+	MaxThreshold         tomlutils.Float64 `toml:"maxThreshold,float" json:"maxThreshold,omitempty"`
+	// MinSubmissionInterval is the minimum time between the rounds started by the node when the
+	// answer deviates, with any type. It does not delay idle timer or drumbeat submissions.
+	MinSubmissionInterval models.Interval `toml:"minSubmissionInterval" json:"minSubmissionInterval,omitempty"`
+}
+
+// Value returns this instance serialized for database storage.
+func (r FluxMonitorDeviationRule) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan reads the database value and returns an instance.
+func (r *FluxMonitorDeviationRule) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", value)
+	}
+	return json.Unmarshal(b, r)
 }
 
 type KeeperSpec struct {
@@ -769,3 +864,74 @@ func (s *GatewaySpec) SetID(value string) error {
 	s.ID = int32(ID)
 	return nil
 }
+
+// EVMLogSpec defines the job spec for a job which runs its pipeline for every log
+// of an event emitted by a contract.
+type EVMLogSpec struct {
+	ID int32
+
+	// EVMChainID is the chain the contract is deployed on.
+	EVMChainID *utils.Big `toml:"evmChainID"`
+
+	// ContractAddress is the address of the contract emitting the event.
+	ContractAddress ethkey.EIP55Address `toml:"contractAddress"`
+
+	// EventABI is the signature of the event with the names of its arguments, in the format of
+	// the ethabidecodelog task, e.g. "Transfer(address indexed from, address indexed to, uint256 value)".
+	EventABI string `toml:"eventABI"`
+
+	// Topic1, Topic2 and Topic3 are the accepted values of the indexed arguments of the
+	// event. Any value is accepted if empty.
+	Topic1 EVMLogTopicValues `toml:"topic1"`
+	Topic2 EVMLogTopicValues `toml:"topic2"`
+	Topic3 EVMLogTopicValues `toml:"topic3"`
+
+	// MinConfirmations is the number of blocks a log must be buried under before it is processed.
+	MinConfirmations uint32 `toml:"minConfirmations"`
+
+	// PollPeriod defines how often the log poller is queried for new logs.
+	PollPeriod time.Duration `toml:"pollPeriod"`
+
+	// CreatedAt is the time this job was created.
+	CreatedAt time.Time `toml:"-"`
+
+	// UpdatedAt is the time this job was last updated.
+	UpdatedAt time.Time `toml:"-"`
+}
+
+// EVMLogTopicValues is a list of topic values, stored as a BYTEA[] column.
+type EVMLogTopicValues []common.Hash
+
+// Contains returns whether the topic is accepted, which is the case for any topic if the list is empty.
+func (v EVMLogTopicValues) Contains(topic common.Hash) bool {
+	if len(v) == 0 {
+		return true
+	}
+	for _, t := range v {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func (v EVMLogTopicValues) Value() (driver.Value, error) {
+	arr := make(pq.ByteaArray, len(v))
+	for i := range v {
+		arr[i] = v[i].Bytes()
+	}
+	return arr.Value()
+}
+
+func (v *EVMLogTopicValues) Scan(src interface{}) error {
+	var arr pq.ByteaArray
+	if err := arr.Scan(src); err != nil {
+		return err
+	}
+	values := make(EVMLogTopicValues, len(arr))
+	for i := range arr {
+		values[i] = common.BytesToHash(arr[i])
+	}
+	*v = values
+	return nil
+}
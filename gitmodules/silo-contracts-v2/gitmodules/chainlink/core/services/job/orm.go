@@ -40,6 +40,8 @@ var (
 	ErrNoSuchTransmitterKey = errors.New("no such transmitter key exists")
 	ErrNoSuchSendingKey     = errors.New("no such sending key exists")
 	ErrNoSuchPublicKey      = errors.New("no such public key exists")
+	ErrExternalJobIDChanged = errors.New("the external job ID of a job cannot be changed")
+	ErrJobTypeChanged       = errors.New("the type of a job cannot be changed")
 )
 
 //go:generate mockery --quiet --name ORM --output ./mocks/ --case=underscore
@@ -48,6 +50,7 @@ type ORM interface {
 	InsertWebhookSpec(webhookSpec *WebhookSpec, qopts ...pg.QOpt) error
 	InsertJob(job *Job, qopts ...pg.QOpt) error
 	CreateJob(jb *Job, qopts ...pg.QOpt) error
+	UpdateJob(jb *Job, qopts ...pg.QOpt) error
 	FindJobs(offset, limit int) ([]Job, int, error)
 	FindJobTx(id int32) (Job, error)
 	FindJob(ctx context.Context, id int32) (Job, error)
@@ -56,6 +59,13 @@ type ORM interface {
 	FindOCR2JobIDByAddress(contractID string, feedID *common.Hash, qopts ...pg.QOpt) (int32, error)
 	FindJobIDsWithBridge(name string) ([]int32, error)
 	DeleteJob(id int32, qopts ...pg.QOpt) error
+	// SetPaused sets whether the job is paused, it does not start or stop any services.
+	SetPaused(id int32, paused bool, qopts ...pg.QOpt) error
+	// SetShadowPipeline stores p as the shadow pipeline of the job, replacing the previous one
+	// and its shadow runs. It does not start or stop any services.
+	SetShadowPipeline(id int32, p pipeline.Pipeline, qopts ...pg.QOpt) error
+	// DeleteShadowPipeline removes the shadow pipeline of the job and its shadow runs.
+	DeleteShadowPipeline(id int32, qopts ...pg.QOpt) error
 	RecordError(jobID int32, description string, qopts ...pg.QOpt) error
 	// TryRecordError is a helper which calls RecordError and logs the returned error if present.
 	TryRecordError(jobID int32, description string, qopts ...pg.QOpt)
@@ -74,6 +84,18 @@ type ORM interface {
 	FindSpecErrorsByJobIDs(ids []int32, qopts ...pg.QOpt) ([]SpecError, error)
 	FindJobWithoutSpecErrors(id int32) (jb Job, err error)
 
+	FindSpecVersions(jobID int32, qopts ...pg.QOpt) ([]SpecVersion, error)
+	FindSpecVersion(jobID int32, version int32, qopts ...pg.QOpt) (SpecVersion, error)
+
+	// CreateTemplate stores t as the next version of the template with its name.
+	CreateTemplate(t *Template, qopts ...pg.QOpt) error
+	// FindTemplates returns the latest version of every template.
+	FindTemplates(qopts ...pg.QOpt) ([]Template, error)
+	// FindTemplate returns the latest version of a template.
+	FindTemplate(name string, qopts ...pg.QOpt) (Template, error)
+	// FindTemplateJobs returns the jobs created from any version of a template.
+	FindTemplateJobs(name string, qopts ...pg.QOpt) ([]TemplateJob, error)
+
 	FindTaskResultByRunIDAndTaskName(runID int64, taskName string, qopts ...pg.QOpt) ([]byte, error)
 	AssertBridgesExist(p pipeline.Pipeline) error
 }
@@ -145,6 +167,36 @@ func (o *orm) AssertBridgesExist(p pipeline.Pipeline) error {
 	return nil
 }
 
+// assertSubpipelinesExist checks that the subpipelines invoked by p exist, so that jobs are not
+// created with subpipeline tasks that can only fail. The subpipelines are locked until tx ends, so
+// they cannot be deleted before the job is stored.
+func (o *orm) assertSubpipelinesExist(tx pg.Queryer, p pipeline.Pipeline) error {
+	if p.Source == "" {
+		return nil
+	}
+	references, err := pipeline.SubpipelineReferences(p.Source)
+	if err != nil {
+		return err
+	}
+	if len(references) == 0 {
+		return nil
+	}
+	if _, err = tx.Exec(`LOCK TABLE subpipelines IN SHARE MODE`); err != nil {
+		return errors.Wrap(err, "failed to lock subpipelines")
+	}
+	for _, reference := range references {
+		if _, err = o.pipelineORM.FindSubpipeline(reference.Name, reference.Version, pg.WithQueryer(tx)); errors.Is(err, sql.ErrNoRows) {
+			if reference.Version == 0 {
+				return errors.Errorf("subpipeline %s does not exist", reference.Name)
+			}
+			return errors.Errorf("version %d of subpipeline %s does not exist", reference.Version, reference.Name)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CreateJob creates the job, and it's associated spec record.
 // Expects an unmarshalled job spec as the jb argument i.e. output from ValidatedXX.
 // Scans all persisted records back into jb
@@ -157,300 +209,682 @@ func (o *orm) CreateJob(jb *Job, qopts ...pg.QOpt) error {
 
 	var jobID int32
 	err := q.Transaction(func(tx pg.Queryer) error {
+		if err := o.assertSubpipelinesExist(tx, p); err != nil {
+			return err
+		}
+
 		// Autogenerate a job ID if not specified
 		if jb.ExternalJobID == (uuid.UUID{}) {
 			jb.ExternalJobID = uuid.New()
 		}
 
-		switch jb.Type {
-		case DirectRequest:
-			var specID int32
-			sql := `INSERT INTO direct_request_specs (contract_address, min_incoming_confirmations, requesters, min_contract_payment, evm_chain_id, created_at, updated_at)
-			VALUES (:contract_address, :min_incoming_confirmations, :requesters, :min_contract_payment, :evm_chain_id, now(), now())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.DirectRequestSpec); err != nil {
-				return errors.Wrap(err, "failed to create DirectRequestSpec")
-			}
-			jb.DirectRequestSpecID = &specID
-		case FluxMonitor:
-			var specID int32
-			sql := `INSERT INTO flux_monitor_specs (contract_address, threshold, absolute_threshold, poll_timer_period, poll_timer_disabled, idle_timer_period, idle_timer_disabled,
-					drumbeat_schedule, drumbeat_random_delay, drumbeat_enabled, min_payment, evm_chain_id, created_at, updated_at)
-			VALUES (:contract_address, :threshold, :absolute_threshold, :poll_timer_period, :poll_timer_disabled, :idle_timer_period, :idle_timer_disabled,
-					:drumbeat_schedule, :drumbeat_random_delay, :drumbeat_enabled, :min_payment, :evm_chain_id, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.FluxMonitorSpec); err != nil {
-				return errors.Wrap(err, "failed to create FluxMonitorSpec")
-			}
-			jb.FluxMonitorSpecID = &specID
-		case OffchainReporting:
-			var specID int32
-			if jb.OCROracleSpec.EncryptedOCRKeyBundleID != nil {
-				_, err := o.keyStore.OCR().Get(jb.OCROracleSpec.EncryptedOCRKeyBundleID.String())
-				if err != nil {
-					return errors.Wrapf(ErrNoSuchKeyBundle, "no key bundle with id: %x", jb.OCROracleSpec.EncryptedOCRKeyBundleID)
-				}
-			}
-			if jb.OCROracleSpec.TransmitterAddress != nil {
-				_, err := o.keyStore.Eth().Get(jb.OCROracleSpec.TransmitterAddress.Hex())
-				if err != nil {
-					return errors.Wrapf(ErrNoSuchTransmitterKey, "no key matching transmitter address: %s", jb.OCROracleSpec.TransmitterAddress.Hex())
-				}
-			}
+		if err := o.insertJobSpec(tx, jb); err != nil {
+			return err
+		}
 
-			if jb.OCROracleSpec.EVMChainID == nil {
-				// If unspecified, assume we're creating a job intended to run on default chain id
-				newChain, err := o.legacyChains.Default()
-				if err != nil {
-					return err
-				}
-				jb.OCROracleSpec.EVMChainID = utils.NewBig(newChain.ID())
-			}
-			newChainID := jb.OCROracleSpec.EVMChainID
+		pipelineSpecID, err := o.pipelineORM.CreateSpec(p, jb.MaxTaskDuration, pg.WithQueryer(tx))
+		if err != nil {
+			return errors.Wrap(err, "failed to create pipeline spec")
+		}
 
-			existingSpec := new(OCROracleSpec)
-			err := tx.Get(existingSpec, `SELECT * FROM ocr_oracle_specs WHERE contract_address = $1 and (evm_chain_id = $2 or evm_chain_id IS NULL) LIMIT 1;`,
-				jb.OCROracleSpec.ContractAddress, newChainID,
-			)
+		jb.PipelineSpecID = pipelineSpecID
 
-			if !errors.Is(err, sql.ErrNoRows) {
-				if err != nil {
-					return errors.Wrap(err, "failed to validate OffchainreportingOracleSpec on creation")
-				}
+		err = o.InsertJob(jb, pg.WithQueryer(tx))
+		jobID = jb.ID
+		if err != nil {
+			return errors.Wrap(err, "failed to insert job")
+		}
+		if err = o.insertTriggers(tx, jb); err != nil {
+			return err
+		}
+		return o.insertSpecVersion(tx, jb)
+	})
+	if err != nil {
+		return errors.Wrap(err, "CreateJobFailed")
+	}
 
-				return errors.Errorf("a job with contract address %s already exists for chain ID %s", jb.OCROracleSpec.ContractAddress, newChainID)
-			}
+	return o.findJob(jb, "id", jobID, qopts...)
+}
 
-			sql := `INSERT INTO ocr_oracle_specs (contract_address, p2p_bootstrap_peers, p2pv2_bootstrappers, is_bootstrap_peer, encrypted_ocr_key_bundle_id, transmitter_address,
-					observation_timeout, blockchain_timeout, contract_config_tracker_subscribe_interval, contract_config_tracker_poll_interval, contract_config_confirmations, evm_chain_id,
-					created_at, updated_at, database_timeout, observation_grace_period, contract_transmitter_transmit_timeout)
-			VALUES (:contract_address, :p2p_bootstrap_peers, :p2pv2_bootstrappers, :is_bootstrap_peer, :encrypted_ocr_key_bundle_id, :transmitter_address,
-					:observation_timeout, :blockchain_timeout, :contract_config_tracker_subscribe_interval, :contract_config_tracker_poll_interval, :contract_config_confirmations, :evm_chain_id,
-					NOW(), NOW(), :database_timeout, :observation_grace_period, :contract_transmitter_transmit_timeout)
-			RETURNING id;`
-			err = pg.PrepareQueryRowx(tx, sql, &specID, jb.OCROracleSpec)
-			if err != nil {
-				return errors.Wrap(err, "failed to create OffchainreportingOracleSpec")
-			}
-			jb.OCROracleSpecID = &specID
-		case OffchainReporting2:
-			var specID int32
+// UpdateJob replaces the spec of the existing job with ID jb.ID by jb. The job and its type
+// specific spec are updated in place, so the job keeps its ID, external job ID, creation time,
+// paused state and pipeline spec ID, and the state stored for it, such as its runs, is kept too.
+// The type of a job cannot be changed.
+// Expects an unmarshalled job spec as the jb argument i.e. output from ValidatedXX.
+// Scans all persisted records back into jb
+func (o *orm) UpdateJob(jb *Job, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	p := jb.Pipeline
+	if err := o.AssertBridgesExist(p); err != nil {
+		return err
+	}
 
-			if jb.OCR2OracleSpec.OCRKeyBundleID.Valid {
-				_, err := o.keyStore.OCR2().Get(jb.OCR2OracleSpec.OCRKeyBundleID.String)
-				if err != nil {
-					return errors.Wrapf(ErrNoSuchKeyBundle, "no key bundle with id: %q", jb.OCR2OracleSpec.OCRKeyBundleID.ValueOrZero())
-				}
-			}
+	err := q.Transaction(func(tx pg.Queryer) error {
+		if err := o.assertSubpipelinesExist(tx, p); err != nil {
+			return err
+		}
+		var existing Job
+		if err := tx.Get(&existing, `SELECT * FROM jobs WHERE id = $1 FOR UPDATE`, jb.ID); err != nil {
+			return errors.Wrap(err, "failed to find job")
+		}
+		if jb.ExternalJobID == (uuid.UUID{}) {
+			jb.ExternalJobID = existing.ExternalJobID
+		} else if jb.ExternalJobID != existing.ExternalJobID {
+			return errors.Wrapf(ErrExternalJobIDChanged, "job has external job ID %s, got %s", existing.ExternalJobID, jb.ExternalJobID)
+		}
+		if jb.Type != existing.Type {
+			return errors.Wrapf(ErrJobTypeChanged, "job is a %s job, got %s", existing.Type, jb.Type)
+		}
 
-			if jb.OCR2OracleSpec.RelayConfig["sendingKeys"] != nil && jb.OCR2OracleSpec.TransmitterID.Valid {
-				return errors.New("sending keys and transmitter ID can't both be defined")
-			}
+		if err := o.updateJobSpec(tx, existing, jb); err != nil {
+			return err
+		}
 
-			// checks if they are present and if they are valid
-			sendingKeysDefined, err := areSendingKeysDefined(jb, o.keyStore)
-			if err != nil {
-				return err
-			}
+		// the pipeline spec is updated in place, since the runs of the job reference it
+		if _, err := tx.Exec(`UPDATE pipeline_specs SET dot_dag_source = $2, max_task_duration = $3 WHERE id = $1`,
+			existing.PipelineSpecID, p.Source, jb.MaxTaskDuration); err != nil {
+			return errors.Wrap(err, "failed to update pipeline spec")
+		}
+		jb.PipelineSpecID = existing.PipelineSpecID
+		jb.Paused = existing.Paused
 
-			if !sendingKeysDefined && !jb.OCR2OracleSpec.TransmitterID.Valid {
-				return errors.New("neither sending keys nor transmitter ID is defined")
-			}
+		query, args, err := tx.BindNamed(`UPDATE jobs SET name = :name, schema_version = :schema_version, max_task_duration = :max_task_duration,
+			gas_limit = :gas_limit, forwarding_allowed = :forwarding_allowed, job_template_id = :job_template_id
+		WHERE id = :id`, jb)
+		if err != nil {
+			return errors.Wrap(err, "failed to bind job")
+		}
+		if _, err = tx.Exec(query, args...); err != nil {
+			return errors.Wrap(err, "failed to update job")
+		}
 
-			if !sendingKeysDefined {
-				if err = ValidateKeyStoreMatch(jb.OCR2OracleSpec, o.keyStore, jb.OCR2OracleSpec.TransmitterID.String); err != nil {
-					return errors.Wrap(ErrNoSuchTransmitterKey, err.Error())
-				}
-			}
+		if _, err = tx.Exec(`DELETE FROM job_triggers WHERE job_id = $1`, jb.ID); err != nil {
+			return errors.Wrap(err, "failed to delete previous triggers")
+		}
+		if err = o.insertTriggers(tx, jb); err != nil {
+			return err
+		}
+		return o.insertSpecVersion(tx, jb)
+	})
+	if err != nil {
+		return errors.Wrap(err, "UpdateJob failed")
+	}
 
-			if jb.ForwardingAllowed && !slices.Contains(ForwardersSupportedPlugins, jb.OCR2OracleSpec.PluginType) {
-				return errors.Errorf("forwarding is not currently supported for %s jobs", jb.OCR2OracleSpec.PluginType)
-			}
+	return o.findJob(jb, "id", jb.ID, qopts...)
+}
 
-			if jb.OCR2OracleSpec.PluginType == Mercury {
-				if jb.OCR2OracleSpec.FeedID == nil {
-					return errors.New("feed ID is required for mercury plugin type")
-				}
-			} else {
-				if jb.OCR2OracleSpec.FeedID != nil {
-					return errors.New("feed ID is not currently supported for non-mercury jobs")
-				}
-			}
+// insertSpecVersion records jb.Definition as the next version of the spec of the job, if set.
+func (o *orm) insertSpecVersion(tx pg.Queryer, jb *Job) error {
+	if jb.Definition == "" {
+		return nil
+	}
+	_, err := tx.Exec(`INSERT INTO job_spec_versions (external_job_id, version, definition, created_at)
+	SELECT $1, COALESCE(MAX(version), 0) + 1, $2, NOW() FROM job_spec_versions WHERE external_job_id = $1`,
+		jb.ExternalJobID, jb.Definition)
+	return errors.Wrap(err, "failed to insert job spec version")
+}
+
+// FindSpecVersions returns the recorded versions of the spec of a job, oldest first.
+func (o *orm) FindSpecVersions(jobID int32, qopts ...pg.QOpt) (versions []SpecVersion, err error) {
+	stmt := `SELECT job_spec_versions.* FROM job_spec_versions
+	JOIN jobs USING (external_job_id)
+	WHERE jobs.id = $1
+	ORDER BY job_spec_versions.version ASC`
+	err = o.q.WithOpts(qopts...).Select(&versions, stmt, jobID)
+	return versions, errors.Wrap(err, "FindSpecVersions failed")
+}
+
+// FindSpecVersion returns a recorded version of the spec of a job.
+func (o *orm) FindSpecVersion(jobID int32, version int32, qopts ...pg.QOpt) (specVersion SpecVersion, err error) {
+	stmt := `SELECT job_spec_versions.* FROM job_spec_versions
+	JOIN jobs USING (external_job_id)
+	WHERE jobs.id = $1 AND job_spec_versions.version = $2`
+	err = o.q.WithOpts(qopts...).Get(&specVersion, stmt, jobID, version)
+	return specVersion, errors.Wrap(err, "FindSpecVersion failed")
+}
+
+// insertTriggers stores the triggers of jb, after checking that they run webhook jobs and that
+// jobs would not trigger each other in a loop.
+func (o *orm) insertTriggers(tx pg.Queryer, jb *Job) error {
+	if len(jb.Triggers) == 0 {
+		return nil
+	}
+	if err := ValidateTriggers(jb.Triggers); err != nil {
+		return err
+	}
 
-			if jb.OCR2OracleSpec.PluginType == Median {
-				var cfg medianconfig.PluginConfig
-				err = json.Unmarshal(jb.OCR2OracleSpec.PluginConfig.Bytes(), &cfg)
-				if err != nil {
-					return errors.Wrap(err, "failed to parse plugin config")
-				}
-				feePipeline, err := pipeline.Parse(cfg.JuelsPerFeeCoinPipeline)
-				if err != nil {
-					return err
-				}
-				if err2 := o.AssertBridgesExist(*feePipeline); err2 != nil {
-					return err2
-				}
-			}
+	downstream := make([]uuid.UUID, len(jb.Triggers))
+	for i, t := range jb.Triggers {
+		var jobType Type
+		err := tx.Get(&jobType, `SELECT type FROM jobs WHERE external_job_id = $1`, t.DownstreamExternalJobID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrapf(ErrInvalidTrigger, "no job with external job ID %s", t.DownstreamExternalJobID)
+		} else if err != nil {
+			return errors.Wrap(err, "failed to find triggered job")
+		}
+		if jobType != Webhook {
+			return errors.Wrapf(ErrInvalidTrigger, "job %s is a %s job, only webhook jobs can be triggered", t.DownstreamExternalJobID, jobType)
+		}
+		downstream[i] = t.DownstreamExternalJobID
+	}
 
-			sql := `INSERT INTO ocr2_oracle_specs (contract_id, feed_id, relay, relay_config, plugin_type, plugin_config, p2pv2_bootstrappers, ocr_key_bundle_id, transmitter_id,
-					blockchain_timeout, contract_config_tracker_poll_interval, contract_config_confirmations,
-					created_at, updated_at)
-			VALUES (:contract_id, :feed_id, :relay, :relay_config, :plugin_type, :plugin_config, :p2pv2_bootstrappers, :ocr_key_bundle_id, :transmitter_id,
-					 :blockchain_timeout, :contract_config_tracker_poll_interval, :contract_config_confirmations,
-					NOW(), NOW())
-			RETURNING id;`
-			err = pg.PrepareQueryRowx(tx, sql, &specID, jb.OCR2OracleSpec)
-			if err != nil {
-				return errors.Wrap(err, "failed to create Offchainreporting2OracleSpec")
-			}
-			jb.OCR2OracleSpecID = &specID
-		case Keeper:
-			var specID int32
-			sql := `INSERT INTO keeper_specs (contract_address, from_address, evm_chain_id, created_at, updated_at)
-			VALUES (:contract_address, :from_address, :evm_chain_id, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.KeeperSpec); err != nil {
-				return errors.Wrap(err, "failed to create KeeperSpec")
-			}
-			jb.KeeperSpecID = &specID
-		case Cron:
-			var specID int32
-			sql := `INSERT INTO cron_specs (cron_schedule, created_at, updated_at)
-			VALUES (:cron_schedule, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.CronSpec); err != nil {
-				return errors.Wrap(err, "failed to create CronSpec")
-			}
-			jb.CronSpecID = &specID
-		case VRF:
-			var specID int32
-			sql := `INSERT INTO vrf_specs (
-				coordinator_address, public_key, min_incoming_confirmations,
-				evm_chain_id, from_addresses, poll_period, requested_confs_delay,
-				request_timeout, chunk_size, batch_coordinator_address, batch_fulfillment_enabled,
-				batch_fulfillment_gas_multiplier, backoff_initial_delay, backoff_max_delay, gas_lane_price,
-                vrf_owner_address,
+	var rows []struct {
+		Upstream   uuid.UUID
+		Downstream uuid.UUID
+	}
+	if err := tx.Select(&rows, `SELECT jobs.external_job_id AS upstream, job_triggers.downstream_external_job_id AS downstream
+	FROM job_triggers JOIN jobs ON jobs.id = job_triggers.job_id`); err != nil {
+		return errors.Wrap(err, "failed to load triggers")
+	}
+	edges := make(map[uuid.UUID][]uuid.UUID, len(rows))
+	for _, r := range rows {
+		edges[r.Upstream] = append(edges[r.Upstream], r.Downstream)
+	}
+	if loop := findTriggerLoop(jb.ExternalJobID, downstream, edges); loop != nil {
+		return errors.Wrapf(ErrInvalidTrigger, "jobs would trigger each other in a loop: %v", loop)
+	}
+
+	for i := range jb.Triggers {
+		t := &jb.Triggers[i]
+		t.JobID = jb.ID
+		err := tx.QueryRowx(`INSERT INTO job_triggers (job_id, downstream_external_job_id, condition) VALUES ($1, $2, $3) RETURNING id`,
+			t.JobID, t.DownstreamExternalJobID, t.Condition).Scan(&t.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert trigger")
+		}
+	}
+	return nil
+}
+
+func (o *orm) CreateTemplate(t *Template, qopts ...pg.QOpt) error {
+	stmt := `INSERT INTO job_templates (name, version, definition, parameters, created_at)
+	SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, NOW() FROM job_templates WHERE name = $1
+	RETURNING *`
+	err := o.q.WithOpts(qopts...).Get(t, stmt, t.Name, t.Definition, t.Parameters)
+	return errors.Wrap(err, "CreateTemplate failed")
+}
+
+func (o *orm) FindTemplates(qopts ...pg.QOpt) (templates []Template, err error) {
+	stmt := `SELECT DISTINCT ON (name) * FROM job_templates ORDER BY name ASC, version DESC`
+	err = o.q.WithOpts(qopts...).Select(&templates, stmt)
+	return templates, errors.Wrap(err, "FindTemplates failed")
+}
+
+func (o *orm) FindTemplate(name string, qopts ...pg.QOpt) (t Template, err error) {
+	stmt := `SELECT * FROM job_templates WHERE name = $1 ORDER BY version DESC LIMIT 1`
+	err = o.q.WithOpts(qopts...).Get(&t, stmt, name)
+	return t, errors.Wrap(err, "FindTemplate failed")
+}
+
+func (o *orm) FindTemplateJobs(name string, qopts ...pg.QOpt) (jobs []TemplateJob, err error) {
+	stmt := `SELECT jobs.id AS job_id, jobs.name AS job_name, job_templates.version AS template_version FROM jobs
+	JOIN job_templates ON job_templates.id = jobs.job_template_id
+	WHERE job_templates.name = $1
+	ORDER BY jobs.id ASC`
+	err = o.q.WithOpts(qopts...).Select(&jobs, stmt, name)
+	return jobs, errors.Wrap(err, "FindTemplateJobs failed")
+}
+
+// insertJobSpec inserts the type specific spec of jb, and sets its ID on jb.
+func (o *orm) insertJobSpec(tx pg.Queryer, jb *Job) error {
+	switch jb.Type {
+	case DirectRequest:
+		var specID int32
+		sql := `INSERT INTO direct_request_specs (contract_address, min_incoming_confirmations, requesters, min_contract_payment, evm_chain_id,
+				batch_fulfillment_enabled, batch_fulfillment_window, batch_fulfillment_max_size, batch_fulfillment_use_batch_method, created_at, updated_at)
+		VALUES (:contract_address, :min_incoming_confirmations, :requesters, :min_contract_payment, :evm_chain_id,
+				:batch_fulfillment_enabled, :batch_fulfillment_window, :batch_fulfillment_max_size, :batch_fulfillment_use_batch_method, now(), now())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.DirectRequestSpec); err != nil {
+			return errors.Wrap(err, "failed to create DirectRequestSpec")
+		}
+		jb.DirectRequestSpecID = &specID
+	case FluxMonitor:
+		var specID int32
+		sql := `INSERT INTO flux_monitor_specs (contract_address, threshold, absolute_threshold, poll_timer_period, poll_timer_disabled, idle_timer_period, idle_timer_disabled,
+				drumbeat_schedule, drumbeat_random_delay, drumbeat_enabled, min_payment, evm_chain_id, deviation_rule, created_at, updated_at)
+		VALUES (:contract_address, :threshold, :absolute_threshold, :poll_timer_period, :poll_timer_disabled, :idle_timer_period, :idle_timer_disabled,
+				:drumbeat_schedule, :drumbeat_random_delay, :drumbeat_enabled, :min_payment, :evm_chain_id, :deviation_rule, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.FluxMonitorSpec); err != nil {
+			return errors.Wrap(err, "failed to create FluxMonitorSpec")
+		}
+		jb.FluxMonitorSpecID = &specID
+	case OffchainReporting:
+		var specID int32
+
+		if err := o.validateOCROracleSpec(tx, jb); err != nil {
+			return err
+		}
+
+		sql := `INSERT INTO ocr_oracle_specs (contract_address, p2p_bootstrap_peers, p2pv2_bootstrappers, is_bootstrap_peer, encrypted_ocr_key_bundle_id, transmitter_address,
+				observation_timeout, blockchain_timeout, contract_config_tracker_subscribe_interval, contract_config_tracker_poll_interval, contract_config_confirmations, evm_chain_id,
+				created_at, updated_at, database_timeout, observation_grace_period, contract_transmitter_transmit_timeout)
+		VALUES (:contract_address, :p2p_bootstrap_peers, :p2pv2_bootstrappers, :is_bootstrap_peer, :encrypted_ocr_key_bundle_id, :transmitter_address,
+				:observation_timeout, :blockchain_timeout, :contract_config_tracker_subscribe_interval, :contract_config_tracker_poll_interval, :contract_config_confirmations, :evm_chain_id,
+				NOW(), NOW(), :database_timeout, :observation_grace_period, :contract_transmitter_transmit_timeout)
+		RETURNING id;`
+		err := pg.PrepareQueryRowx(tx, sql, &specID, jb.OCROracleSpec)
+		if err != nil {
+			return errors.Wrap(err, "failed to create OffchainreportingOracleSpec")
+		}
+		jb.OCROracleSpecID = &specID
+	case OffchainReporting2:
+		var specID int32
+
+		if err := o.validateOCR2OracleSpec(jb); err != nil {
+			return err
+		}
+
+		sql := `INSERT INTO ocr2_oracle_specs (contract_id, feed_id, relay, relay_config, plugin_type, plugin_config, p2pv2_bootstrappers, ocr_key_bundle_id, transmitter_id,
+				blockchain_timeout, contract_config_tracker_poll_interval, contract_config_confirmations,
 				created_at, updated_at)
-			VALUES (
-				:coordinator_address, :public_key, :min_incoming_confirmations,
-				:evm_chain_id, :from_addresses, :poll_period, :requested_confs_delay,
-				:request_timeout, :chunk_size, :batch_coordinator_address, :batch_fulfillment_enabled,
-				:batch_fulfillment_gas_multiplier, :backoff_initial_delay, :backoff_max_delay, :gas_lane_price,
-			    :vrf_owner_address,
+		VALUES (:contract_id, :feed_id, :relay, :relay_config, :plugin_type, :plugin_config, :p2pv2_bootstrappers, :ocr_key_bundle_id, :transmitter_id,
+				 :blockchain_timeout, :contract_config_tracker_poll_interval, :contract_config_confirmations,
 				NOW(), NOW())
-			RETURNING id;`
-
-			err := pg.PrepareQueryRowx(tx, sql, &specID, toVRFSpecRow(jb.VRFSpec))
-			var pqErr *pgconn.PgError
-			ok := errors.As(err, &pqErr)
-			if err != nil && ok && pqErr.Code == "23503" {
-				if pqErr.ConstraintName == "vrf_specs_public_key_fkey" {
-					return errors.Wrapf(ErrNoSuchPublicKey, "%s", jb.VRFSpec.PublicKey.String())
-				}
-			}
-			if err != nil {
-				return errors.Wrap(err, "failed to create VRFSpec")
-			}
-			jb.VRFSpecID = &specID
-		case Webhook:
-			err := o.InsertWebhookSpec(jb.WebhookSpec, pg.WithQueryer(tx))
-			if err != nil {
-				return errors.Wrap(err, "failed to create WebhookSpec")
+		RETURNING id;`
+		err := pg.PrepareQueryRowx(tx, sql, &specID, jb.OCR2OracleSpec)
+		if err != nil {
+			return errors.Wrap(err, "failed to create Offchainreporting2OracleSpec")
+		}
+		jb.OCR2OracleSpecID = &specID
+	case Keeper:
+		var specID int32
+		sql := `INSERT INTO keeper_specs (contract_address, from_address, evm_chain_id, created_at, updated_at)
+		VALUES (:contract_address, :from_address, :evm_chain_id, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.KeeperSpec); err != nil {
+			return errors.Wrap(err, "failed to create KeeperSpec")
+		}
+		jb.KeeperSpecID = &specID
+	case Cron:
+		var specID int32
+		sql := `INSERT INTO cron_specs (cron_schedule, time_zone, jitter, catch_up, created_at, updated_at)
+		VALUES (:cron_schedule, :time_zone, :jitter, :catch_up, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.CronSpec); err != nil {
+			return errors.Wrap(err, "failed to create CronSpec")
+		}
+		jb.CronSpecID = &specID
+	case VRF:
+		var specID int32
+		sql := `INSERT INTO vrf_specs (
+			coordinator_address, public_key, min_incoming_confirmations,
+			evm_chain_id, from_addresses, poll_period, requested_confs_delay,
+			request_timeout, chunk_size, batch_coordinator_address, batch_fulfillment_enabled,
+			batch_fulfillment_gas_multiplier, backoff_initial_delay, backoff_max_delay, gas_lane_price,
+                vrf_owner_address,
+			created_at, updated_at)
+		VALUES (
+			:coordinator_address, :public_key, :min_incoming_confirmations,
+			:evm_chain_id, :from_addresses, :poll_period, :requested_confs_delay,
+			:request_timeout, :chunk_size, :batch_coordinator_address, :batch_fulfillment_enabled,
+			:batch_fulfillment_gas_multiplier, :backoff_initial_delay, :backoff_max_delay, :gas_lane_price,
+		    :vrf_owner_address,
+			NOW(), NOW())
+		RETURNING id;`
+
+		err := pg.PrepareQueryRowx(tx, sql, &specID, toVRFSpecRow(jb.VRFSpec))
+		var pqErr *pgconn.PgError
+		ok := errors.As(err, &pqErr)
+		if err != nil && ok && pqErr.Code == "23503" {
+			if pqErr.ConstraintName == "vrf_specs_public_key_fkey" {
+				return errors.Wrapf(ErrNoSuchPublicKey, "%s", jb.VRFSpec.PublicKey.String())
 			}
-			jb.WebhookSpecID = &jb.WebhookSpec.ID
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to create VRFSpec")
+		}
+		jb.VRFSpecID = &specID
+	case Webhook:
+		err := o.InsertWebhookSpec(jb.WebhookSpec, pg.WithQueryer(tx))
+		if err != nil {
+			return errors.Wrap(err, "failed to create WebhookSpec")
+		}
+		jb.WebhookSpecID = &jb.WebhookSpec.ID
 
-			if len(jb.WebhookSpec.ExternalInitiatorWebhookSpecs) > 0 {
-				for i := range jb.WebhookSpec.ExternalInitiatorWebhookSpecs {
-					jb.WebhookSpec.ExternalInitiatorWebhookSpecs[i].WebhookSpecID = jb.WebhookSpec.ID
-				}
-				sql := `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec)
-			VALUES (:external_initiator_id, :webhook_spec_id, :spec);`
-				query, args, err := tx.BindNamed(sql, jb.WebhookSpec.ExternalInitiatorWebhookSpecs)
-				if err != nil {
-					return errors.Wrap(err, "failed to bindquery for ExternalInitiatorWebhookSpecs")
-				}
-				if _, err = tx.Exec(query, args...); err != nil {
-					return errors.Wrap(err, "failed to create ExternalInitiatorWebhookSpecs")
-				}
-			}
-		case BlockhashStore:
-			var specID int32
-			sql := `INSERT INTO blockhash_store_specs (coordinator_v1_address, coordinator_v2_address, coordinator_v2_plus_address, trusted_blockhash_store_address, trusted_blockhash_store_batch_size, wait_blocks, lookback_blocks, blockhash_store_address, poll_period, run_timeout, evm_chain_id, from_addresses, created_at, updated_at)
-			VALUES (:coordinator_v1_address, :coordinator_v2_address, :coordinator_v2_plus_address, :trusted_blockhash_store_address, :trusted_blockhash_store_batch_size, :wait_blocks, :lookback_blocks, :blockhash_store_address, :poll_period, :run_timeout, :evm_chain_id, :from_addresses, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, toBlockhashStoreSpecRow(jb.BlockhashStoreSpec)); err != nil {
-				return errors.Wrap(err, "failed to create BlockhashStore spec")
+		if len(jb.WebhookSpec.ExternalInitiatorWebhookSpecs) > 0 {
+			for i := range jb.WebhookSpec.ExternalInitiatorWebhookSpecs {
+				jb.WebhookSpec.ExternalInitiatorWebhookSpecs[i].WebhookSpecID = jb.WebhookSpec.ID
 			}
-			jb.BlockhashStoreSpecID = &specID
-		case BlockHeaderFeeder:
-			var specID int32
-			sql := `INSERT INTO block_header_feeder_specs (coordinator_v1_address, coordinator_v2_address, coordinator_v2_plus_address, wait_blocks, lookback_blocks, blockhash_store_address, batch_blockhash_store_address, poll_period, run_timeout, evm_chain_id, from_addresses, get_blockhashes_batch_size, store_blockhashes_batch_size, created_at, updated_at)
-			VALUES (:coordinator_v1_address, :coordinator_v2_address, :coordinator_v2_plus_address, :wait_blocks, :lookback_blocks, :blockhash_store_address, :batch_blockhash_store_address, :poll_period, :run_timeout, :evm_chain_id, :from_addresses,  :get_blockhashes_batch_size, :store_blockhashes_batch_size, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, toBlockHeaderFeederSpecRow(jb.BlockHeaderFeederSpec)); err != nil {
-				return errors.Wrap(err, "failed to create BlockHeaderFeeder spec")
+			sql := `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec)
+		VALUES (:external_initiator_id, :webhook_spec_id, :spec);`
+			query, args, err := tx.BindNamed(sql, jb.WebhookSpec.ExternalInitiatorWebhookSpecs)
+			if err != nil {
+				return errors.Wrap(err, "failed to bindquery for ExternalInitiatorWebhookSpecs")
 			}
-			jb.BlockHeaderFeederSpecID = &specID
-		case LegacyGasStationServer:
-			var specID int32
-			sql := `INSERT INTO legacy_gas_station_server_specs (forwarder_address, evm_chain_id, ccip_chain_selector, from_addresses, created_at, updated_at)
-			VALUES (:forwarder_address, :evm_chain_id, :ccip_chain_selector, :from_addresses, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, toLegacyGasStationServerSpecRow(jb.LegacyGasStationServerSpec)); err != nil {
-				return errors.Wrap(err, "failed to create LegacyGasStationServer spec")
+			if _, err = tx.Exec(query, args...); err != nil {
+				return errors.Wrap(err, "failed to create ExternalInitiatorWebhookSpecs")
 			}
-			jb.LegacyGasStationServerSpecID = &specID
-		case LegacyGasStationSidecar:
-			var specID int32
-			sql := `INSERT INTO legacy_gas_station_sidecar_specs (forwarder_address, off_ramp_address, lookback_blocks, poll_period, run_timeout, evm_chain_id, ccip_chain_selector, created_at, updated_at)
-			VALUES (:forwarder_address, :off_ramp_address, :lookback_blocks, :poll_period, :run_timeout, :evm_chain_id, :ccip_chain_selector, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.LegacyGasStationSidecarSpec); err != nil {
-				return errors.Wrap(err, "failed to create LegacyGasStationSidecar spec")
+		}
+	case BlockhashStore:
+		var specID int32
+		sql := `INSERT INTO blockhash_store_specs (coordinator_v1_address, coordinator_v2_address, coordinator_v2_plus_address, trusted_blockhash_store_address, trusted_blockhash_store_batch_size, wait_blocks, lookback_blocks, blockhash_store_address, poll_period, run_timeout, evm_chain_id, from_addresses, created_at, updated_at)
+		VALUES (:coordinator_v1_address, :coordinator_v2_address, :coordinator_v2_plus_address, :trusted_blockhash_store_address, :trusted_blockhash_store_batch_size, :wait_blocks, :lookback_blocks, :blockhash_store_address, :poll_period, :run_timeout, :evm_chain_id, :from_addresses, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, toBlockhashStoreSpecRow(jb.BlockhashStoreSpec)); err != nil {
+			return errors.Wrap(err, "failed to create BlockhashStore spec")
+		}
+		jb.BlockhashStoreSpecID = &specID
+	case BlockHeaderFeeder:
+		var specID int32
+		sql := `INSERT INTO block_header_feeder_specs (coordinator_v1_address, coordinator_v2_address, coordinator_v2_plus_address, wait_blocks, lookback_blocks, blockhash_store_address, batch_blockhash_store_address, poll_period, run_timeout, evm_chain_id, from_addresses, get_blockhashes_batch_size, store_blockhashes_batch_size, created_at, updated_at)
+		VALUES (:coordinator_v1_address, :coordinator_v2_address, :coordinator_v2_plus_address, :wait_blocks, :lookback_blocks, :blockhash_store_address, :batch_blockhash_store_address, :poll_period, :run_timeout, :evm_chain_id, :from_addresses,  :get_blockhashes_batch_size, :store_blockhashes_batch_size, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, toBlockHeaderFeederSpecRow(jb.BlockHeaderFeederSpec)); err != nil {
+			return errors.Wrap(err, "failed to create BlockHeaderFeeder spec")
+		}
+		jb.BlockHeaderFeederSpecID = &specID
+	case LegacyGasStationServer:
+		var specID int32
+		sql := `INSERT INTO legacy_gas_station_server_specs (forwarder_address, evm_chain_id, ccip_chain_selector, from_addresses, created_at, updated_at)
+		VALUES (:forwarder_address, :evm_chain_id, :ccip_chain_selector, :from_addresses, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, toLegacyGasStationServerSpecRow(jb.LegacyGasStationServerSpec)); err != nil {
+			return errors.Wrap(err, "failed to create LegacyGasStationServer spec")
+		}
+		jb.LegacyGasStationServerSpecID = &specID
+	case LegacyGasStationSidecar:
+		var specID int32
+		sql := `INSERT INTO legacy_gas_station_sidecar_specs (forwarder_address, off_ramp_address, lookback_blocks, poll_period, run_timeout, evm_chain_id, ccip_chain_selector, created_at, updated_at)
+		VALUES (:forwarder_address, :off_ramp_address, :lookback_blocks, :poll_period, :run_timeout, :evm_chain_id, :ccip_chain_selector, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.LegacyGasStationSidecarSpec); err != nil {
+			return errors.Wrap(err, "failed to create LegacyGasStationSidecar spec")
+		}
+		jb.LegacyGasStationSidecarSpecID = &specID
+	case Bootstrap:
+		var specID int32
+		sql := `INSERT INTO bootstrap_specs (contract_id, feed_id, relay, relay_config, monitoring_endpoint,
+				blockchain_timeout, contract_config_tracker_poll_interval,
+				contract_config_confirmations, created_at, updated_at)
+		VALUES (:contract_id, :feed_id, :relay, :relay_config, :monitoring_endpoint,
+				:blockchain_timeout, :contract_config_tracker_poll_interval,
+				:contract_config_confirmations, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.BootstrapSpec); err != nil {
+			return errors.Wrap(err, "failed to create BootstrapSpec for jobSpec")
+		}
+		jb.BootstrapSpecID = &specID
+	case Gateway:
+		var specID int32
+		sql := `INSERT INTO gateway_specs (gateway_config, created_at, updated_at)
+		VALUES (:gateway_config, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.GatewaySpec); err != nil {
+			return errors.Wrap(err, "failed to create GatewaySpec for jobSpec")
+		}
+		jb.GatewaySpecID = &specID
+	case EVMLog:
+		var specID int32
+		sql := `INSERT INTO evm_log_specs (evm_chain_id, contract_address, event_abi, topic1, topic2, topic3, min_confirmations, poll_period, created_at, updated_at)
+		VALUES (:evm_chain_id, :contract_address, :event_abi, :topic1, :topic2, :topic3, :min_confirmations, :poll_period, NOW(), NOW())
+		RETURNING id;`
+		if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.EVMLogSpec); err != nil {
+			return errors.Wrap(err, "failed to create EVMLogSpec for jobSpec")
+		}
+		jb.EVMLogSpecID = &specID
+	default:
+		o.lggr.Panicf("Unsupported jb.Type: %v", jb.Type)
+	}
+	return nil
+}
+
+// updateJobSpec updates the type specific spec of the existing job to the one of jb in place,
+// rather than replacing it, since state such as the contract configs of OCR2 jobs and the
+// registries of keeper jobs is deleted with it. It sets the spec ID on jb.
+func (o *orm) updateJobSpec(tx pg.Queryer, existing Job, jb *Job) error {
+	update := func(stmt string, arg interface{}, specID *int32, name string) error {
+		if specID == nil {
+			return errors.Errorf("job has no %s", name)
+		}
+		query, args, err := tx.BindNamed(stmt, arg)
+		if err != nil {
+			return errors.Wrapf(err, "failed to bind %s", name)
+		}
+		_, err = tx.Exec(query, args...)
+		return errors.Wrapf(err, "failed to update %s", name)
+	}
+
+	switch jb.Type {
+	case DirectRequest:
+		jb.DirectRequestSpecID, jb.DirectRequestSpec.ID = existing.DirectRequestSpecID, derefSpecID(existing.DirectRequestSpecID)
+		return update(`UPDATE direct_request_specs SET contract_address = :contract_address, min_incoming_confirmations = :min_incoming_confirmations,
+				requesters = :requesters, min_contract_payment = :min_contract_payment, evm_chain_id = :evm_chain_id, batch_fulfillment_enabled = :batch_fulfillment_enabled,
+				batch_fulfillment_window = :batch_fulfillment_window, batch_fulfillment_max_size = :batch_fulfillment_max_size,
+				batch_fulfillment_use_batch_method = :batch_fulfillment_use_batch_method, updated_at = NOW()
+		WHERE id = :id`, jb.DirectRequestSpec, jb.DirectRequestSpecID, "DirectRequestSpec")
+	case FluxMonitor:
+		jb.FluxMonitorSpecID, jb.FluxMonitorSpec.ID = existing.FluxMonitorSpecID, derefSpecID(existing.FluxMonitorSpecID)
+		return update(`UPDATE flux_monitor_specs SET contract_address = :contract_address, threshold = :threshold, absolute_threshold = :absolute_threshold,
+				poll_timer_period = :poll_timer_period, poll_timer_disabled = :poll_timer_disabled, idle_timer_period = :idle_timer_period, idle_timer_disabled = :idle_timer_disabled,
+				drumbeat_schedule = :drumbeat_schedule, drumbeat_random_delay = :drumbeat_random_delay, drumbeat_enabled = :drumbeat_enabled, min_payment = :min_payment,
+				evm_chain_id = :evm_chain_id, deviation_rule = :deviation_rule, updated_at = NOW()
+		WHERE id = :id`, jb.FluxMonitorSpec, jb.FluxMonitorSpecID, "FluxMonitorSpec")
+	case OffchainReporting:
+		jb.OCROracleSpecID, jb.OCROracleSpec.ID = existing.OCROracleSpecID, derefSpecID(existing.OCROracleSpecID)
+		if err := o.validateOCROracleSpec(tx, jb); err != nil {
+			return err
+		}
+		return update(`UPDATE ocr_oracle_specs SET contract_address = :contract_address, p2p_bootstrap_peers = :p2p_bootstrap_peers, p2pv2_bootstrappers = :p2pv2_bootstrappers,
+				is_bootstrap_peer = :is_bootstrap_peer, encrypted_ocr_key_bundle_id = :encrypted_ocr_key_bundle_id, transmitter_address = :transmitter_address,
+				observation_timeout = :observation_timeout, blockchain_timeout = :blockchain_timeout, contract_config_tracker_subscribe_interval = :contract_config_tracker_subscribe_interval,
+				contract_config_tracker_poll_interval = :contract_config_tracker_poll_interval, contract_config_confirmations = :contract_config_confirmations, evm_chain_id = :evm_chain_id,
+				database_timeout = :database_timeout, observation_grace_period = :observation_grace_period, contract_transmitter_transmit_timeout = :contract_transmitter_transmit_timeout,
+				updated_at = NOW()
+		WHERE id = :id`, jb.OCROracleSpec, jb.OCROracleSpecID, "OffchainreportingOracleSpec")
+	case OffchainReporting2:
+		jb.OCR2OracleSpecID, jb.OCR2OracleSpec.ID = existing.OCR2OracleSpecID, derefSpecID(existing.OCR2OracleSpecID)
+		if err := o.validateOCR2OracleSpec(jb); err != nil {
+			return err
+		}
+		return update(`UPDATE ocr2_oracle_specs SET contract_id = :contract_id, feed_id = :feed_id, relay = :relay, relay_config = :relay_config, plugin_type = :plugin_type,
+				plugin_config = :plugin_config, p2pv2_bootstrappers = :p2pv2_bootstrappers, ocr_key_bundle_id = :ocr_key_bundle_id, transmitter_id = :transmitter_id,
+				blockchain_timeout = :blockchain_timeout, contract_config_tracker_poll_interval = :contract_config_tracker_poll_interval,
+				contract_config_confirmations = :contract_config_confirmations, updated_at = NOW()
+		WHERE id = :id`, jb.OCR2OracleSpec, jb.OCR2OracleSpecID, "Offchainreporting2OracleSpec")
+	case Keeper:
+		jb.KeeperSpecID, jb.KeeperSpec.ID = existing.KeeperSpecID, derefSpecID(existing.KeeperSpecID)
+		return update(`UPDATE keeper_specs SET contract_address = :contract_address, from_address = :from_address, evm_chain_id = :evm_chain_id, updated_at = NOW()
+		WHERE id = :id`, jb.KeeperSpec, jb.KeeperSpecID, "KeeperSpec")
+	case Cron:
+		jb.CronSpecID, jb.CronSpec.ID = existing.CronSpecID, derefSpecID(existing.CronSpecID)
+		return update(`UPDATE cron_specs SET cron_schedule = :cron_schedule, time_zone = :time_zone, jitter = :jitter, catch_up = :catch_up, updated_at = NOW()
+		WHERE id = :id`, jb.CronSpec, jb.CronSpecID, "CronSpec")
+	case VRF:
+		jb.VRFSpecID, jb.VRFSpec.ID = existing.VRFSpecID, derefSpecID(existing.VRFSpecID)
+		err := update(`UPDATE vrf_specs SET coordinator_address = :coordinator_address, public_key = :public_key, min_incoming_confirmations = :min_incoming_confirmations,
+				evm_chain_id = :evm_chain_id, from_addresses = :from_addresses, poll_period = :poll_period, requested_confs_delay = :requested_confs_delay,
+				request_timeout = :request_timeout, chunk_size = :chunk_size, batch_coordinator_address = :batch_coordinator_address, batch_fulfillment_enabled = :batch_fulfillment_enabled,
+				batch_fulfillment_gas_multiplier = :batch_fulfillment_gas_multiplier, backoff_initial_delay = :backoff_initial_delay, backoff_max_delay = :backoff_max_delay,
+				gas_lane_price = :gas_lane_price, vrf_owner_address = :vrf_owner_address, updated_at = NOW()
+		WHERE id = :id`, toVRFSpecRow(jb.VRFSpec), jb.VRFSpecID, "VRFSpec")
+		var pqErr *pgconn.PgError
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.ConstraintName == "vrf_specs_public_key_fkey" {
+			return errors.Wrapf(ErrNoSuchPublicKey, "%s", jb.VRFSpec.PublicKey.String())
+		}
+		return err
+	case Webhook:
+		if existing.WebhookSpecID == nil {
+			return errors.New("job has no WebhookSpec")
+		}
+		jb.WebhookSpecID, jb.WebhookSpec.ID = existing.WebhookSpecID, *existing.WebhookSpecID
+		// webhook specs only hold the external initiators, which are replaced
+		if _, err := tx.Exec(`DELETE FROM external_initiator_webhook_specs WHERE webhook_spec_id = $1`, jb.WebhookSpec.ID); err != nil {
+			return errors.Wrap(err, "failed to delete previous ExternalInitiatorWebhookSpecs")
+		}
+		if len(jb.WebhookSpec.ExternalInitiatorWebhookSpecs) > 0 {
+			for i := range jb.WebhookSpec.ExternalInitiatorWebhookSpecs {
+				jb.WebhookSpec.ExternalInitiatorWebhookSpecs[i].WebhookSpecID = jb.WebhookSpec.ID
 			}
-			jb.LegacyGasStationSidecarSpecID = &specID
-		case Bootstrap:
-			var specID int32
-			sql := `INSERT INTO bootstrap_specs (contract_id, feed_id, relay, relay_config, monitoring_endpoint,
-					blockchain_timeout, contract_config_tracker_poll_interval,
-					contract_config_confirmations, created_at, updated_at)
-			VALUES (:contract_id, :feed_id, :relay, :relay_config, :monitoring_endpoint,
-					:blockchain_timeout, :contract_config_tracker_poll_interval,
-					:contract_config_confirmations, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.BootstrapSpec); err != nil {
-				return errors.Wrap(err, "failed to create BootstrapSpec for jobSpec")
+			sql := `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec)
+		VALUES (:external_initiator_id, :webhook_spec_id, :spec);`
+			query, args, err := tx.BindNamed(sql, jb.WebhookSpec.ExternalInitiatorWebhookSpecs)
+			if err != nil {
+				return errors.Wrap(err, "failed to bindquery for ExternalInitiatorWebhookSpecs")
 			}
-			jb.BootstrapSpecID = &specID
-		case Gateway:
-			var specID int32
-			sql := `INSERT INTO gateway_specs (gateway_config, created_at, updated_at)
-			VALUES (:gateway_config, NOW(), NOW())
-			RETURNING id;`
-			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.GatewaySpec); err != nil {
-				return errors.Wrap(err, "failed to create GatewaySpec for jobSpec")
+			if _, err = tx.Exec(query, args...); err != nil {
+				return errors.Wrap(err, "failed to create ExternalInitiatorWebhookSpecs")
 			}
-			jb.GatewaySpecID = &specID
-		default:
-			o.lggr.Panicf("Unsupported jb.Type: %v", jb.Type)
 		}
+		_, err := tx.Exec(`UPDATE webhook_specs SET updated_at = NOW() WHERE id = $1`, jb.WebhookSpec.ID)
+		return errors.Wrap(err, "failed to update WebhookSpec")
+	case BlockhashStore:
+		jb.BlockhashStoreSpecID, jb.BlockhashStoreSpec.ID = existing.BlockhashStoreSpecID, derefSpecID(existing.BlockhashStoreSpecID)
+		return update(`UPDATE blockhash_store_specs SET coordinator_v1_address = :coordinator_v1_address, coordinator_v2_address = :coordinator_v2_address,
+				coordinator_v2_plus_address = :coordinator_v2_plus_address, trusted_blockhash_store_address = :trusted_blockhash_store_address,
+				trusted_blockhash_store_batch_size = :trusted_blockhash_store_batch_size, wait_blocks = :wait_blocks, lookback_blocks = :lookback_blocks,
+				blockhash_store_address = :blockhash_store_address, poll_period = :poll_period, run_timeout = :run_timeout, evm_chain_id = :evm_chain_id,
+				from_addresses = :from_addresses, updated_at = NOW()
+		WHERE id = :id`, toBlockhashStoreSpecRow(jb.BlockhashStoreSpec), jb.BlockhashStoreSpecID, "BlockhashStore spec")
+	case BlockHeaderFeeder:
+		jb.BlockHeaderFeederSpecID, jb.BlockHeaderFeederSpec.ID = existing.BlockHeaderFeederSpecID, derefSpecID(existing.BlockHeaderFeederSpecID)
+		return update(`UPDATE block_header_feeder_specs SET coordinator_v1_address = :coordinator_v1_address, coordinator_v2_address = :coordinator_v2_address,
+				coordinator_v2_plus_address = :coordinator_v2_plus_address, wait_blocks = :wait_blocks, lookback_blocks = :lookback_blocks,
+				blockhash_store_address = :blockhash_store_address, batch_blockhash_store_address = :batch_blockhash_store_address, poll_period = :poll_period,
+				run_timeout = :run_timeout, evm_chain_id = :evm_chain_id, from_addresses = :from_addresses, get_blockhashes_batch_size = :get_blockhashes_batch_size,
+				store_blockhashes_batch_size = :store_blockhashes_batch_size, updated_at = NOW()
+		WHERE id = :id`, toBlockHeaderFeederSpecRow(jb.BlockHeaderFeederSpec), jb.BlockHeaderFeederSpecID, "BlockHeaderFeeder spec")
+	case LegacyGasStationServer:
+		jb.LegacyGasStationServerSpecID, jb.LegacyGasStationServerSpec.ID = existing.LegacyGasStationServerSpecID, derefSpecID(existing.LegacyGasStationServerSpecID)
+		return update(`UPDATE legacy_gas_station_server_specs SET forwarder_address = :forwarder_address, evm_chain_id = :evm_chain_id,
+				ccip_chain_selector = :ccip_chain_selector, from_addresses = :from_addresses, updated_at = NOW()
+		WHERE id = :id`, toLegacyGasStationServerSpecRow(jb.LegacyGasStationServerSpec), jb.LegacyGasStationServerSpecID, "LegacyGasStationServer spec")
+	case LegacyGasStationSidecar:
+		jb.LegacyGasStationSidecarSpecID, jb.LegacyGasStationSidecarSpec.ID = existing.LegacyGasStationSidecarSpecID, derefSpecID(existing.LegacyGasStationSidecarSpecID)
+		return update(`UPDATE legacy_gas_station_sidecar_specs SET forwarder_address = :forwarder_address, off_ramp_address = :off_ramp_address,
+				lookback_blocks = :lookback_blocks, poll_period = :poll_period, run_timeout = :run_timeout, evm_chain_id = :evm_chain_id,
+				ccip_chain_selector = :ccip_chain_selector, updated_at = NOW()
+		WHERE id = :id`, jb.LegacyGasStationSidecarSpec, jb.LegacyGasStationSidecarSpecID, "LegacyGasStationSidecar spec")
+	case Bootstrap:
+		jb.BootstrapSpecID, jb.BootstrapSpec.ID = existing.BootstrapSpecID, derefSpecID(existing.BootstrapSpecID)
+		return update(`UPDATE bootstrap_specs SET contract_id = :contract_id, feed_id = :feed_id, relay = :relay, relay_config = :relay_config,
+				monitoring_endpoint = :monitoring_endpoint, blockchain_timeout = :blockchain_timeout,
+				contract_config_tracker_poll_interval = :contract_config_tracker_poll_interval, contract_config_confirmations = :contract_config_confirmations,
+				updated_at = NOW()
+		WHERE id = :id`, jb.BootstrapSpec, jb.BootstrapSpecID, "BootstrapSpec")
+	case Gateway:
+		jb.GatewaySpecID, jb.GatewaySpec.ID = existing.GatewaySpecID, derefSpecID(existing.GatewaySpecID)
+		return update(`UPDATE gateway_specs SET gateway_config = :gateway_config, updated_at = NOW()
+		WHERE id = :id`, jb.GatewaySpec, jb.GatewaySpecID, "GatewaySpec")
+	case EVMLog:
+		jb.EVMLogSpecID, jb.EVMLogSpec.ID = existing.EVMLogSpecID, derefSpecID(existing.EVMLogSpecID)
+		return update(`UPDATE evm_log_specs SET evm_chain_id = :evm_chain_id, contract_address = :contract_address, event_abi = :event_abi,
+				topic1 = :topic1, topic2 = :topic2, topic3 = :topic3, min_confirmations = :min_confirmations, poll_period = :poll_period, updated_at = NOW()
+		WHERE id = :id`, jb.EVMLogSpec, jb.EVMLogSpecID, "EVMLogSpec")
+	default:
+		o.lggr.Panicf("Unsupported jb.Type: %v", jb.Type)
+	}
+	return nil
+}
 
-		pipelineSpecID, err := o.pipelineORM.CreateSpec(p, jb.MaxTaskDuration, pg.WithQueryer(tx))
+func derefSpecID(id *int32) int32 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+// validateOCROracleSpec checks the keys and the contract of the OCR spec of jb, and defaults its
+// chain ID.
+func (o *orm) validateOCROracleSpec(tx pg.Queryer, jb *Job) error {
+	if jb.OCROracleSpec.EncryptedOCRKeyBundleID != nil {
+		_, err := o.keyStore.OCR().Get(jb.OCROracleSpec.EncryptedOCRKeyBundleID.String())
 		if err != nil {
-			return errors.Wrap(err, "failed to create pipeline spec")
+			return errors.Wrapf(ErrNoSuchKeyBundle, "no key bundle with id: %x", jb.OCROracleSpec.EncryptedOCRKeyBundleID)
 		}
+	}
+	if jb.OCROracleSpec.TransmitterAddress != nil {
+		_, err := o.keyStore.Eth().Get(jb.OCROracleSpec.TransmitterAddress.Hex())
+		if err != nil {
+			return errors.Wrapf(ErrNoSuchTransmitterKey, "no key matching transmitter address: %s", jb.OCROracleSpec.TransmitterAddress.Hex())
+		}
+	}
 
-		jb.PipelineSpecID = pipelineSpecID
+	if jb.OCROracleSpec.EVMChainID == nil {
+		// If unspecified, assume we're creating a job intended to run on default chain id
+		newChain, err := o.legacyChains.Default()
+		if err != nil {
+			return err
+		}
+		jb.OCROracleSpec.EVMChainID = utils.NewBig(newChain.ID())
+	}
+	newChainID := jb.OCROracleSpec.EVMChainID
 
-		err = o.InsertJob(jb, pg.WithQueryer(tx))
-		jobID = jb.ID
-		return errors.Wrap(err, "failed to insert job")
-	})
+	// the spec of the job itself is excluded, so that it can be updated
+	existingSpec := new(OCROracleSpec)
+	err := tx.Get(existingSpec, `SELECT * FROM ocr_oracle_specs WHERE contract_address = $1 and (evm_chain_id = $2 or evm_chain_id IS NULL) AND id <> $3 LIMIT 1;`,
+		jb.OCROracleSpec.ContractAddress, newChainID, jb.OCROracleSpec.ID,
+	)
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		if err != nil {
+			return errors.Wrap(err, "failed to validate OffchainreportingOracleSpec on creation")
+		}
+
+		return errors.Errorf("a job with contract address %s already exists for chain ID %s", jb.OCROracleSpec.ContractAddress, newChainID)
+	}
+	return nil
+}
+
+// validateOCR2OracleSpec checks the keys and the plugin of the OCR2 spec of jb.
+func (o *orm) validateOCR2OracleSpec(jb *Job) error {
+	if jb.OCR2OracleSpec.OCRKeyBundleID.Valid {
+		_, err := o.keyStore.OCR2().Get(jb.OCR2OracleSpec.OCRKeyBundleID.String)
+		if err != nil {
+			return errors.Wrapf(ErrNoSuchKeyBundle, "no key bundle with id: %q", jb.OCR2OracleSpec.OCRKeyBundleID.ValueOrZero())
+		}
+	}
+
+	if jb.OCR2OracleSpec.RelayConfig["sendingKeys"] != nil && jb.OCR2OracleSpec.TransmitterID.Valid {
+		return errors.New("sending keys and transmitter ID can't both be defined")
+	}
+
+	// checks if they are present and if they are valid
+	sendingKeysDefined, err := areSendingKeysDefined(jb, o.keyStore)
 	if err != nil {
-		return errors.Wrap(err, "CreateJobFailed")
+		return err
 	}
 
-	return o.findJob(jb, "id", jobID, qopts...)
+	if !sendingKeysDefined && !jb.OCR2OracleSpec.TransmitterID.Valid {
+		return errors.New("neither sending keys nor transmitter ID is defined")
+	}
+
+	if !sendingKeysDefined {
+		if err = ValidateKeyStoreMatch(jb.OCR2OracleSpec, o.keyStore, jb.OCR2OracleSpec.TransmitterID.String); err != nil {
+			return errors.Wrap(ErrNoSuchTransmitterKey, err.Error())
+		}
+	}
+
+	if jb.ForwardingAllowed && !slices.Contains(ForwardersSupportedPlugins, jb.OCR2OracleSpec.PluginType) {
+		return errors.Errorf("forwarding is not currently supported for %s jobs", jb.OCR2OracleSpec.PluginType)
+	}
+
+	if jb.OCR2OracleSpec.PluginType == Mercury {
+		if jb.OCR2OracleSpec.FeedID == nil {
+			return errors.New("feed ID is required for mercury plugin type")
+		}
+	} else {
+		if jb.OCR2OracleSpec.FeedID != nil {
+			return errors.New("feed ID is not currently supported for non-mercury jobs")
+		}
+	}
+
+	if jb.OCR2OracleSpec.PluginType == Median {
+		var cfg medianconfig.PluginConfig
+		err = json.Unmarshal(jb.OCR2OracleSpec.PluginConfig.Bytes(), &cfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse plugin config")
+		}
+		feePipeline, err := pipeline.Parse(cfg.JuelsPerFeeCoinPipeline)
+		if err != nil {
+			return err
+		}
+		if err2 := o.AssertBridgesExist(*feePipeline); err2 != nil {
+			return err2
+		}
+	}
+	return nil
 }
 
 // ValidateKeyStoreMatch confirms that the key has a valid match in the keystore
@@ -520,19 +954,19 @@ func (o *orm) InsertJob(job *Job, qopts ...pg.QOpt) error {
 	// if job has id, emplace otherwise insert with a new id.
 	if job.ID == 0 {
 		query = `INSERT INTO jobs (pipeline_spec_id, name, schema_version, type, max_task_duration, ocr_oracle_spec_id, ocr2_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id,
-				keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, gateway_spec_id, 
-                legacy_gas_station_server_spec_id, legacy_gas_station_sidecar_spec_id, external_job_id, gas_limit, forwarding_allowed, created_at)
+				keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, gateway_spec_id, evm_log_spec_id,
+                legacy_gas_station_server_spec_id, legacy_gas_station_sidecar_spec_id, external_job_id, gas_limit, forwarding_allowed, paused, job_template_id, created_at)
 		VALUES (:pipeline_spec_id, :name, :schema_version, :type, :max_task_duration, :ocr_oracle_spec_id, :ocr2_oracle_spec_id, :direct_request_spec_id, :flux_monitor_spec_id,
-				:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :gateway_spec_id, 
-		        :legacy_gas_station_server_spec_id, :legacy_gas_station_sidecar_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, NOW())
+				:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :gateway_spec_id, :evm_log_spec_id,
+		        :legacy_gas_station_server_spec_id, :legacy_gas_station_sidecar_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, :paused, :job_template_id, NOW())
 		RETURNING *;`
 	} else {
 		query = `INSERT INTO jobs (id, pipeline_spec_id, name, schema_version, type, max_task_duration, ocr_oracle_spec_id, ocr2_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id,
-			keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, gateway_spec_id, 
-                  legacy_gas_station_server_spec_id, legacy_gas_station_sidecar_spec_id, external_job_id, gas_limit, forwarding_allowed, created_at)
+			keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, gateway_spec_id, evm_log_spec_id,
+                  legacy_gas_station_server_spec_id, legacy_gas_station_sidecar_spec_id, external_job_id, gas_limit, forwarding_allowed, paused, job_template_id, created_at)
 		VALUES (:id, :pipeline_spec_id, :name, :schema_version, :type, :max_task_duration, :ocr_oracle_spec_id, :ocr2_oracle_spec_id, :direct_request_spec_id, :flux_monitor_spec_id,
-				:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :gateway_spec_id, 
-				:legacy_gas_station_server_spec_id, :legacy_gas_station_sidecar_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, NOW())
+				:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :gateway_spec_id, :evm_log_spec_id,
+				:legacy_gas_station_server_spec_id, :legacy_gas_station_sidecar_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, :paused, :job_template_id, NOW())
 		RETURNING *;`
 	}
 	return q.GetNamed(query, job, job)
@@ -561,7 +995,10 @@ func (o *orm) DeleteJob(id int32, qopts ...pg.QOpt) error {
 				blockhash_store_spec_id,
 				bootstrap_spec_id,
 				block_header_feeder_spec_id,
-				gateway_spec_id
+				gateway_spec_id,
+				evm_log_spec_id,
+				shadow_pipeline_spec_id,
+				external_job_id
 		),
 		deleted_oracle_specs AS (
 			DELETE FROM ocr_oracle_specs WHERE id IN (SELECT ocr_oracle_spec_id FROM deleted_jobs)
@@ -598,6 +1035,18 @@ func (o *orm) DeleteJob(id int32, qopts ...pg.QOpt) error {
 		),
 		deleted_gateway_specs AS (
 			DELETE FROM gateway_specs WHERE id IN (SELECT gateway_spec_id FROM deleted_jobs)
+		),
+		deleted_evm_log_specs AS (
+			DELETE FROM evm_log_specs WHERE id IN (SELECT evm_log_spec_id FROM deleted_jobs)
+		),
+		deleted_shadow_pipeline_specs AS (
+			DELETE FROM pipeline_specs WHERE id IN (SELECT shadow_pipeline_spec_id FROM deleted_jobs)
+		),
+		deleted_webhook_hmac_secrets AS (
+			DELETE FROM webhook_hmac_secrets WHERE external_job_id IN (SELECT external_job_id FROM deleted_jobs)
+		),
+		deleted_direct_request_fulfillments AS (
+			DELETE FROM direct_request_fulfillments WHERE external_job_id IN (SELECT external_job_id FROM deleted_jobs)
 		)
 		DELETE FROM pipeline_specs WHERE id IN (SELECT pipeline_spec_id FROM deleted_jobs)`
 	res, cancel, err := q.ExecQIter(query, id)
@@ -616,6 +1065,73 @@ func (o *orm) DeleteJob(id int32, qopts ...pg.QOpt) error {
 	return nil
 }
 
+func (o *orm) SetPaused(id int32, paused bool, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	res, cancel, err := q.ExecQIter(`UPDATE jobs SET paused = $2 WHERE id = $1`, id, paused)
+	defer cancel()
+	if err != nil {
+		return errors.Wrap(err, "SetPaused failed to update job")
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "SetPaused failed getting RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	o.lggr.Debugw("Set job paused", "jobID", id, "paused", paused)
+	return nil
+}
+
+func (o *orm) SetShadowPipeline(id int32, p pipeline.Pipeline, qopts ...pg.QOpt) error {
+	if err := o.AssertBridgesExist(p); err != nil {
+		return err
+	}
+	q := o.q.WithOpts(qopts...)
+	err := q.Transaction(func(tx pg.Queryer) error {
+		if err := o.assertSubpipelinesExist(tx, p); err != nil {
+			return err
+		}
+		var existing Job
+		if err := tx.Get(&existing, `SELECT * FROM jobs WHERE id = $1 FOR UPDATE`, id); err != nil {
+			return errors.Wrap(err, "failed to find job")
+		}
+		specID, err := o.pipelineORM.CreateSpec(p, existing.MaxTaskDuration, pg.WithQueryer(tx))
+		if err != nil {
+			return errors.Wrap(err, "failed to create shadow pipeline spec")
+		}
+		if _, err = tx.Exec(`UPDATE jobs SET shadow_pipeline_spec_id = $2 WHERE id = $1`, id, specID); err != nil {
+			return errors.Wrap(err, "failed to update job")
+		}
+		return deleteShadowPipelineSpec(tx, existing.ShadowPipelineSpecID)
+	})
+	return errors.Wrap(err, "SetShadowPipeline failed")
+}
+
+func (o *orm) DeleteShadowPipeline(id int32, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	err := q.Transaction(func(tx pg.Queryer) error {
+		var existing Job
+		if err := tx.Get(&existing, `SELECT * FROM jobs WHERE id = $1 FOR UPDATE`, id); err != nil {
+			return errors.Wrap(err, "failed to find job")
+		}
+		if _, err := tx.Exec(`UPDATE jobs SET shadow_pipeline_spec_id = NULL WHERE id = $1`, id); err != nil {
+			return errors.Wrap(err, "failed to update job")
+		}
+		return deleteShadowPipelineSpec(tx, existing.ShadowPipelineSpecID)
+	})
+	return errors.Wrap(err, "DeleteShadowPipeline failed")
+}
+
+// deleteShadowPipelineSpec deletes a shadow pipeline spec, its shadow runs are deleted with it.
+func deleteShadowPipelineSpec(tx pg.Queryer, id *int32) error {
+	if id == nil {
+		return nil
+	}
+	_, err := tx.Exec(`DELETE FROM pipeline_specs WHERE id = $1`, *id)
+	return errors.Wrap(err, "failed to delete previous shadow pipeline spec")
+}
+
 func (o *orm) RecordError(jobID int32, description string, qopts ...pg.QOpt) error {
 	q := o.q.WithOpts(qopts...)
 	sql := `INSERT INTO job_spec_errors (job_id, description, occurrences, created_at, updated_at)
@@ -683,6 +1199,10 @@ func (o *orm) FindJobs(offset, limit int) (jobs []Job, count int, err error) {
 		if err != nil {
 			return err
 		}
+		err = loadJobsTriggers(tx, jobs)
+		if err != nil {
+			return err
+		}
 		for i := range jobs {
 			err = multierr.Combine(err, o.LoadEnvConfigVars(&jobs[i]))
 		}
@@ -934,6 +1454,9 @@ func (o *orm) findJob(jb *Job, col string, arg interface{}, qopts ...pg.QOpt) er
 		if err = LoadAllJobTypes(tx, jb); err != nil {
 			return err
 		}
+		if err = loadJobTriggers(tx, jb); err != nil {
+			return err
+		}
 
 		return loadJobSpecErrors(tx, jb)
 	})
@@ -1280,6 +1803,7 @@ func LoadAllJobsTypes(tx pg.Queryer, jobs []Job) error {
 func LoadAllJobTypes(tx pg.Queryer, job *Job) error {
 	return multierr.Combine(
 		loadJobType(tx, job, "PipelineSpec", "pipeline_specs", &job.PipelineSpecID),
+		loadJobType(tx, job, "ShadowPipelineSpec", "pipeline_specs", job.ShadowPipelineSpecID),
 		loadJobType(tx, job, "FluxMonitorSpec", "flux_monitor_specs", job.FluxMonitorSpecID),
 		loadJobType(tx, job, "DirectRequestSpec", "direct_request_specs", job.DirectRequestSpecID),
 		loadJobType(tx, job, "OCROracleSpec", "ocr_oracle_specs", job.OCROracleSpecID),
@@ -1294,6 +1818,7 @@ func LoadAllJobTypes(tx pg.Queryer, job *Job) error {
 		loadJobType(tx, job, "LegacyGasStationSidecarSpec", "legacy_gas_station_sidecar_specs", job.LegacyGasStationSidecarSpecID),
 		loadJobType(tx, job, "BootstrapSpec", "bootstrap_specs", job.BootstrapSpecID),
 		loadJobType(tx, job, "GatewaySpec", "gateway_specs", job.GatewaySpecID),
+		loadJobType(tx, job, "EVMLogSpec", "evm_log_specs", job.EVMLogSpecID),
 	)
 }
 
@@ -1475,6 +2000,28 @@ func (r legacyGasStationServerSpecRow) toLegacyGasStationServerSpec() *LegacyGas
 	return r.LegacyGasStationServerSpec
 }
 
+func loadJobTriggers(tx pg.Queryer, jb *Job) error {
+	return errors.Wrapf(tx.Select(&jb.Triggers, `SELECT * FROM job_triggers WHERE job_id = $1 ORDER BY id ASC`, jb.ID), "failed to load triggers for job %d", jb.ID)
+}
+
+func loadJobsTriggers(tx pg.Queryer, jobs []Job) error {
+	ids := make([]int32, len(jobs))
+	idx := make(map[int32]int, len(jobs))
+	for i, jb := range jobs {
+		ids[i] = jb.ID
+		idx[jb.ID] = i
+	}
+	var triggers []Trigger
+	if err := tx.Select(&triggers, `SELECT * FROM job_triggers WHERE job_id = ANY($1) ORDER BY id ASC`, pq.Array(ids)); err != nil {
+		return errors.Wrap(err, "failed to load triggers")
+	}
+	for _, t := range triggers {
+		i := idx[t.JobID]
+		jobs[i].Triggers = append(jobs[i].Triggers, t)
+	}
+	return nil
+}
+
 func loadJobSpecErrors(tx pg.Queryer, jb *Job) error {
 	return errors.Wrapf(tx.Select(&jb.JobSpecErrors, `SELECT * FROM job_spec_errors WHERE job_id = $1`, jb.ID), "failed to load job spec errors for job %d", jb.ID)
 }
@@ -86,7 +86,7 @@ func TestRunner(t *testing.T) {
 	require.NoError(t, err)
 	c := clhttptest.NewTestLocalOnlyHTTPClient()
 
-	runner := pipeline.NewRunner(pipelineORM, btORM, config.JobPipeline(), config.WebServer(), legacyChains, nil, nil, logger.TestLogger(t), c, c)
+	runner := pipeline.NewRunner(pipelineORM, btORM, config.JobPipeline(), config.WebServer(), legacyChains, nil, nil, nil, logger.TestLogger(t), c, c)
 	jobORM := NewTestORM(t, db, legacyChains, pipelineORM, btORM, keyStore, config.Database())
 
 	_, placeHolderAddress := cltest.MustInsertRandomKey(t, keyStore.Eth())
@@ -13,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
@@ -30,8 +31,25 @@ type (
 		CreateJob(jb *Job, qopts ...pg.QOpt) (err error)
 		// DeleteJob deletes a job and stops any active services.
 		DeleteJob(jobID int32, qopts ...pg.QOpt) error
+		// PauseJob marks a job as paused and stops its services, keeping the spec, runs and keys.
+		// Pausing a paused job is a no-op.
+		PauseJob(jobID int32, qopts ...pg.QOpt) error
+		// ResumeJob clears the paused mark of a job and starts its services.
+		// Resuming a job that is not paused is a no-op.
+		ResumeJob(jobID int32, qopts ...pg.QOpt) error
+		// UpdateJob replaces the spec of the job with ID jb.ID, keeping its ID, external job ID
+		// and pipeline runs, and restarts its services unless the job is paused.
+		UpdateJob(jb *Job, qopts ...pg.QOpt) error
+		// SetShadow makes p the shadow pipeline of a job, which is run with the inputs of every
+		// finished run of the job without writing on-chain, and restarts its services if active.
+		SetShadow(jobID int32, p pipeline.Pipeline, qopts ...pg.QOpt) error
+		// RemoveShadow removes the shadow pipeline of a job, and restarts its services if active.
+		RemoveShadow(jobID int32, qopts ...pg.QOpt) error
 		// ActiveJobs returns a map of jobs with active services (started without error).
 		ActiveJobs() map[int32]Job
+		// AddJobListener registers l to be notified of the jobs whose services are started and
+		// stopped from then on.
+		AddJobListener(l JobListener)
 
 		// StartService starts services for the given job spec.
 		// NOTE: Prefer to use CreateJob, this is only publicly exposed for use in tests
@@ -51,6 +69,18 @@ type (
 		utils.StartStopOnce
 		chStop              utils.StopChan
 		lbDependentAwaiters []utils.DependentAwaiter
+		listeners           []JobListener
+	}
+
+	// JobListener keeps in-memory state about the jobs with active services. It is called with
+	// the active jobs locked, so it must not call the spawner.
+	JobListener interface {
+		// JobStarted is called once the services of a job were started, when it is created,
+		// updated or resumed, and on startup.
+		JobStarted(jb Job)
+		// JobStopped is called once the services of a job were stopped, when it is deleted,
+		// updated or paused, and on shutdown.
+		JobStopped(jb Job)
 	}
 
 	// TODO(spook): I can't wait for Go generics
@@ -134,6 +164,10 @@ func (js *spawner) startAllServices(ctx context.Context) {
 	}
 
 	for _, spec := range specs {
+		if spec.Paused {
+			js.lggr.Infow("Not starting services for paused job", "jobID", spec.ID)
+			continue
+		}
 		if err = js.StartService(ctx, spec); err != nil {
 			js.lggr.Errorf("Couldn't start service %q: %v", spec.Name.ValueOrZero(), err)
 		}
@@ -174,6 +208,9 @@ func (js *spawner) stopService(jobID int32) {
 	js.lggr.Debugw("Stopped all services for job", "jobID", jobID)
 
 	delete(js.activeJobs, jobID)
+	for _, l := range js.listeners {
+		l.JobStopped(aj.spec)
+	}
 }
 
 func (js *spawner) StartService(ctx context.Context, jb Job, qopts ...pg.QOpt) error {
@@ -198,6 +235,15 @@ func (js *spawner) StartService(ctx context.Context, jb Job, qopts ...pg.QOpt) e
 	if jb.GasLimit.Valid {
 		jb.PipelineSpec.GasLimit = &jb.GasLimit.Uint32
 	}
+	if jb.ShadowPipelineSpec != nil {
+		shadow := *jb.ShadowPipelineSpec
+		shadow.JobName = jb.PipelineSpec.JobName + " (shadow)"
+		shadow.JobID = jb.ID
+		shadow.JobType = jb.PipelineSpec.JobType
+		shadow.ForwardingAllowed = jb.PipelineSpec.ForwardingAllowed
+		shadow.GasLimit = jb.PipelineSpec.GasLimit
+		jb.PipelineSpec.Shadow = &shadow
+	}
 
 	srvs, err := delegate.ServicesForSpec(jb, qopts...)
 	if err != nil {
@@ -222,6 +268,9 @@ func (js *spawner) StartService(ctx context.Context, jb Job, qopts ...pg.QOpt) e
 	}
 	js.lggr.Debugw("JobSpawner: Finished starting services for job", "jobID", jb.ID, "count", len(srvs))
 	js.activeJobs[jb.ID] = aj
+	for _, l := range js.listeners {
+		l.JobStarted(jb)
+	}
 	return nil
 }
 
@@ -335,6 +384,185 @@ func (js *spawner) DeleteJob(jobID int32, qopts ...pg.QOpt) error {
 	return err
 }
 
+// Should not get called before Start()
+func (js *spawner) UpdateJob(jb *Job, qopts ...pg.QOpt) error {
+	delegate, exists := js.jobTypeDelegates[jb.Type]
+	if !exists {
+		js.lggr.Errorf("job type '%s' has not been registered with the job.Spawner", jb.Type)
+		return pkgerrors.Errorf("job type '%s' has not been registered with the job.Spawner", jb.Type)
+	}
+
+	lggr := js.lggr.With("jobID", jb.ID)
+	lggr.Debugw("Updating job")
+
+	q := js.q.WithOpts(qopts...)
+	pctx, cancel := js.chStop.Ctx(q.ParentCtx)
+	defer cancel()
+	q.ParentCtx = pctx
+	ctx, cancel := q.Context()
+	defer cancel()
+
+	old, err := js.orm.FindJob(ctx, jb.ID)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "job %d not found", jb.ID)
+	}
+	oldDelegate, exists := js.jobTypeDelegates[old.Type]
+	if !exists {
+		js.lggr.Errorw("Job type has not been registered with job.Spawner", "type", old.Type, "jobID", old.ID)
+		return pkgerrors.Errorf("unregistered type %q for job: %d", old.Type, old.ID)
+	}
+
+	err = q.Transaction(func(tx pg.Queryer) error {
+		if err := js.orm.UpdateJob(jb, pg.WithQueryer(tx)); err != nil {
+			js.lggr.Errorw("Error updating job", "jobID", jb.ID, "err", err)
+			return err
+		}
+		// As with DeleteJob, the services of the previous version are only stopped if the update succeeds.
+		return oldDelegate.OnDeleteJob(old, tx)
+	})
+	if err != nil {
+		return err
+	}
+	// BeforeJobDeleted may deregister the job from external systems, so it is only called once the
+	// update is committed.
+	oldDelegate.BeforeJobDeleted(old)
+
+	js.activeJobsMu.RLock()
+	_, active := js.activeJobs[jb.ID]
+	js.activeJobsMu.RUnlock()
+	if active {
+		js.stopService(jb.ID)
+	}
+
+	delegate.BeforeJobCreated(*jb)
+	if !jb.Paused {
+		err = js.StartService(pctx, *jb, pg.WithQueryer(q.Queryer))
+		if err != nil {
+			js.lggr.Errorw("Error starting job services", "type", jb.Type, "jobID", jb.ID, "err", err)
+		} else {
+			js.lggr.Infow("Started job services", "type", jb.Type, "jobID", jb.ID)
+		}
+	}
+	delegate.AfterJobCreated(*jb)
+	lggr.Infow("Updated job", "type", jb.Type)
+
+	return err
+}
+
+// Should not get called before Start()
+func (js *spawner) PauseJob(jobID int32, qopts ...pg.QOpt) error {
+	q := js.q.WithOpts(qopts...)
+	pctx, cancel := js.chStop.Ctx(q.ParentCtx)
+	defer cancel()
+	q.ParentCtx = pctx
+	ctx, cancel := q.Context()
+	defer cancel()
+
+	jb, err := js.orm.FindJob(ctx, jobID)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "job %d not found", jobID)
+	}
+	if jb.Paused {
+		return nil
+	}
+
+	if err = js.orm.SetPaused(jobID, true, pg.WithQueryer(q.Queryer), pg.WithParentCtx(ctx)); err != nil {
+		js.lggr.Errorw("Error pausing job", "jobID", jobID, "err", err)
+		return err
+	}
+
+	js.activeJobsMu.RLock()
+	_, exists := js.activeJobs[jobID]
+	js.activeJobsMu.RUnlock()
+	if exists {
+		js.stopService(jobID)
+	}
+	js.lggr.Infow("Paused job", "type", jb.Type, "jobID", jobID)
+	return nil
+}
+
+// Should not get called before Start()
+func (js *spawner) ResumeJob(jobID int32, qopts ...pg.QOpt) error {
+	q := js.q.WithOpts(qopts...)
+	pctx, cancel := js.chStop.Ctx(q.ParentCtx)
+	defer cancel()
+	q.ParentCtx = pctx
+	ctx, cancel := q.Context()
+	defer cancel()
+
+	jb, err := js.orm.FindJob(ctx, jobID)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "job %d not found", jobID)
+	}
+	if !jb.Paused {
+		return nil
+	}
+
+	if err = js.orm.SetPaused(jobID, false, pg.WithQueryer(q.Queryer), pg.WithParentCtx(ctx)); err != nil {
+		js.lggr.Errorw("Error resuming job", "jobID", jobID, "err", err)
+		return err
+	}
+	jb.Paused = false
+
+	// As with CreateJob, the job stays resumed even if its services fail to start, the error
+	// is recorded on the job.
+	if err = js.StartService(pctx, jb, pg.WithQueryer(q.Queryer)); err != nil {
+		js.lggr.Errorw("Error starting job services", "type", jb.Type, "jobID", jobID, "err", err)
+		return err
+	}
+	js.lggr.Infow("Resumed job", "type", jb.Type, "jobID", jobID)
+	return nil
+}
+
+// Should not get called before Start()
+func (js *spawner) SetShadow(jobID int32, p pipeline.Pipeline, qopts ...pg.QOpt) error {
+	return js.updateShadow(jobID, func(q pg.Q) error {
+		return js.orm.SetShadowPipeline(jobID, p, pg.WithQueryer(q.Queryer), pg.WithParentCtx(q.ParentCtx))
+	}, qopts...)
+}
+
+// Should not get called before Start()
+func (js *spawner) RemoveShadow(jobID int32, qopts ...pg.QOpt) error {
+	return js.updateShadow(jobID, func(q pg.Q) error {
+		return js.orm.DeleteShadowPipeline(jobID, pg.WithQueryer(q.Queryer), pg.WithParentCtx(q.ParentCtx))
+	}, qopts...)
+}
+
+// updateShadow applies update to the shadow pipeline of a job, then restarts the services of the
+// job if they are active, since the pipeline spec handed to them carries the shadow.
+func (js *spawner) updateShadow(jobID int32, update func(q pg.Q) error, qopts ...pg.QOpt) error {
+	q := js.q.WithOpts(qopts...)
+	pctx, cancel := js.chStop.Ctx(q.ParentCtx)
+	defer cancel()
+	q.ParentCtx = pctx
+
+	if err := update(q); err != nil {
+		js.lggr.Errorw("Error updating shadow pipeline", "jobID", jobID, "err", err)
+		return err
+	}
+
+	js.activeJobsMu.RLock()
+	_, active := js.activeJobs[jobID]
+	js.activeJobsMu.RUnlock()
+	if !active {
+		return nil
+	}
+	js.stopService(jobID)
+
+	ctx, cancel := q.Context()
+	defer cancel()
+	jb, err := js.orm.FindJob(ctx, jobID)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "job %d not found", jobID)
+	}
+	if err = js.StartService(pctx, jb, pg.WithQueryer(q.Queryer)); err != nil {
+		js.lggr.Errorw("Error starting job services", "type", jb.Type, "jobID", jobID, "err", err)
+		return err
+	}
+	js.lggr.Infow("Restarted job services with updated shadow pipeline", "type", jb.Type, "jobID", jobID)
+	return nil
+}
+
 func (js *spawner) ActiveJobs() map[int32]Job {
 	js.activeJobsMu.RLock()
 	defer js.activeJobsMu.RUnlock()
@@ -346,6 +574,12 @@ func (js *spawner) ActiveJobs() map[int32]Job {
 	return m
 }
 
+func (js *spawner) AddJobListener(l JobListener) {
+	js.activeJobsMu.Lock()
+	defer js.activeJobsMu.Unlock()
+	js.listeners = append(js.listeners, l)
+}
+
 func (js *spawner) activeJobIDs() []int32 {
 	js.activeJobsMu.RLock()
 	defer js.activeJobsMu.RUnlock()
@@ -1,6 +1,7 @@
 package job_test
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
@@ -57,6 +58,17 @@ func (d delegate) ServicesForSpec(js job.Job, qopts ...pg.QOpt) ([]job.ServiceCt
 	return d.services, nil
 }
 
+// beforeJobDeletedCounter counts the calls to BeforeJobDeleted.
+type beforeJobDeletedCounter struct {
+	job.Delegate
+	calls int
+}
+
+func (d *beforeJobDeletedCounter) BeforeJobDeleted(jb job.Job) {
+	d.calls++
+	d.Delegate.BeforeJobDeleted(jb)
+}
+
 func clearDB(t *testing.T, db *sqlx.DB) {
 	cltest.ClearDBTables(t, db, "jobs", "pipeline_runs", "pipeline_specs", "pipeline_task_runs")
 }
@@ -264,6 +276,143 @@ func TestSpawner_CreateJobDeleteJob(t *testing.T) {
 		clearDB(t, db)
 	})
 
+	t.Run("stops and restarts job services on 'PauseJob()' and 'ResumeJob()'", func(t *testing.T) {
+		jobA := makeOCRJobSpec(t, address, bridge.Name.String(), bridge2.Name.String())
+
+		eventuallyStart := cltest.NewAwaiter()
+		serviceA1 := mocks.NewServiceCtx(t)
+		serviceA2 := mocks.NewServiceCtx(t)
+		serviceA1.On("Start", mock.Anything).Return(nil).Once()
+		serviceA2.On("Start", mock.Anything).Return(nil).Once().Run(func(mock.Arguments) { eventuallyStart.ItHappened() })
+
+		lggr := logger.TestLogger(t)
+		orm := NewTestORM(t, db, legacyChains, pipeline.NewORM(db, lggr, config.Database(), config.JobPipeline().MaxSuccessfulRuns()), bridges.NewORM(db, lggr, config.Database()), keyStore, config.Database())
+		mailMon := srvctest.Start(t, utils.NewMailboxMonitor(t.Name()))
+		d := ocr.NewDelegate(nil, orm, nil, nil, nil, monitoringEndpoint, legacyChains, logger.TestLogger(t), config.Database(), mailMon)
+		delegateA := &delegate{jobA.Type, []job.ServiceCtx{serviceA1, serviceA2}, 0, nil, d}
+		delegates := map[job.Type]job.Delegate{jobA.Type: delegateA}
+
+		err := orm.CreateJob(jobA)
+		require.NoError(t, err)
+		delegateA.jobID = jobA.ID
+
+		spawner := job.NewSpawner(orm, config.Database(), delegates, db, lggr, nil)
+		require.NoError(t, spawner.Start(testutils.Context(t)))
+		eventuallyStart.AwaitOrFail(t)
+
+		serviceA1.On("Close").Return(nil).Once()
+		serviceA2.On("Close").Return(nil).Once()
+
+		require.NoError(t, spawner.PauseJob(jobA.ID))
+		assert.NotContains(t, spawner.ActiveJobs(), jobA.ID)
+		jb, err := orm.FindJob(testutils.Context(t), jobA.ID)
+		require.NoError(t, err)
+		assert.True(t, jb.Paused)
+
+		// pausing again is a no-op
+		require.NoError(t, spawner.PauseJob(jobA.ID))
+		require.NoError(t, spawner.Close())
+
+		// paused jobs are not started with the spawner
+		spawner = job.NewSpawner(orm, config.Database(), delegates, db, lggr, nil)
+		require.NoError(t, spawner.Start(testutils.Context(t)))
+		defer func() { assert.NoError(t, spawner.Close()) }()
+		assert.NotContains(t, spawner.ActiveJobs(), jobA.ID)
+
+		serviceA1.On("Start", mock.Anything).Return(nil).Once()
+		serviceA2.On("Start", mock.Anything).Return(nil).Once()
+
+		require.NoError(t, spawner.ResumeJob(jobA.ID))
+		assert.Contains(t, spawner.ActiveJobs(), jobA.ID)
+		jb, err = orm.FindJob(testutils.Context(t), jobA.ID)
+		require.NoError(t, err)
+		assert.False(t, jb.Paused)
+
+		// resuming a running job is a no-op
+		require.NoError(t, spawner.ResumeJob(jobA.ID))
+
+		serviceA1.On("Close").Return(nil).Once()
+		serviceA2.On("Close").Return(nil).Once()
+
+		require.ErrorIs(t, spawner.PauseJob(123456), sql.ErrNoRows)
+
+		clearDB(t, db)
+	})
+
+	t.Run("replaces the spec of a job in place on 'UpdateJob()'", func(t *testing.T) {
+		jobA := makeOCRJobSpec(t, address, bridge.Name.String(), bridge2.Name.String())
+		jobA.Definition = "version 1"
+
+		eventuallyStart := cltest.NewAwaiter()
+		serviceA1 := mocks.NewServiceCtx(t)
+		serviceA2 := mocks.NewServiceCtx(t)
+		serviceA1.On("Start", mock.Anything).Return(nil).Once()
+		serviceA2.On("Start", mock.Anything).Return(nil).Once().Run(func(mock.Arguments) { eventuallyStart.ItHappened() })
+
+		lggr := logger.TestLogger(t)
+		orm := NewTestORM(t, db, legacyChains, pipeline.NewORM(db, lggr, config.Database(), config.JobPipeline().MaxSuccessfulRuns()), bridges.NewORM(db, lggr, config.Database()), keyStore, config.Database())
+		mailMon := srvctest.Start(t, utils.NewMailboxMonitor(t.Name()))
+		d := ocr.NewDelegate(nil, orm, nil, nil, nil, monitoringEndpoint, legacyChains, logger.TestLogger(t), config.Database(), mailMon)
+		counter := &beforeJobDeletedCounter{Delegate: d}
+		delegateA := &delegate{jobA.Type, []job.ServiceCtx{serviceA1, serviceA2}, 0, nil, counter}
+		delegates := map[job.Type]job.Delegate{jobA.Type: delegateA}
+
+		err := orm.CreateJob(jobA)
+		require.NoError(t, err)
+		delegateA.jobID = jobA.ID
+
+		spawner := job.NewSpawner(orm, config.Database(), delegates, db, lggr, nil)
+		require.NoError(t, spawner.Start(testutils.Context(t)))
+		defer func() { assert.NoError(t, spawner.Close()) }()
+		eventuallyStart.AwaitOrFail(t)
+
+		serviceA1.On("Close").Return(nil).Once()
+		serviceA2.On("Close").Return(nil).Once()
+		serviceA1.On("Start", mock.Anything).Return(nil).Once()
+		serviceA2.On("Start", mock.Anything).Return(nil).Once()
+
+		// state stored for the job is kept when it is updated
+		_, err = db.Exec(`INSERT INTO evm_log_job_progress (job_id, block_number, updated_at) VALUES ($1, 10, NOW())`, jobA.ID)
+		require.NoError(t, err)
+
+		jobB := makeOCRJobSpec(t, address, bridge2.Name.String(), bridge.Name.String())
+		jobB.ID = jobA.ID
+		jobB.ExternalJobID = jobA.ExternalJobID
+		jobB.Definition = "version 2"
+		require.NoError(t, spawner.UpdateJob(jobB))
+		assert.Contains(t, spawner.ActiveJobs(), jobA.ID)
+		assert.Equal(t, 1, counter.calls)
+
+		jb, err := orm.FindJob(testutils.Context(t), jobA.ID)
+		require.NoError(t, err)
+		assert.Equal(t, jobA.ExternalJobID, jb.ExternalJobID)
+		assert.Equal(t, jobA.PipelineSpecID, jb.PipelineSpecID)
+		assert.Equal(t, *jobA.OCROracleSpecID, *jb.OCROracleSpecID)
+		assert.Equal(t, jobB.OCROracleSpec.ContractAddress, jb.OCROracleSpec.ContractAddress)
+		cltest.AssertCount(t, db, "evm_log_job_progress", 1)
+		assert.Equal(t, jobB.Pipeline.Source, jb.PipelineSpec.DotDagSource)
+
+		versions, err := orm.FindSpecVersions(jobA.ID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, int32(1), versions[0].Version)
+		assert.Equal(t, "version 1", versions[0].Definition)
+		assert.Equal(t, int32(2), versions[1].Version)
+		assert.Equal(t, "version 2", versions[1].Definition)
+
+		// the external job ID of a job cannot be changed
+		jobC := makeOCRJobSpec(t, address, bridge.Name.String(), bridge2.Name.String())
+		jobC.ID = jobA.ID
+		require.ErrorIs(t, spawner.UpdateJob(jobC), job.ErrExternalJobIDChanged)
+		assert.Contains(t, spawner.ActiveJobs(), jobA.ID)
+		assert.Equal(t, 1, counter.calls, "the previous version is not deregistered when the update fails")
+
+		serviceA1.On("Close").Return(nil).Once()
+		serviceA2.On("Close").Return(nil).Once()
+
+		clearDB(t, db)
+	})
+
 	t.Run("Unregisters filters on 'DeleteJob()'", func(t *testing.T) {
 		config = configtest2.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
 			c.Feature.LogPoller = func(b bool) *bool { return &b }(true)
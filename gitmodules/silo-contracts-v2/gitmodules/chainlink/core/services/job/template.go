@@ -0,0 +1,189 @@
+package job
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v4"
+)
+
+// TemplateParameterType is the type of the value of a template parameter.
+type TemplateParameterType string
+
+const (
+	TemplateParameterString   TemplateParameterType = "string"
+	TemplateParameterInt      TemplateParameterType = "int"
+	TemplateParameterBool     TemplateParameterType = "bool"
+	TemplateParameterDuration TemplateParameterType = "duration"
+	TemplateParameterAddress  TemplateParameterType = "address"
+	TemplateParameterBytes32  TemplateParameterType = "bytes32"
+)
+
+var templateParameterName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TemplateParameter is a typed parameter of a job template, which is referenced in the
+// definition of the template as {{ .name }}.
+type TemplateParameter struct {
+	Name string                `json:"name"`
+	Type TemplateParameterType `json:"type"`
+}
+
+// TemplateParameters are the parameters of a job template.
+type TemplateParameters []TemplateParameter
+
+// Value returns this instance serialized for database storage.
+func (p TemplateParameters) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan reads the database value and returns an instance.
+func (p *TemplateParameters) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", value)
+	}
+	return json.Unmarshal(b, p)
+}
+
+// Template is an immutable version of a parameterized TOML job spec, which is a Go text
+// template. Jobs created from a template reference the version which produced them.
+type Template struct {
+	ID         int32
+	Name       string
+	Version    int32
+	Definition string
+	Parameters TemplateParameters
+	CreatedAt  time.Time
+}
+
+// TemplateJob is a job created from a version of a template.
+type TemplateJob struct {
+	JobID           int32
+	JobName         null.String
+	TemplateVersion int32
+}
+
+// ValidateTemplate checks the name, definition and parameters of a template.
+func ValidateTemplate(t Template) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("template name must not be empty")
+	}
+	if _, err := parseTemplate(t); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !templateParameterName.MatchString(p.Name) {
+			return errors.Errorf("invalid parameter name %q", p.Name)
+		}
+		if _, ok := seen[p.Name]; ok {
+			return errors.Errorf("duplicate parameter %q", p.Name)
+		}
+		seen[p.Name] = struct{}{}
+		switch p.Type {
+		case TemplateParameterString, TemplateParameterInt, TemplateParameterBool, TemplateParameterDuration,
+			TemplateParameterAddress, TemplateParameterBytes32:
+		default:
+			return errors.Errorf("parameter %q has unknown type %q", p.Name, p.Type)
+		}
+	}
+	return nil
+}
+
+// Render returns the TOML job spec of the template with the given parameter values, which
+// must all be set and valid for their type. The spec still needs to be validated for its
+// job type.
+func (t Template) Render(values map[string]string) (string, error) {
+	tmpl, err := parseTemplate(t)
+	if err != nil {
+		return "", err
+	}
+
+	data := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		v, ok := values[p.Name]
+		if !ok {
+			err = multierr.Append(err, errors.Errorf("missing parameter %q", p.Name))
+			continue
+		}
+		v, perr := p.format(v)
+		if perr != nil {
+			err = multierr.Append(err, perr)
+			continue
+		}
+		data[p.Name] = v
+	}
+	for name := range values {
+		if !t.hasParameter(name) {
+			err = multierr.Append(err, errors.Errorf("unknown parameter %q", name))
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, data); err != nil {
+		return "", errors.Wrapf(err, "failed to render template %s", t.Name)
+	}
+	return b.String(), nil
+}
+
+func (t Template) hasParameter(name string) bool {
+	for _, p := range t.Parameters {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTemplate(t Template) (*template.Template, error) {
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.Definition)
+	return tmpl, errors.Wrap(err, "invalid template definition")
+}
+
+// format validates v and returns it as it is written into the spec. Values are not quoted, so
+// strings may not contain characters which would change the structure of the TOML.
+func (p TemplateParameter) format(v string) (string, error) {
+	var err error
+	switch p.Type {
+	case TemplateParameterString:
+		if strings.ContainsAny(v, "\"'\\\n\r") {
+			err = errors.New("must not contain quotes, backslashes or newlines")
+		}
+	case TemplateParameterInt:
+		_, err = strconv.ParseInt(v, 10, 64)
+	case TemplateParameterBool:
+		var b bool
+		if b, err = strconv.ParseBool(v); err == nil {
+			v = strconv.FormatBool(b)
+		}
+	case TemplateParameterDuration:
+		_, err = time.ParseDuration(v)
+	case TemplateParameterAddress:
+		if !common.IsHexAddress(v) {
+			err = errors.New("not a hex address")
+		} else {
+			v = common.HexToAddress(v).Hex()
+		}
+	case TemplateParameterBytes32:
+		var b []byte
+		if b, err = hexutil.Decode(v); err == nil && len(b) != common.HashLength {
+			err = errors.Errorf("expected %d bytes, got %d", common.HashLength, len(b))
+		}
+	default:
+		err = errors.Errorf("unknown type %q", p.Type)
+	}
+	return v, errors.Wrapf(err, "invalid %s parameter %q", p.Type, p.Name)
+}
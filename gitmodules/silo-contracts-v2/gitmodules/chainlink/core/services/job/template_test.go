@@ -0,0 +1,124 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+const directRequestTemplate = `
+type                = "directrequest"
+schemaVersion       = 1
+name                = "{{ .name }}"
+contractAddress     = "{{ .contractAddress }}"
+observationSource   = """
+    ds1          [type=http method=GET url="http://example.com" allowunrestrictednetworkaccess="true"];
+    ds1_parse    [type=jsonparse path="USD"];
+    ds1_multiply [type=multiply times={{ .times }}];
+    ds1 -> ds1_parse -> ds1_multiply;
+"""
+`
+
+func newDirectRequestTemplate() job.Template {
+	return job.Template{
+		Name:       "direct request",
+		Definition: directRequestTemplate,
+		Parameters: job.TemplateParameters{
+			{Name: "name", Type: job.TemplateParameterString},
+			{Name: "contractAddress", Type: job.TemplateParameterAddress},
+			{Name: "times", Type: job.TemplateParameterInt},
+		},
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, job.ValidateTemplate(newDirectRequestTemplate()))
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*job.Template)
+		err    string
+	}{
+		{"no name", func(t *job.Template) { t.Name = " " }, "template name must not be empty"},
+		{"invalid definition", func(t *job.Template) { t.Definition = "{{ .name " }, "invalid template definition"},
+		{"invalid parameter name", func(t *job.Template) { t.Parameters[0].Name = "feed-id" }, `invalid parameter name "feed-id"`},
+		{"duplicate parameter", func(t *job.Template) { t.Parameters[1].Name = "name" }, `duplicate parameter "name"`},
+		{"unknown type", func(t *job.Template) { t.Parameters[2].Type = "float" }, `parameter "times" has unknown type "float"`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := newDirectRequestTemplate()
+			tc.modify(&tmpl)
+			assert.ErrorContains(t, job.ValidateTemplate(tmpl), tc.err)
+		})
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newDirectRequestTemplate()
+
+	t.Run("renders the values", func(t *testing.T) {
+		spec, err := tmpl.Render(map[string]string{
+			"name":            "ETH / USD",
+			"contractAddress": "0x613a38ac1659769640aae063c651f48e0250454c",
+			"times":           "100",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, spec, `name                = "ETH / USD"`)
+		// addresses are checksummed
+		assert.Contains(t, spec, `contractAddress     = "0x613a38AC1659769640aaE063C651F48E0250454C"`)
+		assert.Contains(t, spec, `[type=multiply times=100]`)
+
+		jobType, err := job.ValidateSpec(spec)
+		require.NoError(t, err)
+		assert.Equal(t, job.DirectRequest, jobType)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := tmpl.Render(map[string]string{
+			"name":            `ETH" / USD`,
+			"contractAddress": "0x613a",
+			"times":           "1e2",
+			"feedID":          "0x01",
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `invalid string parameter "name"`)
+		assert.ErrorContains(t, err, `invalid address parameter "contractAddress"`)
+		assert.ErrorContains(t, err, `invalid int parameter "times"`)
+		assert.ErrorContains(t, err, `unknown parameter "feedID"`)
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		_, err := tmpl.Render(map[string]string{"name": "ETH / USD"})
+		assert.ErrorContains(t, err, `missing parameter "contractAddress"`)
+		assert.ErrorContains(t, err, `missing parameter "times"`)
+	})
+
+	t.Run("other types", func(t *testing.T) {
+		tmpl := job.Template{
+			Name:       "types",
+			Definition: "{{ .b }} {{ .d }} {{ .h }}",
+			Parameters: job.TemplateParameters{
+				{Name: "b", Type: job.TemplateParameterBool},
+				{Name: "d", Type: job.TemplateParameterDuration},
+				{Name: "h", Type: job.TemplateParameterBytes32},
+			},
+		}
+		h := "0x0000000000000000000000000000000000000000000000000000000000000001"
+		out, err := tmpl.Render(map[string]string{"b": "1", "d": "5s", "h": h})
+		require.NoError(t, err)
+		assert.Equal(t, "true 5s "+h, out)
+
+		_, err = tmpl.Render(map[string]string{"b": "yes", "d": "5", "h": "0x01"})
+		assert.ErrorContains(t, err, `invalid bool parameter "b"`)
+		assert.ErrorContains(t, err, `invalid duration parameter "d"`)
+		assert.ErrorContains(t, err, `invalid bytes32 parameter "h": expected 32 bytes, got 1`)
+	})
+}
@@ -0,0 +1,98 @@
+package job
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// TriggerCondition is the outcome of a run of a job which runs the downstream job of a trigger.
+type TriggerCondition string
+
+const (
+	TriggerOnSuccess TriggerCondition = "success"
+	TriggerOnError   TriggerCondition = "error"
+	TriggerAlways    TriggerCondition = "always"
+)
+
+// ErrInvalidTrigger is returned when creating or updating a job with a trigger which does not
+// run an existing webhook job, or which would make jobs trigger each other in a loop.
+var ErrInvalidTrigger = errors.New("invalid trigger")
+
+// Trigger runs a downstream webhook job whenever a run of the job finishes with an outcome
+// matching the condition. The downstream job is referenced by its external job ID, so the
+// trigger is kept when it is updated.
+type Trigger struct {
+	ID                      int64            `toml:"-"`
+	JobID                   int32            `toml:"-"`
+	DownstreamExternalJobID uuid.UUID        `toml:"externalJobID"`
+	Condition               TriggerCondition `toml:"condition"`
+}
+
+// Matches returns whether the finished run of the upstream job runs the downstream job.
+func (t Trigger) Matches(run pipeline.Run) bool {
+	switch t.Condition {
+	case TriggerOnSuccess:
+		return run.State == pipeline.RunStatusCompleted
+	case TriggerOnError:
+		return run.State == pipeline.RunStatusErrored
+	case TriggerAlways:
+		return run.State.Finished()
+	default:
+		return false
+	}
+}
+
+// ValidateTriggers checks the triggers of a job spec, and defaults their condition to success.
+// Whether the downstream jobs exist is checked when the job is stored.
+func ValidateTriggers(triggers []Trigger) error {
+	seen := make(map[uuid.UUID]struct{}, len(triggers))
+	for i := range triggers {
+		t := &triggers[i]
+		if t.DownstreamExternalJobID == (uuid.UUID{}) {
+			return errors.Wrap(ErrInvalidTrigger, "externalJobID of the downstream job is required")
+		}
+		if _, ok := seen[t.DownstreamExternalJobID]; ok {
+			return errors.Wrapf(ErrInvalidTrigger, "duplicate trigger of job %s", t.DownstreamExternalJobID)
+		}
+		seen[t.DownstreamExternalJobID] = struct{}{}
+		switch t.Condition {
+		case "":
+			t.Condition = TriggerOnSuccess
+		case TriggerOnSuccess, TriggerOnError, TriggerAlways:
+		default:
+			return errors.Wrapf(ErrInvalidTrigger, "condition must be one of %s, %s or %s, got '%v'", TriggerOnSuccess, TriggerOnError, TriggerAlways, t.Condition)
+		}
+	}
+	return nil
+}
+
+// findTriggerLoop returns the external job IDs of the jobs which would trigger each other in a
+// loop if jobID triggered the downstream jobs, given the triggers of all other jobs.
+func findTriggerLoop(jobID uuid.UUID, downstream []uuid.UUID, edges map[uuid.UUID][]uuid.UUID) []uuid.UUID {
+	visited := make(map[uuid.UUID]bool)
+	var visit func(id uuid.UUID, path []uuid.UUID) []uuid.UUID
+	visit = func(id uuid.UUID, path []uuid.UUID) []uuid.UUID {
+		path = append(path, id)
+		if id == jobID {
+			return path
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		for _, next := range edges[id] {
+			if loop := visit(next, path); loop != nil {
+				return loop
+			}
+		}
+		return nil
+	}
+	for _, id := range downstream {
+		if loop := visit(id, []uuid.UUID{jobID}); loop != nil {
+			return loop
+		}
+	}
+	return nil
+}
@@ -414,7 +414,7 @@ func TestKeeperForwarderEthIntegration(t *testing.T) {
 
 		forwarderORM := forwarders.NewORM(db, logger.TestLogger(t), config.Database())
 		chainID := utils.Big(*backend.ConfiguredChainID())
-		_, err = forwarderORM.CreateForwarder(fwdrAddress, chainID)
+		_, err = forwarderORM.CreateForwarder(fwdrAddress, chainID, forwarders.TypeAuthorizedForwarder, nil, false)
 		require.NoError(t, err)
 
 		addr, err := app.GetRelayers().LegacyEVMChains().Slice()[0].TxManager().GetForwarderForEOA(nodeAddress)
@@ -444,7 +444,7 @@ func setupForwarderForNode(
 	// add forwarder address to be tracked in db
 	forwarderORM := forwarders.NewORM(app.GetSqlxDB(), logger.TestLogger(t), app.GetConfig().Database())
 	chainID := utils.Big(*backend.Blockchain().Config().ChainID)
-	_, err = forwarderORM.CreateForwarder(faddr, chainID)
+	_, err = forwarderORM.CreateForwarder(faddr, chainID, forwarders.TypeAuthorizedForwarder, nil, false)
 	require.NoError(t, err)
 
 	chain, err := app.GetRelayers().LegacyEVMChains().Get((*big.Int)(&chainID).String())
@@ -284,7 +284,7 @@ func setupNodeOCR2(
 		// Add the forwarder to the node's forwarder manager.
 		forwarderORM := forwarders.NewORM(app.GetSqlxDB(), logger.TestLogger(t), config.Database())
 		chainID := utils.Big(*b.Blockchain().Config().ChainID)
-		_, err = forwarderORM.CreateForwarder(faddr, chainID)
+		_, err = forwarderORM.CreateForwarder(faddr, chainID, forwarders.TypeAuthorizedForwarder, nil, false)
 		require.NoError(t, err)
 		effectiveTransmitter = faddr
 	}
@@ -0,0 +1,262 @@
+package pipeline
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// ArchiveDayLayout is the format of the days the archive is partitioned by.
+const ArchiveDayLayout = "2006-01-02"
+
+var ErrArchiveDisabled = errors.New("pipeline run archive is disabled, see JobPipeline.Archive")
+
+// ArchivedRun is how a run and its task runs are stored in the archive. Unlike Run, it carries the
+// run ID and the job of the run.
+type ArchivedRun struct {
+	ID             int64            `json:"id"`
+	PipelineSpecID int32            `json:"pipelineSpecId"`
+	JobID          int32            `json:"jobId"`
+	JobName        string           `json:"jobName"`
+	JobType        string           `json:"jobType"`
+	DotDagSource   string           `json:"dotDagSource"`
+	Meta           JSONSerializable `json:"meta"`
+	AllErrors      RunErrors        `json:"allErrors"`
+	FatalErrors    RunErrors        `json:"fatalErrors"`
+	Inputs         JSONSerializable `json:"inputs"`
+	Outputs        JSONSerializable `json:"outputs"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	FinishedAt     null.Time        `json:"finishedAt"`
+	State          RunStatus        `json:"state"`
+	TaskRuns       []TaskRun        `json:"taskRuns"`
+}
+
+func NewArchivedRun(run Run) ArchivedRun {
+	return ArchivedRun{
+		ID:             run.ID,
+		PipelineSpecID: run.PipelineSpecID,
+		JobID:          run.PipelineSpec.JobID,
+		JobName:        run.PipelineSpec.JobName,
+		JobType:        run.PipelineSpec.JobType,
+		DotDagSource:   run.PipelineSpec.DotDagSource,
+		Meta:           run.Meta,
+		AllErrors:      run.AllErrors,
+		FatalErrors:    run.FatalErrors,
+		Inputs:         run.Inputs,
+		Outputs:        run.Outputs,
+		CreatedAt:      run.CreatedAt,
+		FinishedAt:     run.FinishedAt,
+		State:          run.State,
+		TaskRuns:       run.PipelineTaskRuns,
+	}
+}
+
+// Run converts the archived run back to a Run.
+func (ar ArchivedRun) Run() Run {
+	taskRuns := make([]TaskRun, len(ar.TaskRuns))
+	for i, tr := range ar.TaskRuns {
+		tr.PipelineRunID = ar.ID
+		taskRuns[i] = tr
+	}
+	return Run{
+		ID:             ar.ID,
+		PipelineSpecID: ar.PipelineSpecID,
+		PipelineSpec: Spec{
+			ID:           ar.PipelineSpecID,
+			DotDagSource: ar.DotDagSource,
+			JobID:        ar.JobID,
+			JobName:      ar.JobName,
+			JobType:      ar.JobType,
+		},
+		Meta:             ar.Meta,
+		AllErrors:        ar.AllErrors,
+		FatalErrors:      ar.FatalErrors,
+		Inputs:           ar.Inputs,
+		Outputs:          ar.Outputs,
+		CreatedAt:        ar.CreatedAt,
+		FinishedAt:       ar.FinishedAt,
+		State:            ar.State,
+		PipelineTaskRuns: taskRuns,
+	}
+}
+
+// finishedDay returns the UTC day the run is partitioned by.
+func (ar ArchivedRun) finishedDay() time.Time {
+	t := ar.CreatedAt
+	if ar.FinishedAt.Valid {
+		t = ar.FinishedAt.Time
+	}
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ArchiveFilter selects archived runs. Zero values match everything.
+type ArchiveFilter struct {
+	JobID int32
+	// From and To are the first and the last UTC day the runs finished on.
+	From time.Time
+	To   time.Time
+}
+
+func (f ArchiveFilter) matchesDay(day time.Time) bool {
+	return (f.From.IsZero() || !day.Before(f.From)) && (f.To.IsZero() || !day.After(f.To))
+}
+
+// RunArchive stores runs as gzip-compressed JSONL files, partitioned by job and by the day the
+// runs finished: <dir>/job_<id>/<yyyy-mm-dd>.jsonl.gz. Every write appends a gzip member to the
+// partition files, so they can be read back with any gzip reader.
+type RunArchive struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewRunArchive(dir string) *RunArchive {
+	return &RunArchive{dir: dir}
+}
+
+func (a *RunArchive) partitionPath(jobID int32, day time.Time) string {
+	return filepath.Join(a.dir, fmt.Sprintf("job_%d", jobID), day.Format(ArchiveDayLayout)+".jsonl.gz")
+}
+
+// Write appends runs to their partitions, and only returns once they are synced to disk.
+func (a *RunArchive) Write(runs []Run) error {
+	partitions := make(map[string][]ArchivedRun)
+	var paths []string
+	for _, run := range runs {
+		ar := NewArchivedRun(run)
+		path := a.partitionPath(ar.JobID, ar.finishedDay())
+		if _, ok := partitions[path]; !ok {
+			paths = append(paths, path)
+		}
+		partitions[path] = append(partitions[path], ar)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, path := range paths {
+		if err := writeArchivePartition(path, partitions[path]); err != nil {
+			return errors.Wrapf(err, "failed to archive runs to %s", path)
+		}
+	}
+	return nil
+}
+
+func writeArchivePartition(path string, runs []ArchivedRun) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	if err = writeArchiveMember(f, runs); err != nil {
+		// drop the partial gzip member, so that the partition stays readable
+		_ = f.Truncate(size)
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func writeArchiveMember(w io.Writer, runs []ArchivedRun) error {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	for _, run := range runs {
+		if err := enc.Encode(run); err != nil {
+			return err
+		}
+	}
+	return gz.Close()
+}
+
+// Query returns the archived runs matching filter, ordered by finish time. A run archived more
+// than once, e.g. after being imported and reaped again, is only returned once.
+func (a *RunArchive) Query(filter ArchiveFilter) ([]Run, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	jobDirs := []string{filepath.Join(a.dir, fmt.Sprintf("job_%d", filter.JobID))}
+	if filter.JobID == 0 {
+		var err error
+		if jobDirs, err = filepath.Glob(filepath.Join(a.dir, "job_*")); err != nil {
+			return nil, errors.Wrap(err, "failed to list archived jobs")
+		}
+	}
+
+	var runs []Run
+	for _, jobDir := range jobDirs {
+		files, err := filepath.Glob(filepath.Join(jobDir, "*.jsonl.gz"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list archived runs")
+		}
+		for _, path := range files {
+			day, err := time.Parse(ArchiveDayLayout, strings.TrimSuffix(filepath.Base(path), ".jsonl.gz"))
+			if err != nil || !filter.matchesDay(day) {
+				continue
+			}
+			partition, err := readArchivePartition(path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read archived runs from %s", path)
+			}
+			runs = append(runs, partition...)
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		if ti, tj := runs[i].FinishedAt.Time, runs[j].FinishedAt.Time; !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return runs[i].ID < runs[j].ID
+	})
+	return runs, nil
+}
+
+func readArchivePartition(path string) ([]Run, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var runs []Run
+	seen := make(map[int64]int)
+	dec := json.NewDecoder(gz)
+	for {
+		var ar ArchivedRun
+		if err = dec.Decode(&ar); errors.Is(err, io.EOF) {
+			return runs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		// the latest copy of a run wins
+		if i, ok := seen[ar.ID]; ok {
+			runs[i] = ar.Run()
+			continue
+		}
+		seen[ar.ID] = len(runs)
+		runs = append(runs, ar.Run())
+	}
+}
@@ -0,0 +1,115 @@
+package pipeline_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func archiveTestRun(id int64, jobID int32, finishedAt time.Time, output string) pipeline.Run {
+	return pipeline.Run{
+		ID:             id,
+		PipelineSpecID: jobID * 10,
+		PipelineSpec: pipeline.Spec{
+			ID:           jobID * 10,
+			JobID:        jobID,
+			JobName:      "job",
+			DotDagSource: `ds [type=memo value="1"]`,
+		},
+		Outputs:    pipeline.JSONSerializable{Val: []interface{}{output}, Valid: true},
+		AllErrors:  pipeline.RunErrors{null.String{}},
+		CreatedAt:  finishedAt.Add(-time.Second),
+		FinishedAt: null.TimeFrom(finishedAt),
+		State:      pipeline.RunStatusCompleted,
+		PipelineTaskRuns: []pipeline.TaskRun{{
+			Type:       pipeline.TaskTypeMemo,
+			DotID:      "ds",
+			Output:     pipeline.JSONSerializable{Val: output, Valid: true},
+			CreatedAt:  finishedAt.Add(-time.Second),
+			FinishedAt: null.TimeFrom(finishedAt),
+		}},
+	}
+}
+
+func TestRunArchive(t *testing.T) {
+	t.Parallel()
+
+	day1 := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2023, 8, 2, 23, 0, 0, 0, time.UTC)
+
+	dir := t.TempDir()
+	archive := pipeline.NewRunArchive(dir)
+	require.NoError(t, archive.Write([]pipeline.Run{
+		archiveTestRun(1, 1, day1, "a"),
+		archiveTestRun(2, 1, day2, "b"),
+		archiveTestRun(3, 2, day1.Add(time.Hour), "c"),
+	}))
+	// a second write appends to the existing partition
+	require.NoError(t, archive.Write([]pipeline.Run{archiveTestRun(4, 1, day1.Add(time.Minute), "d")}))
+
+	for _, path := range []string{"job_1/2023-08-01.jsonl.gz", "job_1/2023-08-02.jsonl.gz", "job_2/2023-08-01.jsonl.gz"} {
+		_, err := os.Stat(filepath.Join(dir, path))
+		require.NoError(t, err, path)
+	}
+
+	ids := func(runs []pipeline.Run) (ids []int64) {
+		for _, run := range runs {
+			ids = append(ids, run.ID)
+		}
+		return
+	}
+
+	t.Run("all runs ordered by finish time", func(t *testing.T) {
+		runs, err := archive.Query(pipeline.ArchiveFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 4, 3, 2}, ids(runs))
+
+		run := runs[0]
+		assert.Equal(t, int32(1), run.PipelineSpec.JobID)
+		assert.Equal(t, int32(10), run.PipelineSpecID)
+		assert.Equal(t, `ds [type=memo value="1"]`, run.PipelineSpec.DotDagSource)
+		assert.Equal(t, pipeline.RunStatusCompleted, run.State)
+		assert.True(t, run.FinishedAt.Time.Equal(day1))
+		assert.Equal(t, []interface{}{"a"}, run.Outputs.Val)
+		require.Len(t, run.PipelineTaskRuns, 1)
+		assert.Equal(t, int64(1), run.PipelineTaskRuns[0].PipelineRunID)
+		assert.Equal(t, "ds", run.PipelineTaskRuns[0].DotID)
+		assert.Equal(t, "a", run.PipelineTaskRuns[0].Output.Val)
+	})
+
+	t.Run("by job", func(t *testing.T) {
+		runs, err := archive.Query(pipeline.ArchiveFilter{JobID: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, ids(runs))
+
+		runs, err = archive.Query(pipeline.ArchiveFilter{JobID: 3})
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+
+	t.Run("by day", func(t *testing.T) {
+		day := time.Date(2023, 8, 2, 0, 0, 0, 0, time.UTC)
+		runs, err := archive.Query(pipeline.ArchiveFilter{From: day})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, ids(runs))
+
+		runs, err = archive.Query(pipeline.ArchiveFilter{JobID: 1, To: day.AddDate(0, 0, -1)})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 4}, ids(runs))
+	})
+
+	t.Run("latest copy of a run wins", func(t *testing.T) {
+		require.NoError(t, archive.Write([]pipeline.Run{archiveTestRun(1, 1, day1, "a2")}))
+		runs, err := archive.Query(pipeline.ArchiveFilter{JobID: 1, To: day1})
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 4}, ids(runs))
+		assert.Equal(t, []interface{}{"a2"}, runs[0].Outputs.Val)
+	})
+}
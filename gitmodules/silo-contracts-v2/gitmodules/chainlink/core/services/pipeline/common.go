@@ -21,6 +21,7 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
+	coreconfig "github.com/smartcontractkit/chainlink/v2/core/config"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	cnull "github.com/smartcontractkit/chainlink/v2/core/null"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
@@ -40,6 +41,7 @@ const (
 	WebhookJobType                 string = "webhook"
 	BootstrapJobType               string = "bootstrap"
 	GatewayJobType                 string = "gateway"
+	EVMLogJobType                  string = "evmlog"
 	LegacyGasStationServerJobType  string = "legacygasstationserver"
 	LegacyGasStationSidecarJobType string = "legacygasstationsidecar"
 )
@@ -62,12 +64,19 @@ type (
 		TaskMaxBackoff() time.Duration
 	}
 
+	// validatedTask is implemented by tasks whose parameters can be checked when the spec is parsed.
+	validatedTask interface {
+		validate() error
+	}
+
 	Config interface {
+		Archive() coreconfig.JobPipelineArchive
 		DefaultHTTPLimit() int64
 		DefaultHTTPTimeout() models.Duration
 		MaxRunDuration() time.Duration
 		ReaperInterval() time.Duration
 		ReaperThreshold() time.Duration
+		SignAllowedJobTypes() []string
 	}
 
 	BridgeConfig interface {
@@ -375,10 +384,15 @@ const (
 	TaskTypeETHCall          TaskType = "ethcall"
 	TaskTypeETHTx            TaskType = "ethtx"
 	TaskTypeEstimateGasLimit TaskType = "estimategaslimit"
+	TaskTypeExpression       TaskType = "expression"
+	TaskTypeForEach          TaskType = "foreach"
 	TaskTypeHTTP             TaskType = "http"
+	TaskTypeHash             TaskType = "hash"
 	TaskTypeHexDecode        TaskType = "hexdecode"
 	TaskTypeHexEncode        TaskType = "hexencode"
 	TaskTypeJSONParse        TaskType = "jsonparse"
+	TaskTypeJSONSchema       TaskType = "jsonschema"
+	TaskTypeJSONTransform    TaskType = "jsontransform"
 	TaskTypeLength           TaskType = "length"
 	TaskTypeLessThan         TaskType = "lessthan"
 	TaskTypeLookup           TaskType = "lookup"
@@ -388,11 +402,15 @@ const (
 	TaskTypeMerge            TaskType = "merge"
 	TaskTypeMode             TaskType = "mode"
 	TaskTypeMultiply         TaskType = "multiply"
+	TaskTypeSign             TaskType = "sign"
+	TaskTypeSubpipeline      TaskType = "subpipeline"
 	TaskTypeSum              TaskType = "sum"
 	TaskTypeUppercase        TaskType = "uppercase"
+	TaskTypeVerifySignature  TaskType = "verifysignature"
 	TaskTypeVRF              TaskType = "vrf"
 	TaskTypeVRFV2            TaskType = "vrfv2"
 	TaskTypeVRFV2Plus        TaskType = "vrfv2plus"
+	TaskTypeWSLatest         TaskType = "wslatest"
 
 	// Testing only.
 	TaskTypePanic TaskType = "panic"
@@ -439,6 +457,10 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &AnyTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeJSONParse:
 		task = &JSONParseTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeJSONSchema:
+		task = &JSONSchemaTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeJSONTransform:
+		task = &JSONTransformTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMemo:
 		task = &MemoTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMultiply:
@@ -451,8 +473,16 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &VRFTaskV2{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeVRFV2Plus:
 		task = &VRFTaskV2Plus{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeWSLatest:
+		task = &WSLatestTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeEstimateGasLimit:
 		task = &EstimateGasLimitTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeExpression:
+		task = &ExpressionTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeForEach:
+		task = &ForEachTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeSubpipeline:
+		task = &SubpipelineTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeETHCall:
 		task = &ETHCallTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeETHTx:
@@ -491,6 +521,12 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &Base64DecodeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeBase64Encode:
 		task = &Base64EncodeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeHash:
+		task = &HashTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeVerifySignature:
+		task = &VerifySignatureTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeSign:
+		task = &SignTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	default:
 		return nil, pkgerrors.Errorf(`unknown task type: "%v"`, taskType)
 	}
@@ -521,6 +557,11 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 	if err != nil {
 		return nil, err
 	}
+	if v, ok := task.(validatedTask); ok {
+		if err = v.validate(); err != nil {
+			return nil, err
+		}
+	}
 	return task, nil
 }
 
@@ -93,7 +93,9 @@ func ParseETHABIArgsString(theABI []byte, isLog bool) (args abi.Arguments, index
 	return args, indexedArgs, nil
 }
 
-func parseETHABIString(theABI []byte, isLog bool) (name string, args abi.Arguments, indexedArgs abi.Arguments, err error) {
+// ParseETHABIString parses a method or, if isLog is set, an event signature with argument names,
+// e.g. "Transfer(address indexed from, address indexed to, uint256 value)".
+func ParseETHABIString(theABI []byte, isLog bool) (name string, args abi.Arguments, indexedArgs abi.Arguments, err error) {
 	matches := ethABIRegex.FindAllSubmatch(theABI, -1)
 	if len(matches) != 1 || len(matches[0]) != 3 {
 		return "", nil, nil, errors.Errorf("bad ABI specification: %s", theABI)
@@ -45,6 +45,7 @@ func makeHTTPRequest(
 	for i := 0; i+1 < len(reqHeaders); i += 2 {
 		request.Header.Set(reqHeaders[i], reqHeaders[i+1])
 	}
+	injectTraceContext(ctx, request.Header)
 
 	httpRequest := clhttp.HTTPRequest{
 		Client:  client,
@@ -0,0 +1,214 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// maxHTTPCacheEntries bounds the number of responses held by an httpResponseCache.
+const maxHTTPCacheEntries = 10_000
+
+var (
+	promHTTPCacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_task_http_cache_hits_total",
+		Help: "Number of http and bridge task requests answered from the shared response cache",
+	},
+		[]string{"task_type"},
+	)
+	promHTTPCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_task_http_cache_misses_total",
+		Help: "Number of cacheable http and bridge task requests not found in the shared response cache",
+	},
+		[]string{"task_type"},
+	)
+	promHTTPCacheCoalesced = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_task_http_cache_coalesced_total",
+		Help: "Number of http and bridge task requests that shared an identical in-flight request",
+	},
+		[]string{"task_type"},
+	)
+)
+
+// httpResponse is a response to an http or bridge task request.
+type httpResponse struct {
+	body       []byte
+	statusCode int
+	headers    http.Header
+	elapsed    time.Duration
+}
+
+type httpCacheEntry struct {
+	response  httpResponse
+	expiresAt time.Time
+}
+
+// httpResponseCache is a cache of successful http and bridge task responses, shared by all jobs
+// run by a runner. Tasks opt in by setting a cache TTL. Identical concurrent requests are coalesced
+// into a single outbound call. The call is not bound to any one caller's context, so a caller
+// giving up does not fail the others waiting on it.
+//
+// Cached responses are shared between jobs, so they must be treated as read-only.
+type httpResponseCache struct {
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]httpCacheEntry
+	now     func() time.Time
+}
+
+func newHTTPResponseCache() *httpResponseCache {
+	return &httpResponseCache{entries: make(map[string]httpCacheEntry), now: time.Now}
+}
+
+// httpCacheKey identifies a request by method, URL, body and headers. Requests sent with the
+// unrestricted client never share responses with those sent with the restricted one, so a
+// restricted request cannot read a response fetched from a local resource.
+func httpCacheKey(method StringParam, url URLParam, reqHeaders []string, requestData MapParam, unrestricted bool) (string, error) {
+	// headers are set in order, so later values win, as in makeHTTPRequest
+	headers := make(http.Header)
+	for i := 0; i+1 < len(reqHeaders); i += 2 {
+		headers.Set(reqHeaders[i], reqHeaders[i+1])
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sortedHeaders := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		sortedHeaders = append(sortedHeaders, name, headers.Get(name))
+	}
+
+	// map keys are marshalled in sorted order, so identical bodies have identical keys
+	b, err := json.Marshal(struct {
+		Method       string
+		URL          string
+		Body         MapParam
+		Headers      []string
+		Unrestricted bool
+	}{string(method), url.String(), requestData, sortedHeaders, unrestricted})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode cache key")
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fetch returns the cached response for key if it has not expired. Otherwise it calls send, or
+// waits for an identical in-flight call, and caches a successful response for ttl. send is called
+// with a context detached from ctx, which times out after timeout if it is positive; ctx only
+// bounds how long this caller waits.
+func (c *httpResponseCache) fetch(ctx context.Context, taskType TaskType, key string, ttl time.Duration, timeout time.Duration, send func(context.Context) (httpResponse, error)) (response httpResponse, cached bool, err error) {
+	if response, ok := c.get(key); ok {
+		promHTTPCacheHits.WithLabelValues(string(taskType)).Inc()
+		return response, true, nil
+	}
+	promHTTPCacheMisses.WithLabelValues(string(taskType)).Inc()
+
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		sendCtx, cancel := context.Background(), context.CancelFunc(func() {})
+		if timeout > 0 {
+			sendCtx, cancel = context.WithTimeout(context.Background(), timeout)
+		}
+		defer cancel()
+		response, err := send(sendCtx)
+		if err == nil {
+			c.set(key, response, ttl)
+		}
+		return response, err
+	})
+	select {
+	case <-ctx.Done():
+		return response, false, errors.New("http request timed out or interrupted")
+	case result := <-ch:
+		if result.Shared {
+			promHTTPCacheCoalesced.WithLabelValues(string(taskType)).Inc()
+		}
+		return result.Val.(httpResponse), false, result.Err
+	}
+}
+
+func (c *httpResponseCache) get(key string) (httpResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return httpResponse{}, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return httpResponse{}, false
+	}
+	return entry.response, true
+}
+
+func (c *httpResponseCache) set(key string, response httpResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= maxHTTPCacheEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxHTTPCacheEntries {
+			return
+		}
+	}
+	c.entries[key] = httpCacheEntry{response: response, expiresAt: now.Add(ttl)}
+}
+
+// makeCachedHTTPRequest sends the request with makeHTTPRequest, through cache if ttl is positive.
+// A request shared through the cache is given the time left before the deadline of ctx, which
+// carries the task timeout. The status code is set on errors too.
+func makeCachedHTTPRequest(
+	ctx context.Context,
+	lggr logger.Logger,
+	cache *httpResponseCache,
+	taskType TaskType,
+	ttl time.Duration,
+	unrestricted bool,
+	method StringParam,
+	url URLParam,
+	reqHeaders []string,
+	requestData MapParam,
+	client *http.Client,
+	httpLimit int64,
+) (response httpResponse, cached bool, err error) {
+	send := func(ctx context.Context) (httpResponse, error) {
+		body, statusCode, headers, elapsed, err := makeHTTPRequest(ctx, lggr, method, url, reqHeaders, requestData, client, httpLimit)
+		return httpResponse{body: body, statusCode: statusCode, headers: headers, elapsed: elapsed}, err
+	}
+	if cache == nil || ttl <= 0 {
+		response, err = send(ctx)
+		return response, false, err
+	}
+
+	key, err := httpCacheKey(method, url, reqHeaders, requestData, unrestricted)
+	if err != nil {
+		return response, false, err
+	}
+	if ttl > stalenessCap {
+		lggr.Warnf("%s task cache TTL exceeds stalenessCap %s, overriding value to stalenessCap", taskType, stalenessCap)
+		ttl = stalenessCap
+	}
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return cache.fetch(ctx, taskType, key, ttl, timeout, send)
+}
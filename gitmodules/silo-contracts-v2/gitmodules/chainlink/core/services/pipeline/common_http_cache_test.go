@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+func TestHTTPCacheKey(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse("https://example.com/price")
+	require.NoError(t, err)
+	key := func(method string, headers []string, body MapParam, unrestricted bool) string {
+		k, err := httpCacheKey(StringParam(method), URLParam(*u), headers, body, unrestricted)
+		require.NoError(t, err)
+		return k
+	}
+
+	base := key("POST", []string{"X-A", "1", "X-B", "2"}, MapParam{"a": 1, "b": "two"}, false)
+	assert.Equal(t, base, key("POST", []string{"x-b", "2", "X-A", "1"}, MapParam{"b": "two", "a": 1}, false), "header and key order do not matter")
+	assert.Equal(t, base, key("POST", []string{"X-A", "0", "X-B", "2", "X-A", "1"}, MapParam{"a": 1, "b": "two"}, false), "later headers win")
+	assert.NotEqual(t, base, key("GET", []string{"X-A", "1", "X-B", "2"}, MapParam{"a": 1, "b": "two"}, false))
+	assert.NotEqual(t, base, key("POST", []string{"X-A", "1"}, MapParam{"a": 1, "b": "two"}, false))
+	assert.NotEqual(t, base, key("POST", []string{"X-A", "1", "X-B", "2"}, MapParam{"a": 2, "b": "two"}, false))
+	assert.NotEqual(t, base, key("POST", []string{"X-A", "1", "X-B", "2"}, MapParam{"a": 1, "b": "two"}, true), "restricted and unrestricted requests do not share responses")
+}
+
+func TestHTTPResponseCache_Fetch(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+
+	t.Run("caches successful responses until they expire", func(t *testing.T) {
+		cache := newHTTPResponseCache()
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		var calls int
+		send := func(context.Context) (httpResponse, error) {
+			calls++
+			return httpResponse{body: []byte("42"), statusCode: 200}, nil
+		}
+
+		response, cached, err := cache.fetch(ctx, TaskTypeHTTP, "k", time.Minute, 0, send)
+		require.NoError(t, err)
+		assert.False(t, cached)
+		assert.Equal(t, "42", string(response.body))
+
+		response, cached, err = cache.fetch(ctx, TaskTypeHTTP, "k", time.Minute, 0, send)
+		require.NoError(t, err)
+		assert.True(t, cached)
+		assert.Equal(t, "42", string(response.body))
+		assert.Equal(t, 1, calls)
+
+		now = now.Add(time.Minute)
+		_, cached, err = cache.fetch(ctx, TaskTypeHTTP, "k", time.Minute, 0, send)
+		require.NoError(t, err)
+		assert.False(t, cached)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		cache := newHTTPResponseCache()
+		var calls int
+		send := func(context.Context) (httpResponse, error) {
+			calls++
+			return httpResponse{statusCode: 500}, errors.New("boom")
+		}
+
+		response, _, err := cache.fetch(ctx, TaskTypeHTTP, "k", time.Minute, 0, send)
+		require.EqualError(t, err, "boom")
+		assert.Equal(t, 500, response.statusCode)
+		_, _, err = cache.fetch(ctx, TaskTypeHTTP, "k", time.Minute, 0, send)
+		require.Error(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("coalesces concurrent requests", func(t *testing.T) {
+		cache := newHTTPResponseCache()
+		release := make(chan struct{})
+		var calls atomic.Int32
+		send := func(context.Context) (httpResponse, error) {
+			calls.Add(1)
+			<-release
+			return httpResponse{body: []byte("42")}, nil
+		}
+
+		const n = 5
+		var wg sync.WaitGroup
+		wg.Add(n)
+		for i := 0; i < n; i++ {
+			go func() {
+				defer wg.Done()
+				response, _, err := cache.fetch(ctx, TaskTypeBridge, "k", time.Minute, 0, send)
+				assert.NoError(t, err)
+				assert.Equal(t, "42", string(response.body))
+			}()
+		}
+		// wait for the first call to be in flight before releasing it
+		require.Eventually(t, func() bool { return calls.Load() == 1 }, testutils.WaitTimeout(t), 10*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("a caller giving up does not fail the others", func(t *testing.T) {
+		cache := newHTTPResponseCache()
+		release := make(chan struct{})
+		started := make(chan struct{})
+		send := func(ctx context.Context) (httpResponse, error) {
+			close(started)
+			select {
+			case <-release:
+				return httpResponse{body: []byte("42")}, nil
+			case <-ctx.Done():
+				return httpResponse{}, ctx.Err()
+			}
+		}
+
+		firstCtx, cancelFirst := context.WithCancel(ctx)
+		firstErr := make(chan error)
+		go func() {
+			_, _, err := cache.fetch(firstCtx, TaskTypeHTTP, "k", time.Minute, time.Minute, send)
+			firstErr <- err
+		}()
+		<-started
+
+		second := make(chan httpResponse)
+		go func() {
+			response, _, err := cache.fetch(ctx, TaskTypeHTTP, "k", time.Minute, time.Minute, send)
+			assert.NoError(t, err)
+			second <- response
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		cancelFirst()
+		require.Error(t, <-firstErr)
+		close(release)
+		assert.Equal(t, "42", string((<-second).body))
+	})
+}
@@ -0,0 +1,342 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jpillora/backoff"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+const (
+	// wsStreamIdleTimeout is how long a stream is kept open without being read by any task.
+	wsStreamIdleTimeout = 10 * time.Minute
+	// wsStreamReadLimit bounds the size of a single message.
+	wsStreamReadLimit   = 1 << 20
+	wsStreamDialTimeout = 10 * time.Second
+)
+
+var (
+	ErrWSStreamClosed = errors.New("websocket stream manager is closed")
+
+	promWSStreamMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pipeline_ws_stream_messages_total",
+		Help: "Number of messages received on websocket streams",
+	})
+	promWSStreamReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pipeline_ws_stream_reconnects_total",
+		Help: "Number of times websocket streams were reconnected",
+	})
+)
+
+// wsStreamKey identifies a stream. Streams with the same URL but different subscribe messages or
+// network access are separate connections.
+type wsStreamKey struct {
+	url          string
+	subscribe    string
+	unrestricted bool
+}
+
+// String identifies the stream by the host of its URL and a hash of the whole key. It is used in
+// health reports, which are served without authentication, so it never includes the path or query
+// of the URL, which may hold credentials, nor the subscribe message.
+func (k wsStreamKey) String() string {
+	host := "invalid-url"
+	if u, err := url.Parse(k.url); err == nil {
+		host = u.Host
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%t", k.url, k.subscribe, k.unrestricted)))
+	return fmt.Sprintf("%s#%s", host, hex.EncodeToString(sum[:6]))
+}
+
+// wsStreamManager keeps a WebSocket connection open for each stream read by wsLatest tasks, and
+// the latest message received on it. It is shared by all jobs run by a runner. Streams are opened
+// on first use, reconnected with backoff, and closed once no task has read them for
+// wsStreamIdleTimeout.
+type wsStreamManager struct {
+	lggr               logger.Logger
+	dialer             *websocket.Dialer
+	unrestrictedDialer *websocket.Dialer
+
+	mu      sync.Mutex
+	streams map[wsStreamKey]*wsStream
+	closed  bool
+
+	chStop utils.StopChan
+	wg     sync.WaitGroup
+}
+
+// newWSStreamManager dials streams with the transports of the given HTTP clients, so that they
+// are subject to the same network restrictions as http tasks.
+func newWSStreamManager(lggr logger.Logger, httpClient, unrestrictedHTTPClient *http.Client) *wsStreamManager {
+	return &wsStreamManager{
+		lggr:               lggr.Named("WSStreams"),
+		dialer:             newWSDialer(httpClient),
+		unrestrictedDialer: newWSDialer(unrestrictedHTTPClient),
+		streams:            make(map[wsStreamKey]*wsStream),
+		chStop:             make(chan struct{}),
+	}
+}
+
+func newWSDialer(client *http.Client) *websocket.Dialer {
+	dialer := &websocket.Dialer{Proxy: http.ProxyFromEnvironment, HandshakeTimeout: wsStreamDialTimeout}
+	if client == nil {
+		return dialer
+	}
+	if tr, ok := client.Transport.(*http.Transport); ok {
+		dialer.Proxy = tr.Proxy
+		dialer.NetDialContext = tr.DialContext
+	}
+	return dialer
+}
+
+type wsStream struct {
+	key wsStreamKey
+
+	mu         sync.Mutex
+	latest     []byte
+	receivedAt time.Time
+	connected  bool
+	err        error
+	readAt     time.Time
+	// maxStaleness is the largest staleness accepted by the tasks reading the stream, used to
+	// report its health
+	maxStaleness time.Duration
+	// chReceived is closed and replaced whenever a message is received
+	chReceived chan struct{}
+}
+
+// wsMessage is the latest message of a stream.
+type wsMessage struct {
+	data       []byte
+	receivedAt time.Time
+}
+
+// latest returns the latest message of the stream identified by key, opening it if needed. If no
+// message was received yet, it waits for one until ctx is done.
+func (m *wsStreamManager) latest(ctx context.Context, key wsStreamKey, maxStaleness time.Duration) (wsMessage, error) {
+	stream, err := m.stream(key)
+	if err != nil {
+		return wsMessage{}, err
+	}
+	for {
+		stream.mu.Lock()
+		if maxStaleness > stream.maxStaleness {
+			stream.maxStaleness = maxStaleness
+		}
+		msg, received, streamErr := wsMessage{stream.latest, stream.receivedAt}, stream.chReceived, stream.err
+		stream.mu.Unlock()
+
+		if !msg.receivedAt.IsZero() {
+			return msg, nil
+		}
+		select {
+		case <-received:
+		case <-ctx.Done():
+			if streamErr != nil {
+				return wsMessage{}, errors.Wrap(streamErr, "no message received")
+			}
+			return wsMessage{}, errors.New("no message received")
+		}
+	}
+}
+
+func (m *wsStreamManager) stream(key wsStreamKey) (*wsStream, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil, ErrWSStreamClosed
+	}
+	// marking the stream as read while holding the lock keeps it from being closed as idle
+	if stream, ok := m.streams[key]; ok {
+		stream.mu.Lock()
+		stream.readAt = time.Now()
+		stream.mu.Unlock()
+		return stream, nil
+	}
+	stream := &wsStream{key: key, readAt: time.Now(), chReceived: make(chan struct{})}
+	m.streams[key] = stream
+	m.wg.Add(1)
+	go m.run(stream)
+	return stream, nil
+}
+
+// run keeps stream connected until it is idle or the manager is closed.
+func (m *wsStreamManager) run(stream *wsStream) {
+	defer m.wg.Done()
+	lggr := m.lggr.With("url", stream.key.url)
+	ctx, cancel := m.chStop.NewCtx()
+	defer cancel()
+
+	b := backoff.Backoff{Min: time.Second, Max: time.Minute, Factor: 2, Jitter: true}
+	for {
+		connectedAt := time.Now()
+		err := m.connect(ctx, stream)
+		if ctx.Err() != nil {
+			return
+		}
+		stream.setDisconnected(err)
+		if m.closeIfIdle(stream) {
+			lggr.Debugw("Closed idle websocket stream", "subscribe", stream.key.subscribe)
+			return
+		}
+		lggr.Warnw("Websocket stream disconnected", "subscribe", stream.key.subscribe, "err", err)
+		promWSStreamReconnects.Inc()
+		// start backing off from the minimum again if the connection stayed up for a while
+		if time.Since(connectedAt) > b.Max {
+			b.Reset()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(b.Duration()):
+		}
+	}
+}
+
+// connect dials the stream, sends the subscribe message and stores received messages until the
+// connection fails or the stream is idle.
+func (m *wsStreamManager) connect(ctx context.Context, stream *wsStream) error {
+	dialCtx, cancel := context.WithTimeout(ctx, wsStreamDialTimeout)
+	dialer := m.dialer
+	if stream.key.unrestricted {
+		dialer = m.unrestrictedDialer
+	}
+	conn, _, err := dialer.DialContext(dialCtx, stream.key.url, nil) //nolint:bodyclose
+	cancel()
+	if err != nil {
+		return errors.Wrap(err, "failed to dial")
+	}
+	defer conn.Close()
+	conn.SetReadLimit(wsStreamReadLimit)
+
+	if stream.key.subscribe != "" {
+		if err = conn.WriteMessage(websocket.TextMessage, []byte(stream.key.subscribe)); err != nil {
+			return errors.Wrap(err, "failed to subscribe")
+		}
+	}
+	stream.setConnected()
+
+	// unblock the read loop when stopping or when the stream becomes idle
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(wsStreamIdleTimeout / 10)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-ticker.C:
+				if stream.idle() {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return errors.Wrap(err, "failed to read")
+		}
+		stream.received(data)
+		promWSStreamMessages.Inc()
+	}
+}
+
+// closeIfIdle removes stream from the manager if no task read it for wsStreamIdleTimeout.
+func (m *wsStreamManager) closeIfIdle(stream *wsStream) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !stream.idle() {
+		return false
+	}
+	delete(m.streams, stream.key)
+	return true
+}
+
+// HealthReport reports every stream as unhealthy while it is disconnected or its latest message
+// is older than the largest maxStaleness of the tasks reading it.
+func (m *wsStreamManager) HealthReport() map[string]error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	report := make(map[string]error, len(m.streams))
+	for key, stream := range m.streams {
+		report[fmt.Sprintf("%s.%s", m.lggr.Name(), key)] = stream.health()
+	}
+	return report
+}
+
+func (m *wsStreamManager) close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	m.mu.Unlock()
+	close(m.chStop)
+	m.wg.Wait()
+}
+
+func (s *wsStream) received(data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest = data
+	s.receivedAt = time.Now()
+	close(s.chReceived)
+	s.chReceived = make(chan struct{})
+}
+
+func (s *wsStream) setConnected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connected = true
+	s.err = nil
+}
+
+func (s *wsStream) setDisconnected(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connected = false
+	s.err = err
+}
+
+func (s *wsStream) idle() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Since(s.readAt) > wsStreamIdleTimeout
+}
+
+func (s *wsStream) health() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case !s.connected && s.err != nil:
+		return errors.Wrap(s.err, "disconnected")
+	case !s.connected:
+		return errors.New("connecting")
+	case s.receivedAt.IsZero():
+		return errors.New("no message received")
+	case s.maxStaleness > 0 && time.Since(s.receivedAt) > s.maxStaleness:
+		return errors.Errorf("stale: latest message received %s ago, max staleness is %s", time.Since(s.receivedAt).Round(time.Second), s.maxStaleness)
+	}
+	return nil
+}
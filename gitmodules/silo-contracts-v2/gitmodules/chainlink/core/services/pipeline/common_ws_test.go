@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	clhttp "github.com/smartcontractkit/chainlink/v2/core/utils/http"
+)
+
+func TestWSStreamManager(t *testing.T) {
+	t.Parallel()
+
+	s, push := NewWSTestServer(t)
+	m := newWSStreamManager(logger.TestLogger(t), nil, clhttp.NewUnrestrictedHTTPClient())
+	t.Cleanup(m.close)
+
+	key := wsStreamKey{url: "ws" + strings.TrimPrefix(s.URL, "http"), subscribe: `{"channel":"ETH-USD"}`, unrestricted: true}
+	msg, err := m.latest(testutils.Context(t), key, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, `subscribed:{"channel":"ETH-USD"}`, string(msg.data))
+
+	push <- "42"
+	require.Eventually(t, func() bool {
+		msg, err = m.latest(testutils.Context(t), key, time.Minute)
+		return err == nil && string(msg.data) == "42"
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+	report := m.HealthReport()
+	require.Len(t, report, 1)
+	for name, err := range report {
+		assert.Contains(t, name, strings.TrimPrefix(s.URL, "http://"))
+		assert.NotContains(t, name, "ETH-USD")
+		assert.NoError(t, err)
+	}
+	secretKey := wsStreamKey{url: "wss://example.com/feed?apiKey=secret", subscribe: key.subscribe}
+	assert.Equal(t, "example.com", strings.Split(secretKey.String(), "#")[0])
+	assert.NotEqual(t, secretKey.String(), wsStreamKey{url: secretKey.url}.String())
+
+	push <- ""
+	require.Eventually(t, func() bool {
+		for _, err := range m.HealthReport() {
+			return err != nil
+		}
+		return false
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+	m.close()
+	_, err = m.latest(testutils.Context(t), key, time.Minute)
+	require.ErrorIs(t, err, ErrWSStreamClosed)
+}
@@ -0,0 +1,618 @@
+package pipeline
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// The expression language evaluated by ExpressionTask. It is deliberately small: there are no
+// loops, assignments or user defined functions, so the cost of evaluating an expression is bounded
+// by the number of nodes in its syntax tree, which is checked when the expression is compiled.
+//
+// Numbers are arbitrary precision decimals with the same semantics as the math tasks. Identifiers
+// are keypaths into the pipeline Vars, e.g. `ds1_parse` or `jobRun.meta.value`, and `input` refers
+// to the single task input, if any.
+//
+//	expr     = or [ "?" expr ":" expr ]
+//	or       = and { "||" and }
+//	and      = equality { "&&" equality }
+//	equality = compare { ( "==" | "!=" ) compare }
+//	compare  = sum { ( "<" | "<=" | ">" | ">=" ) sum }
+//	sum      = product { ( "+" | "-" ) product }
+//	product  = unary { ( "*" | "/" | "%" ) unary }
+//	unary    = ( "-" | "!" ) unary | primary
+//	primary  = number | string | "true" | "false" | keypath | func "(" [ expr { "," expr } ] ")" | "(" expr ")"
+//
+// Supported functions are min, max, abs, floor, ceil and round(x[, places]).
+
+const (
+	// maxExpressionLength is the maximum length of an expression, in bytes.
+	maxExpressionLength = 4096
+	// maxExpressionCost is the maximum number of nodes in the syntax tree of an expression.
+	maxExpressionCost = 1000
+	// maxExpressionScale bounds decimal exponents of literals and the places passed to round,
+	// which would otherwise allow a short expression to allocate arbitrarily large numbers.
+	maxExpressionScale = 1000
+)
+
+var (
+	ErrExpressionSyntax = errors.New("expression syntax error")
+	ErrExpressionCost   = errors.New("expression too expensive")
+	ErrExpressionType   = errors.New("expression type error")
+)
+
+// expressionEnv holds what an expression is evaluated against.
+type expressionEnv struct {
+	vars      Vars
+	input     interface{}
+	hasInput  bool
+	precision *int32
+}
+
+type expressionNode interface {
+	eval(env *expressionEnv) (interface{}, error)
+}
+
+type expressionProgram struct {
+	root expressionNode
+	cost int
+}
+
+// compileExpression parses src and checks that it is within the length and cost limits.
+func compileExpression(src string) (*expressionProgram, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.Wrap(ErrExpressionSyntax, "empty expression")
+	}
+	if len(src) > maxExpressionLength {
+		return nil, errors.Wrapf(ErrExpressionCost, "expression is %d bytes long, max is %d", len(src), maxExpressionLength)
+	}
+	tokens, err := lexExpression(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &expressionParser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != exprTokEOF {
+		return nil, errors.Wrapf(ErrExpressionSyntax, "unexpected %s at offset %d", tok, tok.pos)
+	}
+	return &expressionProgram{root: root, cost: p.cost}, nil
+}
+
+// eval evaluates the program. Panics from the decimal library, e.g. on exponent overflow, are
+// returned as errors.
+func (p *expressionProgram) eval(env *expressionEnv) (val interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			val, err = nil, errors.Errorf("expression evaluation failed: %v", r)
+		}
+	}()
+	return p.root.eval(env)
+}
+
+type exprTokenKind int
+
+const (
+	exprTokEOF exprTokenKind = iota
+	exprTokNumber
+	exprTokString
+	exprTokIdent
+	exprTokPunct
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	num  decimal.Decimal
+	pos  int
+}
+
+func (t exprToken) String() string {
+	if t.kind == exprTokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+var exprPuncts = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%", "!", "?", ":", "(", ")", ","}
+
+func lexExpression(src string) (tokens []exprToken, err error) {
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isExprDigit(c):
+			start := i
+			for i < len(src) && isExprDigit(src[i]) {
+				i++
+			}
+			if i < len(src) && src[i] == '.' {
+				i++
+				for i < len(src) && isExprDigit(src[i]) {
+					i++
+				}
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && isExprDigit(src[i]) {
+					i++
+				}
+			}
+			d, err := decimal.NewFromString(src[start:i])
+			if err != nil {
+				return nil, errors.Wrapf(ErrExpressionSyntax, "invalid number %q at offset %d", src[start:i], start)
+			}
+			if exp := d.Exponent(); exp > maxExpressionScale || exp < -maxExpressionScale {
+				return nil, errors.Wrapf(ErrExpressionSyntax, "number %q at offset %d is out of range", src[start:i], start)
+			}
+			tokens = append(tokens, exprToken{kind: exprTokNumber, text: src[start:i], num: d, pos: start})
+		case isExprIdentStart(c):
+			start := i
+			for i < len(src) && (isExprIdentStart(src[i]) || isExprDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			ident := src[start:i]
+			if strings.HasSuffix(ident, ".") || strings.Contains(ident, "..") {
+				return nil, errors.Wrapf(ErrExpressionSyntax, "invalid keypath %q at offset %d", ident, start)
+			}
+			tokens = append(tokens, exprToken{kind: exprTokIdent, text: ident, pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for ; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				sb.WriteByte(src[i])
+			}
+			if i >= len(src) {
+				return nil, errors.Wrapf(ErrExpressionSyntax, "unterminated string at offset %d", start)
+			}
+			i++
+			tokens = append(tokens, exprToken{kind: exprTokString, text: sb.String(), pos: start})
+		default:
+			var punct string
+			for _, p := range exprPuncts {
+				if strings.HasPrefix(src[i:], p) {
+					punct = p
+					break
+				}
+			}
+			if punct == "" {
+				return nil, errors.Wrapf(ErrExpressionSyntax, "unexpected character %q at offset %d", c, i)
+			}
+			tokens = append(tokens, exprToken{kind: exprTokPunct, text: punct, pos: i})
+			i += len(punct)
+		}
+	}
+	return append(tokens, exprToken{kind: exprTokEOF, pos: len(src)}), nil
+}
+
+func isExprDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isExprIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+type expressionParser struct {
+	tokens []exprToken
+	pos    int
+	cost   int
+}
+
+func (p *expressionParser) peek() exprToken { return p.tokens[p.pos] }
+
+func (p *expressionParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != exprTokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of puncts.
+func (p *expressionParser) accept(puncts ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != exprTokPunct {
+		return "", false
+	}
+	for _, punct := range puncts {
+		if tok.text == punct {
+			p.pos++
+			return punct, true
+		}
+	}
+	return "", false
+}
+
+func (p *expressionParser) expect(punct string) error {
+	if _, ok := p.accept(punct); !ok {
+		tok := p.peek()
+		return errors.Wrapf(ErrExpressionSyntax, "expected %q, got %s at offset %d", punct, tok, tok.pos)
+	}
+	return nil
+}
+
+// node accounts for a new node in the syntax tree.
+func (p *expressionParser) node(n expressionNode) (expressionNode, error) {
+	p.cost++
+	if p.cost > maxExpressionCost {
+		return nil, errors.Wrapf(ErrExpressionCost, "expression has more than %d nodes", maxExpressionCost)
+	}
+	return n, nil
+}
+
+func (p *expressionParser) parseExpr() (expressionNode, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+	then, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return p.node(&exprTernary{cond: cond, then: then, otherwise: otherwise})
+}
+
+// exprBinaryLevels lists the binary operators from lowest to highest precedence.
+var exprBinaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *expressionParser) parseBinary(level int) (expressionNode, error) {
+	if level == len(exprBinaryLevels) {
+		return p.parseUnary()
+	}
+	x, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(exprBinaryLevels[level]...)
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		if x, err = p.node(&exprBinary{op: op, x: x, y: y}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *expressionParser) parseUnary() (expressionNode, error) {
+	if op, ok := p.accept("-", "!"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return p.node(&exprUnary{op: op, x: x})
+	}
+	return p.parsePrimary()
+}
+
+func (p *expressionParser) parsePrimary() (expressionNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case exprTokNumber:
+		return p.node(&exprLiteral{value: tok.num})
+	case exprTokString:
+		return p.node(&exprLiteral{value: tok.text})
+	case exprTokIdent:
+		switch tok.text {
+		case "true":
+			return p.node(&exprLiteral{value: true})
+		case "false":
+			return p.node(&exprLiteral{value: false})
+		}
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(tok)
+		}
+		return p.node(&exprVar{keypath: tok.text})
+	case exprTokPunct:
+		if tok.text == "(" {
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrExpressionSyntax, "unexpected %s at offset %d", tok, tok.pos)
+}
+
+func (p *expressionParser) parseCall(name exprToken) (expressionNode, error) {
+	fn, ok := exprFuncs[name.text]
+	if !ok {
+		return nil, errors.Wrapf(ErrExpressionSyntax, "unknown function %q at offset %d", name.text, name.pos)
+	}
+	var args []expressionNode
+	if _, ok = p.accept(")"); !ok {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok = p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, errors.Wrapf(ErrExpressionSyntax, "wrong number of arguments to %s at offset %d: got %d", name.text, name.pos, len(args))
+	}
+	return p.node(&exprCall{name: name.text, fn: fn, args: args})
+}
+
+type exprLiteral struct{ value interface{} }
+
+func (n *exprLiteral) eval(*expressionEnv) (interface{}, error) { return n.value, nil }
+
+type exprVar struct{ keypath string }
+
+func (n *exprVar) eval(env *expressionEnv) (interface{}, error) {
+	if n.keypath == InputTaskKey {
+		if !env.hasInput {
+			return nil, errors.Wrap(ErrParameterEmpty, "expression refers to input, but the task has no inputs")
+		}
+		return env.input, nil
+	}
+	return env.vars.Get(n.keypath)
+}
+
+type exprUnary struct {
+	op string
+	x  expressionNode
+}
+
+func (n *exprUnary) eval(env *expressionEnv) (interface{}, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		b, err := exprBool(x)
+		return !b, err
+	}
+	d, err := exprDecimal(x)
+	return d.Neg(), err
+}
+
+type exprBinary struct {
+	op   string
+	x, y expressionNode
+}
+
+func (n *exprBinary) eval(env *expressionEnv) (interface{}, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "&&", "||":
+		b, err := exprBool(x)
+		if err != nil || b == (n.op == "||") {
+			return b, err
+		}
+		y, err := n.y.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		return exprBool(y)
+	}
+
+	y, err := n.y.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==", "!=":
+		eq, err := exprEqual(x, y)
+		return eq == (n.op == "=="), err
+	}
+
+	a, err := exprDecimal(x)
+	if err != nil {
+		return nil, err
+	}
+	b, err := exprDecimal(y)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "<":
+		return a.LessThan(b), nil
+	case "<=":
+		return a.LessThanOrEqual(b), nil
+	case ">":
+		return a.GreaterThan(b), nil
+	case ">=":
+		return a.GreaterThanOrEqual(b), nil
+	case "+":
+		return a.Add(b), nil
+	case "-":
+		return a.Sub(b), nil
+	case "*":
+		if exp := int64(a.Exponent()) + int64(b.Exponent()); exp > math.MaxInt32 || exp < math.MinInt32 {
+			return nil, ErrMultiplyOverlow
+		}
+		return a.Mul(b), nil
+	case "/":
+		if b.IsZero() {
+			return nil, ErrDivideByZero
+		}
+		if env.precision == nil {
+			return a.Div(b), nil
+		}
+		if exp := int64(a.Exponent()) - int64(b.Exponent()) + int64(*env.precision); exp > math.MaxInt32 || exp < math.MinInt32 {
+			return nil, ErrDivisionOverlow
+		}
+		return a.DivRound(b, *env.precision), nil
+	case "%":
+		if b.IsZero() {
+			return nil, ErrDivideByZero
+		}
+		return a.Mod(b), nil
+	}
+	return nil, errors.Errorf("unknown operator %q", n.op)
+}
+
+type exprTernary struct {
+	cond, then, otherwise expressionNode
+}
+
+func (n *exprTernary) eval(env *expressionEnv) (interface{}, error) {
+	c, err := n.cond.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	b, err := exprBool(c)
+	if err != nil {
+		return nil, err
+	}
+	if b {
+		return n.then.eval(env)
+	}
+	return n.otherwise.eval(env)
+}
+
+type exprFunc struct {
+	minArgs, maxArgs int
+	call             func(args []decimal.Decimal) (decimal.Decimal, error)
+}
+
+var exprFuncs = map[string]exprFunc{
+	"min": {1, -1, func(args []decimal.Decimal) (decimal.Decimal, error) {
+		return decimal.Min(args[0], args[1:]...), nil
+	}},
+	"max": {1, -1, func(args []decimal.Decimal) (decimal.Decimal, error) {
+		return decimal.Max(args[0], args[1:]...), nil
+	}},
+	"abs": {1, 1, func(args []decimal.Decimal) (decimal.Decimal, error) {
+		return args[0].Abs(), nil
+	}},
+	"floor": {1, 1, func(args []decimal.Decimal) (decimal.Decimal, error) {
+		return args[0].Floor(), nil
+	}},
+	"ceil": {1, 1, func(args []decimal.Decimal) (decimal.Decimal, error) {
+		return args[0].Ceil(), nil
+	}},
+	"round": {1, 2, func(args []decimal.Decimal) (decimal.Decimal, error) {
+		var places int64
+		if len(args) == 2 {
+			if !args[1].Equal(args[1].Truncate(0)) {
+				return decimal.Decimal{}, errors.Wrapf(ErrExpressionType, "round places must be an integer, got %s", args[1])
+			}
+			places = args[1].IntPart()
+			if places > maxExpressionScale || places < -maxExpressionScale {
+				return decimal.Decimal{}, errors.Wrapf(ErrExpressionType, "round places must be between %d and %d, got %d", -maxExpressionScale, maxExpressionScale, places)
+			}
+		}
+		return args[0].Round(int32(places)), nil
+	}},
+}
+
+type exprCall struct {
+	name string
+	fn   exprFunc
+	args []expressionNode
+}
+
+func (n *exprCall) eval(env *expressionEnv) (interface{}, error) {
+	args := make([]decimal.Decimal, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if args[i], err = exprDecimal(v); err != nil {
+			return nil, errors.Wrapf(err, "argument %d to %s", i, n.name)
+		}
+	}
+	return n.fn.call(args)
+}
+
+func exprDecimal(v interface{}) (decimal.Decimal, error) {
+	if d, ok := v.(decimal.Decimal); ok {
+		return d, nil
+	}
+	if _, ok := v.(bool); ok {
+		return decimal.Decimal{}, errors.Wrap(ErrExpressionType, "expected a number, got a bool")
+	}
+	var d DecimalParam
+	if err := d.UnmarshalPipelineParam(v); err != nil {
+		return decimal.Decimal{}, errors.Wrapf(ErrExpressionType, "expected a number, got %T: %v", v, err)
+	}
+	return d.Decimal(), nil
+}
+
+func exprBool(v interface{}) (bool, error) {
+	var b BoolParam
+	if err := b.UnmarshalPipelineParam(v); err != nil {
+		return false, errors.Wrapf(ErrExpressionType, "expected a bool, got %T", v)
+	}
+	return bool(b), nil
+}
+
+// exprEqual compares bools with bools and strings with strings. Anything else is compared as a number.
+func exprEqual(x, y interface{}) (bool, error) {
+	_, xBool := x.(bool)
+	_, yBool := y.(bool)
+	if xBool || yBool {
+		a, err := exprBool(x)
+		if err != nil {
+			return false, err
+		}
+		b, err := exprBool(y)
+		return a == b, err
+	}
+	xs, xString := x.(string)
+	ys, yString := y.(string)
+	if xString && yString {
+		return xs == ys, nil
+	}
+	a, err := exprDecimal(x)
+	if err != nil {
+		return false, err
+	}
+	b, err := exprDecimal(y)
+	if err != nil {
+		return false, err
+	}
+	return a.Equal(b), nil
+}
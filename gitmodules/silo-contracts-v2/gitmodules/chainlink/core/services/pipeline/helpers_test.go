@@ -2,11 +2,16 @@ package pipeline
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"testing"
 
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
 const (
@@ -50,6 +55,12 @@ func (t *HTTPTask) HelperSetDependencies(config Config, restrictedHTTPClient, un
 	t.unrestrictedHTTPClient = unrestrictedHTTPClient
 }
 
+// HelperSetDependencies gives the task its own stream manager, closed at the end of the test.
+func (t *WSLatestTask) HelperSetDependencies(tb testing.TB, restrictedHTTPClient, unrestrictedHTTPClient *http.Client) {
+	t.streams = newWSStreamManager(logger.TestLogger(tb), restrictedHTTPClient, unrestrictedHTTPClient)
+	tb.Cleanup(t.streams.close)
+}
+
 func (t *ETHCallTask) HelperSetDependencies(legacyChains evm.LegacyChainContainer, config Config, specGasLimit *uint32, jobType string) {
 	t.legacyChains = legacyChains
 	t.config = config
@@ -63,3 +74,47 @@ func (t *ETHTxTask) HelperSetDependencies(legacyChains evm.LegacyChainContainer,
 	t.specGasLimit = specGasLimit
 	t.jobType = jobType
 }
+
+func (t *SignTask) HelperSetDependencies(config Config, ocr2KeyStore OCR2KeyStore, jobID int32, jobType string) {
+	t.config = config
+	t.ocr2KeyStore = ocr2KeyStore
+	t.jobID = jobID
+	t.jobType = jobType
+}
+
+// NewWSTestServer starts a websocket server that answers the subscribe message with "subscribed:"
+// followed by the message, then sends every value pushed on the returned channel. Pushing an empty
+// value closes the connection.
+func NewWSTestServer(t *testing.T) (*httptest.Server, chan<- string) {
+	push := make(chan string)
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		_, subscribe, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if err = conn.WriteMessage(websocket.TextMessage, append([]byte("subscribed:"), subscribe...)); err != nil {
+			return
+		}
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case msg := <-push:
+				if msg == "" {
+					return
+				}
+				if err = conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s, push
+}
@@ -0,0 +1,308 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// The JSON Schema (https://json-schema.org) subset validated by JSONSchemaTask. Schemas may use
+// the following keywords, and annotations such as title and description:
+//
+//	type, enum
+//	properties, required, additionalProperties
+//	items, minItems, maxItems
+//	minLength, maxLength
+//	minimum, maximum
+//
+// Any other keyword is rejected, rather than ignored, so that a schema never validates less than
+// it appears to.
+
+const (
+	// maxJSONSchemaDepth bounds the nesting of schemas.
+	maxJSONSchemaDepth = 32
+	// maxJSONSchemaErrors is the maximum number of violations reported.
+	maxJSONSchemaErrors = 10
+)
+
+var (
+	ErrJSONSchemaInvalid    = errors.New("invalid JSON schema")
+	ErrJSONSchemaValidation = errors.New("data does not match schema")
+)
+
+var jsonSchemaIdentRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var jsonSchemaTypes = map[string]bool{
+	"null": true, "boolean": true, "integer": true, "number": true, "string": true, "array": true, "object": true,
+}
+
+// jsonSchemaAnnotations are the keywords accepted without effect on validation.
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true, "examples": true, "default": true,
+}
+
+type jsonSchema struct {
+	// always is set for the boolean schemas true and false
+	always *bool
+
+	types []string
+	enum  []interface{}
+
+	properties           map[string]*jsonSchema
+	required             []string
+	additionalProperties *jsonSchema
+
+	items    *jsonSchema
+	minItems *int
+	maxItems *int
+
+	minLength *int
+	maxLength *int
+
+	minimum *decimal.Decimal
+	maximum *decimal.Decimal
+}
+
+// compileJSONSchema parses a JSON encoded schema.
+func compileJSONSchema(src string) (*jsonSchema, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.Wrap(ErrJSONSchemaInvalid, "empty schema")
+	}
+	var p JSONParam
+	if err := p.UnmarshalPipelineParam(src); err != nil {
+		return nil, errors.Wrapf(ErrJSONSchemaInvalid, "%v", err)
+	}
+	return compileJSONSchemaValue(p.Value(), "#", 0)
+}
+
+func jsonSchemaInvalid(ptr, format string, args ...interface{}) error {
+	return errors.Wrapf(ErrJSONSchemaInvalid, "%s: %s", ptr, fmt.Sprintf(format, args...))
+}
+
+func compileJSONSchemaValue(v interface{}, ptr string, depth int) (*jsonSchema, error) {
+	if depth > maxJSONSchemaDepth {
+		return nil, jsonSchemaInvalid(ptr, "schemas are nested more than %d levels deep", maxJSONSchemaDepth)
+	}
+	s := &jsonSchema{}
+	if b, ok := v.(bool); ok {
+		s.always = &b
+		return s, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, jsonSchemaInvalid(ptr, "schema must be an object or a boolean, got %s", jqTypeName(v))
+	}
+
+	for _, key := range jqSortedKeys(m) {
+		var err error
+		value, keyPtr := m[key], jsonSchemaPointer(ptr, key)
+		switch key {
+		case "type":
+			names := []interface{}{value}
+			if arr, isArr := value.([]interface{}); isArr {
+				names = arr
+			}
+			for _, name := range names {
+				n, isString := name.(string)
+				if !isString || !jsonSchemaTypes[n] {
+					return nil, jsonSchemaInvalid(keyPtr, "unknown type %s", jqDescribe(name))
+				}
+				s.types = append(s.types, n)
+			}
+		case "enum":
+			if s.enum, ok = value.([]interface{}); !ok {
+				return nil, jsonSchemaInvalid(keyPtr, "must be an array")
+			}
+		case "properties":
+			props, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, jsonSchemaInvalid(keyPtr, "must be an object")
+			}
+			s.properties = make(map[string]*jsonSchema, len(props))
+			for _, name := range jqSortedKeys(props) {
+				if s.properties[name], err = compileJSONSchemaValue(props[name], jsonSchemaPointer(keyPtr, name), depth+1); err != nil {
+					return nil, err
+				}
+			}
+		case "required":
+			arr, ok := value.([]interface{})
+			if !ok {
+				return nil, jsonSchemaInvalid(keyPtr, "must be an array of strings")
+			}
+			for _, name := range arr {
+				n, ok := name.(string)
+				if !ok {
+					return nil, jsonSchemaInvalid(keyPtr, "must be an array of strings")
+				}
+				s.required = append(s.required, n)
+			}
+		case "additionalProperties":
+			s.additionalProperties, err = compileJSONSchemaValue(value, keyPtr, depth+1)
+		case "items":
+			s.items, err = compileJSONSchemaValue(value, keyPtr, depth+1)
+		case "minItems":
+			s.minItems, err = jsonSchemaCount(value, keyPtr)
+		case "maxItems":
+			s.maxItems, err = jsonSchemaCount(value, keyPtr)
+		case "minLength":
+			s.minLength, err = jsonSchemaCount(value, keyPtr)
+		case "maxLength":
+			s.maxLength, err = jsonSchemaCount(value, keyPtr)
+		case "minimum", "maximum":
+			d, ok := value.(decimal.Decimal)
+			if !ok {
+				return nil, jsonSchemaInvalid(keyPtr, "must be a number")
+			}
+			if key == "minimum" {
+				s.minimum = &d
+			} else {
+				s.maximum = &d
+			}
+		default:
+			if !jsonSchemaAnnotations[key] {
+				return nil, jsonSchemaInvalid(keyPtr, "unsupported keyword")
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func jsonSchemaCount(v interface{}, ptr string) (*int, error) {
+	d, ok := v.(decimal.Decimal)
+	if !ok || d.IsNegative() || !d.Equal(d.Truncate(0)) || !d.LessThan(decimal.NewFromInt(1<<31)) {
+		return nil, jsonSchemaInvalid(ptr, "must be a non-negative integer")
+	}
+	n := int(d.IntPart())
+	return &n, nil
+}
+
+// jsonSchemaPointer appends the escaped token to the JSON pointer ptr.
+func jsonSchemaPointer(ptr string, token string) string {
+	return ptr + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// validate returns an error listing the violations of the schema by v, which must be normalized
+// by JSONParam.
+func (s *jsonSchema) validate(v interface{}) error {
+	var violations []string
+	s.check(v, "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+	if len(violations) > maxJSONSchemaErrors {
+		violations = append(violations[:maxJSONSchemaErrors], fmt.Sprintf("and %d more", len(violations)-maxJSONSchemaErrors))
+	}
+	return errors.Wrap(ErrJSONSchemaValidation, strings.Join(violations, "; "))
+}
+
+// check appends the violations of the schema by v, located at path, to violations.
+func (s *jsonSchema) check(v interface{}, path string, violations *[]string) {
+	location := path
+	if location == "" {
+		location = "."
+	}
+	violate := func(format string, args ...interface{}) {
+		*violations = append(*violations, location+": "+fmt.Sprintf(format, args...))
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			violate("no value is allowed")
+		}
+		return
+	}
+	if len(s.types) > 0 && !jsonSchemaHasType(v, s.types) {
+		violate("expected %s, got %s", strings.Join(s.types, " or "), jqTypeName(v))
+		// the other keywords would only report the same mismatch
+		return
+	}
+	if s.enum != nil && !jqContains(s.enum, v) {
+		violate("value is not one of the allowed values")
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				*violations = append(*violations, jsonSchemaPath(path, name)+": required property is missing")
+			}
+		}
+		for _, name := range jqSortedKeys(v) {
+			sub, ok := s.properties[name]
+			if !ok {
+				sub = s.additionalProperties
+			}
+			if sub == nil {
+				continue
+			}
+			if sub.always != nil && !*sub.always && !ok {
+				*violations = append(*violations, jsonSchemaPath(path, name)+": property is not allowed")
+				continue
+			}
+			sub.check(v[name], jsonSchemaPath(path, name), violations)
+		}
+	case []interface{}:
+		if s.minItems != nil && len(v) < *s.minItems {
+			violate("%d items is less than minItems %d", len(v), *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			violate("%d items is more than maxItems %d", len(v), *s.maxItems)
+		}
+		if s.items != nil {
+			for i, x := range v {
+				s.items.check(x, fmt.Sprintf("%s[%d]", jsonSchemaArrayPath(path), i), violations)
+			}
+		}
+	case string:
+		n := len([]rune(v))
+		if s.minLength != nil && n < *s.minLength {
+			violate("length %d is less than minLength %d", n, *s.minLength)
+		}
+		if s.maxLength != nil && n > *s.maxLength {
+			violate("length %d is greater than maxLength %d", n, *s.maxLength)
+		}
+	case decimal.Decimal:
+		if s.minimum != nil && v.LessThan(*s.minimum) {
+			violate("%s is less than minimum %s", v, s.minimum)
+		}
+		if s.maximum != nil && v.GreaterThan(*s.maximum) {
+			violate("%s is greater than maximum %s", v, s.maximum)
+		}
+	}
+}
+
+func jsonSchemaHasType(v interface{}, types []string) bool {
+	name := jqTypeName(v)
+	for _, t := range types {
+		if t == name {
+			return true
+		}
+		if d, ok := v.(decimal.Decimal); ok && t == "integer" && d.Equal(d.Truncate(0)) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonSchemaPath returns the jq path of the property name of the object at path.
+func jsonSchemaPath(path, name string) string {
+	if jsonSchemaIdentRegexp.MatchString(name) {
+		return path + "." + name
+	}
+	return fmt.Sprintf("%s[%q]", jsonSchemaArrayPath(path), name)
+}
+
+// jsonSchemaArrayPath returns the path to index into with brackets, so that indexing the root
+// gives ".[0]" rather than "[0]".
+func jsonSchemaArrayPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
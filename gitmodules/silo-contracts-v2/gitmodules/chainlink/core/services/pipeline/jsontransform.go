@@ -0,0 +1,1082 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// The filter language evaluated by JSONTransformTask is a small subset of jq
+// (https://jqlang.github.io/jq/manual/). Filters take a JSON value and produce zero or more
+// values, e.g. `[.data[] | select(.volume > 0) | .price | tonumber] | add / length`. Numbers are
+// arbitrary precision decimals with the same semantics as the math tasks, and object values are
+// visited in key order.
+//
+//	pipe     = compare { "|" compare }
+//	compare  = sum [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) sum ]
+//	sum      = product { ( "+" | "-" ) product }
+//	product  = postfix { ( "*" | "/" ) postfix }
+//	postfix  = term { field | "[" [ pipe ] "]" }
+//	term     = "." | field | [ "-" ] number | string | "true" | "false" | "null" | var
+//	         | "[" [ pipe ] "]" | "(" pipe ")" | name [ "(" pipe ")" ]
+//	         | "reduce" postfix "as" var "(" pipe ";" pipe ")"
+//	field    = "." name | "." string
+//	var      = "$" name
+//
+// Variables are only bound by reduce. The supported builtins are listed in jqFuncs. Evaluation is
+// bounded by maxJSONTransformSteps, which is charged for every node evaluated and every value
+// produced, before the values are built.
+
+const (
+	// maxJSONTransformLength is the maximum length of a filter, in bytes.
+	maxJSONTransformLength = 4096
+	// maxJSONTransformCost is the maximum number of nodes in the syntax tree of a filter.
+	maxJSONTransformCost = 1000
+	// maxJSONTransformSteps bounds the work done to evaluate a filter against a value.
+	maxJSONTransformSteps = 1_000_000
+)
+
+var (
+	ErrJSONTransformSyntax = errors.New("jsontransform syntax error")
+	ErrJSONTransformCost   = errors.New("jsontransform too expensive")
+	ErrJSONTransformType   = errors.New("jsontransform type error")
+)
+
+type jqProgram struct {
+	root jqNode
+}
+
+// compileJQ parses src and checks that it is within the length and cost limits.
+func compileJQ(src string) (*jqProgram, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.Wrap(ErrJSONTransformSyntax, "empty filter")
+	}
+	if len(src) > maxJSONTransformLength {
+		return nil, errors.Wrapf(ErrJSONTransformCost, "filter is %d bytes long, max is %d", len(src), maxJSONTransformLength)
+	}
+	tokens, err := lexJQ(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &jqParser{tokens: tokens}
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != jqTokEOF {
+		return nil, errors.Wrapf(ErrJSONTransformSyntax, "unexpected %s at offset %d", tok, tok.pos)
+	}
+	return &jqProgram{root: root}, nil
+}
+
+// run returns the values produced by the program for input, which must be normalized by
+// JSONParam. Panics from the decimal library, e.g. on exponent overflow, are returned as errors.
+func (p *jqProgram) run(input interface{}) (outputs []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			outputs, err = nil, errors.Errorf("jsontransform evaluation failed: %v", r)
+		}
+	}()
+	return (&jqEnv{}).eval(p.root, input)
+}
+
+type jqTokenKind int
+
+const (
+	jqTokEOF jqTokenKind = iota
+	jqTokNumber
+	jqTokString
+	jqTokIdent
+	jqTokField
+	jqTokVar
+	jqTokPunct
+)
+
+type jqToken struct {
+	kind jqTokenKind
+	text string
+	num  decimal.Decimal
+	pos  int
+}
+
+func (t jqToken) String() string {
+	switch t.kind {
+	case jqTokEOF:
+		return "end of filter"
+	case jqTokField:
+		return fmt.Sprintf("field %q", t.text)
+	case jqTokVar:
+		return fmt.Sprintf("$%s", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (t jqToken) is(punct string) bool {
+	return t.kind == jqTokPunct && t.text == punct
+}
+
+var jqPuncts = []string{"==", "!=", "<=", ">=", "|", "<", ">", "+", "-", "*", "/", "(", ")", "[", "]", ";", "."}
+
+func lexJQ(src string) (tokens []jqToken, err error) {
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isExprDigit(c):
+			start := i
+			for i < len(src) && isExprDigit(src[i]) {
+				i++
+			}
+			if i+1 < len(src) && src[i] == '.' && isExprDigit(src[i+1]) {
+				i++
+				for i < len(src) && isExprDigit(src[i]) {
+					i++
+				}
+			}
+			d, err := decimal.NewFromString(src[start:i])
+			if err != nil {
+				return nil, errors.Wrapf(ErrJSONTransformSyntax, "invalid number %q at offset %d", src[start:i], start)
+			}
+			tokens = append(tokens, jqToken{kind: jqTokNumber, text: src[start:i], num: d, pos: start})
+		case isExprIdentStart(c):
+			start := i
+			i = scanJQIdent(src, i)
+			tokens = append(tokens, jqToken{kind: jqTokIdent, text: src[start:i], pos: start})
+		case c == '$' && i+1 < len(src) && isExprIdentStart(src[i+1]):
+			start := i
+			i = scanJQIdent(src, i+1)
+			tokens = append(tokens, jqToken{kind: jqTokVar, text: src[start+1 : i], pos: start})
+		case c == '.' && i+1 < len(src) && isExprIdentStart(src[i+1]):
+			start := i
+			i = scanJQIdent(src, i+1)
+			tokens = append(tokens, jqToken{kind: jqTokField, text: src[start+1 : i], pos: start})
+		case c == '.' && i+1 < len(src) && src[i+1] == '"':
+			start := i
+			var s string
+			if s, i, err = scanJQString(src, i+1); err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, jqToken{kind: jqTokField, text: s, pos: start})
+		case c == '"':
+			start := i
+			var s string
+			if s, i, err = scanJQString(src, i); err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, jqToken{kind: jqTokString, text: s, pos: start})
+		default:
+			var punct string
+			for _, p := range jqPuncts {
+				if strings.HasPrefix(src[i:], p) {
+					punct = p
+					break
+				}
+			}
+			if punct == "" {
+				return nil, errors.Wrapf(ErrJSONTransformSyntax, "unexpected character %q at offset %d", c, i)
+			}
+			tokens = append(tokens, jqToken{kind: jqTokPunct, text: punct, pos: i})
+			i += len(punct)
+		}
+	}
+	return append(tokens, jqToken{kind: jqTokEOF, pos: len(src)}), nil
+}
+
+func scanJQIdent(src string, i int) int {
+	for i < len(src) && (isExprIdentStart(src[i]) || isExprDigit(src[i])) {
+		i++
+	}
+	return i
+}
+
+// scanJQString scans the JSON string starting at src[start] and returns it with the offset
+// following it.
+func scanJQString(src string, start int) (string, int, error) {
+	i := start + 1
+	for ; i < len(src) && src[i] != '"'; i++ {
+		if src[i] == '\\' {
+			i++
+		}
+	}
+	if i >= len(src) {
+		return "", 0, errors.Wrapf(ErrJSONTransformSyntax, "unterminated string at offset %d", start)
+	}
+	var s string
+	if err := json.Unmarshal([]byte(src[start:i+1]), &s); err != nil {
+		return "", 0, errors.Wrapf(ErrJSONTransformSyntax, "invalid string at offset %d: %v", start, err)
+	}
+	return s, i + 1, nil
+}
+
+type jqParser struct {
+	tokens []jqToken
+	pos    int
+	cost   int
+	// vars are the variables bound at the current position
+	vars []string
+}
+
+func (p *jqParser) peek() jqToken { return p.tokens[p.pos] }
+
+func (p *jqParser) next() jqToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != jqTokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of puncts.
+func (p *jqParser) accept(puncts ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != jqTokPunct {
+		return "", false
+	}
+	for _, punct := range puncts {
+		if tok.text == punct {
+			p.pos++
+			return punct, true
+		}
+	}
+	return "", false
+}
+
+func (p *jqParser) expect(punct string) error {
+	if _, ok := p.accept(punct); !ok {
+		tok := p.peek()
+		return errors.Wrapf(ErrJSONTransformSyntax, "expected %q, got %s at offset %d", punct, tok, tok.pos)
+	}
+	return nil
+}
+
+func (p *jqParser) expectKeyword(keyword string) error {
+	if tok := p.next(); tok.kind != jqTokIdent || tok.text != keyword {
+		return errors.Wrapf(ErrJSONTransformSyntax, "expected %q, got %s at offset %d", keyword, tok, tok.pos)
+	}
+	return nil
+}
+
+// node accounts for a new node in the syntax tree.
+func (p *jqParser) node(n jqNode) (jqNode, error) {
+	p.cost++
+	if p.cost > maxJSONTransformCost {
+		return nil, errors.Wrapf(ErrJSONTransformCost, "filter has more than %d nodes", maxJSONTransformCost)
+	}
+	return n, nil
+}
+
+func (p *jqParser) parsePipe() (jqNode, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("|"); !ok {
+			return left, nil
+		}
+		right, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = p.node(&jqPipe{left: left, right: right}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *jqParser) parseCompare() (jqNode, error) {
+	left, err := p.parseArith(0)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseArith(0)
+	if err != nil {
+		return nil, err
+	}
+	return p.node(&jqBinary{op: op, left: left, right: right})
+}
+
+var jqArithLevels = [][]string{
+	{"+", "-"},
+	{"*", "/"},
+}
+
+func (p *jqParser) parseArith(level int) (jqNode, error) {
+	if level == len(jqArithLevels) {
+		return p.parsePostfix()
+	}
+	left, err := p.parseArith(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(jqArithLevels[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseArith(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		if left, err = p.node(&jqBinary{op: op, left: left, right: right}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *jqParser) parsePostfix() (jqNode, error) {
+	x, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == jqTokField:
+			p.next()
+			x, err = p.node(&jqIndex{target: x, key: &jqLiteral{value: tok.text}})
+		case tok.is("["):
+			p.next()
+			if _, ok := p.accept("]"); ok {
+				x, err = p.node(&jqIterate{target: x})
+				break
+			}
+			var key jqNode
+			if key, err = p.parsePipe(); err != nil {
+				return nil, err
+			}
+			if err = p.expect("]"); err != nil {
+				return nil, err
+			}
+			x, err = p.node(&jqIndex{target: x, key: key})
+		default:
+			return x, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *jqParser) parseTerm() (jqNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case jqTokNumber:
+		return p.node(&jqLiteral{value: tok.num})
+	case jqTokString:
+		return p.node(&jqLiteral{value: tok.text})
+	case jqTokField:
+		return p.node(&jqIndex{target: &jqIdentity{}, key: &jqLiteral{value: tok.text}})
+	case jqTokIdent:
+		switch tok.text {
+		case "true":
+			return p.node(&jqLiteral{value: true})
+		case "false":
+			return p.node(&jqLiteral{value: false})
+		case "null":
+			return p.node(&jqLiteral{value: nil})
+		case "reduce":
+			return p.parseReduce()
+		}
+		return p.parseCall(tok)
+	case jqTokVar:
+		for _, name := range p.vars {
+			if name == tok.text {
+				return p.node(&jqVar{name: tok.text})
+			}
+		}
+		return nil, errors.Wrapf(ErrJSONTransformSyntax, "unbound variable %s at offset %d", tok, tok.pos)
+	case jqTokPunct:
+		switch tok.text {
+		case ".":
+			return p.node(&jqIdentity{})
+		case "-":
+			if num := p.peek(); num.kind == jqTokNumber {
+				p.next()
+				return p.node(&jqLiteral{value: num.num.Neg()})
+			}
+		case "(":
+			x, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expect(")")
+		case "[":
+			if _, ok := p.accept("]"); ok {
+				return p.node(&jqCollect{})
+			}
+			body, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect("]"); err != nil {
+				return nil, err
+			}
+			return p.node(&jqCollect{body: body})
+		}
+	}
+	return nil, errors.Wrapf(ErrJSONTransformSyntax, "unexpected %s at offset %d", tok, tok.pos)
+}
+
+// parseReduce parses a reduce expression following the reduce keyword.
+func (p *jqParser) parseReduce() (jqNode, error) {
+	source, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("as"); err != nil {
+		return nil, err
+	}
+	tok := p.next()
+	if tok.kind != jqTokVar {
+		return nil, errors.Wrapf(ErrJSONTransformSyntax, "expected a variable, got %s at offset %d", tok, tok.pos)
+	}
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	init, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(";"); err != nil {
+		return nil, err
+	}
+	p.vars = append(p.vars, tok.text)
+	update, err := p.parsePipe()
+	p.vars = p.vars[:len(p.vars)-1]
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+	return p.node(&jqReduce{source: source, name: tok.text, init: init, update: update})
+}
+
+func (p *jqParser) parseCall(name jqToken) (jqNode, error) {
+	var args []jqNode
+	if _, ok := p.accept("("); ok {
+		arg, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	fn, ok := jqFuncs[fmt.Sprintf("%s/%d", name.text, len(args))]
+	if !ok {
+		return nil, errors.Wrapf(ErrJSONTransformSyntax, "unknown function %s/%d at offset %d", name.text, len(args), name.pos)
+	}
+	return p.node(&jqCall{name: name.text, fn: fn, args: args})
+}
+
+// jqEnv holds the state of an evaluation.
+type jqEnv struct {
+	steps int
+	// vars are the bound variables, innermost last
+	vars []jqBinding
+}
+
+type jqBinding struct {
+	name  string
+	value interface{}
+}
+
+// step charges n steps to the evaluation.
+func (e *jqEnv) step(n int) error {
+	e.steps += n
+	if e.steps > maxJSONTransformSteps {
+		return errors.Wrapf(ErrJSONTransformCost, "evaluation took more than %d steps", maxJSONTransformSteps)
+	}
+	return nil
+}
+
+// stepProduct charges a*b steps, for the values produced by combining each of a values with each
+// of b values, without overflowing.
+func (e *jqEnv) stepProduct(a, b int) error {
+	if b > 0 && a > (maxJSONTransformSteps-e.steps)/b {
+		return errors.Wrapf(ErrJSONTransformCost, "evaluation took more than %d steps", maxJSONTransformSteps)
+	}
+	return e.step(a * b)
+}
+
+func (e *jqEnv) eval(n jqNode, input interface{}) ([]interface{}, error) {
+	if err := e.step(1); err != nil {
+		return nil, err
+	}
+	return n.eval(e, input)
+}
+
+type jqNode interface {
+	eval(env *jqEnv, input interface{}) ([]interface{}, error)
+}
+
+type jqIdentity struct{}
+
+func (n *jqIdentity) eval(_ *jqEnv, input interface{}) ([]interface{}, error) {
+	return []interface{}{input}, nil
+}
+
+type jqLiteral struct{ value interface{} }
+
+func (n *jqLiteral) eval(*jqEnv, interface{}) ([]interface{}, error) {
+	return []interface{}{n.value}, nil
+}
+
+// jqIndex indexes target with key, both evaluated against the input.
+type jqIndex struct {
+	target, key jqNode
+}
+
+func (n *jqIndex) eval(env *jqEnv, input interface{}) ([]interface{}, error) {
+	targets, err := env.eval(n.target, input)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := env.eval(n.key, input)
+	if err != nil {
+		return nil, err
+	}
+	if err = env.stepProduct(len(targets), len(keys)); err != nil {
+		return nil, err
+	}
+	outputs := make([]interface{}, 0, len(targets)*len(keys))
+	for _, target := range targets {
+		for _, key := range keys {
+			v, err := jqIndexValue(target, key)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, v)
+		}
+	}
+	return outputs, nil
+}
+
+func jqIndexValue(v, key interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		switch key.(type) {
+		case nil, string, decimal.Decimal:
+			return nil, nil
+		}
+	case map[string]interface{}:
+		if k, ok := key.(string); ok {
+			return v[k], nil
+		}
+	case []interface{}:
+		if k, ok := key.(decimal.Decimal); ok {
+			if k.Abs().GreaterThan(decimal.NewFromInt(math.MaxInt32)) {
+				return nil, nil
+			}
+			// negative indices count from the end
+			i := int(k.Floor().IntPart())
+			if i < 0 {
+				i += len(v)
+			}
+			if i < 0 || i >= len(v) {
+				return nil, nil
+			}
+			return v[i], nil
+		}
+	}
+	return nil, errors.Wrapf(ErrJSONTransformType, "cannot index %s with %s", jqTypeName(v), jqDescribe(key))
+}
+
+type jqIterate struct{ target jqNode }
+
+func (n *jqIterate) eval(env *jqEnv, input interface{}) ([]interface{}, error) {
+	targets, err := env.eval(n.target, input)
+	if err != nil {
+		return nil, err
+	}
+	var outputs []interface{}
+	for _, target := range targets {
+		values, err := jqValues(target)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, values...)
+	}
+	return outputs, env.step(len(outputs))
+}
+
+// jqValues returns the elements of an array or the values of an object, in key order.
+func jqValues(v interface{}) ([]interface{}, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		return v, nil
+	case map[string]interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, k := range jqSortedKeys(v) {
+			values = append(values, v[k])
+		}
+		return values, nil
+	}
+	return nil, errors.Wrapf(ErrJSONTransformType, "cannot iterate over %s", jqTypeName(v))
+}
+
+type jqPipe struct{ left, right jqNode }
+
+func (n *jqPipe) eval(env *jqEnv, input interface{}) ([]interface{}, error) {
+	lefts, err := env.eval(n.left, input)
+	if err != nil {
+		return nil, err
+	}
+	var outputs []interface{}
+	for _, v := range lefts {
+		rights, err := env.eval(n.right, v)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, rights...)
+	}
+	return outputs, nil
+}
+
+type jqBinary struct {
+	op          string
+	left, right jqNode
+}
+
+func (n *jqBinary) eval(env *jqEnv, input interface{}) ([]interface{}, error) {
+	rights, err := env.eval(n.right, input)
+	if err != nil {
+		return nil, err
+	}
+	lefts, err := env.eval(n.left, input)
+	if err != nil {
+		return nil, err
+	}
+	if err = env.stepProduct(len(lefts), len(rights)); err != nil {
+		return nil, err
+	}
+	outputs := make([]interface{}, 0, len(lefts)*len(rights))
+	for _, r := range rights {
+		for _, l := range lefts {
+			v, err := jqOperate(env, n.op, l, r)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, v)
+		}
+	}
+	return outputs, nil
+}
+
+func jqOperate(env *jqEnv, op string, x, y interface{}) (interface{}, error) {
+	switch op {
+	case "==":
+		return jqCompare(x, y) == 0, nil
+	case "!=":
+		return jqCompare(x, y) != 0, nil
+	case "<":
+		return jqCompare(x, y) < 0, nil
+	case "<=":
+		return jqCompare(x, y) <= 0, nil
+	case ">":
+		return jqCompare(x, y) > 0, nil
+	case ">=":
+		return jqCompare(x, y) >= 0, nil
+	}
+
+	a, aNum := x.(decimal.Decimal)
+	b, bNum := y.(decimal.Decimal)
+	if aNum && bNum {
+		switch op {
+		case "+":
+			return a.Add(b), nil
+		case "-":
+			return a.Sub(b), nil
+		case "*":
+			if exp := int64(a.Exponent()) + int64(b.Exponent()); exp > math.MaxInt32 || exp < math.MinInt32 {
+				return nil, ErrMultiplyOverlow
+			}
+			return a.Mul(b), nil
+		case "/":
+			if b.IsZero() {
+				return nil, ErrDivideByZero
+			}
+			return a.Div(b), nil
+		}
+	}
+
+	// + also concatenates strings and arrays, merges objects and ignores null
+	if op == "+" {
+		if x == nil {
+			return y, nil
+		} else if y == nil {
+			return x, nil
+		}
+		switch x := x.(type) {
+		case string:
+			if y, ok := y.(string); ok {
+				return x + y, env.step(len(x) + len(y))
+			}
+		case []interface{}:
+			if y, ok := y.([]interface{}); ok {
+				return append(append(make([]interface{}, 0, len(x)+len(y)), x...), y...), env.step(len(x) + len(y))
+			}
+		case map[string]interface{}:
+			if y, ok := y.(map[string]interface{}); ok {
+				m := make(map[string]interface{}, len(x)+len(y))
+				for k, v := range x {
+					m[k] = v
+				}
+				for k, v := range y {
+					m[k] = v
+				}
+				return m, env.step(len(x) + len(y))
+			}
+		}
+	}
+	return nil, errors.Wrapf(ErrJSONTransformType, "cannot apply %q to %s and %s", op, jqTypeName(x), jqTypeName(y))
+}
+
+type jqVar struct{ name string }
+
+func (n *jqVar) eval(env *jqEnv, _ interface{}) ([]interface{}, error) {
+	for i := len(env.vars) - 1; i >= 0; i-- {
+		if env.vars[i].name == n.name {
+			return []interface{}{env.vars[i].value}, nil
+		}
+	}
+	// unreachable, variables are checked when parsing
+	return nil, errors.Errorf("unbound variable $%s", n.name)
+}
+
+// jqReduce folds the values of source, bound to name, into each value of init with update. As in
+// jq, the state becomes the last value produced by update, or null if it produces none.
+type jqReduce struct {
+	source       jqNode
+	name         string
+	init, update jqNode
+}
+
+func (n *jqReduce) eval(env *jqEnv, input interface{}) ([]interface{}, error) {
+	values, err := env.eval(n.source, input)
+	if err != nil {
+		return nil, err
+	}
+	states, err := env.eval(n.init, input)
+	if err != nil {
+		return nil, err
+	}
+	if err = env.stepProduct(len(states), len(values)); err != nil {
+		return nil, err
+	}
+	outputs := make([]interface{}, 0, len(states))
+	for _, state := range states {
+		for _, v := range values {
+			env.vars = append(env.vars, jqBinding{name: n.name, value: v})
+			updated, err := env.eval(n.update, state)
+			env.vars = env.vars[:len(env.vars)-1]
+			if err != nil {
+				return nil, err
+			}
+			state = nil
+			if len(updated) > 0 {
+				state = updated[len(updated)-1]
+			}
+		}
+		outputs = append(outputs, state)
+	}
+	return outputs, nil
+}
+
+// jqCollect collects the values of body into an array.
+type jqCollect struct{ body jqNode }
+
+func (n *jqCollect) eval(env *jqEnv, input interface{}) ([]interface{}, error) {
+	if n.body == nil {
+		return []interface{}{[]interface{}{}}, nil
+	}
+	values, err := env.eval(n.body, input)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{append([]interface{}{}, values...)}, nil
+}
+
+type jqCall struct {
+	name string
+	fn   jqFunc
+	args []jqNode
+}
+
+func (n *jqCall) eval(env *jqEnv, input interface{}) ([]interface{}, error) {
+	outputs, err := n.fn(env, input, n.args)
+	return outputs, errors.Wrap(err, n.name)
+}
+
+// jqFunc implements a builtin. Arguments are filters, evaluated by the builtin as needed.
+type jqFunc func(env *jqEnv, input interface{}, args []jqNode) ([]interface{}, error)
+
+// jqFuncs are the supported builtins, keyed by name and number of arguments.
+var jqFuncs map[string]jqFunc
+
+func init() {
+	jqFuncs = map[string]jqFunc{
+		"not/0": jqSimple(func(v interface{}) (interface{}, error) {
+			return !jqTruthy(v), nil
+		}),
+		"length/0": jqSimple(func(v interface{}) (interface{}, error) {
+			switch v := v.(type) {
+			case nil:
+				return decimal.Zero, nil
+			case decimal.Decimal:
+				return v.Abs(), nil
+			case string:
+				return decimal.NewFromInt(int64(len([]rune(v)))), nil
+			case []interface{}:
+				return decimal.NewFromInt(int64(len(v))), nil
+			case map[string]interface{}:
+				return decimal.NewFromInt(int64(len(v))), nil
+			}
+			return nil, errors.Wrapf(ErrJSONTransformType, "%s has no length", jqTypeName(v))
+		}),
+		"keys/0": jqSimple(func(v interface{}) (interface{}, error) {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, errors.Wrapf(ErrJSONTransformType, "%s has no keys", jqTypeName(v))
+			}
+			keys := make([]interface{}, 0, len(m))
+			for _, k := range jqSortedKeys(m) {
+				keys = append(keys, k)
+			}
+			return keys, nil
+		}),
+		"add/0": func(env *jqEnv, input interface{}, _ []jqNode) ([]interface{}, error) {
+			values, err := jqValues(input)
+			if err != nil {
+				return nil, err
+			}
+			var sum interface{}
+			for _, v := range values {
+				if sum, err = jqOperate(env, "+", sum, v); err != nil {
+					return nil, err
+				}
+			}
+			return []interface{}{sum}, nil
+		},
+		"min/0":  jqExtreme(-1),
+		"max/0":  jqExtreme(1),
+		"sort/0": jqSimple(func(v interface{}) (interface{}, error) { return jqSorted(v) }),
+		"map/1": func(env *jqEnv, input interface{}, args []jqNode) ([]interface{}, error) {
+			values, err := jqValues(input)
+			if err != nil {
+				return nil, err
+			}
+			mapped := []interface{}{}
+			for _, v := range values {
+				outputs, err := env.eval(args[0], v)
+				if err != nil {
+					return nil, err
+				}
+				mapped = append(mapped, outputs...)
+			}
+			return []interface{}{mapped}, nil
+		},
+		"select/1": func(env *jqEnv, input interface{}, args []jqNode) ([]interface{}, error) {
+			conds, err := env.eval(args[0], input)
+			if err != nil {
+				return nil, err
+			}
+			var outputs []interface{}
+			for _, c := range conds {
+				if jqTruthy(c) {
+					outputs = append(outputs, input)
+				}
+			}
+			return outputs, nil
+		},
+		"tonumber/0": jqSimple(func(v interface{}) (interface{}, error) {
+			switch v := v.(type) {
+			case decimal.Decimal:
+				return v, nil
+			case string:
+				d, err := decimal.NewFromString(strings.TrimSpace(v))
+				if err != nil {
+					return nil, errors.Wrapf(ErrJSONTransformType, "cannot parse %q as a number", v)
+				}
+				if exp := d.Exponent(); exp > maxJSONParamScale || exp < -maxJSONParamScale {
+					return nil, errors.Wrapf(ErrJSONTransformType, "number %q is out of range", v)
+				}
+				return d, nil
+			}
+			return nil, errors.Wrapf(ErrJSONTransformType, "cannot parse %s as a number", jqTypeName(v))
+		}),
+	}
+}
+
+// jqSimple returns a builtin without arguments.
+func jqSimple(fn func(v interface{}) (interface{}, error)) jqFunc {
+	return func(_ *jqEnv, input interface{}, _ []jqNode) ([]interface{}, error) {
+		v, err := fn(input)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{v}, nil
+	}
+}
+
+// jqExtreme returns a builtin producing the minimum (sign -1) or maximum (sign 1) of an array.
+func jqExtreme(sign int) jqFunc {
+	return func(env *jqEnv, input interface{}, _ []jqNode) ([]interface{}, error) {
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJSONTransformType, "cannot compare the elements of %s", jqTypeName(input))
+		}
+		if len(arr) == 0 {
+			return []interface{}{nil}, nil
+		}
+		extreme := arr[0]
+		for _, v := range arr[1:] {
+			if jqCompare(v, extreme)*sign > 0 {
+				extreme = v
+			}
+		}
+		return []interface{}{extreme}, env.step(len(arr))
+	}
+}
+
+func jqSorted(v interface{}) (interface{}, error) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.Wrapf(ErrJSONTransformType, "cannot sort %s", jqTypeName(v))
+	}
+	sorted := append([]interface{}{}, arr...)
+	sort.SliceStable(sorted, func(i, j int) bool { return jqCompare(sorted[i], sorted[j]) < 0 })
+	return sorted, nil
+}
+
+func jqContains(values []interface{}, v interface{}) bool {
+	for _, x := range values {
+		if jqCompare(x, v) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func jqTruthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	}
+	return true
+}
+
+func jqTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case decimal.Decimal:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// jqDescribe describes v in error messages.
+func jqDescribe(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case decimal.Decimal:
+		return v.String()
+	}
+	return jqTypeName(v)
+}
+
+// jqRank orders values of different types as jq does.
+func jqRank(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 2
+		}
+		return 1
+	case decimal.Decimal:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	}
+	return 6
+}
+
+// jqCompare orders null < false < true < numbers < strings < arrays < objects. Arrays are
+// compared element by element, and objects by their sorted keys and then by their values.
+func jqCompare(x, y interface{}) int {
+	if rx, ry := jqRank(x), jqRank(y); rx != ry {
+		if rx < ry {
+			return -1
+		}
+		return 1
+	}
+	switch x := x.(type) {
+	case decimal.Decimal:
+		return x.Cmp(y.(decimal.Decimal))
+	case string:
+		return strings.Compare(x, y.(string))
+	case []interface{}:
+		y := y.([]interface{})
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := jqCompare(x[i], y[i]); c != 0 {
+				return c
+			}
+		}
+		return len(x) - len(y)
+	case map[string]interface{}:
+		y := y.(map[string]interface{})
+		xk, yk := jqSortedKeys(x), jqSortedKeys(y)
+		for i := 0; i < len(xk) && i < len(yk); i++ {
+			if c := strings.Compare(xk[i], yk[i]); c != 0 {
+				return c
+			}
+		}
+		if len(xk) != len(yk) {
+			return len(xk) - len(yk)
+		}
+		for _, k := range xk {
+			if c := jqCompare(x[k], y[k]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func jqSortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	models "github.com/smartcontractkit/chainlink/v2/core/store/models"
+	config "github.com/smartcontractkit/chainlink/v2/core/config"
 	mock "github.com/stretchr/testify/mock"
 
+	models "github.com/smartcontractkit/chainlink/v2/core/store/models"
+
 	time "time"
 )
 
@@ -14,6 +16,22 @@ type Config struct {
 	mock.Mock
 }
 
+// Archive provides a mock function with given fields:
+func (_m *Config) Archive() config.JobPipelineArchive {
+	ret := _m.Called()
+
+	var r0 config.JobPipelineArchive
+	if rf, ok := ret.Get(0).(func() config.JobPipelineArchive); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(config.JobPipelineArchive)
+		}
+	}
+
+	return r0
+}
+
 // DefaultHTTPLimit provides a mock function with given fields:
 func (_m *Config) DefaultHTTPLimit() int64 {
 	ret := _m.Called()
@@ -84,6 +102,22 @@ func (_m *Config) ReaperThreshold() time.Duration {
 	return r0
 }
 
+// SignAllowedJobTypes provides a mock function with given fields:
+func (_m *Config) SignAllowedJobTypes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewConfig interface {
 	mock.TestingT
 	Cleanup(func())
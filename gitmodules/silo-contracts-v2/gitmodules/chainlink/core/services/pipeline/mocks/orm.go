@@ -22,6 +22,20 @@ type ORM struct {
 	mock.Mock
 }
 
+// ArchiveRunsOlderThan provides a mock function with given fields: ctx, threshold, archive
+func (_m *ORM) ArchiveRunsOlderThan(ctx context.Context, threshold time.Duration, archive func([]pipeline.Run) error) error {
+	ret := _m.Called(ctx, threshold, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, func([]pipeline.Run) error) error); ok {
+		r0 = rf(ctx, threshold, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *ORM) Close() error {
 	ret := _m.Called()
@@ -88,6 +102,37 @@ func (_m *ORM) CreateSpec(_a0 pipeline.Pipeline, maxTaskTimeout models.Interval,
 	return r0, r1
 }
 
+// CreateSubpipeline provides a mock function with given fields: name, source, qopts
+func (_m *ORM) CreateSubpipeline(name string, source string, qopts ...pg.QOpt) (pipeline.Subpipeline, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name, source)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 pipeline.Subpipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, ...pg.QOpt) (pipeline.Subpipeline, error)); ok {
+		return rf(name, source, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, ...pg.QOpt) pipeline.Subpipeline); ok {
+		r0 = rf(name, source, qopts...)
+	} else {
+		r0 = ret.Get(0).(pipeline.Subpipeline)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, ...pg.QOpt) error); ok {
+		r1 = rf(name, source, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteRun provides a mock function with given fields: id
 func (_m *ORM) DeleteRun(id int64) error {
 	ret := _m.Called(id)
@@ -116,6 +161,20 @@ func (_m *ORM) DeleteRunsOlderThan(_a0 context.Context, _a1 time.Duration) error
 	return r0
 }
 
+// DeleteSubpipeline provides a mock function with given fields: name
+func (_m *ORM) DeleteSubpipeline(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindRun provides a mock function with given fields: id
 func (_m *ORM) FindRun(id int64) (pipeline.Run, error) {
 	ret := _m.Called(id)
@@ -140,6 +199,96 @@ func (_m *ORM) FindRun(id int64) (pipeline.Run, error) {
 	return r0, r1
 }
 
+// FindShadowRuns provides a mock function with given fields: jobID, pipelineSpecID, limit, qopts
+func (_m *ORM) FindShadowRuns(jobID int32, pipelineSpecID int32, limit int, qopts ...pg.QOpt) ([]pipeline.ShadowRun, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, jobID, pipelineSpecID, limit)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []pipeline.ShadowRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32, int32, int, ...pg.QOpt) ([]pipeline.ShadowRun, error)); ok {
+		return rf(jobID, pipelineSpecID, limit, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(int32, int32, int, ...pg.QOpt) []pipeline.ShadowRun); ok {
+		r0 = rf(jobID, pipelineSpecID, limit, qopts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.ShadowRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int32, int32, int, ...pg.QOpt) error); ok {
+		r1 = rf(jobID, pipelineSpecID, limit, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSubpipeline provides a mock function with given fields: name, version, qopts
+func (_m *ORM) FindSubpipeline(name string, version int32, qopts ...pg.QOpt) (pipeline.Subpipeline, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name, version)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 pipeline.Subpipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int32, ...pg.QOpt) (pipeline.Subpipeline, error)); ok {
+		return rf(name, version, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func(string, int32, ...pg.QOpt) pipeline.Subpipeline); ok {
+		r0 = rf(name, version, qopts...)
+	} else {
+		r0 = ret.Get(0).(pipeline.Subpipeline)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int32, ...pg.QOpt) error); ok {
+		r1 = rf(name, version, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSubpipelines provides a mock function with given fields:
+func (_m *ORM) FindSubpipelines() ([]pipeline.Subpipeline, error) {
+	ret := _m.Called()
+
+	var r0 []pipeline.Subpipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]pipeline.Subpipeline, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []pipeline.Subpipeline); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.Subpipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllRuns provides a mock function with given fields:
 func (_m *ORM) GetAllRuns() ([]pipeline.Run, error) {
 	ret := _m.Called()
@@ -210,6 +359,39 @@ func (_m *ORM) HealthReport() map[string]error {
 	return r0
 }
 
+// ImportRuns provides a mock function with given fields: runs, qopts
+func (_m *ORM) ImportRuns(runs []pipeline.Run, qopts ...pg.QOpt) ([]pipeline.Run, error) {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, runs)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []pipeline.Run
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]pipeline.Run, ...pg.QOpt) ([]pipeline.Run, error)); ok {
+		return rf(runs, qopts...)
+	}
+	if rf, ok := ret.Get(0).(func([]pipeline.Run, ...pg.QOpt) []pipeline.Run); ok {
+		r0 = rf(runs, qopts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]pipeline.Run, ...pg.QOpt) error); ok {
+		r1 = rf(runs, qopts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertFinishedRun provides a mock function with given fields: run, saveSuccessfulTaskRuns, qopts
 func (_m *ORM) InsertFinishedRun(run *pipeline.Run, saveSuccessfulTaskRuns bool, qopts ...pg.QOpt) error {
 	_va := make([]interface{}, len(qopts))
@@ -273,6 +455,27 @@ func (_m *ORM) InsertRun(run *pipeline.Run, qopts ...pg.QOpt) error {
 	return r0
 }
 
+// InsertShadowRun provides a mock function with given fields: shadowRun, qopts
+func (_m *ORM) InsertShadowRun(shadowRun *pipeline.ShadowRun, qopts ...pg.QOpt) error {
+	_va := make([]interface{}, len(qopts))
+	for _i := range qopts {
+		_va[_i] = qopts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, shadowRun)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pipeline.ShadowRun, ...pg.QOpt) error); ok {
+		r0 = rf(shadowRun, qopts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Name provides a mock function with given fields:
 func (_m *ORM) Name() string {
 	ret := _m.Called()
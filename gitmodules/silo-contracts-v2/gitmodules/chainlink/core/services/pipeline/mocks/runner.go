@@ -20,6 +20,22 @@ type Runner struct {
 	mock.Mock
 }
 
+// Archive provides a mock function with given fields:
+func (_m *Runner) Archive() *pipeline.RunArchive {
+	ret := _m.Called()
+
+	var r0 *pipeline.RunArchive
+	if rf, ok := ret.Get(0).(func() *pipeline.RunArchive); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.RunArchive)
+		}
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *Runner) Close() error {
 	ret := _m.Called()
@@ -170,9 +186,9 @@ func (_m *Runner) Name() string {
 	return r0
 }
 
-// OnRunFinished provides a mock function with given fields: _a0
-func (_m *Runner) OnRunFinished(_a0 func(*pipeline.Run)) {
-	_m.Called(_a0)
+// OnRunFinished provides a mock function with given fields: fn
+func (_m *Runner) OnRunFinished(fn func(*pipeline.Run)) {
+	_m.Called(fn)
 }
 
 // Ready provides a mock function with given fields:
@@ -189,6 +205,30 @@ func (_m *Runner) Ready() error {
 	return r0
 }
 
+// ReplayRun provides a mock function with given fields: ctx, runID, opts, l
+func (_m *Runner) ReplayRun(ctx context.Context, runID int64, opts pipeline.ReplayOptions, l logger.Logger) (pipeline.ReplayResult, error) {
+	ret := _m.Called(ctx, runID, opts, l)
+
+	var r0 pipeline.ReplayResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.ReplayOptions, logger.Logger) (pipeline.ReplayResult, error)); ok {
+		return rf(ctx, runID, opts, l)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.ReplayOptions, logger.Logger) pipeline.ReplayResult); ok {
+		r0 = rf(ctx, runID, opts, l)
+	} else {
+		r0 = ret.Get(0).(pipeline.ReplayResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, pipeline.ReplayOptions, logger.Logger) error); ok {
+		r1 = rf(ctx, runID, opts, l)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResumeRun provides a mock function with given fields: taskID, value, err
 func (_m *Runner) ResumeRun(taskID uuid.UUID, value interface{}, err error) error {
 	ret := _m.Called(taskID, value, err)
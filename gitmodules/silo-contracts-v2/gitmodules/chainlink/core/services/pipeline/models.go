@@ -28,6 +28,9 @@ type Spec struct {
 	JobID   int32  `json:"-"`
 	JobName string `json:"-"`
 	JobType string `json:"-"`
+
+	// Shadow is the candidate spec run with the inputs of every finished run of this one, see ShadowRun
+	Shadow *Spec `json:"-" db:"-"`
 }
 
 func (s Spec) Pipeline() (*Pipeline, error) {
@@ -55,6 +58,11 @@ type Run struct {
 	Pending bool
 	// FailSilently is used to signal that a task with the failEarly flag has failed, and we want to not put this in the db
 	FailSilently bool
+
+	// Set when replaying a stored run, see ReplayRun
+	replay *replayRecording
+	// Set when running a shadow spec, whose tasks must not write on-chain
+	shadow bool
 }
 
 func (r Run) GetID() string {
@@ -233,6 +241,32 @@ func (re RunErrors) ToError() error {
 	return multierr.Combine(errs...)
 }
 
+// ShadowRun holds the final results of a run of the shadow spec of a job, together with those of
+// the live run whose inputs it was run with.
+type ShadowRun struct {
+	ID             int64
+	JobID          int32
+	PipelineSpecID int32
+	LiveOutputs    JSONSerializable
+	LiveErrors     RunErrors
+	ShadowOutputs  JSONSerializable
+	ShadowErrors   RunErrors
+	CreatedAt      time.Time
+}
+
+// Subpipeline is a named, versioned pipeline fragment that jobs can invoke with a subpipeline task.
+type Subpipeline struct {
+	ID           int64     `json:"-"`
+	Name         string    `json:"name"`
+	Version      int32     `json:"version"`
+	DotDagSource string    `json:"dotDagSource"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (s Subpipeline) Pipeline() (*Pipeline, error) {
+	return Parse(s.DotDagSource)
+}
+
 type ResumeRequest struct {
 	Error null.String     `json:"error"`
 	Value json.RawMessage `json:"value"`
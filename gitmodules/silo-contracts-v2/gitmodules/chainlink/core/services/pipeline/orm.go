@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -88,9 +90,31 @@ type ORM interface {
 	InsertFinishedRuns(run []*Run, saveSuccessfulTaskRuns bool, qopts ...pg.QOpt) (err error)
 
 	DeleteRunsOlderThan(context.Context, time.Duration) error
+	// ArchiveRunsOlderThan passes batches of the runs finished before threshold, and of the
+	// successful runs above the MaxSuccessfulRuns limit of their job, to archive, and deletes each
+	// batch once it is archived.
+	ArchiveRunsOlderThan(ctx context.Context, threshold time.Duration, archive func(runs []Run) error) error
+	// ImportRuns inserts archived runs with their original IDs, and returns the runs that were
+	// inserted. Runs that already exist or whose pipeline spec was deleted are skipped.
+	ImportRuns(runs []Run, qopts ...pg.QOpt) ([]Run, error)
 	FindRun(id int64) (Run, error)
 	GetAllRuns() ([]Run, error)
 	GetUnfinishedRuns(context.Context, time.Time, func(run Run) error) error
+
+	// CreateSubpipeline stores source as the next version of the named subpipeline.
+	CreateSubpipeline(name string, source string, qopts ...pg.QOpt) (Subpipeline, error)
+	// FindSubpipeline returns the given version of the named subpipeline, or the latest if version is 0.
+	FindSubpipeline(name string, version int32, qopts ...pg.QOpt) (Subpipeline, error)
+	// FindSubpipelines returns every version of every subpipeline, ordered by name and version.
+	FindSubpipelines() ([]Subpipeline, error)
+	// DeleteSubpipeline deletes all versions of the named subpipeline.
+	DeleteSubpipeline(name string) error
+
+	// InsertShadowRun stores the results of a run of the shadow spec of a job.
+	InsertShadowRun(shadowRun *ShadowRun, qopts ...pg.QOpt) error
+	// FindShadowRuns returns the latest runs of the given shadow spec of a job, newest first.
+	FindShadowRuns(jobID int32, pipelineSpecID int32, limit int, qopts ...pg.QOpt) ([]ShadowRun, error)
+
 	GetQ() pg.Q
 }
 
@@ -104,6 +128,8 @@ type orm struct {
 	wg   sync.WaitGroup
 	ctx  context.Context
 	cncl context.CancelFunc
+	// archiveEnabled leaves the runs above maxSuccessfulRuns to ArchiveRunsOlderThan
+	archiveEnabled bool
 }
 
 var _ ORM = (*orm)(nil)
@@ -119,14 +145,23 @@ func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig, jobPipelineMaxSucce
 		sync.WaitGroup{},
 		ctx,
 		cancel,
+		false,
 	}
 }
 
+// EnableArchive stops Prune from deleting the successful runs above MaxSuccessfulRuns, which are
+// archived and deleted by ArchiveRunsOlderThan instead. It must be called before the ORM is used.
+func (o *orm) EnableArchive() {
+	o.archiveEnabled = true
+}
+
 func (o *orm) Start(_ context.Context) error {
 	return o.StartOnce("pipeline.ORM", func() error {
 		var msg string
 		if o.maxSuccessfulRuns == 0 {
 			msg = "Pipeline runs saving is disabled for all jobs: MaxSuccessfulRuns=0"
+		} else if o.archiveEnabled {
+			msg = fmt.Sprintf("Pipeline runs will be archived above per-job limit of MaxSuccessfulRuns=%d", o.maxSuccessfulRuns)
 		} else {
 			msg = fmt.Sprintf("Pipeline runs will be pruned above per-job limit of MaxSuccessfulRuns=%d", o.maxSuccessfulRuns)
 		}
@@ -510,6 +545,100 @@ WHERE pipeline_runs.id = batched_pipeline_runs.id`,
 	return nil
 }
 
+func (o *orm) ArchiveRunsOlderThan(ctx context.Context, threshold time.Duration, archive func(runs []Run) error) error {
+	start := time.Now()
+
+	q := o.q.WithOpts(pg.WithParentCtxInheritTimeout(ctx))
+
+	queryThreshold := start.Add(-threshold)
+
+	rowsArchived := 0
+
+	err := pg.Batch(func(_, limit uint) (count uint, err error) {
+		err = q.Transaction(func(tx pg.Queryer) error {
+			var runs []*Run
+			// runs above the limit are the completed runs of a spec that are not among its
+			// maxSuccessfulRuns most recent ones, as in execPrune
+			if err = tx.Select(&runs, `SELECT * FROM pipeline_runs WHERE finished_at < $1 OR ($3 > 0 AND id IN (
+	SELECT id FROM (
+		SELECT id, row_number() OVER (PARTITION BY pipeline_spec_id ORDER BY id DESC) AS n
+		FROM pipeline_runs WHERE state = $4
+	) ranked WHERE n > $3
+))
+ORDER BY finished_at ASC, id ASC LIMIT $2`, queryThreshold, limit, o.maxSuccessfulRuns, RunStatusCompleted); err != nil {
+				return errors.Wrap(err, "failed to load pipeline_runs")
+			}
+			count = uint(len(runs))
+			if len(runs) == 0 {
+				return nil
+			}
+			if err = loadAssociations(tx, runs); err != nil {
+				return err
+			}
+
+			batch := make([]Run, len(runs))
+			ids := make([]int64, len(runs))
+			for i, run := range runs {
+				batch[i] = *run
+				ids[i] = run.ID
+			}
+			if err = archive(batch); err != nil {
+				return err
+			}
+			_, err = tx.Exec(`DELETE FROM pipeline_runs WHERE id = ANY($1)`, ids)
+			return errors.Wrap(err, "failed to delete archived pipeline_runs")
+		})
+		if err == nil {
+			rowsArchived += int(count)
+		}
+		return count, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "ArchiveRunsOlderThan failed")
+	}
+
+	o.lggr.Debugw("pipeline_runs archiver completed", "rowsArchived", rowsArchived, "duration", time.Since(start))
+	return nil
+}
+
+func (o *orm) ImportRuns(runs []Run, qopts ...pg.QOpt) (imported []Run, err error) {
+	q := o.q.WithOpts(qopts...)
+	err = q.Transaction(func(tx pg.Queryer) error {
+		for _, run := range runs {
+			// the insert is skipped when the run exists or its spec is gone, and returns no rows
+			query, args, e := tx.BindNamed(`INSERT INTO pipeline_runs (id, pipeline_spec_id, meta, all_errors, fatal_errors, inputs, outputs, created_at, finished_at, state)
+			SELECT :id, :pipeline_spec_id, :meta, :all_errors, :fatal_errors, :inputs, :outputs, :created_at, :finished_at, :state
+			WHERE EXISTS (SELECT 1 FROM pipeline_specs WHERE id = :pipeline_spec_id)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING id;`, run)
+			if e != nil {
+				return errors.Wrap(e, "failed to bind")
+			}
+			var id int64
+			if e = tx.QueryRowx(query, args...).Scan(&id); errors.Is(e, sql.ErrNoRows) {
+				continue
+			} else if e != nil {
+				return errors.Wrapf(e, "failed to import pipeline_run %d", run.ID)
+			}
+
+			if len(run.PipelineTaskRuns) > 0 {
+				_, e = tx.NamedExec(`INSERT INTO pipeline_task_runs (pipeline_run_id, id, type, index, output, error, dot_id, created_at, finished_at)
+				VALUES (:pipeline_run_id, :id, :type, :index, :output, :error, :dot_id, :created_at, :finished_at)
+				ON CONFLICT DO NOTHING;`, run.PipelineTaskRuns)
+				if e != nil {
+					return errors.Wrapf(e, "failed to import pipeline_task_runs of pipeline_run %d", run.ID)
+				}
+			}
+			imported = append(imported, run)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ImportRuns failed")
+	}
+	return imported, nil
+}
+
 func (o *orm) FindRun(id int64) (r Run, err error) {
 	var runs []*Run
 	err = o.q.Transaction(func(tx pg.Queryer) error {
@@ -612,6 +741,173 @@ func loadAssociations(q pg.Queryer, runs []*Run) error {
 	return nil
 }
 
+var subpipelineNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+func (o *orm) CreateSubpipeline(name string, source string, qopts ...pg.QOpt) (subpipeline Subpipeline, err error) {
+	if !subpipelineNameRegexp.MatchString(name) {
+		return subpipeline, errors.Errorf("invalid subpipeline name %q: must only contain letters, digits, '_' and '-'", name)
+	}
+	p, err := Parse(source)
+	if err != nil {
+		return subpipeline, err
+	}
+	if err = checkSingleTerminal(p); err != nil {
+		return subpipeline, err
+	}
+	references, err := subpipelineNames(source)
+	if err != nil {
+		return subpipeline, err
+	}
+
+	q := o.q.WithOpts(qopts...)
+	err = q.Transaction(func(tx pg.Queryer) error {
+		// serialize creation so that concurrent versions cannot introduce a cycle together
+		if _, err = tx.Exec(`LOCK TABLE subpipelines IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return errors.Wrap(err, "failed to lock subpipelines")
+		}
+		var latest []Subpipeline
+		if err = tx.Select(&latest, `SELECT DISTINCT ON (name) * FROM subpipelines ORDER BY name, version DESC`); err != nil {
+			return errors.Wrap(err, "failed to load subpipelines")
+		}
+		graph := map[string][]string{name: references}
+		for _, s := range latest {
+			if s.Name == name {
+				continue
+			}
+			if graph[s.Name], err = subpipelineNames(s.DotDagSource); err != nil {
+				return errors.Wrapf(err, "subpipeline %s", s.Name)
+			}
+		}
+		for _, reference := range references {
+			if _, exists := graph[reference]; !exists {
+				return errors.Errorf("subpipeline %s does not exist", reference)
+			}
+		}
+		if cycle := findSubpipelineCycle(graph, name); cycle != nil {
+			return errors.Wrap(ErrSubpipelineCycle, strings.Join(cycle, " -> "))
+		}
+
+		sql := `INSERT INTO subpipelines (name, version, dot_dag_source, created_at)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, NOW() FROM subpipelines WHERE name = $1
+		RETURNING *;`
+		return errors.Wrap(tx.Get(&subpipeline, sql, name, source), "failed to insert subpipeline")
+	})
+	return subpipeline, errors.Wrap(err, "CreateSubpipeline failed")
+}
+
+func subpipelineNames(source string) ([]string, error) {
+	references, err := SubpipelineReferences(source)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(references))
+	for i, reference := range references {
+		names[i] = reference.Name
+	}
+	return names, nil
+}
+
+// findSubpipelineCycle returns the path of a cycle through the named subpipeline, if any.
+func findSubpipelineCycle(graph map[string][]string, name string) []string {
+	path := []string{name}
+	visited := make(map[string]bool)
+	var visit func(current string) []string
+	visit = func(current string) []string {
+		for _, next := range graph[current] {
+			if next == name {
+				return append(append([]string{}, path...), next)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			path = append(path, next)
+			if cycle := visit(next); cycle != nil {
+				return cycle
+			}
+			path = path[:len(path)-1]
+		}
+		return nil
+	}
+	return visit(name)
+}
+
+func (o *orm) FindSubpipeline(name string, version int32, qopts ...pg.QOpt) (subpipeline Subpipeline, err error) {
+	q := o.q.WithOpts(qopts...)
+	if version == 0 {
+		err = q.Get(&subpipeline, `SELECT * FROM subpipelines WHERE name = $1 ORDER BY version DESC LIMIT 1`, name)
+	} else {
+		err = q.Get(&subpipeline, `SELECT * FROM subpipelines WHERE name = $1 AND version = $2`, name, version)
+	}
+	return subpipeline, errors.Wrap(err, "FindSubpipeline failed")
+}
+
+func (o *orm) FindSubpipelines() (subpipelines []Subpipeline, err error) {
+	err = o.q.Select(&subpipelines, `SELECT * FROM subpipelines ORDER BY name ASC, version ASC`)
+	return subpipelines, errors.Wrap(err, "FindSubpipelines failed")
+}
+
+// DeleteSubpipeline refuses to delete a subpipeline that the latest version of another one, or the
+// pipeline or shadow pipeline of a job invokes. Jobs lock the subpipelines when they are created
+// with subpipeline tasks, so they cannot be created with it concurrently.
+func (o *orm) DeleteSubpipeline(name string) error {
+	err := o.q.Transaction(func(tx pg.Queryer) error {
+		if _, err := tx.Exec(`LOCK TABLE subpipelines IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return errors.Wrap(err, "failed to lock subpipelines")
+		}
+		var latest []Subpipeline
+		if err := tx.Select(&latest, `SELECT DISTINCT ON (name) * FROM subpipelines WHERE name != $1 ORDER BY name, version DESC`, name); err != nil {
+			return errors.Wrap(err, "failed to load subpipelines")
+		}
+		for _, s := range latest {
+			references, err := subpipelineNames(s.DotDagSource)
+			if err != nil {
+				return errors.Wrapf(err, "subpipeline %s", s.Name)
+			}
+			for _, reference := range references {
+				if reference == name {
+					return errors.Wrapf(ErrSubpipelineInUse, "%s is used by subpipeline %s", name, s.Name)
+				}
+			}
+		}
+
+		var jobSpecs []struct {
+			JobID        int32
+			DotDagSource string
+		}
+		if err := tx.Select(&jobSpecs, `SELECT jobs.id AS job_id, pipeline_specs.dot_dag_source FROM jobs
+		JOIN pipeline_specs ON pipeline_specs.id IN (jobs.pipeline_spec_id, jobs.shadow_pipeline_spec_id)
+		WHERE strpos(pipeline_specs.dot_dag_source, $1) > 0`, name); err != nil {
+			return errors.Wrap(err, "failed to load job pipeline specs")
+		}
+		for _, spec := range jobSpecs {
+			references, err := subpipelineNames(spec.DotDagSource)
+			if err != nil {
+				return errors.Wrapf(err, "job %d", spec.JobID)
+			}
+			for _, reference := range references {
+				if reference == name {
+					return errors.Wrapf(ErrSubpipelineInUse, "%s is used by job %d", name, spec.JobID)
+				}
+			}
+		}
+
+		result, err := tx.Exec(`DELETE FROM subpipelines WHERE name = $1`, name)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+	return errors.Wrap(err, "DeleteSubpipeline failed")
+}
+
 func (o *orm) GetQ() pg.Q {
 	return o.q
 }
@@ -639,10 +935,16 @@ const syncLimit = 1000
 //
 // Note this does not guarantee the pipeline_runs table is kept to exactly the
 // max length, rather that it doesn't excessively larger than it.
+//
+// Prune does nothing when the archive is enabled, since the runs must be
+// archived before they are deleted.
 func (o *orm) Prune(tx pg.Queryer, pipelineSpecID int32) {
 	if pipelineSpecID == 0 {
 		o.lggr.Panic("expected a non-zero pipeline spec ID")
 	}
+	if o.archiveEnabled {
+		return
+	}
 	// For small maxSuccessfulRuns its fast enough to prune every time
 	if o.maxSuccessfulRuns < syncLimit {
 		o.execPrune(tx, pipelineSpecID)
@@ -703,3 +1005,18 @@ LIMIT $3
 		o.lggr.Debugw("Pruned runs", "rowsAffected", rowsAffected, "pipelineSpecID", pipelineSpecID)
 	}
 }
+
+func (o *orm) InsertShadowRun(shadowRun *ShadowRun, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	err := q.GetNamed(`INSERT INTO pipeline_shadow_runs (job_id, pipeline_spec_id, live_outputs, live_errors, shadow_outputs, shadow_errors, created_at)
+	VALUES (:job_id, :pipeline_spec_id, :live_outputs, :live_errors, :shadow_outputs, :shadow_errors, NOW())
+	RETURNING id, created_at`, shadowRun, shadowRun)
+	return errors.Wrap(err, "InsertShadowRun failed")
+}
+
+func (o *orm) FindShadowRuns(jobID int32, pipelineSpecID int32, limit int, qopts ...pg.QOpt) (shadowRuns []ShadowRun, err error) {
+	q := o.q.WithOpts(qopts...)
+	err = q.Select(&shadowRuns, `SELECT * FROM pipeline_shadow_runs WHERE job_id = $1 AND pipeline_spec_id = $2
+	ORDER BY id DESC LIMIT $3`, jobID, pipelineSpecID, limit)
+	return shadowRuns, errors.Wrap(err, "FindShadowRuns failed")
+}
@@ -1,10 +1,12 @@
 package pipeline_test
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/smartcontractkit/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -767,3 +769,126 @@ func Test_Prune(t *testing.T) {
 	cnt = pgtest.MustCount(t, db, "SELECT count(*) FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = $2", ps2.ID, pipeline.RunStatusSuspended)
 	assert.Equal(t, 3, cnt)
 }
+
+func Test_PipelineORM_ArchiveRunsOlderThan(t *testing.T) {
+	t.Parallel()
+
+	n := uint64(2)
+
+	cfg := configtest2.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.JobPipeline.MaxSuccessfulRuns = &n
+	})
+	db := pgtest.NewSqlxDB(t)
+	porm := pipeline.NewORM(db, logger.TestLogger(t), cfg.Database(), cfg.JobPipeline().MaxSuccessfulRuns())
+	porm.EnableArchive()
+
+	ps := cltest.MustInsertPipelineSpec(t, db)
+	var completed []int64
+	for i := 0; i < 5; i++ {
+		completed = append(completed, cltest.MustInsertPipelineRunWithStatus(t, db, ps.ID, pipeline.RunStatusCompleted).ID)
+	}
+	cltest.MustInsertPipelineRunWithStatus(t, db, ps.ID, pipeline.RunStatusErrored)
+
+	// the runs above the limit are left to the archiver
+	porm.Prune(db, ps.ID)
+	cnt := pgtest.MustCount(t, db, "SELECT count(*) FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = $2", ps.ID, pipeline.RunStatusCompleted)
+	assert.Equal(t, 5, cnt)
+
+	var archived []int64
+	err := porm.ArchiveRunsOlderThan(testutils.Context(t), time.Hour, func(runs []pipeline.Run) error {
+		for _, run := range runs {
+			archived = append(archived, run.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, completed[:3], archived)
+
+	cnt = pgtest.MustCount(t, db, "SELECT count(*) FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = $2", ps.ID, pipeline.RunStatusCompleted)
+	assert.Equal(t, 2, cnt)
+	cnt = pgtest.MustCount(t, db, "SELECT count(*) FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = $2", ps.ID, pipeline.RunStatusErrored)
+	assert.Equal(t, 1, cnt)
+
+	t.Run("keeps the runs when archiving fails", func(t *testing.T) {
+		cltest.MustInsertPipelineRunWithStatus(t, db, ps.ID, pipeline.RunStatusCompleted)
+
+		err := porm.ArchiveRunsOlderThan(testutils.Context(t), time.Hour, func([]pipeline.Run) error {
+			return errors.New("disk full")
+		})
+		require.Error(t, err)
+		cnt := pgtest.MustCount(t, db, "SELECT count(*) FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = $2", ps.ID, pipeline.RunStatusCompleted)
+		assert.Equal(t, 3, cnt)
+	})
+}
+
+func Test_PipelineORM_Subpipelines(t *testing.T) {
+	_, orm := setupLiteORM(t)
+
+	price := `fetch [type=memo value=1]; double [type=multiply input="$(fetch)" times=2]; fetch -> double`
+
+	t.Run("creates versions", func(t *testing.T) {
+		v1, err := orm.CreateSubpipeline("price", price)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), v1.Version)
+
+		v2, err := orm.CreateSubpipeline("price", `fetch [type=memo value=2]`)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), v2.Version)
+
+		latest, err := orm.FindSubpipeline("price", 0)
+		require.NoError(t, err)
+		assert.Equal(t, v2.ID, latest.ID)
+
+		found, err := orm.FindSubpipeline("price", 1)
+		require.NoError(t, err)
+		assert.Equal(t, price, found.DotDagSource)
+
+		_, err = orm.FindSubpipeline("price", 3)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("rejects invalid subpipelines", func(t *testing.T) {
+		_, err := orm.CreateSubpipeline("bad name", price)
+		require.ErrorContains(t, err, "invalid subpipeline name")
+
+		_, err = orm.CreateSubpipeline("two_terminals", `a [type=memo value=1]; b [type=memo value=2]`)
+		require.ErrorContains(t, err, "exactly one terminal task")
+
+		_, err = orm.CreateSubpipeline("missing", `a [type=subpipeline name="nope"]`)
+		require.ErrorContains(t, err, "subpipeline nope does not exist")
+	})
+
+	t.Run("detects cycles", func(t *testing.T) {
+		_, err := orm.CreateSubpipeline("self", `a [type=subpipeline name="self"]`)
+		require.ErrorIs(t, err, pipeline.ErrSubpipelineCycle)
+
+		_, err = orm.CreateSubpipeline("outer", `a [type=subpipeline name="price"]`)
+		require.NoError(t, err)
+
+		// price -> outer -> price, through a foreach sub-graph
+		_, err = orm.CreateSubpipeline("price", `fan [type=foreach input="[1]" subgraph=<a [type=subpipeline name="outer"]>]`)
+		require.ErrorIs(t, err, pipeline.ErrSubpipelineCycle)
+		require.ErrorContains(t, err, "price -> outer -> price")
+	})
+
+	t.Run("lists and deletes", func(t *testing.T) {
+		subpipelines, err := orm.FindSubpipelines()
+		require.NoError(t, err)
+		require.Len(t, subpipelines, 3)
+		assert.Equal(t, "outer", subpipelines[0].Name)
+		assert.Equal(t, "price", subpipelines[1].Name)
+		assert.Equal(t, int32(1), subpipelines[1].Version)
+
+		err = orm.DeleteSubpipeline("price")
+		require.ErrorIs(t, err, pipeline.ErrSubpipelineInUse)
+		require.ErrorContains(t, err, "used by subpipeline outer")
+
+		require.NoError(t, orm.DeleteSubpipeline("outer"))
+		require.NoError(t, orm.DeleteSubpipeline("price"))
+		require.ErrorIs(t, orm.DeleteSubpipeline("price"), sql.ErrNoRows)
+
+		subpipelines, err = orm.FindSubpipelines()
+		require.NoError(t, err)
+		require.Empty(t, subpipelines)
+	})
+}
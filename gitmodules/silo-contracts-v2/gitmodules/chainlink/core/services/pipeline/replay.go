@@ -0,0 +1,229 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+var ErrInvalidReplayOptions = errors.New("invalid replay options")
+
+// ReplayOptions configures how a stored run is replayed.
+type ReplayOptions struct {
+	// Refetch lists the dot IDs of http, bridge, ethcall, estimategaslimit and wsLatest tasks to
+	// execute live instead of substituting their recorded results.
+	Refetch []string `json:"refetch"`
+}
+
+// ReplayTaskOutput is the output of a task in the original or the replayed run.
+type ReplayTaskOutput struct {
+	Output JSONSerializable `json:"output"`
+	Error  null.String      `json:"error"`
+}
+
+// ReplayTaskDiff compares the outputs of a task in the original and the replayed run. Original or
+// Replayed is nil if the task only ran in the other one.
+type ReplayTaskDiff struct {
+	DotID string   `json:"dotId"`
+	Type  TaskType `json:"type"`
+	// Substituted is true if the replayed output is the recorded one rather than re-executed.
+	Substituted bool              `json:"substituted"`
+	Changed     bool              `json:"changed"`
+	Original    *ReplayTaskOutput `json:"original"`
+	Replayed    *ReplayTaskOutput `json:"replayed"`
+}
+
+// ReplayResult is the outcome of replaying a stored run. The replayed run is never saved.
+type ReplayResult struct {
+	OriginalRunID int64
+	Run           Run
+	Tasks         []ReplayTaskDiff
+}
+
+// Changed returns the number of tasks whose output changed.
+func (r ReplayResult) Changed() (n int) {
+	for _, task := range r.Tasks {
+		if task.Changed {
+			n++
+		}
+	}
+	return
+}
+
+// replayRecording holds the task runs of the run being replayed. Tasks that fetch external data
+// return their recorded results unless they are refetched, and ethtx tasks are never executed.
+type replayRecording struct {
+	taskRuns map[string]TaskRun
+	refetch  map[string]bool
+
+	mu          sync.Mutex
+	substituted map[string]bool
+}
+
+func newReplayRecording(taskRuns []TaskRun, refetch []string) *replayRecording {
+	rr := &replayRecording{
+		taskRuns:    make(map[string]TaskRun, len(taskRuns)),
+		refetch:     make(map[string]bool, len(refetch)),
+		substituted: make(map[string]bool),
+	}
+	for _, tr := range taskRuns {
+		rr.taskRuns[tr.DotID] = tr
+	}
+	for _, dotID := range refetch {
+		rr.refetch[dotID] = true
+	}
+	return rr
+}
+
+// isReplayRefetchable returns true for the tasks that fetch external data.
+func isReplayRefetchable(taskType TaskType) bool {
+	switch taskType {
+	case TaskTypeHTTP, TaskTypeBridge, TaskTypeETHCall, TaskTypeEstimateGasLimit, TaskTypeWSLatest:
+		return true
+	}
+	return false
+}
+
+// recorded returns the recorded result of task, if it must not be executed. It is safe to call on
+// a nil recording, which never substitutes results.
+func (rr *replayRecording) recorded(task Task) (TaskRunResult, bool) {
+	if rr == nil {
+		return TaskRunResult{}, false
+	}
+	switch {
+	case task.Type() == TaskTypeETHTx:
+	case isReplayRefetchable(task.Type()) && !rr.refetch[task.DotID()]:
+	default:
+		return TaskRunResult{}, false
+	}
+
+	var result Result
+	tr, ok := rr.taskRuns[task.DotID()]
+	switch {
+	case !ok:
+		result.Error = errors.Errorf("no recorded result for %s task %s", task.Type(), task.DotID())
+	case !tr.FinishedAt.Valid:
+		result.Error = errors.Errorf("recorded %s task %s did not finish", task.Type(), task.DotID())
+	case tr.Error.Valid:
+		result.Error = errors.New(tr.Error.String)
+	case tr.Output.Valid:
+		result.Value = tr.Output.Val
+	}
+	rr.mu.Lock()
+	rr.substituted[task.DotID()] = true
+	rr.mu.Unlock()
+
+	now := time.Now()
+	return TaskRunResult{
+		ID:         task.Base().uuid,
+		Task:       task,
+		Result:     result,
+		CreatedAt:  now,
+		FinishedAt: null.TimeFrom(now),
+	}, true
+}
+
+// ReplayRun re-executes the spec of a stored run with the same inputs, substituting the recorded
+// results of the tasks that fetch external data except for those in opts.Refetch, and compares the
+// output of every task to the stored one. ethtx tasks are never executed.
+func (r *runner) ReplayRun(ctx context.Context, runID int64, opts ReplayOptions, l logger.Logger) (ReplayResult, error) {
+	original, err := r.orm.FindRun(runID)
+	if err != nil {
+		return ReplayResult{}, errors.Wrapf(err, "failed to load run %d", runID)
+	}
+
+	inputs, _ := original.Inputs.Val.(map[string]interface{})
+	vars := NewVarsFrom(inputs)
+	run := NewRun(original.PipelineSpec, vars)
+	run.replay = newReplayRecording(original.PipelineTaskRuns, opts.Refetch)
+
+	pipeline, err := r.initializePipeline(ctx, &run)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	for _, dotID := range opts.Refetch {
+		if isSubgraphDotID(dotID) {
+			continue
+		}
+		task := pipeline.ByDotID(dotID)
+		if task == nil {
+			return ReplayResult{}, errors.Wrapf(ErrInvalidReplayOptions, "cannot refetch %s: no such task", dotID)
+		}
+		if !isReplayRefetchable(task.Type()) {
+			return ReplayResult{}, errors.Wrapf(ErrInvalidReplayOptions, "cannot refetch %s: only http, bridge, ethcall, estimategaslimit and wsLatest tasks can be refetched", dotID)
+		}
+	}
+
+	l = l.Named("Replay").With("originalRunID", runID)
+	r.run(ctx, pipeline, &run, vars, l)
+	if run.Pending {
+		return ReplayResult{}, errors.New("replayed run is pending on an asynchronous task")
+	}
+
+	return ReplayResult{
+		OriginalRunID: runID,
+		Run:           run,
+		Tasks:         diffReplayedTaskRuns(original.PipelineTaskRuns, run.PipelineTaskRuns, run.replay.substituted),
+	}, nil
+}
+
+// diffReplayedTaskRuns pairs task runs by dot ID, in the order of the replayed run followed by
+// the tasks that only ran in the original one.
+func diffReplayedTaskRuns(original, replayed []TaskRun, substituted map[string]bool) []ReplayTaskDiff {
+	originals := make(map[string]TaskRun, len(original))
+	for _, tr := range original {
+		originals[tr.DotID] = tr
+	}
+
+	diffs := make([]ReplayTaskDiff, 0, len(replayed))
+	seen := make(map[string]bool, len(replayed))
+	for _, tr := range replayed {
+		seen[tr.DotID] = true
+		diff := ReplayTaskDiff{
+			DotID:       tr.DotID,
+			Type:        tr.Type,
+			Substituted: substituted[tr.DotID],
+			Replayed:    &ReplayTaskOutput{Output: tr.Output, Error: tr.Error},
+			Changed:     true,
+		}
+		if o, ok := originals[tr.DotID]; ok {
+			diff.Original = &ReplayTaskOutput{Output: o.Output, Error: o.Error}
+			diff.Changed = !diff.Original.equal(*diff.Replayed)
+		}
+		diffs = append(diffs, diff)
+	}
+	for _, tr := range original {
+		if seen[tr.DotID] {
+			continue
+		}
+		diffs = append(diffs, ReplayTaskDiff{
+			DotID:    tr.DotID,
+			Type:     tr.Type,
+			Changed:  true,
+			Original: &ReplayTaskOutput{Output: tr.Output, Error: tr.Error},
+		})
+	}
+	return diffs
+}
+
+// equal compares outputs by their JSON encoding, the way they are stored.
+func (o ReplayTaskOutput) equal(other ReplayTaskOutput) bool {
+	if o.Error != other.Error {
+		return false
+	}
+	a, err := o.Output.MarshalJSON()
+	if err != nil {
+		return false
+	}
+	b, err := other.Output.MarshalJSON()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(a, b)
+}
@@ -48,7 +48,18 @@ type Runner interface {
 	// Note that the spec MUST have a DOT graph for this to work.
 	ExecuteAndInsertFinishedRun(ctx context.Context, spec Spec, vars Vars, l logger.Logger, saveSuccessfulTaskRuns bool) (runID int64, finalResult FinalResult, err error)
 
-	OnRunFinished(func(*Run))
+	// ReplayRun re-executes a stored run without saving it, and compares the output of each task
+	// to the stored one. See ReplayOptions.
+	ReplayRun(ctx context.Context, runID int64, opts ReplayOptions, l logger.Logger) (ReplayResult, error)
+
+	// Archive returns the archive the reaper moves runs to, or nil if JobPipeline.Archive is
+	// disabled.
+	Archive() *RunArchive
+
+	// OnRunFinished registers fn to be called with every run that is stored once it has
+	// finished or suspended, by Run, InsertFinishedRun(s) or ExecuteAndInsertFinishedRun.
+	// Runs inserted in a transaction are reported before it is committed. fn must not block.
+	OnRunFinished(fn func(*Run))
 }
 
 type runner struct {
@@ -59,13 +70,17 @@ type runner struct {
 	legacyEVMChains        evm.LegacyChainContainer
 	ethKeyStore            ETHKeyStore
 	vrfKeyStore            VRFKeyStore
+	ocr2KeyStore           OCR2KeyStore
 	runReaperWorker        utils.SleeperTask
 	lggr                   logger.Logger
 	httpClient             *http.Client
 	unrestrictedHTTPClient *http.Client
+	httpResponseCache      *httpResponseCache
+	wsStreams              *wsStreamManager
+	archive                *RunArchive
 
-	// test helper
-	runFinished func(*Run)
+	runFinishedMu sync.RWMutex
+	runFinished   []func(*Run)
 
 	utils.StartStopOnce
 	chStop utils.StopChan
@@ -102,7 +117,7 @@ var (
 	)
 )
 
-func NewRunner(orm ORM, btORM bridges.ORM, cfg Config, bridgeCfg BridgeConfig, legacyChains evm.LegacyChainContainer, ethks ETHKeyStore, vrfks VRFKeyStore, lggr logger.Logger, httpClient, unrestrictedHTTPClient *http.Client) *runner {
+func NewRunner(orm ORM, btORM bridges.ORM, cfg Config, bridgeCfg BridgeConfig, legacyChains evm.LegacyChainContainer, ethks ETHKeyStore, vrfks VRFKeyStore, ocr2ks OCR2KeyStore, lggr logger.Logger, httpClient, unrestrictedHTTPClient *http.Client) *runner {
 	r := &runner{
 		orm:                    orm,
 		btORM:                  btORM,
@@ -111,12 +126,17 @@ func NewRunner(orm ORM, btORM bridges.ORM, cfg Config, bridgeCfg BridgeConfig, l
 		legacyEVMChains:        legacyChains,
 		ethKeyStore:            ethks,
 		vrfKeyStore:            vrfks,
+		ocr2KeyStore:           ocr2ks,
 		chStop:                 make(chan struct{}),
 		wgDone:                 sync.WaitGroup{},
-		runFinished:            func(*Run) {},
 		lggr:                   lggr.Named("PipelineRunner"),
 		httpClient:             httpClient,
 		unrestrictedHTTPClient: unrestrictedHTTPClient,
+		httpResponseCache:      newHTTPResponseCache(),
+	}
+	r.wsStreams = newWSStreamManager(r.lggr, httpClient, unrestrictedHTTPClient)
+	if archive := cfg.Archive(); archive.Enabled() {
+		r.archive = NewRunArchive(archive.Dir())
 	}
 	r.runReaperWorker = utils.NewSleeperTask(
 		utils.SleeperFuncTask(r.runReaper, "PipelineRunnerReaper"),
@@ -141,6 +161,7 @@ func (r *runner) Close() error {
 	return r.StopOnce("PipelineRunner", func() error {
 		close(r.chStop)
 		r.wgDone.Wait()
+		r.wsStreams.close()
 		return nil
 	})
 }
@@ -150,7 +171,11 @@ func (r *runner) Name() string {
 }
 
 func (r *runner) HealthReport() map[string]error {
-	return map[string]error{r.Name(): r.StartStopOnce.Healthy()}
+	report := map[string]error{r.Name(): r.StartStopOnce.Healthy()}
+	for name, err := range r.wsStreams.HealthReport() {
+		report[name] = err
+	}
+	return report
 }
 
 func (r *runner) destroy() {
@@ -207,8 +232,22 @@ func NewRun(spec Spec, vars Vars) Run {
 	}
 }
 
+func (r *runner) Archive() *RunArchive {
+	return r.archive
+}
+
 func (r *runner) OnRunFinished(fn func(*Run)) {
-	r.runFinished = fn
+	r.runFinishedMu.Lock()
+	defer r.runFinishedMu.Unlock()
+	r.runFinished = append(r.runFinished, fn)
+}
+
+func (r *runner) notifyRunFinished(run *Run) {
+	r.runFinishedMu.RLock()
+	defer r.runFinishedMu.RUnlock()
+	for _, fn := range r.runFinished {
+		fn(run)
+	}
 }
 
 // Be careful with the ctx passed in here: it applies to requests in individual
@@ -221,68 +260,35 @@ func (r *runner) ExecuteRun(
 ) (Run, TaskRunResults, error) {
 	run := NewRun(spec, vars)
 
-	pipeline, err := r.initializePipeline(&run)
+	pipeline, err := r.initializePipeline(ctx, &run)
 
 	if err != nil {
 		return run, nil, err
 	}
 
+	// the run adds the results of its tasks to vars
+	inputs := vars.Copy()
 	taskRunResults := r.run(ctx, pipeline, &run, vars, l)
 
 	if run.Pending {
 		return run, nil, pkgerrors.Wrapf(err, "unexpected async run for spec ID %v, tried executing via ExecuteAndInsertFinishedRun", spec.ID)
 	}
+	r.runShadow(run, inputs, l)
 
 	return run, taskRunResults, nil
 }
 
-func (r *runner) initializePipeline(run *Run) (*Pipeline, error) {
+func (r *runner) initializePipeline(ctx context.Context, run *Run) (*Pipeline, error) {
 	pipeline, err := Parse(run.PipelineSpec.DotDagSource)
 	if err != nil {
 		return nil, err
 	}
+	subpipelines := r.loadSubpipelines(ctx, pipeline)
 
 	// initialize certain task params
 	for _, task := range pipeline.Tasks {
 		task.Base().uuid = uuid.New()
-
-		switch task.Type() {
-		case TaskTypeHTTP:
-			task.(*HTTPTask).config = r.config
-			task.(*HTTPTask).httpClient = r.httpClient
-			task.(*HTTPTask).unrestrictedHTTPClient = r.unrestrictedHTTPClient
-		case TaskTypeBridge:
-			task.(*BridgeTask).config = r.config
-			task.(*BridgeTask).bridgeConfig = r.bridgeConfig
-			task.(*BridgeTask).orm = r.btORM
-			task.(*BridgeTask).specId = run.PipelineSpec.ID
-			// URL is "safe" because it comes from the node's own database. We
-			// must use the unrestrictedHTTPClient because some node operators
-			// may run external adapters on their own hardware
-			task.(*BridgeTask).httpClient = r.unrestrictedHTTPClient
-		case TaskTypeETHCall:
-			task.(*ETHCallTask).legacyChains = r.legacyEVMChains
-			task.(*ETHCallTask).config = r.config
-			task.(*ETHCallTask).specGasLimit = run.PipelineSpec.GasLimit
-			task.(*ETHCallTask).jobType = run.PipelineSpec.JobType
-		case TaskTypeVRF:
-			task.(*VRFTask).keyStore = r.vrfKeyStore
-		case TaskTypeVRFV2:
-			task.(*VRFTaskV2).keyStore = r.vrfKeyStore
-		case TaskTypeVRFV2Plus:
-			task.(*VRFTaskV2Plus).keyStore = r.vrfKeyStore
-		case TaskTypeEstimateGasLimit:
-			task.(*EstimateGasLimitTask).legacyChains = r.legacyEVMChains
-			task.(*EstimateGasLimitTask).specGasLimit = run.PipelineSpec.GasLimit
-			task.(*EstimateGasLimitTask).jobType = run.PipelineSpec.JobType
-		case TaskTypeETHTx:
-			task.(*ETHTxTask).keyStore = r.ethKeyStore
-			task.(*ETHTxTask).legacyChains = r.legacyEVMChains
-			task.(*ETHTxTask).specGasLimit = run.PipelineSpec.GasLimit
-			task.(*ETHTxTask).jobType = run.PipelineSpec.JobType
-			task.(*ETHTxTask).forwardingAllowed = run.PipelineSpec.ForwardingAllowed
-		default:
-		}
+		r.initializeTask(task, run, subpipelines)
 	}
 
 	// retain old UUID values
@@ -290,7 +296,7 @@ func (r *runner) initializePipeline(run *Run) (*Pipeline, error) {
 		task := pipeline.ByDotID(taskRun.DotID)
 		if task != nil && task.Base() != nil {
 			task.Base().uuid = taskRun.ID
-		} else {
+		} else if !isSubgraphDotID(taskRun.DotID) {
 			return nil, pkgerrors.Errorf("failed to match a pipeline task for dot ID: %v", taskRun.DotID)
 		}
 	}
@@ -298,10 +304,95 @@ func (r *runner) initializePipeline(run *Run) (*Pipeline, error) {
 	return pipeline, nil
 }
 
+// loadSubpipelines loads the subpipelines invoked by pipeline, directly or from other subpipelines.
+// Errors are kept with each subpipeline, so that only the tasks invoking it fail.
+func (r *runner) loadSubpipelines(ctx context.Context, pipeline *Pipeline) subpipelineSources {
+	// invalid foreach sub-graphs fail when they are expanded
+	references, _ := subpipelineReferences(pipeline)
+	if len(references) == 0 {
+		return nil
+	}
+	sources := make(subpipelineSources)
+	for len(references) > 0 {
+		reference := references[0]
+		references = references[1:]
+		if _, loaded := sources[reference]; loaded {
+			continue
+		}
+		subpipeline, err := r.orm.FindSubpipeline(reference.Name, reference.Version, pg.WithParentCtx(ctx))
+		sources[reference] = subpipelineSource{source: subpipeline.DotDagSource, err: err}
+		if err != nil {
+			continue
+		}
+		if p, err := Parse(subpipeline.DotDagSource); err == nil {
+			nested, _ := subpipelineReferences(p)
+			references = append(references, nested...)
+		}
+	}
+	return sources
+}
+
+func (r *runner) initializeTask(task Task, run *Run, subpipelines subpipelineSources) {
+	switch task.Type() {
+	case TaskTypeHTTP:
+		task.(*HTTPTask).config = r.config
+		task.(*HTTPTask).httpClient = r.httpClient
+		task.(*HTTPTask).unrestrictedHTTPClient = r.unrestrictedHTTPClient
+		task.(*HTTPTask).responseCache = r.httpResponseCache
+	case TaskTypeBridge:
+		task.(*BridgeTask).config = r.config
+		task.(*BridgeTask).bridgeConfig = r.bridgeConfig
+		task.(*BridgeTask).orm = r.btORM
+		task.(*BridgeTask).specId = run.PipelineSpec.ID
+		// URL is "safe" because it comes from the node's own database. We
+		// must use the unrestrictedHTTPClient because some node operators
+		// may run external adapters on their own hardware
+		task.(*BridgeTask).httpClient = r.unrestrictedHTTPClient
+		task.(*BridgeTask).responseCache = r.httpResponseCache
+	case TaskTypeETHCall:
+		task.(*ETHCallTask).legacyChains = r.legacyEVMChains
+		task.(*ETHCallTask).config = r.config
+		task.(*ETHCallTask).specGasLimit = run.PipelineSpec.GasLimit
+		task.(*ETHCallTask).jobType = run.PipelineSpec.JobType
+	case TaskTypeVRF:
+		task.(*VRFTask).keyStore = r.vrfKeyStore
+	case TaskTypeVRFV2:
+		task.(*VRFTaskV2).keyStore = r.vrfKeyStore
+	case TaskTypeVRFV2Plus:
+		task.(*VRFTaskV2Plus).keyStore = r.vrfKeyStore
+	case TaskTypeEstimateGasLimit:
+		task.(*EstimateGasLimitTask).legacyChains = r.legacyEVMChains
+		task.(*EstimateGasLimitTask).specGasLimit = run.PipelineSpec.GasLimit
+		task.(*EstimateGasLimitTask).jobType = run.PipelineSpec.JobType
+	case TaskTypeETHTx:
+		task.(*ETHTxTask).keyStore = r.ethKeyStore
+		task.(*ETHTxTask).legacyChains = r.legacyEVMChains
+		task.(*ETHTxTask).specGasLimit = run.PipelineSpec.GasLimit
+		task.(*ETHTxTask).jobType = run.PipelineSpec.JobType
+		task.(*ETHTxTask).forwardingAllowed = run.PipelineSpec.ForwardingAllowed
+	case TaskTypeForEach:
+		task.(*ForEachTask).initializeTask = func(t Task) { r.initializeTask(t, run, subpipelines) }
+	case TaskTypeWSLatest:
+		task.(*WSLatestTask).streams = r.wsStreams
+	case TaskTypeSign:
+		task.(*SignTask).config = r.config
+		task.(*SignTask).ocr2KeyStore = r.ocr2KeyStore
+		task.(*SignTask).jobID = run.PipelineSpec.JobID
+		task.(*SignTask).jobType = run.PipelineSpec.JobType
+	case TaskTypeSubpipeline:
+		task.(*SubpipelineTask).sources = subpipelines
+		task.(*SubpipelineTask).initializeTask = func(t Task) { r.initializeTask(t, run, subpipelines) }
+	default:
+	}
+}
+
 func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Vars, l logger.Logger) TaskRunResults {
 	l = l.With("jobID", run.PipelineSpec.JobID, "jobName", run.PipelineSpec.JobName)
 	l.Debug("Initiating tasks for pipeline run of spec")
 
+	ctx, span := startRunSpan(ctx, run)
+	defer endRunSpan(span, run)
+
 	scheduler := newScheduler(pipeline, run, vars, l)
 	go scheduler.Run()
 
@@ -319,7 +410,13 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 		taskRun := taskRun
 		// execute
 		go recovery.WrapRecoverHandle(l, func() {
-			result := r.executeTaskRun(ctx, run.PipelineSpec, taskRun, l)
+			result, ok := run.replay.recorded(taskRun.task)
+			if !ok && run.shadow {
+				result, ok = skippedInShadow(taskRun.task)
+			}
+			if !ok {
+				result = r.executeTaskRun(ctx, run.PipelineSpec, taskRun, l)
+			}
 
 			logTaskRunToPrometheus(result, run.PipelineSpec)
 
@@ -428,6 +525,8 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 		"taskType", taskRun.task.Type(),
 		"attempt", taskRun.attempts)
 
+	ctx, span := startTaskSpan(ctx, taskRun)
+
 	// Task timeout will be whichever of the following timesout/cancels first:
 	// - Pipeline-level timeout
 	// - Specific task timeout (task.TaskTimeout)
@@ -450,6 +549,7 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 	}
 
 	result, runInfo := taskRun.task.Run(ctx, l, taskRun.vars, taskRun.inputs)
+	endTaskSpan(span, result, runInfo)
 	loggerFields := []interface{}{"runInfo", runInfo,
 		"resultValue", result.Value,
 		"resultError", result.Error,
@@ -508,12 +608,13 @@ func (r *runner) ExecuteAndInsertFinishedRun(ctx context.Context, spec Spec, var
 	if err = r.orm.InsertFinishedRun(&run, saveSuccessfulTaskRuns); err != nil {
 		return 0, finalResult, pkgerrors.Wrapf(err, "error inserting finished results for spec ID %v", spec.ID)
 	}
+	r.notifyRunFinished(&run)
 	return run.ID, finalResult, nil
 
 }
 
 func (r *runner) Run(ctx context.Context, run *Run, l logger.Logger, saveSuccessfulTaskRuns bool, fn func(tx pg.Queryer) error) (incomplete bool, err error) {
-	pipeline, err := r.initializePipeline(run)
+	pipeline, err := r.initializePipeline(ctx, run)
 	if err != nil {
 		return false, err
 	}
@@ -554,6 +655,7 @@ func (r *runner) Run(ctx context.Context, run *Run, l logger.Logger, saveSuccess
 		return false, err
 	}
 
+	inputs := NewVarsFrom(run.Inputs.Val.(map[string]interface{})).Copy()
 	for {
 		r.run(ctx, pipeline, run, NewVarsFrom(run.Inputs.Val.(map[string]interface{})), l)
 
@@ -591,7 +693,8 @@ func (r *runner) Run(ctx context.Context, run *Run, l logger.Logger, saveSuccess
 			}
 		}
 
-		r.runFinished(run)
+		r.notifyRunFinished(run)
+		r.runShadow(*run, inputs, l)
 
 		return run.Pending, err
 	}
@@ -621,11 +724,21 @@ func (r *runner) ResumeRun(taskID uuid.UUID, value interface{}, err error) error
 }
 
 func (r *runner) InsertFinishedRun(run *Run, saveSuccessfulTaskRuns bool, qopts ...pg.QOpt) error {
-	return r.orm.InsertFinishedRun(run, saveSuccessfulTaskRuns, qopts...)
+	if err := r.orm.InsertFinishedRun(run, saveSuccessfulTaskRuns, qopts...); err != nil {
+		return err
+	}
+	r.notifyRunFinished(run)
+	return nil
 }
 
 func (r *runner) InsertFinishedRuns(runs []*Run, saveSuccessfulTaskRuns bool, qopts ...pg.QOpt) error {
-	return r.orm.InsertFinishedRuns(runs, saveSuccessfulTaskRuns, qopts...)
+	if err := r.orm.InsertFinishedRuns(runs, saveSuccessfulTaskRuns, qopts...); err != nil {
+		return err
+	}
+	for _, run := range runs {
+		r.notifyRunFinished(run)
+	}
+	return nil
 }
 
 func (r *runner) runReaper() {
@@ -633,6 +746,18 @@ func (r *runner) runReaper() {
 	ctx, cancel := r.chStop.CtxCancel(context.WithTimeout(context.Background(), r.config.ReaperInterval()))
 	defer cancel()
 
+	if r.archive != nil {
+		// archiving deletes the runs, and the remaining ones are kept in the database until they
+		// can be archived
+		if err := r.orm.ArchiveRunsOlderThan(ctx, r.config.Archive().Threshold(), r.archive.Write); err != nil {
+			r.lggr.Errorw("Pipeline run archiver failed", "err", err)
+			r.SvcErrBuffer.Append(err)
+		} else {
+			r.lggr.Debugw("Pipeline run archiver completed successfully")
+		}
+		return
+	}
+
 	err := r.orm.DeleteRunsOlderThan(ctx, r.config.ReaperThreshold())
 	if err != nil {
 		r.lggr.Errorw("Pipeline run reaper failed", "err", err)
@@ -2,6 +2,7 @@ package pipeline_test
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -51,7 +53,7 @@ func newRunner(t testing.TB, db *sqlx.DB, bridgeORM bridges.ORM, cfg chainlink.G
 
 	orm.On("GetQ").Return(q).Maybe()
 	c := clhttptest.NewTestLocalOnlyHTTPClient()
-	r := pipeline.NewRunner(orm, bridgeORM, cfg.JobPipeline(), cfg.WebServer(), legacyChains, ethKeyStore, nil, logger.TestLogger(t), c, c)
+	r := pipeline.NewRunner(orm, bridgeORM, cfg.JobPipeline(), cfg.WebServer(), legacyChains, ethKeyStore, nil, nil, logger.TestLogger(t), c, c)
 	return r, orm
 }
 
@@ -485,7 +487,7 @@ func Test_PipelineRunner_HandleFaultsPersistRun(t *testing.T) {
 	legacyChains, err := evmrelay.NewLegacyChainsFromRelayerExtenders(relayExtenders)
 	require.NoError(t, err)
 	lggr := logger.TestLogger(t)
-	r := pipeline.NewRunner(orm, btORM, cfg.JobPipeline(), cfg.WebServer(), legacyChains, ethKeyStore, nil, lggr, nil, nil)
+	r := pipeline.NewRunner(orm, btORM, cfg.JobPipeline(), cfg.WebServer(), legacyChains, ethKeyStore, nil, nil, lggr, nil, nil)
 
 	spec := pipeline.Spec{DotDagSource: `
 fail_but_i_dont_care [type=fail]
@@ -559,6 +561,79 @@ a->b2;`,
 	assert.Equal(t, mustDecimal(t, "12").String(), result.Values[1].(decimal.Decimal).String())
 }
 
+func Test_PipelineRunner_ForEach(t *testing.T) {
+	cfg := configtest.NewTestGeneralConfig(t)
+	btORM := bridgesMocks.NewORM(t)
+	r, _ := newRunner(t, pgtest.NewSqlxDB(t), btORM, cfg)
+	lggr := logger.TestLogger(t)
+
+	spec := pipeline.Spec{
+		DotDagSource: `
+fan [type=foreach input="$(prices)" subgraph=<double [type=multiply input="$(item)" times=2]; add [type=sum values="[ $(double), $(index) ]"]>]
+total [type=median values="$(fan)" index=0]
+fan -> total`,
+	}
+
+	t.Run("collects the results of every element", func(t *testing.T) {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(map[string]interface{}{"prices": []interface{}{"1", "2", "3"}}), lggr)
+		require.NoError(t, err)
+		require.Len(t, trrs, 8)
+
+		// double = 2, 4, 6; add = 2, 5, 8
+		result, err := trrs.FinalResult(lggr).SingularResult()
+		require.NoError(t, err)
+		assert.Equal(t, "5", result.Value.(decimal.Decimal).String())
+
+		var dotIDs []string
+		for _, taskRun := range run.PipelineTaskRuns {
+			dotIDs = append(dotIDs, taskRun.DotID)
+		}
+		assert.ElementsMatch(t, []string{"fan", "total", "fan.0.double", "fan.0.add", "fan.1.double", "fan.1.add", "fan.2.double", "fan.2.add"}, dotIDs)
+
+		fan := run.ByDotID("fan")
+		require.NotNil(t, fan)
+		values := fan.Output.Val.([]interface{})
+		require.Len(t, values, 3)
+		for i, want := range []string{"2", "5", "8"} {
+			assert.Equal(t, want, values[i].(decimal.Decimal).String())
+		}
+	})
+
+	t.Run("no elements", func(t *testing.T) {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{
+			DotDagSource: `fan [type=foreach input="$(prices)" subgraph=<double [type=multiply input="$(item)" times=2]>]`,
+		}, pipeline.NewVarsFrom(map[string]interface{}{"prices": []interface{}{}}), lggr)
+		require.NoError(t, err)
+		require.Len(t, trrs, 1)
+		result, err := trrs.FinalResult(lggr).SingularResult()
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{}, result.Value)
+		assert.Equal(t, pipeline.RunStatusCompleted, run.State)
+	})
+
+	t.Run("an element fails", func(t *testing.T) {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(map[string]interface{}{"prices": []interface{}{"1", "foo", "3"}}), lggr)
+		require.NoError(t, err)
+		assert.True(t, trrs.FinalResult(lggr).HasFatalErrors())
+		assert.Equal(t, pipeline.RunStatusErrored, run.State)
+
+		fan := run.ByDotID("fan")
+		require.NotNil(t, fan)
+		assert.Contains(t, fan.Error.String, "element 1")
+		assert.True(t, run.ByDotID("fan.1.double").Error.Valid)
+		assert.False(t, run.ByDotID("fan.0.double").Error.Valid)
+	})
+
+	t.Run("input is not an array", func(t *testing.T) {
+		run, _, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(map[string]interface{}{"prices": 42}), lggr)
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusErrored, run.State)
+		fan := run.ByDotID("fan")
+		require.NotNil(t, fan)
+		assert.Contains(t, fan.Error.String, "input")
+	})
+}
+
 func Test_PipelineRunner_AsyncJob_Basic(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 
@@ -944,3 +1019,185 @@ en->de
 	require.NoError(t, err)
 	assert.Equal(t, inputBytes, result.Value)
 }
+
+func Test_PipelineRunner_Subpipeline(t *testing.T) {
+	cfg := configtest.NewTestGeneralConfig(t)
+	btORM := bridgesMocks.NewORM(t)
+	r, orm := newRunner(t, pgtest.NewSqlxDB(t), btORM, cfg)
+	lggr := logger.TestLogger(t)
+
+	orm.On("FindSubpipeline", "double", int32(0), mock.Anything).Return(pipeline.Subpipeline{
+		Name: "double", Version: 1, DotDagSource: `mul [type=multiply input="$(inputs.value)" times=2]`,
+	}, nil).Maybe()
+	orm.On("FindSubpipeline", "outer", int32(2), mock.Anything).Return(pipeline.Subpipeline{
+		Name: "outer", Version: 2, DotDagSource: `inner [type=subpipeline name="double" inputs=<{"value": $(inputs.value)}>]`,
+	}, nil).Maybe()
+	orm.On("FindSubpipeline", "loop", int32(0), mock.Anything).Return(pipeline.Subpipeline{
+		Name: "loop", Version: 1, DotDagSource: `again [type=subpipeline name="loop"]`,
+	}, nil).Maybe()
+	orm.On("FindSubpipeline", "missing", int32(0), mock.Anything).Return(pipeline.Subpipeline{}, sql.ErrNoRows).Maybe()
+
+	vars := pipeline.NewVarsFrom(map[string]interface{}{"value": 21})
+
+	t.Run("returns the result of the subpipeline", func(t *testing.T) {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{
+			DotDagSource: `price [type=subpipeline name="double" inputs=<{"value": $(value)}>]`,
+		}, vars, lggr)
+		require.NoError(t, err)
+		require.Len(t, trrs, 2)
+		result, err := trrs.FinalResult(lggr).SingularResult()
+		require.NoError(t, err)
+		assert.Equal(t, "42", result.Value.(decimal.Decimal).String())
+		assert.NotNil(t, run.ByDotID("price.mul"))
+	})
+
+	t.Run("displays nested task runs inline", func(t *testing.T) {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{
+			DotDagSource: `price [type=subpipeline name="outer" version=2 inputs=<{"value": $(value)}>]`,
+		}, vars, lggr)
+		require.NoError(t, err)
+		result, err := trrs.FinalResult(lggr).SingularResult()
+		require.NoError(t, err)
+		assert.Equal(t, "42", result.Value.(decimal.Decimal).String())
+
+		var dotIDs []string
+		for _, taskRun := range run.PipelineTaskRuns {
+			dotIDs = append(dotIDs, taskRun.DotID)
+		}
+		assert.ElementsMatch(t, []string{"price", "price.inner", "price.inner.mul"}, dotIDs)
+	})
+
+	t.Run("detects cycles", func(t *testing.T) {
+		run, _, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{
+			DotDagSource: `a [type=subpipeline name="loop"]`,
+		}, vars, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusErrored, run.State)
+		assert.Contains(t, run.ByDotID("a.again").Error.String, "loop -> loop: subpipeline cycle")
+	})
+
+	t.Run("subpipeline does not exist", func(t *testing.T) {
+		run, _, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{
+			DotDagSource: `a [type=subpipeline name="missing"]`,
+		}, vars, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusErrored, run.State)
+		assert.Contains(t, run.ByDotID("a").Error.String, "failed to load subpipeline missing")
+	})
+}
+
+func Test_PipelineRunner_HTTPResponseCache(t *testing.T) {
+	var requests atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"price": 42}`))
+	}))
+	defer s.Close()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	btORM := bridgesMocks.NewORM(t)
+	r, _ := newRunner(t, pgtest.NewSqlxDB(t), btORM, cfg)
+	lggr := logger.TestLogger(t)
+
+	spec := pipeline.Spec{
+		DotDagSource: fmt.Sprintf(`
+ds1 [type=http method=GET url="%[1]s" responseCacheTTL="1m"]
+ds2 [type=http method=GET url="%[1]s" responseCacheTTL="1m"]
+ds3 [type=http method=GET url="%[1]s"]`, s.URL),
+	}
+
+	for i := 0; i < 2; i++ {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(nil), lggr)
+		require.NoError(t, err)
+		require.Len(t, trrs, 3)
+		assert.Equal(t, pipeline.RunStatusCompleted, run.State)
+		for _, trr := range trrs {
+			assert.Equal(t, `{"price": 42}`, trr.Result.Value)
+		}
+	}
+
+	// ds1 and ds2 share a single cached request, ds3 is not cached
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func Test_PipelineRunner_ReplayRun(t *testing.T) {
+	var price, requests atomic.Int32
+	price.Store(42)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = fmt.Fprintf(w, `{"price": %d}`, price.Load())
+	}))
+	defer s.Close()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	btORM := bridgesMocks.NewORM(t)
+	r, orm := newRunner(t, pgtest.NewSqlxDB(t), btORM, cfg)
+	lggr := logger.TestLogger(t)
+
+	spec := pipeline.Spec{
+		DotDagSource: fmt.Sprintf(`
+ds    [type=http method=GET url="%s"]
+parse [type=jsonparse path="price"]
+mul   [type=multiply times="$(times)"]
+ds -> parse -> mul`, s.URL),
+	}
+	run, _, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(map[string]interface{}{"times": 2}), lggr)
+	require.NoError(t, err)
+	require.Equal(t, pipeline.RunStatusCompleted, run.State)
+
+	// store the run the way the ORM loads it
+	run.ID = 1
+	for i := range run.PipelineTaskRuns {
+		b, err2 := run.PipelineTaskRuns[i].Output.MarshalJSON()
+		require.NoError(t, err2)
+		run.PipelineTaskRuns[i].Output = pipeline.JSONSerializable{}
+		require.NoError(t, run.PipelineTaskRuns[i].Output.UnmarshalJSON(b))
+	}
+	orm.On("FindRun", int64(1)).Return(run, nil)
+	orm.On("FindRun", int64(2)).Return(pipeline.Run{}, sql.ErrNoRows)
+
+	price.Store(43)
+	require.Equal(t, int32(1), requests.Load())
+
+	t.Run("substitutes recorded results", func(t *testing.T) {
+		result, err := r.ReplayRun(testutils.Context(t), 1, pipeline.ReplayOptions{}, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), requests.Load())
+		assert.Equal(t, int64(1), result.OriginalRunID)
+		assert.Equal(t, 0, result.Changed())
+		require.Len(t, result.Tasks, 3)
+		assert.Equal(t, "ds", result.Tasks[0].DotID)
+		assert.True(t, result.Tasks[0].Substituted)
+		assert.False(t, result.Tasks[2].Substituted)
+		assert.Equal(t, "84", result.Run.Outputs.Val.([]interface{})[0].(decimal.Decimal).String())
+	})
+
+	t.Run("refetches live results", func(t *testing.T) {
+		result, err := r.ReplayRun(testutils.Context(t), 1, pipeline.ReplayOptions{Refetch: []string{"ds"}}, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+		assert.Equal(t, 3, result.Changed())
+		mul := result.Tasks[2]
+		assert.Equal(t, "mul", mul.DotID)
+		assert.Equal(t, `"84"`, string(mustMarshalJSON(t, mul.Original.Output)))
+		assert.Equal(t, `"86"`, string(mustMarshalJSON(t, mul.Replayed.Output)))
+	})
+
+	t.Run("only refetches external tasks", func(t *testing.T) {
+		_, err := r.ReplayRun(testutils.Context(t), 1, pipeline.ReplayOptions{Refetch: []string{"parse"}}, lggr)
+		require.ErrorIs(t, err, pipeline.ErrInvalidReplayOptions)
+		_, err = r.ReplayRun(testutils.Context(t), 1, pipeline.ReplayOptions{Refetch: []string{"missing"}}, lggr)
+		require.ErrorIs(t, err, pipeline.ErrInvalidReplayOptions)
+	})
+
+	t.Run("unknown run", func(t *testing.T) {
+		_, err := r.ReplayRun(testutils.Context(t), 2, pipeline.ReplayOptions{}, lggr)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func mustMarshalJSON(t *testing.T, js pipeline.JSONSerializable) []byte {
+	b, err := js.MarshalJSON()
+	require.NoError(t, err)
+	return b
+}
@@ -3,8 +3,10 @@ package pipeline
 import (
 	"context"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jpillora/backoff"
 	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v4"
@@ -15,6 +17,17 @@ import (
 func (s *scheduler) newMemoryTaskRun(task Task, vars Vars) *memoryTaskRun {
 	run := &memoryTaskRun{task: task, vars: vars}
 
+	// an expanded task takes the results of its sub-graph instances, in order
+	if _, ok := task.(subgraphTask); ok {
+		if expansion, expanded := s.expansions[task.ID()]; expanded {
+			run.inputs = make([]Result, len(expansion.terminals))
+			for i, terminal := range expansion.terminals {
+				run.inputs[i] = s.results[terminal.ID()].Result
+			}
+			return run
+		}
+	}
+
 	propagatableInputs := 0
 	for _, i := range task.Inputs() {
 		if i.PropagateResult {
@@ -62,6 +75,81 @@ type scheduler struct {
 
 	taskCh   chan *memoryTaskRun
 	resultCh chan TaskRunResult
+
+	// expansions holds the expanded sub-graph tasks, keyed by task ID
+	expansions map[int]*subgraphExpansion
+	// scopes holds the sub-graph instance of each task created by an expansion, keyed by task ID
+	scopes map[int]*subgraphScope
+	// nextID is the ID of the next task created by an expansion
+	nextID int
+	// deferred holds the sub-graph tasks that were ready before the scheduler started running
+	deferred []Task
+}
+
+// subgraphTask is implemented by tasks that the scheduler expands into instances of a sub-graph.
+// The task itself is run once every instance is done, with their terminal results as inputs.
+type subgraphTask interface {
+	Task
+	instances(vars Vars, inputs []Result) ([]subgraphInstance, error)
+}
+
+type subgraphInstance struct {
+	// pipeline must have exactly one terminal task
+	pipeline *Pipeline
+	// prefix is prepended to the dot IDs of the instance tasks
+	prefix string
+	vars   Vars
+}
+
+type subgraphExpansion struct {
+	// terminals holds the terminal task of each instance, in order
+	terminals []Task
+}
+
+type subgraphScope struct {
+	vars Vars
+	// dotIDs maps task IDs to their dot IDs within the sub-graph, which are used as variable names
+	dotIDs map[int]string
+}
+
+var ErrSubgraphAsync = errors.New("asynchronous tasks are not supported inside sub-graphs")
+
+// parseSubgraph parses source into a sub-graph pipeline with a single terminal task, and gives
+// each task a new UUID and its dependencies via initializeTask.
+func parseSubgraph(source string, initializeTask func(Task)) (*Pipeline, error) {
+	p, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSingleTerminal(p); err != nil {
+		return nil, err
+	}
+	for _, task := range p.Tasks {
+		task.Base().uuid = uuid.New()
+		if initializeTask != nil {
+			initializeTask(task)
+		}
+	}
+	return p, nil
+}
+
+func checkSingleTerminal(p *Pipeline) error {
+	var terminals int
+	for _, task := range p.Tasks {
+		if len(task.Outputs()) == 0 {
+			terminals++
+		}
+	}
+	if terminals != 1 {
+		return errors.Errorf("must have exactly one terminal task, got %d", terminals)
+	}
+	return nil
+}
+
+// isSubgraphDotID reports whether dotID belongs to a task created by a sub-graph task. Pipeline
+// dot IDs cannot contain the keypath separator, since they are used as variable names.
+func isSubgraphDotID(dotID string) bool {
+	return strings.Contains(dotID, KeypathSeparator)
 }
 
 func newScheduler(p *Pipeline, run *Run, vars Vars, lggr logger.Logger) *scheduler {
@@ -83,6 +171,10 @@ func newScheduler(p *Pipeline, run *Run, vars Vars, lggr logger.Logger) *schedul
 		// taskCh should never block
 		taskCh:   make(chan *memoryTaskRun, len(dependencies)),
 		resultCh: make(chan TaskRunResult),
+
+		expansions: make(map[int]*subgraphExpansion),
+		scopes:     make(map[int]*subgraphScope),
+		nextID:     len(p.Tasks),
 	}
 
 	// if there's results already present on Run, then this is a resumption. Loop over them and fill results table
@@ -100,6 +192,12 @@ func newScheduler(p *Pipeline, run *Run, vars Vars, lggr logger.Logger) *schedul
 			continue
 		}
 
+		// sub-graph instances may not fit in taskCh, so they are created once the runner is reading from it
+		if _, ok := task.(subgraphTask); ok {
+			s.deferred = append(s.deferred, task)
+			continue
+		}
+
 		run := s.newMemoryTaskRun(task, s.vars.Copy())
 
 		lggr.Tracew("scheduling task run", "dot_id", task.DotID(), "attempts", run.attempts)
@@ -117,6 +215,10 @@ func (s *scheduler) reconstructResults() {
 		task := s.pipeline.ByDotID(r.DotID)
 
 		if task == nil {
+			// sub-graph instances are re-created if the task that expanded them did not finish
+			if isSubgraphDotID(r.DotID) {
+				continue
+			}
 			panic("can't find task by dot id")
 		}
 
@@ -163,6 +265,9 @@ func (s *scheduler) reconstructResults() {
 func (s *scheduler) Run() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	for _, task := range s.deferred {
+		s.schedule(task)
+	}
 	for s.waiting > 0 {
 		// we don't "for result in resultCh" because it would stall if the
 		// pipeline is completely empty
@@ -185,6 +290,14 @@ func (s *scheduler) Run() {
 		// store task run
 		s.results[result.Task.ID()] = result
 
+		// sub-graph instances are re-created on every run, so they cannot be resumed
+		if result.runInfo.IsPending && s.scopes[result.Task.ID()] != nil {
+			result.Result = Result{Error: ErrSubgraphAsync}
+			result.runInfo.IsPending = false
+			result.FinishedAt = null.TimeFrom(time.Now())
+			s.results[result.Task.ID()] = result
+		}
+
 		// catch the pending state, we will keep the pipeline running until no more progress is made
 		if result.runInfo.IsPending {
 			s.pending = true
@@ -194,14 +307,10 @@ func (s *scheduler) Run() {
 		}
 
 		// store the result in vars
-		var err error
 		if result.Result.Error != nil {
-			err = s.vars.Set(result.Task.DotID(), result.Result.Error)
+			s.setVar(result.Task, result.Result.Error)
 		} else {
-			err = s.vars.Set(result.Task.DotID(), result.Result.Value)
-		}
-		if err != nil {
-			s.logger.Panicf("Vars.Set error: %v", err)
+			s.setVar(result.Task, result.Result.Value)
 		}
 
 		// if the task was marked as failEarly, and the result is a fail
@@ -249,7 +358,7 @@ func (s *scheduler) Run() {
 					s.logger.Tracew("scheduling task run", "dot_id", run.task.DotID(), "attempts", run.attempts)
 					s.taskCh <- run
 				}
-			}(s.vars.Copy()) // must Copy() from current goroutine
+			}(s.varsFor(result.Task).Copy()) // must Copy() from current goroutine
 
 			// skip scheduling dependencies since it's the task is not complete yet
 			continue
@@ -261,12 +370,7 @@ func (s *scheduler) Run() {
 
 			// if all dependencies are done, schedule task run
 			if s.dependencies[id] == 0 {
-				task := s.pipeline.Tasks[id]
-				run := s.newMemoryTaskRun(task, s.vars.Copy())
-
-				s.logger.Tracew("scheduling task run", "dot_id", run.task.DotID(), "attempts", run.attempts)
-				s.taskCh <- run
-				s.waiting++
+				s.schedule(output)
 			}
 		}
 
@@ -275,6 +379,99 @@ func (s *scheduler) Run() {
 	close(s.taskCh)
 }
 
+// schedule sends a task run for a task whose dependencies are done. A sub-graph task is expanded
+// into its instances instead, and scheduled again once they are done.
+func (s *scheduler) schedule(task Task) {
+	if subgraph, ok := task.(subgraphTask); ok {
+		if _, expanded := s.expansions[task.ID()]; !expanded {
+			s.expand(subgraph)
+			return
+		}
+	}
+
+	run := s.newMemoryTaskRun(task, s.varsFor(task).Copy())
+
+	s.logger.Tracew("scheduling task run", "dot_id", run.task.DotID(), "attempts", run.attempts)
+	s.taskCh <- run
+	s.waiting++
+}
+
+// expand schedules the sub-graph instances of task. The terminal task of each instance becomes a
+// dependency of task, and each instance has its own vars.
+func (s *scheduler) expand(task subgraphTask) {
+	run := s.newMemoryTaskRun(task, s.varsFor(task).Copy())
+	instances, err := task.instances(run.vars, run.inputs)
+	if err != nil {
+		s.fail(task, err)
+		return
+	}
+
+	expansion := &subgraphExpansion{}
+	s.expansions[task.ID()] = expansion
+	s.dependencies[task.ID()] = uint(len(instances))
+
+	var ready []Task
+	for _, instance := range instances {
+		scope := &subgraphScope{vars: instance.vars, dotIDs: make(map[int]string, len(instance.pipeline.Tasks))}
+		for _, t := range instance.pipeline.Tasks {
+			t.Base().id = s.nextID
+			s.nextID++
+			scope.dotIDs[t.ID()] = t.DotID()
+			t.Base().dotID = instance.prefix + t.DotID()
+			s.scopes[t.ID()] = scope
+			s.dependencies[t.ID()] = uint(len(t.Inputs()))
+
+			if len(t.Outputs()) == 0 {
+				t.Base().outputs = []Task{task}
+				expansion.terminals = append(expansion.terminals, t)
+			}
+			if len(t.Inputs()) == 0 {
+				ready = append(ready, t)
+			}
+		}
+	}
+	s.logger.Debugw("expanded sub-graph task", "dot_id", task.DotID(), "type", task.Type(), "instances", len(instances))
+
+	if len(instances) == 0 {
+		s.schedule(task)
+		return
+	}
+	for _, t := range ready {
+		s.schedule(t)
+	}
+}
+
+// fail reports an error result for a task that could not be scheduled.
+func (s *scheduler) fail(task Task, err error) {
+	now := time.Now()
+	s.waiting++
+	go s.report(context.Background(), TaskRunResult{
+		ID:         task.Base().uuid,
+		Task:       task,
+		Result:     Result{Error: err},
+		CreatedAt:  now,
+		FinishedAt: null.TimeFrom(now),
+	})
+}
+
+// varsFor returns the vars visible to task: those of its sub-graph instance, if any.
+func (s *scheduler) varsFor(task Task) Vars {
+	if scope, ok := s.scopes[task.ID()]; ok {
+		return scope.vars
+	}
+	return s.vars
+}
+
+func (s *scheduler) setVar(task Task, value interface{}) {
+	vars, name := s.vars, task.DotID()
+	if scope, ok := s.scopes[task.ID()]; ok {
+		vars, name = scope.vars, scope.dotIDs[task.ID()]
+	}
+	if err := vars.Set(name, value); err != nil {
+		s.logger.Panicf("Vars.Set error: %v", err)
+	}
+}
+
 func (s *scheduler) markRemaining(err error) {
 	now := time.Now()
 	for _, task := range s.pipeline.Tasks {
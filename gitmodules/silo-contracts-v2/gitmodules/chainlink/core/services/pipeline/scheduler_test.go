@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -168,3 +169,81 @@ func TestScheduler(t *testing.T) {
 
 	}
 }
+
+func TestScheduler_ForEach(t *testing.T) {
+	p, err := Parse(`
+	fan [type=foreach input="$(feeds)" subgraph=<fetch [type=memo value="$(item)"]>]
+	out [type=median index=0]
+	fan -> out`)
+	require.NoError(t, err)
+	vars := NewVarsFrom(map[string]interface{}{"feeds": []interface{}{"a", "b"}})
+	run := NewRun(Spec{}, vars)
+	s := newScheduler(p, &run, vars, logger.TestLogger(t))
+
+	go s.Run()
+
+	next := func() *memoryTaskRun {
+		select {
+		case taskRun := <-s.taskCh:
+			return taskRun
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for task run")
+		}
+		return nil
+	}
+	report := func(taskRun *memoryTaskRun, value interface{}) {
+		now := time.Now()
+		s.report(testutils.Context(t), TaskRunResult{
+			ID:         uuid.New(),
+			Task:       taskRun.task,
+			Result:     Result{Value: value},
+			FinishedAt: null.TimeFrom(now),
+			CreatedAt:  now,
+		})
+	}
+
+	// one instance per element, each with its own item and index
+	var instances []*memoryTaskRun
+	for i, item := range []string{"a", "b"} {
+		taskRun := next()
+		require.Equal(t, fmt.Sprintf("fan.%d.fetch", i), taskRun.task.DotID())
+		v, err := taskRun.vars.Get(ForEachItemKey)
+		require.NoError(t, err)
+		require.Equal(t, item, v)
+		v, err = taskRun.vars.Get(ForEachIndexKey)
+		require.NoError(t, err)
+		require.Equal(t, i, v)
+		instances = append(instances, taskRun)
+	}
+	// report out of order, results are still collected in element order
+	report(instances[1], "B")
+	report(instances[0], "A")
+
+	taskRun := next()
+	require.Equal(t, "fan", taskRun.task.DotID())
+	require.Equal(t, []Result{{Value: "A"}, {Value: "B"}}, taskRun.inputs)
+	report(taskRun, []interface{}{"A", "B"})
+
+	taskRun = next()
+	require.Equal(t, "out", taskRun.task.DotID())
+	v, err := taskRun.vars.Get("fan")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"A", "B"}, v)
+	// instance results are scoped to their instance
+	_, err = taskRun.vars.Get("fetch")
+	require.ErrorIs(t, err, ErrKeypathNotFound)
+	report(taskRun, "done")
+
+	select {
+	case _, ok := <-s.taskCh:
+		require.Falsef(t, ok, "scheduler has more tasks to schedule")
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for scheduler to halt")
+	}
+
+	var dotIDs []string
+	for _, result := range s.results {
+		dotIDs = append(dotIDs, result.Task.DotID())
+	}
+	require.ElementsMatch(t, []string{"fan", "out", "fan.0.fetch", "fan.1.fetch"}, dotIDs)
+}
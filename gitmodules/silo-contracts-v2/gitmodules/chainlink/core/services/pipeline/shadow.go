@@ -0,0 +1,145 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// runShadow runs live.PipelineSpec.Shadow in the background with the inputs of the finished live
+// run, and stores both final results as a ShadowRun. Tasks writing on-chain are not executed.
+func (r *runner) runShadow(live Run, vars Vars, l logger.Logger) {
+	if live.PipelineSpec.Shadow == nil || live.Pending {
+		return
+	}
+	spec := *live.PipelineSpec.Shadow
+
+	r.wgDone.Add(1)
+	go func() {
+		defer r.wgDone.Done()
+		ctx, cancel := r.chStop.NewCtx()
+		defer cancel()
+
+		l = l.Named("Shadow").With("shadowSpecID", spec.ID)
+		run := NewRun(spec, vars)
+		run.shadow = true
+		pipeline, err := r.initializePipeline(ctx, &run)
+		if err != nil {
+			l.Errorw("Failed to initialize shadow pipeline", "err", err)
+			return
+		}
+		r.run(ctx, pipeline, &run, vars, l)
+		if run.Pending {
+			l.Errorw("Shadow run is pending on an asynchronous task")
+			return
+		}
+
+		err = r.orm.InsertShadowRun(&ShadowRun{
+			JobID:          spec.JobID,
+			PipelineSpecID: spec.ID,
+			LiveOutputs:    live.Outputs,
+			LiveErrors:     live.FatalErrors,
+			ShadowOutputs:  run.Outputs,
+			ShadowErrors:   run.FatalErrors,
+		}, pg.WithParentCtx(ctx))
+		if err != nil && ctx.Err() == nil {
+			l.Errorw("Failed to store shadow run", "err", err)
+		}
+	}()
+}
+
+// skippedInShadow returns an empty result for tasks which must not be executed by shadow runs,
+// since they write on-chain.
+func skippedInShadow(task Task) (TaskRunResult, bool) {
+	if task.Type() != TaskTypeETHTx {
+		return TaskRunResult{}, false
+	}
+	now := time.Now()
+	return TaskRunResult{
+		ID:         task.Base().uuid,
+		Task:       task,
+		CreatedAt:  now,
+		FinishedAt: null.TimeFrom(now),
+	}, true
+}
+
+// ShadowReport compares the results of the shadow runs of a job to those of the live runs.
+type ShadowReport struct {
+	Runs         int
+	LiveErrors   int
+	ShadowErrors int
+	// Compared is the number of runs where both the live and the shadow run returned a
+	// numeric first output, and the live one is not zero.
+	Compared int
+	// MeanDeviation and MaxDeviation are the relative deviations of the shadow values from
+	// the live ones, over the compared runs.
+	MeanDeviation decimal.Decimal
+	MaxDeviation  decimal.Decimal
+}
+
+// NewShadowReport compares the final results of the given shadow runs.
+func NewShadowReport(shadowRuns []ShadowRun) ShadowReport {
+	report := ShadowReport{Runs: len(shadowRuns)}
+	sum := decimal.Zero
+	for _, sr := range shadowRuns {
+		liveFailed, shadowFailed := sr.LiveErrors.HasError(), sr.ShadowErrors.HasError()
+		if liveFailed {
+			report.LiveErrors++
+		}
+		if shadowFailed {
+			report.ShadowErrors++
+		}
+		if liveFailed || shadowFailed {
+			continue
+		}
+		live, ok := firstDecimalOutput(sr.LiveOutputs)
+		if !ok || live.IsZero() {
+			continue
+		}
+		shadow, ok := firstDecimalOutput(sr.ShadowOutputs)
+		if !ok {
+			continue
+		}
+		deviation := shadow.Sub(live).Div(live).Abs()
+		sum = sum.Add(deviation)
+		if deviation.GreaterThan(report.MaxDeviation) {
+			report.MaxDeviation = deviation
+		}
+		report.Compared++
+	}
+	if report.Compared > 0 {
+		report.MeanDeviation = sum.Div(decimal.NewFromInt(int64(report.Compared)))
+	}
+	return report
+}
+
+// LiveErrorRate returns the share of live runs which errored.
+func (r ShadowReport) LiveErrorRate() float64 {
+	return errorRate(r.LiveErrors, r.Runs)
+}
+
+// ShadowErrorRate returns the share of shadow runs which errored.
+func (r ShadowReport) ShadowErrorRate() float64 {
+	return errorRate(r.ShadowErrors, r.Runs)
+}
+
+func errorRate(errors, runs int) float64 {
+	if runs == 0 {
+		return 0
+	}
+	return float64(errors) / float64(runs)
+}
+
+func firstDecimalOutput(outputs JSONSerializable) (decimal.Decimal, bool) {
+	values, ok := outputs.Val.([]interface{})
+	if !outputs.Valid || !ok || len(values) == 0 || values[0] == nil {
+		return decimal.Decimal{}, false
+	}
+	d, err := utils.ToDecimal(values[0])
+	return d, err == nil
+}
@@ -0,0 +1,101 @@
+package pipeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	bridgesMocks "github.com/smartcontractkit/chainlink/v2/core/bridges/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func Test_PipelineRunner_ShadowRun(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	r, orm := newRunner(t, db, bridgesMocks.NewORM(t), cfg)
+
+	shadowRuns := make(chan *pipeline.ShadowRun, 1)
+	orm.On("InsertShadowRun", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { shadowRuns <- args.Get(0).(*pipeline.ShadowRun) }).
+		Return(nil).Once()
+
+	spec := pipeline.Spec{
+		ID:           1,
+		JobID:        2,
+		DotDagSource: `a [type=multiply input="$(val)" times=2]`,
+		Shadow: &pipeline.Spec{
+			ID:    3,
+			JobID: 2,
+			// the ethtx task would fail, as there is no key to send from
+			DotDagSource: `
+tx [type=ethtx to="0x0000000000000000000000000000000000000001" data="0x"]
+a  [type=multiply input="$(val)" times=3]
+tx->a`,
+		},
+	}
+	lggr := logger.TestLogger(t)
+	run, _, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(map[string]interface{}{"val": 2}), lggr)
+	require.NoError(t, err)
+	require.False(t, run.HasFatalErrors())
+
+	select {
+	case sr := <-shadowRuns:
+		assert.Equal(t, int32(2), sr.JobID)
+		assert.Equal(t, int32(3), sr.PipelineSpecID)
+		assert.False(t, sr.LiveErrors.HasError())
+		assert.False(t, sr.ShadowErrors.HasError())
+		assert.Equal(t, run.Outputs, sr.LiveOutputs)
+
+		report := pipeline.NewShadowReport([]pipeline.ShadowRun{*sr})
+		assert.Equal(t, 1, report.Compared)
+		assert.Equal(t, "0.5", report.MaxDeviation.String())
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("timed out waiting for the shadow run")
+	}
+}
+
+func TestNewShadowReport(t *testing.T) {
+	t.Parallel()
+
+	outputs := func(v interface{}) pipeline.JSONSerializable {
+		return pipeline.JSONSerializable{Val: []interface{}{v}, Valid: true}
+	}
+	failed := pipeline.RunErrors{null.StringFrom("boom")}
+	ok := pipeline.RunErrors{null.String{}}
+
+	report := pipeline.NewShadowReport([]pipeline.ShadowRun{
+		{LiveOutputs: outputs("100"), LiveErrors: ok, ShadowOutputs: outputs("110"), ShadowErrors: ok},
+		{LiveOutputs: outputs(decimal.NewFromInt(200)), LiveErrors: ok, ShadowOutputs: outputs(190.0), ShadowErrors: ok},
+		// not compared: the shadow run errored
+		{LiveOutputs: outputs("100"), LiveErrors: ok, ShadowOutputs: outputs(nil), ShadowErrors: failed},
+		// not compared: the live run errored
+		{LiveOutputs: outputs(nil), LiveErrors: failed, ShadowOutputs: outputs("100"), ShadowErrors: ok},
+		// not compared: the live value is zero
+		{LiveOutputs: outputs("0"), LiveErrors: ok, ShadowOutputs: outputs("1"), ShadowErrors: ok},
+		// not compared: the output is not numeric
+		{LiveOutputs: outputs("foo"), LiveErrors: ok, ShadowOutputs: outputs("bar"), ShadowErrors: ok},
+	})
+
+	assert.Equal(t, 6, report.Runs)
+	assert.Equal(t, 1, report.LiveErrors)
+	assert.Equal(t, 1, report.ShadowErrors)
+	assert.Equal(t, 2, report.Compared)
+	assert.Equal(t, "0.075", report.MeanDeviation.String())
+	assert.Equal(t, "0.1", report.MaxDeviation.String())
+	assert.InDelta(t, 1.0/6, report.LiveErrorRate(), 1e-9)
+	assert.InDelta(t, 1.0/6, report.ShadowErrorRate(), 1e-9)
+
+	empty := pipeline.NewShadowReport(nil)
+	assert.Zero(t, empty.Compared)
+	assert.Zero(t, empty.LiveErrorRate())
+	assert.True(t, empty.MeanDeviation.IsZero())
+}
@@ -50,6 +50,10 @@ var (
 	)
 )
 
+// BridgeTask sends a request to an external adapter. cacheTTL sets how long a successful response
+// can be used as a fallback if later requests fail. Setting responseCacheTTL (e.g. "10s") opts the
+// task into the response cache shared by all jobs, see HTTPTask; it cannot be used with async.
+//
 // Return types:
 //
 //	string
@@ -62,12 +66,14 @@ type BridgeTask struct {
 	Async             string `json:"async"`
 	CacheTTL          string `json:"cacheTTL"`
 	Headers           string `json:"headers"`
-
-	specId       int32
-	orm          bridges.ORM
-	config       Config
-	bridgeConfig BridgeConfig
-	httpClient   *http.Client
+	ResponseCacheTTL  string `json:"responseCacheTTL"`
+
+	specId        int32
+	orm           bridges.ORM
+	config        Config
+	bridgeConfig  BridgeConfig
+	httpClient    *http.Client
+	responseCache *httpResponseCache
 }
 
 var _ Task = (*BridgeTask)(nil)
@@ -80,6 +86,13 @@ func (t *BridgeTask) Type() TaskType {
 	return TaskTypeBridge
 }
 
+func (t *BridgeTask) validate() error {
+	if t.Async == "true" && t.ResponseCacheTTL != "" {
+		return errors.New("responseCacheTTL cannot be used with async, responses are specific to each task run")
+	}
+	return nil
+}
+
 func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
 	inputValues, err := CheckInputs(inputs, -1, -1, 0)
 	if err != nil {
@@ -92,6 +105,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		includeInputAtKey StringParam
 		cacheTTL          Uint64Param
 		reqHeaders        StringSliceParam
+		responseCacheTTL  Uint64Param
 	)
 	err = multierr.Combine(
 		errors.Wrap(ResolveParam(&name, From(NonemptyString(t.Name))), "name"),
@@ -99,6 +113,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		errors.Wrap(ResolveParam(&includeInputAtKey, From(t.IncludeInputAtKey)), "includeInputAtKey"),
 		errors.Wrap(ResolveParam(&cacheTTL, From(ValidDurationInSeconds(t.CacheTTL), t.bridgeConfig.BridgeCacheTTL().Seconds())), "cacheTTL"),
 		errors.Wrap(ResolveParam(&reqHeaders, From(NonemptyString(t.Headers), "[]")), "reqHeaders"),
+		errors.Wrap(ResolveParam(&responseCacheTTL, From(ValidDurationInSeconds(t.ResponseCacheTTL), 0)), "responseCacheTTL"),
 	)
 	if err != nil {
 		return Result{Error: err}, runInfo
@@ -165,8 +180,11 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		cacheDuration = stalenessCap
 	}
 
+	// bridges are always called with the unrestricted client, see runner.initializeTask
 	var cachedResponse bool
-	responseBytes, statusCode, headers, elapsed, err := makeHTTPRequest(requestCtx, lggr, "POST", URLParam(url), reqHeaders, requestData, t.httpClient, t.config.DefaultHTTPLimit())
+	response, sharedResponse, err := makeCachedHTTPRequest(requestCtx, lggr, t.responseCache, t.Type(), time.Duration(responseCacheTTL)*time.Second, true,
+		"POST", URLParam(url), reqHeaders, requestData, t.httpClient, t.config.DefaultHTTPLimit())
+	responseBytes, statusCode, headers, elapsed := response.body, response.statusCode, response.headers, response.elapsed
 	if err != nil {
 		promBridgeErrors.WithLabelValues(t.Name).Inc()
 		if cacheTTL == 0 {
@@ -191,7 +209,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 			"url", url.String(),
 		)
 		cachedResponse = true
-	} else {
+	} else if !sharedResponse {
 		promBridgeLatency.WithLabelValues(t.Name).Set(elapsed.Seconds())
 	}
 
@@ -209,7 +227,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		}
 	}
 
-	if !cachedResponse && cacheTTL > 0 {
+	if !cachedResponse && !sharedResponse && cacheTTL > 0 {
 		err := t.orm.UpsertBridgeResponse(t.dotID, t.specId, responseBytes)
 		if err != nil {
 			lggr.Errorw("Bridge task: failed to upsert response in bridge cache", "err", err)
@@ -230,6 +248,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		"url", url.String(),
 		"dotID", t.DotID(),
 		"cached", cachedResponse,
+		"sharedCache", sharedResponse,
 	)
 	return result, runInfo
 }
@@ -1016,3 +1016,13 @@ func TestBridgeTask_Headers(t *testing.T) {
 		assert.Equal(t, []string{"Content-Length", "38", "Content-Type", "footype", "User-Agent", "Go-http-client/1.1", "X-Header-1", "foo", "X-Header-2", "bar"}, allHeaders(headers))
 	})
 }
+
+func TestBridgeTask_ResponseCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	_, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeBridge, map[string]interface{}{"name": "foo", "responseCacheTTL": "10s"}, 0, "ds")
+	require.NoError(t, err)
+
+	_, err = pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeBridge, map[string]interface{}{"name": "foo", "async": "true", "responseCacheTTL": "10s"}, 0, "ds")
+	require.ErrorContains(t, err, "responseCacheTTL cannot be used with async")
+}
@@ -46,7 +46,7 @@ func (t *ETHABIDecodeLogTask) Run(_ context.Context, _ logger.Logger, vars Vars,
 		return Result{Error: err}, runInfo
 	}
 
-	_, args, indexedArgs, err := parseETHABIString([]byte(theABI), true)
+	_, args, indexedArgs, err := ParseETHABIString([]byte(theABI), true)
 	if err != nil {
 		return Result{Error: errors.Wrap(ErrBadInput, err.Error())}, runInfo
 	}
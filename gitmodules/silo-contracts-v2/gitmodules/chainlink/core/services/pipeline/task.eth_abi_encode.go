@@ -44,7 +44,7 @@ func (t *ETHABIEncodeTask) Run(_ context.Context, _ logger.Logger, vars Vars, in
 		return Result{Error: err}, runInfo
 	}
 
-	methodName, args, _, err := parseETHABIString([]byte(theABI), false)
+	methodName, args, _, err := ParseETHABIString([]byte(theABI), false)
 	if err != nil {
 		return Result{Error: errors.Wrapf(ErrBadInput, "ETHABIEncode: while parsing ABI string: %v", err)}, runInfo
 	}
@@ -124,7 +124,7 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 		With("gasFeeCap", call.GasFeeCap)
 
 	start := time.Now()
-	resp, err := chain.Client().CallContract(ctx, call, nil)
+	resp, err := chain.Client().CallContract(withRPCTraceContext(ctx), call, nil)
 	elapsed := time.Since(start)
 	if err != nil {
 		if t.ExtractRevertReason {
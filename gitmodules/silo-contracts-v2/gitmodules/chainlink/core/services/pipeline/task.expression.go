@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// ExpressionTask evaluates an arithmetic or logical expression over the pipeline variables,
+// e.g. `(ds1 * ds2 - fee) / 100 > 0 && !paused`. See expression.go for the language.
+// The optional precision is applied to every division, as in DivideTask.
+//
+// Return types:
+//
+//	decimal.Decimal
+//	bool
+//	string
+type ExpressionTask struct {
+	BaseTask   `mapstructure:",squash"`
+	Expression string `json:"expression"`
+	Precision  string `json:"precision"`
+
+	program *expressionProgram
+}
+
+var _ Task = (*ExpressionTask)(nil)
+
+func (t *ExpressionTask) Type() TaskType {
+	return TaskTypeExpression
+}
+
+// validate compiles the expression, so that invalid expressions are rejected when the spec is parsed.
+func (t *ExpressionTask) validate() (err error) {
+	t.program, err = compileExpression(t.Expression)
+	return errors.Wrap(err, "expression")
+}
+
+func (t *ExpressionTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var maybePrecision MaybeInt32Param
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&maybePrecision, From(VarExpr(t.Precision, vars), t.Precision)), "precision"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	program := t.program
+	if program == nil {
+		if program, err = compileExpression(t.Expression); err != nil {
+			return Result{Error: errors.Wrap(err, "expression")}, runInfo
+		}
+	}
+
+	env := &expressionEnv{vars: vars}
+	if len(inputs) == 1 {
+		env.input, env.hasInput = inputs[0].Value, true
+	}
+	if precision, isSet := maybePrecision.Int32(); isSet {
+		env.precision = &precision
+	}
+
+	value, err := program.eval(env)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "expression")}, runInfo
+	}
+	return Result{Value: value}, runInfo
+}
@@ -0,0 +1,175 @@
+package pipeline_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestExpressionTask_Happy(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]interface{}{
+		"a":      "10",
+		"b":      int64(3),
+		"c":      float64(1.5),
+		"d":      big.NewInt(2),
+		"paused": false,
+		"name":   "eth",
+		"ds1": map[string]interface{}{
+			"prices": []interface{}{"100", "200"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		precision  string
+		input      interface{}
+		want       interface{}
+	}{
+		{"arithmetic", "(a * b - c) / d", "", nil, decimal.RequireFromString("14.25")},
+		{"precedence", "1 + 2 * 3 - 4 % 3", "", nil, decimal.NewFromInt(6)},
+		{"unary minus", "-a + --b", "", nil, decimal.NewFromInt(-7)},
+		{"division default precision", "1 / 3", "", nil, decimal.RequireFromString("0.3333333333333333")},
+		{"division with precision", "1 / 3", "2", nil, decimal.RequireFromString("0.33")},
+		{"large numbers", "1e18 * 1e18 + 1", "", nil, decimal.RequireFromString("1000000000000000000000000000000000001")},
+		{"keypaths", "ds1.prices.1 - ds1.prices.0", "", nil, decimal.NewFromInt(100)},
+		{"input", "input * 2", "", "21", decimal.NewFromInt(42)},
+		{"comparison", "a > b && b >= 3 && c < d && d <= 2", "", nil, true},
+		{"logic", "!paused && (a == 10 || missing)", "", nil, true},
+		{"short circuit", "paused && missing", "", nil, false},
+		{"string equality", "name == 'eth' && name != \"btc\"", "", nil, true},
+		{"bool equality", "paused == false", "", nil, true},
+		{"numeric string equality", "a == 10.0", "", nil, true},
+		{"ternary", "a > b ? a : b", "", nil, "10"},
+		{"nested ternary", "paused ? 1 : a < b ? 2 : 3", "", nil, decimal.NewFromInt(3)},
+		{"functions", "min(a, b, c) + max(a, b) + abs(-d) + floor(c) + ceil(c) + round(1.2345, 2)", "", nil, decimal.RequireFromString("17.73")},
+		{"round", "round(2.5)", "", nil, decimal.NewFromInt(3)},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.ExpressionTask{
+				BaseTask:   pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Expression: test.expression,
+				Precision:  test.precision,
+			}
+			var inputs []pipeline.Result
+			if test.input != nil {
+				inputs = []pipeline.Result{{Value: test.input}}
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(vars), inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+			require.NoError(t, result.Error)
+			if want, ok := test.want.(decimal.Decimal); ok {
+				require.IsType(t, decimal.Decimal{}, result.Value)
+				assert.True(t, want.Equal(result.Value.(decimal.Decimal)), "expected %s, got %s", want, result.Value)
+				return
+			}
+			assert.Equal(t, test.want, result.Value)
+		})
+	}
+}
+
+func TestExpressionTask_Errors(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]interface{}{
+		"a":      "10",
+		"paused": false,
+		"name":   "eth",
+		"big":    "1e-2147483647",
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		inputs     []pipeline.Result
+		wantErr    error
+	}{
+		{"divide by zero", "a / 0", nil, pipeline.ErrDivideByZero},
+		{"modulo by zero", "a % (a - 10)", nil, pipeline.ErrDivideByZero},
+		{"missing var", "a + missing", nil, pipeline.ErrKeypathNotFound},
+		{"missing input", "input + 1", nil, pipeline.ErrParameterEmpty},
+		{"too many inputs", "a", []pipeline.Result{{Value: 1}, {Value: 2}}, pipeline.ErrWrongInputCardinality},
+		{"bool arithmetic", "paused + 1", nil, pipeline.ErrExpressionType},
+		{"string arithmetic", "name * 2", nil, pipeline.ErrExpressionType},
+		{"number as bool", "a && true", nil, pipeline.ErrExpressionType},
+		{"fractional round places", "round(a, 0.5)", nil, pipeline.ErrExpressionType},
+		{"multiply overflow", "big * big", nil, pipeline.ErrMultiplyOverlow},
+		{"syntax", "a +", nil, pipeline.ErrExpressionSyntax},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.ExpressionTask{
+				BaseTask:   pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Expression: test.expression,
+			}
+			result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(vars), test.inputs)
+			require.Error(t, result.Error)
+			assert.ErrorIs(t, result.Error, test.wantErr)
+			assert.Nil(t, result.Value)
+		})
+	}
+}
+
+func TestExpressionTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    error
+	}{
+		{"empty", " ", pipeline.ErrExpressionSyntax},
+		{"unbalanced parens", "(1 + 2", pipeline.ErrExpressionSyntax},
+		{"trailing tokens", "1 2", pipeline.ErrExpressionSyntax},
+		{"unknown character", "a = 1", pipeline.ErrExpressionSyntax},
+		{"unterminated string", "'abc", pipeline.ErrExpressionSyntax},
+		{"invalid keypath", "a..b", pipeline.ErrExpressionSyntax},
+		{"unknown function", "sqrt(4)", pipeline.ErrExpressionSyntax},
+		{"wrong arity", "abs(1, 2)", pipeline.ErrExpressionSyntax},
+		{"literal out of range", "1e-100000", pipeline.ErrExpressionSyntax},
+		{"too long", strings.Repeat("1+", 2500) + "1", pipeline.ErrExpressionCost},
+		{"too many nodes", strings.Repeat("1+", 600) + "1", pipeline.ErrExpressionCost},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeExpression, map[string]interface{}{"expression": test.expression}, 0, "expr")
+			require.Error(t, err)
+			assert.ErrorIs(t, err, test.wantErr)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		task, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeExpression, map[string]interface{}{"expression": "round(ds1 * ds2, 2)", "precision": "4"}, 0, "expr")
+		require.NoError(t, err)
+		require.IsType(t, &pipeline.ExpressionTask{}, task)
+	})
+
+	t.Run("rejected when the spec is parsed", func(t *testing.T) {
+		_, err := pipeline.Parse(`expr [type="expression" expression="1 +"];`)
+		require.ErrorIs(t, err, pipeline.ErrExpressionSyntax)
+	})
+}
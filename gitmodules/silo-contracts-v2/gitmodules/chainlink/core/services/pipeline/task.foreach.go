@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	// ForEachItemKey and ForEachIndexKey are the variables holding the current element and its
+	// index within each instance of a foreach sub-graph.
+	ForEachItemKey  = "item"
+	ForEachIndexKey = "index"
+
+	// maxForEachElements bounds the number of sub-graph instances a single foreach task can create.
+	maxForEachElements = 1000
+)
+
+// ForEachTask runs a templated sub-graph once for each element of an array, and collects the
+// result of every instance into an array, e.g.
+//
+//	fan [type=foreach input="$(feeds)" subgraph=<fetch [type=http method=GET url="$(item)"]; parse [type=jsonparse path="price" data="$(fetch)"]>]
+//
+// The sub-graph is instantiated by the scheduler when the task becomes ready. Each instance sees
+// the pipeline variables plus $(item) and $(index), and must have exactly one terminal task.
+// Instance task runs are persisted with dot IDs prefixed by the foreach task and the element
+// index, e.g. fan.0.fetch. If any element fails, the task fails.
+//
+// Return types:
+//
+//	[]interface{}
+type ForEachTask struct {
+	BaseTask `mapstructure:",squash"`
+	Input    string `json:"input"`
+	Subgraph string `json:"subgraph"`
+
+	initializeTask func(Task)
+}
+
+var _ subgraphTask = (*ForEachTask)(nil)
+
+func (t *ForEachTask) Type() TaskType {
+	return TaskTypeForEach
+}
+
+// TaskRetries is always zero: instances are not re-created, retries belong on the sub-graph tasks.
+func (t *ForEachTask) TaskRetries() uint32 {
+	return 0
+}
+
+func (t *ForEachTask) validate() error {
+	p, err := Parse(t.Subgraph)
+	if err != nil {
+		return errors.Wrap(err, "subgraph")
+	}
+	for _, task := range p.Tasks {
+		switch task.DotID() {
+		case ForEachItemKey, ForEachIndexKey:
+			return errors.Errorf("subgraph: '%v' is a reserved keyword that cannot be used as a task's name", task.DotID())
+		}
+	}
+	return errors.Wrap(checkSingleTerminal(p), "subgraph")
+}
+
+// items resolves the array to iterate over.
+func (t *ForEachTask) items(vars Vars, inputs []Result) ([]interface{}, error) {
+	var items SliceParam
+	err := multierr.Combine(
+		errors.Wrap(ResolveParam(&items, From(VarExpr(t.Input, vars), JSONWithVarExprs(t.Input, vars, false), Input(inputs, 0))), "input"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > maxForEachElements {
+		return nil, errors.Wrapf(ErrBadInput, "input has %d elements, max is %d", len(items), maxForEachElements)
+	}
+	return items, nil
+}
+
+// instances creates one sub-graph instance per element, with dot IDs prefixed by the foreach
+// dot ID and the element index.
+func (t *ForEachTask) instances(vars Vars, inputs []Result) ([]subgraphInstance, error) {
+	items, err := t.items(vars, inputs)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]subgraphInstance, len(items))
+	for i, item := range items {
+		p, err := parseSubgraph(t.Subgraph, t.initializeTask)
+		if err != nil {
+			return nil, errors.Wrap(err, "subgraph")
+		}
+		instanceVars := vars.Copy()
+		if err = multierr.Combine(instanceVars.Set(ForEachItemKey, item), instanceVars.Set(ForEachIndexKey, i)); err != nil {
+			return nil, err
+		}
+		instances[i] = subgraphInstance{pipeline: p, prefix: fmt.Sprintf("%s.%d.", t.DotID(), i), vars: instanceVars}
+	}
+	return instances, nil
+}
+
+// Run collects the results of the sub-graph instances, which the scheduler passes as inputs.
+func (t *ForEachTask) Run(_ context.Context, _ logger.Logger, _ Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	values := make([]interface{}, len(inputs))
+	var errs error
+	for i, input := range inputs {
+		if input.Error != nil {
+			errs = multierr.Append(errs, errors.Wrapf(input.Error, "element %d", i))
+			continue
+		}
+		values[i] = input.Value
+	}
+	if errs != nil {
+		return Result{Error: errs}, runInfo
+	}
+	return Result{Value: values}, runInfo
+}
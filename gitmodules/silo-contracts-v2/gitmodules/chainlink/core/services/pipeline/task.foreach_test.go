@@ -0,0 +1,63 @@
+package pipeline_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestForEachTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		subgraph string
+		wantErr  string
+	}{
+		{"missing", "", "subgraph: empty pipeline"},
+		{"invalid", "a [type=nope]", `unknown task type: "nope"`},
+		{"no single terminal", `a [type=memo value=1]; b [type=memo value=2]`, "exactly one terminal task, got 2"},
+		{"reserved item", `item [type=memo value=1]`, "'item' is a reserved keyword"},
+		{"reserved index", `index [type=memo value=1]`, "'index' is a reserved keyword"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeForEach, map[string]interface{}{"input": "$(feeds)", "subgraph": test.subgraph}, 0, "fan")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		p, err := pipeline.Parse(`fan [type=foreach input="$(feeds)" subgraph=<a [type=memo value="$(item)"]; b [type=multiply input="$(a)" times=2]>]`)
+		require.NoError(t, err)
+		require.Len(t, p.Tasks, 1)
+		require.IsType(t, &pipeline.ForEachTask{}, p.Tasks[0])
+		assert.Equal(t, uint32(0), p.Tasks[0].TaskRetries())
+	})
+}
+
+func TestForEachTask_Run(t *testing.T) {
+	t.Parallel()
+
+	task := pipeline.ForEachTask{BaseTask: pipeline.NewBaseTask(0, "fan", nil, nil, 0)}
+
+	result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: "a"}, {Value: 2}})
+	require.NoError(t, result.Error)
+	assert.Equal(t, []interface{}{"a", 2}, result.Value)
+
+	result, _ = task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: "a"}, {Error: errors.New("boom")}})
+	require.Error(t, result.Error)
+	assert.Contains(t, result.Error.Error(), "element 1: boom")
+	assert.Nil(t, result.Value)
+}
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	HashAlgorithmKeccak256 = "keccak256"
+	HashAlgorithmSHA256    = "sha256"
+)
+
+// HashTask hashes its data, e.g.
+//
+//	hash [type=hash algorithm=keccak256 data="$(encode)"]
+//
+// Hex strings with a 0x prefix are decoded before hashing, other strings are hashed as is.
+//
+// Return types:
+//
+//	string (0x prefixed hex)
+type HashTask struct {
+	BaseTask  `mapstructure:",squash"`
+	Algorithm string `json:"algorithm"`
+	Data      string `json:"data"`
+}
+
+var _ Task = (*HashTask)(nil)
+
+func (t *HashTask) Type() TaskType {
+	return TaskTypeHash
+}
+
+func (t *HashTask) validate() error {
+	switch t.Algorithm {
+	case HashAlgorithmKeccak256, HashAlgorithmSHA256:
+		return nil
+	case "":
+		return errors.Wrap(ErrParameterEmpty, "algorithm")
+	}
+	return errors.Errorf("algorithm: must be %s or %s, got %q", HashAlgorithmKeccak256, HashAlgorithmSHA256, t.Algorithm)
+}
+
+func (t *HashTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var data BytesParam
+	err = errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), NonemptyString(t.Data), Input(inputs, 0))), "data")
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	switch t.Algorithm {
+	case HashAlgorithmKeccak256:
+		return Result{Value: hexutil.Encode(crypto.Keccak256(data))}, runInfo
+	case HashAlgorithmSHA256:
+		sum := sha256.Sum256(data)
+		return Result{Value: hexutil.Encode(sum[:])}, runInfo
+	}
+	return Result{Error: t.validate()}, runInfo
+}
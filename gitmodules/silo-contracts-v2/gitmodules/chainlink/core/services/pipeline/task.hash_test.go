@@ -0,0 +1,71 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestHashTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		algorithm string
+		data      string
+		vars      map[string]interface{}
+		inputs    []pipeline.Result
+		want      string
+		wantErr   string
+	}{
+		{"keccak256 of string", "keccak256", "hello", nil, nil, "0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8", ""},
+		{"sha256 of string", "sha256", "hello", nil, nil, "0x2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", ""},
+		{"keccak256 of hex", "keccak256", "0x68656c6c6f", nil, nil, "0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8", ""},
+		{"keccak256 of empty bytes", "keccak256", "$(foo)", map[string]interface{}{"foo": []byte{}}, nil, "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", ""},
+		{"sha256 of input", "sha256", "", nil, []pipeline.Result{{Value: []byte("hello")}}, "0x2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", ""},
+		{"errored input", "sha256", "", nil, []pipeline.Result{{Error: pipeline.ErrBadInput}}, "", "data"},
+		{"bad data", "sha256", "$(foo)", map[string]interface{}{"foo": true}, nil, "", "data"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.HashTask{
+				BaseTask:  pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Algorithm: test.algorithm,
+				Data:      test.data,
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(test.vars), test.inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+			if test.wantErr != "" {
+				require.Error(t, result.Error)
+				assert.Contains(t, result.Error.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, result.Error)
+			assert.Equal(t, test.want, result.Value)
+		})
+	}
+}
+
+func TestHashTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	_, err := pipeline.UnmarshalTaskFromMap("hash", map[string]interface{}{"algorithm": "keccak256"}, 0, "hash")
+	require.NoError(t, err)
+
+	_, err = pipeline.UnmarshalTaskFromMap("hash", map[string]interface{}{}, 0, "hash")
+	require.ErrorIs(t, err, pipeline.ErrParameterEmpty)
+
+	_, err = pipeline.UnmarshalTaskFromMap("hash", map[string]interface{}{"algorithm": "md5"}, 0, "hash")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "algorithm: must be keccak256 or sha256")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -14,6 +15,10 @@ import (
 	clhttp "github.com/smartcontractkit/chainlink/v2/core/utils/http"
 )
 
+// HTTPTask sends an HTTP request. Setting responseCacheTTL (e.g. "10s") opts the task into the response
+// cache shared by all jobs: successful responses to identical requests are reused until they
+// expire, and identical concurrent requests are sent once.
+//
 // Return types:
 //
 //	string
@@ -24,10 +29,12 @@ type HTTPTask struct {
 	RequestData                    string `json:"requestData"`
 	AllowUnrestrictedNetworkAccess string
 	Headers                        string
+	ResponseCacheTTL               string `json:"responseCacheTTL"`
 
 	config                 Config
 	httpClient             *http.Client
 	unrestrictedHTTPClient *http.Client
+	responseCache          *httpResponseCache
 }
 
 var _ Task = (*HTTPTask)(nil)
@@ -63,6 +70,7 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 		requestData                    MapParam
 		allowUnrestrictedNetworkAccess BoolParam
 		reqHeaders                     StringSliceParam
+		responseCacheTTL               Uint64Param
 	)
 	err = multierr.Combine(
 		errors.Wrap(ResolveParam(&method, From(NonemptyString(t.Method), "GET")), "method"),
@@ -73,6 +81,7 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 		// You must set allowUnrestrictedNetworkAccess=true on the task to enable variable-interpolated URLs to make restricted network requests
 		errors.Wrap(ResolveParam(&allowUnrestrictedNetworkAccess, From(NonemptyString(t.AllowUnrestrictedNetworkAccess), !variableRegexp.MatchString(t.URL))), "allowUnrestrictedNetworkAccess"),
 		errors.Wrap(ResolveParam(&reqHeaders, From(NonemptyString(t.Headers), "[]")), "reqHeaders"),
+		errors.Wrap(ResolveParam(&responseCacheTTL, From(ValidDurationInSeconds(t.ResponseCacheTTL), 0)), "responseCacheTTL"),
 	)
 	if err != nil {
 		return Result{Error: err}, runInfo
@@ -103,22 +112,27 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 	} else {
 		client = t.httpClient
 	}
-	responseBytes, statusCode, respHeaders, elapsed, err := makeHTTPRequest(requestCtx, lggr, method, url, reqHeaders, requestData, client, t.config.DefaultHTTPLimit())
+	response, cached, err := makeCachedHTTPRequest(requestCtx, lggr, t.responseCache, t.Type(), time.Duration(responseCacheTTL)*time.Second, bool(allowUnrestrictedNetworkAccess),
+		method, url, reqHeaders, requestData, client, t.config.DefaultHTTPLimit())
 	if err != nil {
 		if errors.Is(errors.Cause(err), clhttp.ErrDisallowedIP) {
 			err = errors.Wrap(err, `connections to local resources are disabled by default, if you are sure this is safe, you can enable on a per-task basis by setting allowUnrestrictedNetworkAccess="true" in the pipeline task spec, e.g. fetch [type="http" method=GET url="$(decode_cbor.url)" allowUnrestrictedNetworkAccess="true"]`)
 		}
-		return Result{Error: err}, RunInfo{IsRetryable: isRetryableHTTPError(statusCode, err)}
+		return Result{Error: err}, RunInfo{IsRetryable: isRetryableHTTPError(response.statusCode, err)}
 	}
+	responseBytes := response.body
 
 	lggr.Debugw("HTTP task got response",
 		"response", string(responseBytes),
-		"respHeaders", respHeaders,
+		"respHeaders", response.headers,
 		"url", url.String(),
 		"dotID", t.DotID(),
+		"cached", cached,
 	)
 
-	promHTTPFetchTime.WithLabelValues(t.DotID()).Set(float64(elapsed))
+	if !cached {
+		promHTTPFetchTime.WithLabelValues(t.DotID()).Set(float64(response.elapsed))
+	}
 	promHTTPResponseBodySize.WithLabelValues(t.DotID()).Set(float64(len(responseBytes)))
 
 	// NOTE: We always stringify the response since this is required for all current jobs.
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// JSONSchemaTask validates a JSON value against a JSON Schema and returns it, so that malformed
+// responses fail early with a description of what is wrong, e.g.
+//
+//	check [type=jsonschema schema=<{"type": "object", "required": ["price"], "properties": {"price": {"type": "number"}}}>]
+//
+// See jsonschema.go for the supported keywords. The schema must be a literal. The data defaults
+// to the single task input, and may be JSON text or a value returned by another task.
+//
+// Return types:
+//
+//	float64
+//	int64, uint64, *big.Int
+//	string
+//	bool
+//	map[string]interface{}
+//	[]interface{}
+//	nil
+type JSONSchemaTask struct {
+	BaseTask `mapstructure:",squash"`
+	Schema   string `json:"schema"`
+	Data     string `json:"data"`
+
+	schema *jsonSchema
+}
+
+var _ Task = (*JSONSchemaTask)(nil)
+
+func (t *JSONSchemaTask) Type() TaskType {
+	return TaskTypeJSONSchema
+}
+
+// validate compiles the schema, so that invalid schemas are rejected when the spec is parsed.
+func (t *JSONSchemaTask) validate() (err error) {
+	t.schema, err = compileJSONSchema(t.Schema)
+	return errors.Wrap(err, "schema")
+}
+
+func (t *JSONSchemaTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var data JSONParam
+	err = errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), Input(inputs, 0))), "data")
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	schema := t.schema
+	if schema == nil {
+		if schema, err = compileJSONSchema(t.Schema); err != nil {
+			return Result{Error: errors.Wrap(err, "schema")}, runInfo
+		}
+	}
+
+	if err = schema.validate(data.Value()); err != nil {
+		return Result{Error: err}, runInfo
+	}
+	value, err := jsonParamResult(data.Value())
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	return Result{Value: value}, runInfo
+}
@@ -0,0 +1,164 @@
+package pipeline_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+const jsonSchemaTestSchema = `{
+	"title": "price",
+	"type": "object",
+	"required": ["sym", "price", "ts"],
+	"additionalProperties": false,
+	"properties": {
+		"sym": {"type": "string", "minLength": 2, "maxLength": 5},
+		"price": {"type": "number", "minimum": 0.01},
+		"ts": {"type": "integer"},
+		"tags": {"type": "array", "items": {"enum": ["a", "b"]}, "maxItems": 2},
+		"source name": {"type": ["string", "null"]}
+	}
+}`
+
+func TestJSONSchemaTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		data       interface{}
+		want       interface{}
+		violations []string
+	}{
+		{
+			"valid",
+			`{"sym": "ETH", "price": 1850.5, "ts": 1690000000, "tags": ["a"], "source name": null}`,
+			map[string]interface{}{"sym": "ETH", "price": 1850.5, "ts": int64(1690000000), "tags": []interface{}{"a"}, "source name": nil},
+			nil,
+		},
+		{
+			"decoded value with wrong types",
+			map[string]interface{}{"sym": "BTC", "price": "29000", "ts": int64(1690000000)},
+			nil,
+			[]string{".price: expected number, got string"},
+		},
+		{
+			"missing properties",
+			`{"price": 1}`,
+			nil,
+			[]string{".sym: required property is missing", ".ts: required property is missing"},
+		},
+		{
+			"every violation is reported",
+			`{"sym": "E", "price": 0, "ts": 1.5, "tags": ["a", "a", "c"], "extra": 1, "source name": 1}`,
+			nil,
+			[]string{
+				".extra: property is not allowed",
+				".price: 0 is less than minimum 0.01",
+				".sym: length 1 is less than minLength 2",
+				".tags: 3 items is more than maxItems 2",
+				".tags[2]: value is not one of the allowed values",
+				".ts: expected integer, got number",
+				`.["source name"]: expected string or null, got number`,
+			},
+		},
+		{
+			"wrong type",
+			`[1, 2]`,
+			nil,
+			[]string{".: expected object, got array"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.JSONSchemaTask{
+				BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Schema:   jsonSchemaTestSchema,
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: test.data}})
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+			if test.violations == nil {
+				require.NoError(t, result.Error)
+				assert.Equal(t, test.want, result.Value)
+				return
+			}
+			require.ErrorIs(t, result.Error, pipeline.ErrJSONSchemaValidation)
+			for _, violation := range test.violations {
+				assert.Contains(t, result.Error.Error(), violation)
+			}
+		})
+	}
+
+	t.Run("valid data from vars", func(t *testing.T) {
+		t.Parallel()
+
+		vars := pipeline.NewVarsFrom(map[string]interface{}{
+			"ds1": map[string]interface{}{"sym": "LINK", "price": float64(6.1), "ts": int64(1690000000)},
+		})
+		task := pipeline.JSONSchemaTask{
+			BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+			Schema:   jsonSchemaTestSchema,
+			Data:     "$(ds1)",
+		}
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.NoError(t, result.Error)
+		assert.Equal(t, map[string]interface{}{"sym": "LINK", "price": 6.1, "ts": int64(1690000000)}, result.Value)
+	})
+
+	t.Run("nesting is bounded", func(t *testing.T) {
+		t.Parallel()
+
+		task := pipeline.JSONSchemaTask{
+			BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+			Schema:   strings.Repeat(`{"items": `, 40) + "true" + strings.Repeat("}", 40),
+		}
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: "1"}})
+		require.ErrorIs(t, result.Error, pipeline.ErrJSONSchemaInvalid)
+	})
+}
+
+func TestJSONSchemaTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		schema string
+		errMsg string
+	}{
+		{"empty", " ", "empty schema"},
+		{"invalid JSON", `{"type": `, "invalid JSON"},
+		{"not a schema", `[]`, "schema must be an object or a boolean"},
+		{"unknown type", `{"type": "decimal"}`, `#/type: unknown type "decimal"`},
+		{"invalid keyword value", `{"properties": {"a": {"minimum": "1"}}}`, "#/properties/a/minimum: must be a number"},
+		{"invalid count", `{"maxItems": 1.5}`, "#/maxItems: must be a non-negative integer"},
+		{"unsupported keyword", `{"properties": {"a": {"pattern": "^a"}}}`, "#/properties/a/pattern: unsupported keyword"},
+		{"unsupported reference", `{"$ref": "#/$defs/price"}`, "#/$ref: unsupported keyword"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeJSONSchema, map[string]interface{}{"schema": test.schema}, 0, "check")
+			require.ErrorIs(t, err, pipeline.ErrJSONSchemaInvalid)
+			assert.Contains(t, err.Error(), test.errMsg)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		task, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeJSONSchema, map[string]interface{}{"schema": jsonSchemaTestSchema}, 0, "check")
+		require.NoError(t, err)
+		require.IsType(t, &pipeline.JSONSchemaTask{}, task)
+	})
+}
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// JSONTransformTask applies a jq-style filter to a JSON value, e.g.
+//
+//	avg [type=jsontransform filter="[.data[] | select(.volume > 0) | .price | tonumber] | add / length"]
+//
+// See jsontransform.go for the language. The filter must be a literal, and produce exactly one
+// value; wrap it in brackets to collect several values into an array. The data defaults to the
+// single task input, and may be JSON text or a value returned by another task.
+//
+// Return types:
+//
+//	float64
+//	int64, uint64, *big.Int
+//	string
+//	bool
+//	map[string]interface{}
+//	[]interface{}
+//	nil
+type JSONTransformTask struct {
+	BaseTask `mapstructure:",squash"`
+	Filter   string `json:"filter"`
+	Data     string `json:"data"`
+
+	program *jqProgram
+}
+
+var _ Task = (*JSONTransformTask)(nil)
+
+func (t *JSONTransformTask) Type() TaskType {
+	return TaskTypeJSONTransform
+}
+
+// validate compiles the filter, so that invalid filters are rejected when the spec is parsed.
+func (t *JSONTransformTask) validate() (err error) {
+	t.program, err = compileJQ(t.Filter)
+	return errors.Wrap(err, "filter")
+}
+
+func (t *JSONTransformTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var data JSONParam
+	err = errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), Input(inputs, 0))), "data")
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	program := t.program
+	if program == nil {
+		if program, err = compileJQ(t.Filter); err != nil {
+			return Result{Error: errors.Wrap(err, "filter")}, runInfo
+		}
+	}
+
+	outputs, err := program.run(data.Value())
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "filter")}, runInfo
+	}
+	if len(outputs) != 1 {
+		return Result{Error: errors.Errorf("filter produced %d values, expected exactly 1", len(outputs))}, runInfo
+	}
+	value, err := jsonParamResult(outputs[0])
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	return Result{Value: value}, runInfo
+}
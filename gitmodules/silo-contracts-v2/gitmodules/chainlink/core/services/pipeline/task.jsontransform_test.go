@@ -0,0 +1,178 @@
+package pipeline_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+const jsonTransformTestData = `{
+	"data": [
+		{"sym": "ETH", "price": "1850.5", "volume": 10},
+		{"sym": "BTC", "price": "29000", "volume": 0},
+		{"sym": "LINK", "price": "6.1", "volume": 3}
+	],
+	"meta": {"ts": 1690000000, "ok": true, "tags": ["a", "b"]},
+	"missing": null,
+	"big": 123456789012345678901234567890
+}`
+
+func TestJSONTransformTask_Happy(t *testing.T) {
+	t.Parallel()
+
+	bigResult, _ := new(big.Int).SetString("123456789012345678901234567891", 10)
+
+	tests := []struct {
+		name   string
+		filter string
+		want   interface{}
+	}{
+		{"identity field", ".meta.ts", int64(1690000000)},
+		{"array index", ".data[0].sym", "ETH"},
+		{"negative index", ".data[-1].sym", "LINK"},
+		{"out of range index", ".data[5]", nil},
+		{"quoted field", `."meta"["ok"]`, true},
+		{"identity index", ".data | .[1].sym", "BTC"},
+		{"select and collect", "[.data[] | select(.volume > 0) | .sym]", []interface{}{"ETH", "LINK"}},
+		{"add", "[.data[].price | tonumber] | add", 30856.6},
+		{"average", "[.data[].volume] | add / length", 4.3333333333333333},
+		{"map and max", ".data | map(.volume) | max", int64(10)},
+		{"min", "[.data[].price | tonumber] | min", 6.1},
+		{"sort", "[.data[].sym] | sort", []interface{}{"BTC", "ETH", "LINK"}},
+		{"keys", ".meta | keys", []interface{}{"ok", "tags", "ts"}},
+		{"not", ".meta.ok | not", false},
+		{"string comparison", `[.data[] | select(.sym != "BTC")] | length`, int64(2)},
+		{"merge objects", ".meta + .data[0] | keys | length", int64(6)},
+		{"concatenate", `.data[0].sym + "/" + .meta.tags[0]`, "ETH/a"},
+		{"big numbers", ".big + 1", bigResult},
+		{"arithmetic precedence", "(7 - 1) * 2 / 4 + 1", int64(4)},
+		{"reduce", "reduce .data[] as $d (0; . + ($d.price | tonumber) * $d.volume)", 18523.3},
+		{"reduce with several initial values", "[reduce .meta.tags[] as $t (.data[].volume; . + 1)] | add", int64(19)},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.JSONTransformTask{
+				BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Filter:   test.filter,
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: jsonTransformTestData}})
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+			require.NoError(t, result.Error)
+			assert.Equal(t, test.want, result.Value)
+		})
+	}
+
+	t.Run("data from vars", func(t *testing.T) {
+		t.Parallel()
+
+		vars := pipeline.NewVarsFrom(map[string]interface{}{
+			"ds1": map[string]interface{}{"prices": []interface{}{"1.5", float64(2.5), int64(3)}},
+		})
+		task := pipeline.JSONTransformTask{
+			BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+			Filter:   "[.prices[] | tonumber] | add",
+			Data:     "$(ds1)",
+		}
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.NoError(t, result.Error)
+		assert.Equal(t, int64(7), result.Value)
+	})
+}
+
+func TestJSONTransformTask_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  string
+		data    string
+		wantErr error
+		errMsg  string
+	}{
+		{"invalid data", ".", "{", pipeline.ErrBadInput, "invalid JSON"},
+		{"type error", ".meta.ts.x", jsonTransformTestData, pipeline.ErrJSONTransformType, `cannot index number with "x"`},
+		{"cannot iterate", ".meta.ts[]", jsonTransformTestData, pipeline.ErrJSONTransformType, "cannot iterate over number"},
+		{"divide by zero", ".meta.ts / 0", jsonTransformTestData, pipeline.ErrDivideByZero, "divide by zero"},
+		{"no values", ".data[] | select(.volume > 100)", jsonTransformTestData, nil, "filter produced 0 values, expected exactly 1"},
+		{"several values", ".data[].sym", jsonTransformTestData, nil, "filter produced 3 values, expected exactly 1"},
+		{"not a number", ".data[0].sym | tonumber", jsonTransformTestData, pipeline.ErrJSONTransformType, `cannot parse "ETH" as a number`},
+		{"too many steps", ".data" + strings.Repeat(" | . + .", 20) + " | length", jsonTransformTestData, pipeline.ErrJSONTransformCost, "evaluation took more than"},
+		{"too many combined values", ".[] + .[]", "[" + strings.Repeat("0,", 1999) + "0]", pipeline.ErrJSONTransformCost, "evaluation took more than"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.JSONTransformTask{
+				BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Filter:   test.filter,
+			}
+			result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: test.data}})
+			require.Error(t, result.Error)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, result.Error, test.wantErr)
+			}
+			assert.Contains(t, result.Error.Error(), test.errMsg)
+		})
+	}
+}
+
+func TestJSONTransformTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  string
+		wantErr error
+	}{
+		{"empty", " ", pipeline.ErrJSONTransformSyntax},
+		{"unbalanced brackets", ".data[0", pipeline.ErrJSONTransformSyntax},
+		{"trailing tokens", ". .", pipeline.ErrJSONTransformSyntax},
+		{"unterminated string", `"abc`, pipeline.ErrJSONTransformSyntax},
+		{"unknown function", "del(.a)", pipeline.ErrJSONTransformSyntax},
+		{"wrong arity", "map", pipeline.ErrJSONTransformSyntax},
+		{"unsupported operator", `.a // "default"`, pipeline.ErrJSONTransformSyntax},
+		{"unbound variable", "$x", pipeline.ErrJSONTransformSyntax},
+		{"variable out of scope", "reduce .[] as $x (0; . + $x) + $x", pipeline.ErrJSONTransformSyntax},
+		{"reduce without variable", "reduce .[] as x (0; . + 1)", pipeline.ErrJSONTransformSyntax},
+		{"unsupported object construction", "{a: 1}", pipeline.ErrJSONTransformSyntax},
+		{"too long", strings.Repeat(".a|", 1500) + ".", pipeline.ErrJSONTransformCost},
+		{"too many nodes", strings.Repeat("1+", 600) + "1", pipeline.ErrJSONTransformCost},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeJSONTransform, map[string]interface{}{"filter": test.filter}, 0, "transform")
+			require.Error(t, err)
+			assert.ErrorIs(t, err, test.wantErr)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		task, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeJSONTransform, map[string]interface{}{"filter": "[.data[] | .price] | add", "data": "$(ds1)"}, 0, "transform")
+		require.NoError(t, err)
+		require.IsType(t, &pipeline.JSONTransformTask{}, task)
+	})
+
+	t.Run("rejected when the spec is parsed", func(t *testing.T) {
+		_, err := pipeline.Parse(`transform [type="jsontransform" filter=".data["];`)
+		require.ErrorIs(t, err, pipeline.ErrJSONTransformSyntax)
+	})
+}
@@ -0,0 +1,128 @@
+package pipeline
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+)
+
+const (
+	SignKeyTypeOCR2 = "ocr2"
+
+	// SignDomainSeparator prefixes every message signed by a sign task.
+	SignDomainSeparator = "chainlink-pipeline-sign:"
+)
+
+var ErrSignNotAllowed = errors.New("sign task is not allowed for this job type")
+
+type OCR2KeyStore interface {
+	Get(id string) (ocr2key.KeyBundle, error)
+}
+
+// SignTask signs its data with the offchain key of an OCR2 key bundle, e.g.
+//
+//	sign [type=sign keyType=ocr2 keyID="<key bundle ID>" data="$(encode)"]
+//
+// The data is never signed as is, so that a job cannot obtain signatures over messages meant for
+// other protocols using the same key. The signed message is SignMessage(jobID, data), i.e.
+//
+//	"chainlink-pipeline-sign:" || decimal job ID || ":" || data
+//
+// and verifiers must rebuild it, e.g. with a verifysignature task using the ed25519 scheme. Signing
+// is only allowed for the job types listed in JobPipeline.SignAllowedJobTypes.
+//
+// Return types:
+//
+//	string (0x prefixed hex)
+type SignTask struct {
+	BaseTask `mapstructure:",squash"`
+	KeyType  string `json:"keyType"`
+	KeyID    string `json:"keyID"`
+	Data     string `json:"data"`
+
+	config       Config
+	ocr2KeyStore OCR2KeyStore
+	jobID        int32
+	jobType      string
+}
+
+var _ Task = (*SignTask)(nil)
+
+func (t *SignTask) Type() TaskType {
+	return TaskTypeSign
+}
+
+func (t *SignTask) validate() error {
+	switch t.KeyType {
+	case SignKeyTypeOCR2:
+	case "":
+		return errors.Wrap(ErrParameterEmpty, "keyType")
+	default:
+		return errors.Errorf("keyType: must be %s, got %q", SignKeyTypeOCR2, t.KeyType)
+	}
+	if t.KeyID == "" {
+		return errors.Wrap(ErrParameterEmpty, "keyID")
+	}
+	if variableRegexp.MatchString(t.KeyID) {
+		return errors.New("keyID cannot use variables")
+	}
+	return nil
+}
+
+func (t *SignTask) allowed() bool {
+	if t.config == nil {
+		return false
+	}
+	for _, jobType := range t.config.SignAllowedJobTypes() {
+		if jobType == t.jobType {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *SignTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+	if err = t.validate(); err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if !t.allowed() {
+		return Result{Error: errors.Wrapf(ErrSignNotAllowed, "job type %q is not in JobPipeline.SignAllowedJobTypes", t.jobType)}, runInfo
+	}
+
+	var data BytesParam
+	err = errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), NonemptyString(t.Data), Input(inputs, 0))), "data")
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	if t.ocr2KeyStore == nil {
+		return Result{Error: errors.New("no OCR2 keystore configured")}, runInfo
+	}
+	bundle, err := t.ocr2KeyStore.Get(t.KeyID)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "failed to get OCR2 key bundle")}, runInfo
+	}
+	signature, err := bundle.OffchainSign(SignMessage(t.jobID, data))
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "failed to sign")}, runInfo
+	}
+	return Result{Value: hexutil.Encode(signature)}, runInfo
+}
+
+// SignMessage returns the message signed by a sign task of the job with jobID for data.
+func SignMessage(jobID int32, data []byte) []byte {
+	msg := make([]byte, 0, len(SignDomainSeparator)+12+len(data))
+	msg = append(msg, SignDomainSeparator...)
+	msg = strconv.AppendInt(msg, int64(jobID), 10)
+	msg = append(msg, ':')
+	return append(msg, data...)
+}
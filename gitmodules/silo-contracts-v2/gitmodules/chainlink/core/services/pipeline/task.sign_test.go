@@ -0,0 +1,124 @@
+package pipeline_test
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
+)
+
+type testOCR2KeyStore map[string]ocr2key.KeyBundle
+
+func (ks testOCR2KeyStore) Get(id string) (ocr2key.KeyBundle, error) {
+	if key, ok := ks[id]; ok {
+		return key, nil
+	}
+	return nil, errors.Errorf("unable to find OCR2 key bundle with id %s", id)
+}
+
+func TestSignTask(t *testing.T) {
+	t.Parallel()
+
+	ocr2Key, err := ocr2key.New(chaintype.EVM)
+	require.NoError(t, err)
+	ocr2Pub := ocr2Key.OffchainPublicKey()
+
+	ocr2KeyStore := testOCR2KeyStore{ocr2Key.ID(): ocr2Key}
+	data := []byte("attestation")
+
+	tests := []struct {
+		name      string
+		keyType   string
+		keyID     string
+		jobType   string
+		publicKey ed25519.PublicKey
+		wantErr   string
+	}{
+		{"ocr2", "ocr2", ocr2Key.ID(), "cron", ocr2Pub[:], ""},
+		{"unknown ocr2 key", "ocr2", "deadbeef", "webhook", nil, "failed to get OCR2 key bundle"},
+		{"job type not allowed", "ocr2", ocr2Key.ID(), "offchainreporting2", nil, pipeline.ErrSignNotAllowed.Error()},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := mocks.NewConfig(t)
+			config.On("SignAllowedJobTypes").Return([]string{"webhook", "cron"})
+
+			task := pipeline.SignTask{
+				BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				KeyType:  test.keyType,
+				KeyID:    test.keyID,
+			}
+			task.HelperSetDependencies(config, ocr2KeyStore, 42, test.jobType)
+
+			result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: data}})
+			if test.wantErr != "" {
+				require.Error(t, result.Error)
+				assert.Contains(t, result.Error.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, result.Error)
+			signature, err := hexutil.Decode(result.Value.(string))
+			require.NoError(t, err)
+			assert.True(t, ed25519.Verify(test.publicKey, []byte("chainlink-pipeline-sign:42:attestation"), signature))
+			assert.False(t, ed25519.Verify(test.publicKey, data, signature), "the data is never signed as is")
+		})
+	}
+
+	t.Run("not allowed without config", func(t *testing.T) {
+		task := pipeline.SignTask{
+			BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+			KeyType:  "ocr2",
+			KeyID:    ocr2Key.ID(),
+		}
+		task.HelperSetDependencies(nil, ocr2KeyStore, 42, "webhook")
+
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: data}})
+		require.ErrorIs(t, result.Error, pipeline.ErrSignNotAllowed)
+	})
+}
+
+func TestSignTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"valid", map[string]interface{}{"keyType": "ocr2", "keyID": "abc"}, ""},
+		{"missing keyType", map[string]interface{}{"keyID": "abc"}, "keyType: parameter is empty"},
+		{"unknown keyType", map[string]interface{}{"keyType": "eth", "keyID": "abc"}, "keyType: must be ocr2"},
+		{"csa keyType", map[string]interface{}{"keyType": "csa", "keyID": "abc"}, "keyType: must be ocr2"},
+		{"missing keyID", map[string]interface{}{"keyType": "ocr2"}, "keyID: parameter is empty"},
+		{"variable keyID", map[string]interface{}{"keyType": "ocr2", "keyID": "$(key)"}, "keyID cannot use variables"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap("sign", test.params, 0, "sign")
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
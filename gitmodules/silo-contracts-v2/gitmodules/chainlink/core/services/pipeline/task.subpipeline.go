@@ -0,0 +1,204 @@
+package pipeline
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	// SubpipelineInputsKey is the variable holding the inputs passed to a subpipeline.
+	SubpipelineInputsKey = "inputs"
+
+	// maxSubpipelineDepth bounds how deeply subpipelines can invoke each other.
+	maxSubpipelineDepth = 10
+)
+
+var (
+	ErrSubpipelineCycle = errors.New("subpipeline cycle")
+	ErrSubpipelineInUse = errors.New("subpipeline in use")
+)
+
+// SubpipelineReference identifies the subpipeline invoked by a subpipeline task. Version is zero
+// for the latest version.
+type SubpipelineReference struct {
+	Name    string
+	Version int32
+}
+
+// subpipelineSources holds the subpipelines a run may invoke. They are loaded when the pipeline is
+// initialized, so that the scheduler does not query the database while the run is in progress.
+type subpipelineSources map[SubpipelineReference]subpipelineSource
+
+type subpipelineSource struct {
+	source string
+	err    error
+}
+
+// SubpipelineTask runs a named subpipeline stored with the pipeline ORM, and returns the result
+// of its terminal task, e.g.
+//
+//	price [type=subpipeline name="median_price" version=2 inputs=<{"pair": $(jobSpec.pair)}>]
+//
+// The latest version is used if none is given. The subpipeline sees $(inputs), $(jobSpec) and
+// $(jobRun), and its task runs are persisted inline with dot IDs prefixed by the subpipeline
+// task, e.g. price.fetch.
+//
+// Return types:
+//
+//	the type of the subpipeline's terminal task
+type SubpipelineTask struct {
+	BaseTask `mapstructure:",squash"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Inputs   string `json:"inputs"`
+
+	sources        subpipelineSources
+	initializeTask func(Task)
+	// ancestors holds the names of the subpipelines this task is nested in, outermost first
+	ancestors []string
+}
+
+var _ subgraphTask = (*SubpipelineTask)(nil)
+
+func (t *SubpipelineTask) Type() TaskType {
+	return TaskTypeSubpipeline
+}
+
+// TaskRetries is always zero: instances are not re-created, retries belong on the subpipeline tasks.
+func (t *SubpipelineTask) TaskRetries() uint32 {
+	return 0
+}
+
+func (t *SubpipelineTask) validate() error {
+	if t.Name == "" {
+		return errors.Wrap(ErrParameterEmpty, "name")
+	}
+	// names are resolved statically so that cycles can be detected when subpipelines are created
+	if variableRegexp.MatchString(t.Name) {
+		return errors.Errorf("name: variables are not supported, got %q", t.Name)
+	}
+	_, err := t.version()
+	return err
+}
+
+// version returns the requested version, or zero for the latest one.
+func (t *SubpipelineTask) version() (int32, error) {
+	if t.Version == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(t.Version, 10, 32)
+	if err != nil || v <= 0 {
+		return 0, errors.Errorf("version: must be a positive integer, got %q", t.Version)
+	}
+	return int32(v), nil
+}
+
+// instances creates the single instance of the subpipeline, with dot IDs prefixed by the
+// subpipeline task dot ID.
+func (t *SubpipelineTask) instances(vars Vars, _ []Result) ([]subgraphInstance, error) {
+	ancestors := append(append([]string{}, t.ancestors...), t.Name)
+	for _, ancestor := range t.ancestors {
+		if ancestor == t.Name {
+			return nil, errors.Wrap(ErrSubpipelineCycle, strings.Join(ancestors, " -> "))
+		}
+	}
+	if len(ancestors) > maxSubpipelineDepth {
+		return nil, errors.Errorf("subpipelines are nested more than %d levels deep", maxSubpipelineDepth)
+	}
+
+	var inputs MapParam
+	err := multierr.Combine(
+		errors.Wrap(ResolveParam(&inputs, From(VarExpr(t.Inputs, vars), JSONWithVarExprs(t.Inputs, vars, false), nil)), "inputs"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	version, err := t.version()
+	if err != nil {
+		return nil, err
+	}
+	subpipeline, ok := t.sources[SubpipelineReference{Name: t.Name, Version: version}]
+	if !ok {
+		return nil, errors.Errorf("subpipeline %s was not loaded", t.Name)
+	} else if subpipeline.err != nil {
+		return nil, errors.Wrapf(subpipeline.err, "failed to load subpipeline %s", t.Name)
+	}
+	p, err := parseSubgraph(subpipeline.source, inheritAncestors(t.initializeTask, ancestors))
+	if err != nil {
+		return nil, errors.Wrapf(err, "subpipeline %s", t.Name)
+	}
+
+	subVars := NewVarsFrom(map[string]interface{}{SubpipelineInputsKey: map[string]interface{}(inputs)})
+	for _, key := range []string{"jobSpec", "jobRun"} {
+		if value, err := vars.Get(key); err == nil {
+			if err = subVars.Set(key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return []subgraphInstance{{pipeline: p, prefix: t.DotID() + KeypathSeparator, vars: subVars}}, nil
+}
+
+// Run returns the result of the subpipeline, which the scheduler passes as the only input.
+func (t *SubpipelineTask) Run(_ context.Context, _ logger.Logger, _ Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	if len(inputs) != 1 {
+		return Result{Error: errors.Wrapf(ErrWrongInputCardinality, "subpipeline %s", t.Name)}, runInfo
+	}
+	if inputs[0].Error != nil {
+		return Result{Error: errors.Wrapf(inputs[0].Error, "subpipeline %s", t.Name)}, runInfo
+	}
+	return inputs[0], runInfo
+}
+
+// inheritAncestors wraps initializeTask so that subpipeline tasks created by it, including those
+// nested in foreach tasks, know which subpipelines they are running in.
+func inheritAncestors(initializeTask func(Task), ancestors []string) func(Task) {
+	return func(task Task) {
+		if initializeTask != nil {
+			initializeTask(task)
+		}
+		switch t := task.(type) {
+		case *SubpipelineTask:
+			t.ancestors = ancestors
+		case *ForEachTask:
+			t.initializeTask = inheritAncestors(t.initializeTask, ancestors)
+		}
+	}
+}
+
+// SubpipelineReferences returns the subpipelines invoked by a pipeline source, including those
+// invoked from foreach sub-graphs.
+func SubpipelineReferences(source string) ([]SubpipelineReference, error) {
+	p, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return subpipelineReferences(p)
+}
+
+func subpipelineReferences(p *Pipeline) ([]SubpipelineReference, error) {
+	var references []SubpipelineReference
+	for _, task := range p.Tasks {
+		switch t := task.(type) {
+		case *SubpipelineTask:
+			version, err := t.version()
+			if err != nil {
+				return nil, errors.Wrapf(err, "task %s", t.DotID())
+			}
+			references = append(references, SubpipelineReference{Name: t.Name, Version: version})
+		case *ForEachTask:
+			nested, err := SubpipelineReferences(t.Subgraph)
+			if err != nil {
+				return nil, errors.Wrapf(err, "task %s", t.DotID())
+			}
+			references = append(references, nested...)
+		}
+	}
+	return references, nil
+}
@@ -0,0 +1,57 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestSubpipelineTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"valid", map[string]interface{}{"name": "price"}, ""},
+		{"valid with version", map[string]interface{}{"name": "price", "version": "3"}, ""},
+		{"missing name", map[string]interface{}{}, "name: parameter is empty"},
+		{"variable name", map[string]interface{}{"name": "$(name)"}, "variables are not supported"},
+		{"invalid version", map[string]interface{}{"name": "price", "version": "latest"}, "version: must be a positive integer"},
+		{"zero version", map[string]interface{}{"name": "price", "version": "0"}, "version: must be a positive integer"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap(pipeline.TaskTypeSubpipeline, test.params, 0, "sub")
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubpipelineReferences(t *testing.T) {
+	t.Parallel()
+
+	references, err := pipeline.SubpipelineReferences(`
+a [type=subpipeline name="first"]
+fan [type=foreach input="[1, 2]" subgraph=<b [type=subpipeline name="second" version=3]>]
+c [type=memo value=1]
+a -> fan -> c`)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []pipeline.SubpipelineReference{{Name: "first"}, {Name: "second", Version: 3}}, references)
+
+	_, err = pipeline.SubpipelineReferences(`a [type=nope]`)
+	require.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	// SignatureSchemeECDSA is a secp256k1 signature [R || S || V] over the keccak256 hash of the data.
+	SignatureSchemeECDSA = "ecdsa"
+	// SignatureSchemeEIP191 is a secp256k1 signature [R || S || V] over the keccak256 hash of the
+	// data with the "\x19Ethereum Signed Message:\n" prefix, as produced by personal_sign.
+	SignatureSchemeEIP191 = "eip191"
+	// SignatureSchemeEd25519 is an ed25519 signature over the data, as produced by the sign task.
+	SignatureSchemeEd25519 = "ed25519"
+)
+
+// VerifySignatureTask checks that data was signed by the owner of a key, e.g.
+//
+//	verify [type=verifysignature scheme=eip191 data="$(response.body)" signature="$(response.signature)" address="0x..."]
+//
+// ECDSA signatures are checked against either an address or a public key. Ed25519 signatures are
+// checked against a public key. Combine it with a conditional task to fail runs on invalid
+// signatures.
+//
+// Return types:
+//
+//	bool
+type VerifySignatureTask struct {
+	BaseTask  `mapstructure:",squash"`
+	Scheme    string `json:"scheme"`
+	Data      string `json:"data"`
+	Signature string `json:"signature"`
+	PublicKey string `json:"publicKey"`
+	Address   string `json:"address"`
+}
+
+var _ Task = (*VerifySignatureTask)(nil)
+
+func (t *VerifySignatureTask) Type() TaskType {
+	return TaskTypeVerifySignature
+}
+
+func (t *VerifySignatureTask) validate() error {
+	switch t.Scheme {
+	case SignatureSchemeECDSA, SignatureSchemeEIP191:
+		if (t.PublicKey == "") == (t.Address == "") {
+			return errors.New("exactly one of publicKey and address must be set")
+		}
+	case SignatureSchemeEd25519:
+		if t.PublicKey == "" {
+			return errors.Wrap(ErrParameterEmpty, "publicKey")
+		}
+		if t.Address != "" {
+			return errors.New("address cannot be used with ed25519 signatures")
+		}
+	case "":
+		return errors.Wrap(ErrParameterEmpty, "scheme")
+	default:
+		return errors.Errorf("scheme: must be %s, %s or %s, got %q", SignatureSchemeECDSA, SignatureSchemeEIP191, SignatureSchemeEd25519, t.Scheme)
+	}
+	if t.Signature == "" {
+		return errors.Wrap(ErrParameterEmpty, "signature")
+	}
+	return nil
+}
+
+func (t *VerifySignatureTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+	if err = t.validate(); err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	var (
+		data      BytesParam
+		signature BytesParam
+		publicKey BytesParam
+		address   AddressParam
+	)
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), NonemptyString(t.Data), Input(inputs, 0))), "data"),
+		errors.Wrap(ResolveParam(&signature, From(VarExpr(t.Signature, vars), NonemptyString(t.Signature))), "signature"),
+	)
+	if t.PublicKey != "" {
+		err = multierr.Append(err, errors.Wrap(ResolveParam(&publicKey, From(VarExpr(t.PublicKey, vars), NonemptyString(t.PublicKey))), "publicKey"))
+	} else {
+		err = multierr.Append(err, errors.Wrap(ResolveParam(&address, From(VarExpr(t.Address, vars), NonemptyString(t.Address))), "address"))
+	}
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	var valid bool
+	switch t.Scheme {
+	case SignatureSchemeECDSA:
+		valid, err = verifyECDSA(crypto.Keccak256(data), signature, publicKey, common.Address(address))
+	case SignatureSchemeEIP191:
+		valid, err = verifyECDSA(accounts.TextHash(data), signature, publicKey, common.Address(address))
+	case SignatureSchemeEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return Result{Error: errors.Errorf("publicKey: ed25519 public keys are %d bytes, got %d", ed25519.PublicKeySize, len(publicKey))}, runInfo
+		}
+		valid = len(signature) == ed25519.SignatureSize && ed25519.Verify(ed25519.PublicKey(publicKey), data, signature)
+	}
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	return Result{Value: valid}, runInfo
+}
+
+// verifyECDSA recovers the signer of hash and compares it to publicKey, or to address if
+// publicKey is empty. Malleable signatures with a high S value are rejected.
+func verifyECDSA(hash, signature, publicKey []byte, address common.Address) (bool, error) {
+	var signer common.Address
+	if len(publicKey) > 0 {
+		var (
+			pub *ecdsa.PublicKey
+			err error
+		)
+		if len(publicKey) == 33 {
+			pub, err = crypto.DecompressPubkey(publicKey)
+		} else {
+			pub, err = crypto.UnmarshalPubkey(publicKey)
+		}
+		if err != nil {
+			return false, errors.Wrap(err, "publicKey")
+		}
+		signer = crypto.PubkeyToAddress(*pub)
+	} else {
+		signer = address
+	}
+
+	if len(signature) != crypto.SignatureLength {
+		return false, nil
+	}
+	sig := bytes.Clone(signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(sig[crypto.RecoveryIDOffset], r, s, true) {
+		return false, nil
+	}
+	recovered, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return false, nil
+	}
+	return crypto.PubkeyToAddress(*recovered) == signer, nil
+}
@@ -0,0 +1,130 @@
+package pipeline_test
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestVerifySignatureTask(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"price": 1234}`)
+
+	ecdsaKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(ecdsaKey.PublicKey).Hex()
+	compressedPub := hexutil.Encode(crypto.CompressPubkey(&ecdsaKey.PublicKey))
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherAddress := crypto.PubkeyToAddress(otherKey.PublicKey).Hex()
+
+	ecdsaSig, err := crypto.Sign(crypto.Keccak256(data), ecdsaKey)
+	require.NoError(t, err)
+	eip191Sig, err := crypto.Sign(accounts.TextHash(data), ecdsaKey)
+	require.NoError(t, err)
+	eip191Sig[64] += 27
+
+	// flipping S to N - S and the recovery id produces a valid but malleable signature
+	malleableSig := append([]byte{}, ecdsaSig...)
+	s := new(big.Int).SetBytes(malleableSig[32:64])
+	new(big.Int).Sub(crypto.S256().Params().N, s).FillBytes(malleableSig[32:64])
+	malleableSig[64] ^= 1
+
+	edPub, edPriv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	edSig := ed25519.Sign(edPriv, data)
+
+	tests := []struct {
+		name      string
+		scheme    string
+		signature string
+		publicKey string
+		address   string
+		want      bool
+		wantErr   string
+	}{
+		{"ecdsa address", "ecdsa", hexutil.Encode(ecdsaSig), "", address, true, ""},
+		{"ecdsa public key", "ecdsa", hexutil.Encode(ecdsaSig), compressedPub, "", true, ""},
+		{"ecdsa uncompressed public key", "ecdsa", hexutil.Encode(ecdsaSig), hexutil.Encode(crypto.FromECDSAPub(&ecdsaKey.PublicKey)), "", true, ""},
+		{"ecdsa other address", "ecdsa", hexutil.Encode(ecdsaSig), "", otherAddress, false, ""},
+		{"ecdsa signature of other scheme", "ecdsa", hexutil.Encode(eip191Sig), "", address, false, ""},
+		{"ecdsa malleable signature", "ecdsa", hexutil.Encode(malleableSig), "", address, false, ""},
+		{"ecdsa short signature", "ecdsa", hexutil.Encode(ecdsaSig[:64]), "", address, false, ""},
+		{"ecdsa bad public key", "ecdsa", hexutil.Encode(ecdsaSig), "0x1234", "", false, "publicKey"},
+		{"eip191 address", "eip191", hexutil.Encode(eip191Sig), "", address, true, ""},
+		{"eip191 other address", "eip191", hexutil.Encode(eip191Sig), "", otherAddress, false, ""},
+		{"ed25519", "ed25519", hexutil.Encode(edSig), hexutil.Encode(edPub), "", true, ""},
+		{"ed25519 other data", "ed25519", hexutil.Encode(ed25519.Sign(edPriv, []byte("other"))), hexutil.Encode(edPub), "", false, ""},
+		{"ed25519 bad public key", "ed25519", hexutil.Encode(edSig), "0x1234", "", false, "ed25519 public keys are 32 bytes"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.VerifySignatureTask{
+				BaseTask:  pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Scheme:    test.scheme,
+				Data:      "$(data)",
+				Signature: test.signature,
+				PublicKey: test.publicKey,
+				Address:   test.address,
+			}
+			vars := pipeline.NewVarsFrom(map[string]interface{}{"data": data})
+			result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+			if test.wantErr != "" {
+				require.Error(t, result.Error)
+				assert.Contains(t, result.Error.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, result.Error)
+			assert.Equal(t, test.want, result.Value)
+		})
+	}
+}
+
+func TestVerifySignatureTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"ecdsa address", map[string]interface{}{"scheme": "ecdsa", "signature": "$(sig)", "address": "$(signer)"}, ""},
+		{"ed25519 public key", map[string]interface{}{"scheme": "ed25519", "signature": "$(sig)", "publicKey": "0x00"}, ""},
+		{"missing scheme", map[string]interface{}{"signature": "$(sig)", "address": "0x00"}, "scheme: parameter is empty"},
+		{"unknown scheme", map[string]interface{}{"scheme": "rsa", "signature": "$(sig)", "publicKey": "0x00"}, "scheme: must be"},
+		{"ecdsa without key", map[string]interface{}{"scheme": "ecdsa", "signature": "$(sig)"}, "exactly one of publicKey and address"},
+		{"ecdsa with both keys", map[string]interface{}{"scheme": "eip191", "signature": "$(sig)", "address": "0x00", "publicKey": "0x00"}, "exactly one of publicKey and address"},
+		{"ed25519 with address", map[string]interface{}{"scheme": "ed25519", "signature": "$(sig)", "publicKey": "0x00", "address": "0x00"}, "address cannot be used"},
+		{"missing signature", map[string]interface{}{"scheme": "ed25519", "publicKey": "0x00"}, "signature: parameter is empty"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap("verifysignature", test.params, 0, "verify")
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
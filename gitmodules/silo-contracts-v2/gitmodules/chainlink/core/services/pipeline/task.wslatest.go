@@ -0,0 +1,118 @@
+package pipeline
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// defaultWSMaxStaleness is the max staleness of a wsLatest task that does not set one.
+const defaultWSMaxStaleness = time.Minute
+
+var ErrWSStale = errors.New("stale websocket message")
+
+// WSLatestTask returns the latest message pushed on a WebSocket stream, e.g.
+//
+//	price [type=wsLatest url="wss://example.com/stream" subscribe=<{"op": "subscribe", "channel": "ETH-USD"}> maxStaleness="10s"]
+//
+// Streams are kept open by the node and shared by all tasks with the same url and subscribe
+// message, which is sent after connecting. If the stream has not received a message yet, the task
+// waits for one until it times out. Both url and subscribe must be literals.
+//
+// Like http tasks with variable URLs, streams cannot connect to local or private networks unless
+// allowUnrestrictedNetworkAccess=true is set.
+//
+// Return types:
+//
+//	string
+type WSLatestTask struct {
+	BaseTask                       `mapstructure:",squash"`
+	URL                            string `json:"url"`
+	Subscribe                      string `json:"subscribe"`
+	MaxStaleness                   string `json:"maxStaleness"`
+	AllowUnrestrictedNetworkAccess string `json:"allowUnrestrictedNetworkAccess"`
+
+	streams *wsStreamManager
+}
+
+var _ Task = (*WSLatestTask)(nil)
+
+func (t *WSLatestTask) Type() TaskType {
+	return TaskTypeWSLatest
+}
+
+func (t *WSLatestTask) validate() error {
+	if t.URL == "" {
+		return errors.Wrap(ErrParameterEmpty, "url")
+	}
+	if variableRegexp.MatchString(t.URL) || variableRegexp.MatchString(t.Subscribe) || variableRegexp.MatchString(t.AllowUnrestrictedNetworkAccess) {
+		return errors.New("url, subscribe and allowUnrestrictedNetworkAccess cannot use variables, streams are shared by all runs")
+	}
+	if _, err := t.unrestricted(); err != nil {
+		return err
+	}
+	u, err := url.Parse(t.URL)
+	if err != nil {
+		return errors.Wrap(err, "url")
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return errors.Errorf("url: scheme must be ws or wss, got %q", u.Scheme)
+	}
+	_, err = t.maxStaleness()
+	return err
+}
+
+func (t *WSLatestTask) maxStaleness() (time.Duration, error) {
+	if t.MaxStaleness == "" {
+		return defaultWSMaxStaleness, nil
+	}
+	d, err := time.ParseDuration(t.MaxStaleness)
+	if err != nil || d <= 0 {
+		return 0, errors.Errorf("maxStaleness: must be a positive duration, got %q", t.MaxStaleness)
+	}
+	return d, nil
+}
+
+func (t *WSLatestTask) unrestricted() (bool, error) {
+	var unrestricted BoolParam
+	err := ResolveParam(&unrestricted, From(NonemptyString(t.AllowUnrestrictedNetworkAccess), false))
+	return bool(unrestricted), errors.Wrap(err, "allowUnrestrictedNetworkAccess")
+}
+
+func (t *WSLatestTask) Run(ctx context.Context, lggr logger.Logger, _ Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, -1, -1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+	maxStaleness, err := t.maxStaleness()
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	unrestricted, err := t.unrestricted()
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if t.streams == nil {
+		return Result{Error: errors.New("wsLatest: no stream manager")}, runInfo
+	}
+
+	msg, err := t.streams.latest(ctx, wsStreamKey{url: t.URL, subscribe: t.Subscribe, unrestricted: unrestricted}, maxStaleness)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if age := time.Since(msg.receivedAt); age > maxStaleness {
+		return Result{Error: errors.Wrapf(ErrWSStale, "latest message received %s ago, max staleness is %s", age.Round(time.Millisecond), maxStaleness)}, runInfo
+	}
+
+	lggr.Tracew("wsLatest task: got message",
+		"url", t.URL,
+		"message", string(msg.data),
+		"receivedAt", msg.receivedAt,
+		"dotID", t.DotID(),
+	)
+	return Result{Value: string(msg.data)}, runInfo
+}
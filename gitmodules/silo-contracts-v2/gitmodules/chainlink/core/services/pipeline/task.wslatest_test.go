@@ -0,0 +1,104 @@
+package pipeline_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	clhttp "github.com/smartcontractkit/chainlink/v2/core/utils/http"
+)
+
+func TestWSLatestTask(t *testing.T) {
+	t.Parallel()
+
+	config := configtest.NewTestGeneralConfig(t)
+	s, _ := pipeline.NewWSTestServer(t)
+	url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+	newTask := func(t *testing.T, subscribe, maxStaleness string) *pipeline.WSLatestTask {
+		task := &pipeline.WSLatestTask{
+			BaseTask:                       pipeline.NewBaseTask(0, "ws", nil, nil, 0),
+			URL:                            url,
+			Subscribe:                      subscribe,
+			MaxStaleness:                   maxStaleness,
+			AllowUnrestrictedNetworkAccess: "true",
+		}
+		// Use real clients here to actually test the local connection blocking
+		task.HelperSetDependencies(t, clhttp.NewRestrictedHTTPClient(config.Database(), logger.TestLogger(t)), clhttp.NewUnrestrictedHTTPClient())
+		return task
+	}
+
+	t.Run("returns the latest message", func(t *testing.T) {
+		task := newTask(t, "a", "")
+		result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		assert.False(t, runInfo.IsPending)
+		require.NoError(t, result.Error)
+		assert.Equal(t, "subscribed:a", result.Value)
+	})
+
+	t.Run("fails if the latest message is stale", func(t *testing.T) {
+		task := newTask(t, "b", "50ms")
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.NoError(t, result.Error)
+
+		time.Sleep(100 * time.Millisecond)
+		result, _ = task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.ErrorIs(t, result.Error, pipeline.ErrWSStale)
+	})
+
+	t.Run("blocks local networks by default", func(t *testing.T) {
+		task := newTask(t, "c", "")
+		task.AllowUnrestrictedNetworkAccess = ""
+
+		// the task waits for a message until it times out, and then reports why the stream failed
+		ctx, cancel := context.WithTimeout(testutils.Context(t), 2*time.Second)
+		defer cancel()
+		result, _ := task.Run(ctx, logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.Error(t, result.Error)
+		require.Contains(t, result.Error.Error(), "Connections to local/private and multicast networks are disabled")
+		require.Nil(t, result.Value)
+	})
+}
+
+func TestWSLatestTask_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"valid", map[string]interface{}{"url": "wss://example.com/stream", "subscribe": `{"op":"sub"}`, "maxStaleness": "5s"}, ""},
+		{"unrestricted", map[string]interface{}{"url": "wss://example.com/stream", "allowUnrestrictedNetworkAccess": "true"}, ""},
+		{"missing url", map[string]interface{}{}, "url: parameter is empty"},
+		{"http url", map[string]interface{}{"url": "https://example.com"}, "scheme must be ws or wss"},
+		{"variable url", map[string]interface{}{"url": "wss://$(host)/stream"}, "cannot use variables"},
+		{"variable subscribe", map[string]interface{}{"url": "wss://example.com", "subscribe": "$(pair)"}, "cannot use variables"},
+		{"variable allowUnrestrictedNetworkAccess", map[string]interface{}{"url": "wss://example.com", "allowUnrestrictedNetworkAccess": "$(allow)"}, "cannot use variables"},
+		{"invalid allowUnrestrictedNetworkAccess", map[string]interface{}{"url": "wss://example.com", "allowUnrestrictedNetworkAccess": "maybe"}, "allowUnrestrictedNetworkAccess"},
+		{"invalid maxStaleness", map[string]interface{}{"url": "wss://example.com", "maxStaleness": "-1s"}, "maxStaleness: must be a positive duration"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pipeline.UnmarshalTaskFromMap("wsLatest", test.params, 0, "ws")
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package pipeline
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"io"
 	"math"
 	"math/big"
 	"net/url"
@@ -808,3 +810,139 @@ func (p *MaybeBigIntParam) UnmarshalPipelineParam(val interface{}) error {
 func (p MaybeBigIntParam) BigInt() *big.Int {
 	return p.n
 }
+
+// maxJSONParamScale bounds the decimal exponents of numbers in a JSONParam, which would otherwise
+// allow a short document to allocate arbitrarily large numbers.
+const maxJSONParamScale = 1000
+
+// JSONParam accepts JSON-encoded strings or bytes, and values decoded by other tasks, e.g. the
+// maps returned by jsonparse. The value is normalized to nil, bool, string, decimal.Decimal,
+// []interface{} or map[string]interface{}.
+type JSONParam struct {
+	value interface{}
+}
+
+func (p *JSONParam) UnmarshalPipelineParam(val interface{}) error {
+	switch v := val.(type) {
+	case string:
+		return p.UnmarshalPipelineParam([]byte(v))
+	case []byte:
+		decoded, err := decodeJSONParam(v)
+		if err != nil {
+			return err
+		}
+		val = decoded
+	}
+	value, err := normalizeJSONParam(val)
+	if err != nil {
+		return err
+	}
+	*p = JSONParam{value: value}
+	return nil
+}
+
+func (p JSONParam) Value() interface{} {
+	return p.value
+}
+
+// decodeJSONParam decodes a single JSON value, keeping numbers as json.Number.
+func decodeJSONParam(b []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var decoded interface{}
+	if err := d.Decode(&decoded); err != nil {
+		return nil, errors.Wrapf(ErrBadInput, "invalid JSON: %v", err)
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.Wrap(ErrBadInput, "invalid JSON: unexpected data after the top-level value")
+	}
+	return decoded, nil
+}
+
+func normalizeJSONParam(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case nil, bool, string:
+		return v, nil
+	case decimal.Decimal:
+		if exp := v.Exponent(); exp > maxJSONParamScale || exp < -maxJSONParamScale {
+			return nil, errors.Wrapf(ErrBadInput, "number %s is out of range", v)
+		}
+		return v, nil
+	case json.Number:
+		d, err := decimal.NewFromString(v.String())
+		if err != nil {
+			return nil, errors.Wrapf(ErrBadInput, "invalid number %q", v)
+		}
+		return normalizeJSONParam(d)
+	case uint64:
+		return normalizeJSONParam(decimal.NewFromBigInt(new(big.Int).SetUint64(v), 0))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, float32, float64, *big.Int, *decimal.Decimal:
+		d, err := utils.ToDecimal(v)
+		if err != nil {
+			return nil, errors.Wrap(ErrBadInput, err.Error())
+		}
+		return normalizeJSONParam(d)
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, x := range v {
+			nx, err := normalizeJSONParam(x)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = nx
+		}
+		return s, nil
+	case SliceParam:
+		return normalizeJSONParam([]interface{}(v))
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			nx, err := normalizeJSONParam(x)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = nx
+		}
+		return m, nil
+	case MapParam:
+		return normalizeJSONParam(map[string]interface{}(v))
+	}
+
+	// anything else, e.g. ObjectParam or []string, is normalized through its JSON encoding
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Wrapf(ErrBadInput, "expected a JSON value, got %T", val)
+	}
+	decoded, err := decodeJSONParam(b)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeJSONParam(decoded)
+}
+
+// jsonParamResult converts a value normalized by JSONParam back to the types returned by jsonparse,
+// with integers as int64, uint64 or *big.Int and other numbers as float64.
+func jsonParamResult(val interface{}) (interface{}, error) {
+	return reinterpetJsonNumbers(jsonParamNumbers(val))
+}
+
+// jsonParamNumbers replaces the decimals in val with json.Number.
+func jsonParamNumbers(val interface{}) interface{} {
+	switch v := val.(type) {
+	case decimal.Decimal:
+		return json.Number(v.String())
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, x := range v {
+			s[i] = jsonParamNumbers(x)
+		}
+		return s
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			m[k] = jsonParamNumbers(x)
+		}
+		return m
+	}
+	return val
+}
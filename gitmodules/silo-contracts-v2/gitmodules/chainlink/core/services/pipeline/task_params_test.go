@@ -661,6 +661,67 @@ func TestJSONPathParam_UnmarshalPipelineParam(t *testing.T) {
 	}
 }
 
+func TestJSONParam_UnmarshalPipelineParam(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]interface{}{
+		"a": decimal.NewFromInt(1),
+		"b": []interface{}{"x", true, nil, decimal.RequireFromString("2.5")},
+	}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+		err      error
+	}{
+		{"string", `{"a": 1, "b": ["x", true, null, 2.5]}`, expected, nil},
+		{"[]byte", []byte(`{"a": 1, "b": ["x", true, null, 2.5]}`), expected, nil},
+		{"map", map[string]interface{}{"a": int64(1), "b": []interface{}{"x", true, nil, float64(2.5)}}, expected, nil},
+		{"MapParam", pipeline.MapParam{"a": uint64(1), "b": pipeline.SliceParam{"x", true, nil, decimal.RequireFromString("2.5")}}, expected, nil},
+		{"big.Int", big.NewInt(7), decimal.NewFromInt(7), nil},
+		{"[]string", []string{"x"}, []interface{}{"x"}, nil},
+		{"JSON string", `"x"`, "x", nil},
+		{"JSON null", `null`, nil, nil},
+		{"invalid JSON", `{"a": `, nil, pipeline.ErrBadInput},
+		{"trailing data", `{} {}`, nil, pipeline.ErrBadInput},
+		{"number out of range", `1e10000`, nil, pipeline.ErrBadInput},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var p pipeline.JSONParam
+			err := p.UnmarshalPipelineParam(test.input)
+			require.Equal(t, test.err, errors.Cause(err))
+			require.Equal(t, test.expected, normalizeDecimals(p.Value()))
+		})
+	}
+}
+
+// normalizeDecimals makes equal decimals comparable with assert.Equal.
+func normalizeDecimals(v interface{}) interface{} {
+	switch v := v.(type) {
+	case decimal.Decimal:
+		return decimal.RequireFromString(v.String())
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, x := range v {
+			s[i] = normalizeDecimals(x)
+		}
+		return s
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			m[k] = normalizeDecimals(x)
+		}
+		return m
+	}
+	return v
+}
+
 func TestResolveValue(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+	"github.com/smartcontractkit/chainlink/v2/core/static"
+)
+
+const tracerName = "github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+
+// NewTracerProvider returns a tracer provider exporting pipeline spans as configured by cfg. It
+// is registered globally by the application, since the runner and tasks use the global provider
+// and propagator.
+func NewTracerProvider(cfg config.JobPipelineTracing) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter() {
+	case "otlp":
+		if cfg.CollectorURL() == nil {
+			return nil, errors.New("otlp exporter requires a collector URL")
+		}
+		exporter = NewOTLPHTTPSpanExporter(cfg.CollectorURL().String(), &http.Client{Timeout: otlpExportTimeout})
+	case "file":
+		fileExporter, err := NewFileSpanExporter(cfg.FilePath())
+		if err != nil {
+			return nil, err
+		}
+		exporter = fileExporter
+	default:
+		return nil, errors.Errorf("unknown span exporter: %s", cfg.Exporter())
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio()))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "chainlink"),
+			attribute.String("service.version", static.Version),
+		)),
+	), nil
+}
+
+func tracer() trace.Tracer {
+	// looked up on every use, so that changes to the global provider take effect
+	return otel.GetTracerProvider().Tracer(tracerName)
+}
+
+func startRunSpan(ctx context.Context, run *Run) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "pipeline.run", trace.WithAttributes(
+		attribute.Int64("pipeline.run.id", run.ID),
+		attribute.Int("pipeline.spec.id", int(run.PipelineSpecID)),
+		attribute.Int("job.id", int(run.PipelineSpec.JobID)),
+		attribute.String("job.name", run.PipelineSpec.JobName),
+		attribute.String("job.type", run.PipelineSpec.JobType),
+	))
+}
+
+func endRunSpan(span trace.Span, run *Run) {
+	span.SetAttributes(
+		attribute.String("pipeline.run.state", string(run.State)),
+		attribute.Bool("pipeline.run.pending", run.Pending),
+	)
+	if run.HasFatalErrors() {
+		var msgs []string
+		for _, err := range run.FatalErrors {
+			if err.Valid {
+				msgs = append(msgs, err.String)
+			}
+		}
+		span.SetStatus(codes.Error, strings.Join(msgs, "; "))
+	}
+	span.End()
+}
+
+func startTaskSpan(ctx context.Context, taskRun *memoryTaskRun) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "pipeline.task."+string(taskRun.task.Type()), trace.WithAttributes(
+		attribute.String("pipeline.task.type", string(taskRun.task.Type())),
+		attribute.String("pipeline.task.dot_id", taskRun.task.DotID()),
+		attribute.Int("pipeline.task.retries", int(taskRun.attempts)),
+	))
+}
+
+func endTaskSpan(span trace.Span, result Result, runInfo RunInfo) {
+	span.SetAttributes(
+		attribute.Bool("pipeline.task.pending", runInfo.IsPending),
+		attribute.Bool("pipeline.task.retryable", runInfo.IsRetryable),
+	)
+	if result.Error != nil {
+		span.RecordError(result.Error)
+		span.SetStatus(codes.Error, result.Error.Error())
+	}
+	span.End()
+}
+
+// injectTraceContext adds the trace context of ctx to the headers of an outbound request.
+func injectTraceContext(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// withRPCTraceContext returns a context that makes the RPC client send the trace context of ctx
+// as request headers.
+func withRPCTraceContext(ctx context.Context) context.Context {
+	header := http.Header{}
+	injectTraceContext(ctx, header)
+	if len(header) == 0 {
+		return ctx
+	}
+	return rpc.NewContextWithHeaders(ctx, header)
+}
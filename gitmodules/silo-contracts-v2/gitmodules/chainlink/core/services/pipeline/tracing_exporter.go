@@ -0,0 +1,292 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const otlpExportTimeout = 10 * time.Second
+
+var (
+	_ sdktrace.SpanExporter = (*OTLPHTTPSpanExporter)(nil)
+	_ sdktrace.SpanExporter = (*FileSpanExporter)(nil)
+)
+
+// OTLPHTTPSpanExporter sends spans to an OpenTelemetry collector using OTLP over HTTP with JSON
+// encoding.
+type OTLPHTTPSpanExporter struct {
+	url    string
+	client *http.Client
+}
+
+func NewOTLPHTTPSpanExporter(url string, client *http.Client) *OTLPHTTPSpanExporter {
+	return &OTLPHTTPSpanExporter{url: url, client: client}
+}
+
+func (e *OTLPHTTPSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	body, err := marshalOTLPSpans(spans)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create span export request")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := e.client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to export spans")
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return errors.Errorf("failed to export spans: collector responded with status %d: %s", response.StatusCode, msg)
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	return nil
+}
+
+func (e *OTLPHTTPSpanExporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// FileSpanExporter appends spans to a file, one OTLP JSON export request per line, so that they
+// can be inspected locally or replayed to a collector.
+type FileSpanExporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSpanExporter(path string) (*FileSpanExporter, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open span export file")
+	}
+	return &FileSpanExporter{file: file}, nil
+}
+
+func (e *FileSpanExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	line, err := marshalOTLPSpans(spans)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.file.Write(append(line, '\n'))
+	return errors.Wrap(err, "failed to write spans")
+}
+
+func (e *FileSpanExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.file.Close()
+}
+
+// The types below follow the JSON encoding of the OTLP ExportTraceServiceRequest message.
+
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+// OTLP status codes, which are numbered differently from codes.Code
+const (
+	otlpStatusCodeOK    = 1
+	otlpStatusCodeError = 2
+)
+
+func marshalOTLPSpans(spans []sdktrace.ReadOnlySpan) ([]byte, error) {
+	var request otlpTraceRequest
+	resources := make(map[*resource.Resource]int)
+	scopes := make(map[*resource.Resource]map[instrumentation.Scope]int)
+
+	for _, span := range spans {
+		res := span.Resource()
+		ri, ok := resources[res]
+		if !ok {
+			ri = len(request.ResourceSpans)
+			resources[res] = ri
+			scopes[res] = make(map[instrumentation.Scope]int)
+			request.ResourceSpans = append(request.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{Attributes: otlpAttributes(res.Attributes())},
+			})
+		}
+		rs := &request.ResourceSpans[ri]
+
+		scope := span.InstrumentationScope()
+		si, ok := scopes[res][scope]
+		if !ok {
+			si = len(rs.ScopeSpans)
+			scopes[res][scope] = si
+			rs.ScopeSpans = append(rs.ScopeSpans, otlpScopeSpans{Scope: otlpScope{Name: scope.Name, Version: scope.Version}})
+		}
+		rs.ScopeSpans[si].Spans = append(rs.ScopeSpans[si].Spans, otlpSpanFrom(span))
+	}
+
+	b, err := json.Marshal(request)
+	return b, errors.Wrap(err, "failed to encode spans")
+}
+
+func otlpSpanFrom(span sdktrace.ReadOnlySpan) otlpSpan {
+	sc := span.SpanContext()
+	traceID, spanID := sc.TraceID(), sc.SpanID()
+	s := otlpSpan{
+		TraceID:           hex.EncodeToString(traceID[:]),
+		SpanID:            hex.EncodeToString(spanID[:]),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: otlpTime(span.StartTime()),
+		EndTimeUnixNano:   otlpTime(span.EndTime()),
+		Attributes:        otlpAttributes(span.Attributes()),
+		Status:            otlpStatus{Message: span.Status().Description},
+	}
+	if parent := span.Parent(); parent.HasSpanID() {
+		parentID := parent.SpanID()
+		s.ParentSpanID = hex.EncodeToString(parentID[:])
+	}
+	switch span.Status().Code {
+	case codes.Ok:
+		s.Status.Code = otlpStatusCodeOK
+	case codes.Error:
+		s.Status.Code = otlpStatusCodeError
+	}
+	for _, event := range span.Events() {
+		s.Events = append(s.Events, otlpEvent{
+			TimeUnixNano: otlpTime(event.Time),
+			Name:         event.Name,
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+	return s
+}
+
+func otlpTime(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpAttributes(kvs []attribute.KeyValue) []otlpKeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+	attrs := make([]otlpKeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		attrs = append(attrs, otlpKeyValue{Key: string(kv.Key), Value: otlpValue(kv.Value)})
+	}
+	return attrs
+}
+
+func otlpValue(v attribute.Value) (av otlpAnyValue) {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		av.BoolValue = &b
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		av.IntValue = &i
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		av.DoubleValue = &f
+	case attribute.BOOLSLICE:
+		av.ArrayValue = &otlpArrayValue{}
+		for _, b := range v.AsBoolSlice() {
+			av.ArrayValue.Values = append(av.ArrayValue.Values, otlpValue(attribute.BoolValue(b)))
+		}
+	case attribute.INT64SLICE:
+		av.ArrayValue = &otlpArrayValue{}
+		for _, i := range v.AsInt64Slice() {
+			av.ArrayValue.Values = append(av.ArrayValue.Values, otlpValue(attribute.Int64Value(i)))
+		}
+	case attribute.FLOAT64SLICE:
+		av.ArrayValue = &otlpArrayValue{}
+		for _, f := range v.AsFloat64Slice() {
+			av.ArrayValue.Values = append(av.ArrayValue.Values, otlpValue(attribute.Float64Value(f)))
+		}
+	case attribute.STRINGSLICE:
+		av.ArrayValue = &otlpArrayValue{}
+		for _, s := range v.AsStringSlice() {
+			av.ArrayValue.Values = append(av.ArrayValue.Values, otlpValue(attribute.StringValue(s)))
+		}
+	default:
+		s := v.Emit()
+		av.StringValue = &s
+	}
+	return
+}
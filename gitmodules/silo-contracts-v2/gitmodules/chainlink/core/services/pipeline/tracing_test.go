@@ -0,0 +1,218 @@
+package pipeline_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	bridgesMocks "github.com/smartcontractkit/chainlink/v2/core/bridges/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// setTestTracerProvider registers a global tracer provider recording spans in memory for the
+// duration of the test.
+func setTestTracerProvider(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return exporter
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func Test_PipelineRunner_Tracing(t *testing.T) {
+	exporter := setTestTracerProvider(t)
+
+	traceparent := make(chan string, 1)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent <- r.Header.Get("traceparent")
+		_, _ = w.Write([]byte(`{"price": 42}`))
+	}))
+	defer s.Close()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	r, _ := newRunner(t, pgtest.NewSqlxDB(t), bridgesMocks.NewORM(t), cfg)
+
+	spec := pipeline.Spec{
+		JobName: "traced",
+		DotDagSource: fmt.Sprintf(`
+ds    [type=http method=GET url="%s"]
+parse [type=jsonparse path="price"]
+fail  [type=fail msg="boom"]
+ds -> parse -> fail`, s.URL),
+	}
+	run, _, err := r.ExecuteRun(testutils.Context(t), spec, pipeline.NewVarsFrom(nil), logger.TestLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, pipeline.RunStatusErrored, run.State)
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	require.Len(t, spans, 4)
+
+	runSpan := spans["pipeline.run"]
+	assert.Equal(t, "traced", spanAttribute(runSpan, "job.name").AsString())
+	assert.Equal(t, string(pipeline.RunStatusErrored), spanAttribute(runSpan, "pipeline.run.state").AsString())
+	assert.Equal(t, codes.Error, runSpan.Status.Code)
+	assert.Contains(t, runSpan.Status.Description, "boom")
+
+	for _, name := range []string{"pipeline.task.http", "pipeline.task.jsonparse", "pipeline.task.fail"} {
+		span, ok := spans[name]
+		require.True(t, ok, name)
+		assert.Equal(t, runSpan.SpanContext.TraceID(), span.SpanContext.TraceID())
+		assert.Equal(t, runSpan.SpanContext.SpanID(), span.Parent.SpanID())
+		assert.Equal(t, int64(0), spanAttribute(span, "pipeline.task.retries").AsInt64())
+	}
+	assert.Equal(t, "ds", spanAttribute(spans["pipeline.task.http"], "pipeline.task.dot_id").AsString())
+	assert.Equal(t, codes.Unset, spans["pipeline.task.http"].Status.Code)
+	assert.Equal(t, codes.Error, spans["pipeline.task.fail"].Status.Code)
+
+	httpSpan := spans["pipeline.task.http"].SpanContext
+	assert.Equal(t, fmt.Sprintf("00-%s-%s-01", httpSpan.TraceID(), httpSpan.SpanID()), <-traceparent)
+}
+
+func recordTestSpans(t *testing.T, exporter sdktrace.SpanExporter) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.SetAttributes(attribute.Int("retries", 2), attribute.StringSlice("tags", []string{"a", "b"}))
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+	parent.End()
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
+
+type otlpTestRequest struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Scope struct {
+				Name string `json:"name"`
+			} `json:"scope"`
+			Spans []struct {
+				TraceID      string `json:"traceId"`
+				SpanID       string `json:"spanId"`
+				ParentSpanID string `json:"parentSpanId"`
+				Name         string `json:"name"`
+				Attributes   []struct {
+					Key   string                 `json:"key"`
+					Value map[string]interface{} `json:"value"`
+				} `json:"attributes"`
+				Status struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestFileSpanExporter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	exporter, err := pipeline.NewFileSpanExporter(path)
+	require.NoError(t, err)
+	recordTestSpans(t, exporter)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	// one line per exported batch, and the syncer exports every span on its own
+	var requests []otlpTestRequest
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var request otlpTestRequest
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &request))
+		requests = append(requests, request)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, requests, 2)
+
+	child := requests[0].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	parent := requests[1].ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "test", requests[0].ResourceSpans[0].ScopeSpans[0].Scope.Name)
+	assert.Equal(t, "child", child.Name)
+	assert.Equal(t, "parent", parent.Name)
+	assert.Equal(t, parent.TraceID, child.TraceID)
+	assert.Equal(t, parent.SpanID, child.ParentSpanID)
+	assert.Len(t, child.TraceID, 32)
+	assert.Len(t, child.SpanID, 16)
+	assert.Equal(t, 2, child.Status.Code)
+	assert.Equal(t, "boom", child.Status.Message)
+	require.Len(t, child.Attributes, 2)
+	assert.Equal(t, map[string]interface{}{"intValue": "2"}, child.Attributes[0].Value)
+	assert.Equal(t, map[string]interface{}{"arrayValue": map[string]interface{}{"values": []interface{}{
+		map[string]interface{}{"stringValue": "a"},
+		map[string]interface{}{"stringValue": "b"},
+	}}}, child.Attributes[1].Value)
+	assert.Equal(t, 0, parent.Status.Code)
+}
+
+func TestOTLPHTTPSpanExporter(t *testing.T) {
+	t.Parallel()
+
+	var requests []otlpTestRequest
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request otlpTestRequest
+		require.NoError(t, json.Unmarshal(body, &request))
+		requests = append(requests, request)
+	}))
+	defer s.Close()
+
+	recordTestSpans(t, pipeline.NewOTLPHTTPSpanExporter(s.URL+"/v1/traces", s.Client()))
+	require.Len(t, requests, 2)
+	assert.Equal(t, "child", requests[0].ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+	assert.Equal(t, "parent", requests[1].ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+
+	t.Run("collector error", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer s.Close()
+
+		exporter := pipeline.NewOTLPHTTPSpanExporter(s.URL, s.Client())
+		err := exporter.ExportSpans(testutils.Context(t), tracetest.SpanStubs{{Name: "span"}}.Snapshots())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "collector responded with status 503: unavailable")
+	})
+}
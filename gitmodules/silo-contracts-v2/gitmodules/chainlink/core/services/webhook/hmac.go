@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+const (
+	// TimestampHeader is the header with the time a signed request was sent at, in unix seconds.
+	TimestampHeader = "X-Chainlink-Webhook-Timestamp"
+	// NonceHeader is the header with a value which is unique to a signed request.
+	NonceHeader = "X-Chainlink-Webhook-Nonce"
+	// SignatureHeader is the header with the hex encoded HMAC-SHA256 of a signed request, see Sign.
+	SignatureHeader = "X-Chainlink-Webhook-Signature"
+
+	// MaxRequestAge is how far the timestamp of a signed request may be from the time it is
+	// received at. Nonces are remembered until the requests they were used by are stale, across
+	// restarts.
+	MaxRequestAge = 5 * time.Minute
+
+	maxNonceLength = 128
+)
+
+// ErrUnauthorizedRequest is returned by the HMAC authorizer for requests which are not signed
+// with the secret of the job, are stale or are replayed.
+var ErrUnauthorizedRequest = errors.New("unauthorized webhook request")
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp, nonce and body of a request,
+// joined with dots, keyed with the secret of the job.
+func Sign(secret string, timestamp string, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type hmacAuthorizer struct {
+	orm       ORM
+	timestamp string
+	nonce     string
+	signature string
+	body      []byte
+}
+
+var _ Authorizer = &hmacAuthorizer{}
+
+// NewHMACAuthorizer returns an Authorizer for a request signed with the secret of the job, see
+// Sign. Stale requests, and requests with a nonce which was already used, are rejected with
+// ErrUnauthorizedRequest.
+func NewHMACAuthorizer(orm ORM, header http.Header, body []byte) Authorizer {
+	return &hmacAuthorizer{
+		orm:       orm,
+		timestamp: header.Get(TimestampHeader),
+		nonce:     header.Get(NonceHeader),
+		signature: header.Get(SignatureHeader),
+		body:      body,
+	}
+}
+
+func (ha *hmacAuthorizer) CanRun(ctx context.Context, _ AuthorizerConfig, jobUUID uuid.UUID) (bool, error) {
+	if ha.timestamp == "" || ha.nonce == "" || ha.signature == "" {
+		return false, errors.Wrapf(ErrUnauthorizedRequest, "%s, %s and %s headers are required", TimestampHeader, NonceHeader, SignatureHeader)
+	}
+	if len(ha.nonce) > maxNonceLength {
+		return false, errors.Wrapf(ErrUnauthorizedRequest, "nonce is longer than %d characters", maxNonceLength)
+	}
+	sentAt, err := strconv.ParseInt(ha.timestamp, 10, 64)
+	if err != nil {
+		return false, errors.Wrap(ErrUnauthorizedRequest, "invalid timestamp")
+	}
+	now := time.Now()
+	if age := now.Sub(time.Unix(sentAt, 0)); age > MaxRequestAge || age < -MaxRequestAge {
+		return false, errors.Wrap(ErrUnauthorizedRequest, "stale request")
+	}
+
+	q := pg.WithParentCtx(ctx)
+	secret, err := ha.orm.FindSecret(jobUUID, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, errors.Wrap(ErrUnauthorizedRequest, "job does not accept signed requests")
+	} else if err != nil {
+		return false, err
+	}
+	if !hmac.Equal([]byte(Sign(secret, ha.timestamp, ha.nonce, ha.body)), []byte(ha.signature)) {
+		return false, errors.Wrap(ErrUnauthorizedRequest, "invalid signature")
+	}
+	// the nonce is only recorded for requests with a valid signature, and it is scoped to the job
+	used, err := ha.orm.UseNonce(jobUUID, ha.nonce, now, q)
+	if err != nil {
+		return false, err
+	} else if !used {
+		return false, errors.Wrap(ErrUnauthorizedRequest, "replayed request")
+	}
+	return true, nil
+}
@@ -0,0 +1,149 @@
+package webhook_test
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+)
+
+func signedHeader(secret string, sentAt time.Time, nonce string, body []byte) http.Header {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	header := http.Header{}
+	header.Set(webhook.TimestampHeader, timestamp)
+	header.Set(webhook.NonceHeader, nonce)
+	header.Set(webhook.SignatureHeader, webhook.Sign(secret, timestamp, nonce, body))
+	return header
+}
+
+func Test_HMACAuthorizer(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	ctx := testutils.Context(t)
+
+	jb, _ := cltest.MustInsertWebhookSpec(t, db)
+	jobWithoutSecret, _ := cltest.MustInsertWebhookSpec(t, db)
+
+	orm := webhook.NewORM(db, logger.TestLogger(t), pgtest.NewQConfig(true))
+	secret, err := orm.GenerateSecret(jb.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+
+	_, err = orm.GenerateSecret(-1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	body := []byte(`{"foo":"bar"}`)
+	canRun := func(header http.Header, body []byte, jobUUID uuid.UUID) (bool, error) {
+		return webhook.NewHMACAuthorizer(orm, header, body).CanRun(ctx, eiDisabledCfg{}, jobUUID)
+	}
+	assertUnauthorized := func(t *testing.T, can bool, err error, reason string) {
+		assert.False(t, can)
+		assert.ErrorIs(t, err, webhook.ErrUnauthorizedRequest)
+		assert.ErrorContains(t, err, reason)
+	}
+
+	t.Run("signed request", func(t *testing.T) {
+		can, err := canRun(signedHeader(secret, time.Now(), "1", body), body, jb.ExternalJobID)
+		require.NoError(t, err)
+		assert.True(t, can)
+	})
+
+	t.Run("replayed request", func(t *testing.T) {
+		header := signedHeader(secret, time.Now(), "2", body)
+		can, err := canRun(header, body, jb.ExternalJobID)
+		require.NoError(t, err)
+		assert.True(t, can)
+
+		can, err = canRun(header, body, jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "replayed request")
+	})
+
+	t.Run("stale request", func(t *testing.T) {
+		can, err := canRun(signedHeader(secret, time.Now().Add(-2*webhook.MaxRequestAge), "3", body), body, jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "stale request")
+		can, err = canRun(signedHeader(secret, time.Now().Add(2*webhook.MaxRequestAge), "3", body), body, jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "stale request")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		header := signedHeader(secret, time.Now(), "4", body)
+		can, err := canRun(header, []byte(`{"foo":"baz"}`), jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "invalid signature")
+
+		can, err = canRun(signedHeader("wrong secret", time.Now(), "5", body), body, jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "invalid signature")
+
+		// the nonce of a rejected request can still be used
+		can, err = canRun(signedHeader(secret, time.Now(), "4", body), body, jb.ExternalJobID)
+		require.NoError(t, err)
+		assert.True(t, can)
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		header := signedHeader(secret, time.Now(), "6", body)
+		header.Del(webhook.NonceHeader)
+		can, err := canRun(header, body, jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "headers are required")
+	})
+
+	t.Run("job without secret", func(t *testing.T) {
+		can, err := canRun(signedHeader(secret, time.Now(), "7", body), body, jobWithoutSecret.ExternalJobID)
+		assertUnauthorized(t, can, err, "job does not accept signed requests")
+	})
+
+	t.Run("rotated secret", func(t *testing.T) {
+		newSecret, err := orm.GenerateSecret(jb.ID)
+		require.NoError(t, err)
+		require.NotEqual(t, secret, newSecret)
+
+		can, err := canRun(signedHeader(secret, time.Now(), "8", body), body, jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "invalid signature")
+		can, err = canRun(signedHeader(newSecret, time.Now(), "9", body), body, jb.ExternalJobID)
+		require.NoError(t, err)
+		assert.True(t, can)
+		secret = newSecret
+	})
+
+	t.Run("deleted secret", func(t *testing.T) {
+		require.NoError(t, orm.DeleteSecret(jb.ID))
+		require.ErrorIs(t, orm.DeleteSecret(jb.ID), sql.ErrNoRows)
+
+		can, err := canRun(signedHeader(secret, time.Now(), "10", body), body, jb.ExternalJobID)
+		assertUnauthorized(t, can, err, "job does not accept signed requests")
+	})
+}
+
+func Test_ORM_UseNonce(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	orm := webhook.NewORM(db, logger.TestLogger(t), pgtest.NewQConfig(true))
+	jobUUID, otherJobUUID := uuid.New(), uuid.New()
+	// the database stores microseconds
+	now := time.Now().Truncate(time.Second)
+
+	used, err := orm.UseNonce(jobUUID, "a", now)
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = orm.UseNonce(otherJobUUID, "a", now)
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	// nonces are remembered by a new ORM, as after a restart
+	orm = webhook.NewORM(db, logger.TestLogger(t), pgtest.NewQConfig(true))
+	used, err = orm.UseNonce(jobUUID, "a", now.Add(2*webhook.MaxRequestAge-time.Second))
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	used, err = orm.UseNonce(jobUUID, "a", now.Add(2*webhook.MaxRequestAge))
+	require.NoError(t, err)
+	assert.True(t, used)
+}
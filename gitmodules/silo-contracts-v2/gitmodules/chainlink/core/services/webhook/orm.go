@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// ORM stores the secrets webhook jobs can be run with by signed requests, and the nonces of the
+// signed requests they were run by. Both are linked to the external job ID, so they are kept
+// across updates of the job.
+type ORM interface {
+	// GenerateSecret sets a new random secret for the webhook job with the given ID, replacing
+	// the previous one, and returns it. It returns sql.ErrNoRows if there is no such webhook job.
+	GenerateSecret(jobID int32, qopts ...pg.QOpt) (string, error)
+	// DeleteSecret removes the secret of the webhook job with the given ID, so it can no longer
+	// be run by signed requests. It returns sql.ErrNoRows if the job has no secret.
+	DeleteSecret(jobID int32, qopts ...pg.QOpt) error
+	// FindSecret returns the secret of a webhook job, or sql.ErrNoRows if it has none.
+	FindSecret(externalJobID uuid.UUID, qopts ...pg.QOpt) (string, error)
+	// UseNonce records the nonce of a signed request to a webhook job, and returns false if it
+	// was already recorded. Nonces are forgotten once the requests they were used by are stale,
+	// which is at most twice MaxRequestAge after they were received.
+	UseNonce(externalJobID uuid.UUID, nonce string, usedAt time.Time, qopts ...pg.QOpt) (bool, error)
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("WebhookORM"), cfg)}
+}
+
+func (o *orm) GenerateSecret(jobID int32, qopts ...pg.QOpt) (string, error) {
+	secret := utils.NewSecret(32)
+	res, err := o.q.WithOpts(qopts...).Exec(`INSERT INTO webhook_hmac_secrets (external_job_id, secret, created_at)
+	SELECT external_job_id, $2, NOW() FROM jobs WHERE id = $1 AND type = 'webhook'
+	ON CONFLICT (external_job_id) DO UPDATE SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at`, jobID, secret)
+	if err != nil {
+		return "", errors.Wrap(err, "GenerateSecret failed")
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return "", errors.Wrap(err, "GenerateSecret failed")
+	}
+	if rowsAffected == 0 {
+		return "", sql.ErrNoRows
+	}
+	return secret, nil
+}
+
+func (o *orm) DeleteSecret(jobID int32, qopts ...pg.QOpt) error {
+	res, err := o.q.WithOpts(qopts...).Exec(`DELETE FROM webhook_hmac_secrets
+	WHERE external_job_id = (SELECT external_job_id FROM jobs WHERE id = $1)`, jobID)
+	if err != nil {
+		return errors.Wrap(err, "DeleteSecret failed")
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "DeleteSecret failed")
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (o *orm) FindSecret(externalJobID uuid.UUID, qopts ...pg.QOpt) (secret string, err error) {
+	err = o.q.WithOpts(qopts...).Get(&secret, `SELECT secret FROM webhook_hmac_secrets WHERE external_job_id = $1`, externalJobID)
+	return secret, errors.Wrap(err, "FindSecret failed")
+}
+
+func (o *orm) UseNonce(externalJobID uuid.UUID, nonce string, usedAt time.Time, qopts ...pg.QOpt) (used bool, err error) {
+	err = o.q.WithOpts(qopts...).Transaction(func(tx pg.Queryer) error {
+		if _, err := tx.Exec(`DELETE FROM webhook_nonces WHERE used_at <= $1`, usedAt.Add(-2*MaxRequestAge)); err != nil {
+			return errors.Wrap(err, "failed to prune nonces")
+		}
+		res, err := tx.Exec(`INSERT INTO webhook_nonces (external_job_id, nonce, used_at) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`, externalJobID, nonce, usedAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert nonce")
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		used = rowsAffected == 1
+		return nil
+	})
+	return used, errors.Wrap(err, "UseNonce failed")
+}
@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// maxTriggerDepth is the maximum number of jobs in a chain of runs triggering each other.
+const maxTriggerDepth = 16
+
+// TriggerDispatcher runs the downstream webhook jobs of the triggers of a job when its runs
+// finish, see job.Trigger. The outcome of the upstream run is passed to the downstream run as
+// $(jobRun.meta.upstream), with the outputs, errors and state of the run, the IDs of the job
+// and the run, and the chain of external job IDs which led to it.
+//
+// The triggers of the active jobs are kept in memory, and updated by the job spawner when jobs
+// are started and stopped.
+type TriggerDispatcher struct {
+	utils.StartStopOnce
+	jobRunner JobRunner
+	lggr      logger.Logger
+	chStop    utils.StopChan
+	wg        sync.WaitGroup
+
+	mu      sync.RWMutex
+	running bool
+	// triggers are the triggers of the active jobs with any, by pipeline spec ID
+	triggers map[int32]jobTriggers
+}
+
+type jobTriggers struct {
+	externalJobID uuid.UUID
+	triggers      []job.Trigger
+}
+
+var _ job.JobListener = (*TriggerDispatcher)(nil)
+
+// NewTriggerDispatcher returns a TriggerDispatcher listening to the runs finished by
+// pipelineRunner. It must be added as a listener of the job spawner.
+func NewTriggerDispatcher(pipelineRunner pipeline.Runner, jobRunner JobRunner, lggr logger.Logger) *TriggerDispatcher {
+	d := &TriggerDispatcher{
+		jobRunner: jobRunner,
+		lggr:      lggr.Named("TriggerDispatcher"),
+		chStop:    make(chan struct{}),
+		triggers:  make(map[int32]jobTriggers),
+	}
+	pipelineRunner.OnRunFinished(d.onRunFinished)
+	return d
+}
+
+func (d *TriggerDispatcher) Start(context.Context) error {
+	return d.StartOnce("TriggerDispatcher", func() error {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.running = true
+		return nil
+	})
+}
+
+func (d *TriggerDispatcher) Close() error {
+	return d.StopOnce("TriggerDispatcher", func() error {
+		d.mu.Lock()
+		d.running = false
+		d.mu.Unlock()
+
+		close(d.chStop)
+		d.wg.Wait()
+		return nil
+	})
+}
+
+func (d *TriggerDispatcher) Name() string {
+	return d.lggr.Name()
+}
+
+func (d *TriggerDispatcher) HealthReport() map[string]error {
+	return map[string]error{d.Name(): d.StartStopOnce.Healthy()}
+}
+
+// JobStarted implements job.JobListener.
+func (d *TriggerDispatcher) JobStarted(jb job.Job) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(jb.Triggers) == 0 {
+		delete(d.triggers, jb.PipelineSpecID)
+		return
+	}
+	d.triggers[jb.PipelineSpecID] = jobTriggers{
+		externalJobID: jb.ExternalJobID,
+		triggers:      append([]job.Trigger(nil), jb.Triggers...),
+	}
+}
+
+// JobStopped implements job.JobListener.
+func (d *TriggerDispatcher) JobStopped(jb job.Job) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.triggers, jb.PipelineSpecID)
+}
+
+func (d *TriggerDispatcher) onRunFinished(run *pipeline.Run) {
+	// runs suspended by async tasks are reported again once they are resumed and finish
+	if !run.State.Finished() {
+		return
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if !d.running {
+		return
+	}
+	jt, ok := d.triggers[run.PipelineSpecID]
+	if !ok {
+		return
+	}
+	d.wg.Add(1)
+	go func(run pipeline.Run) {
+		defer d.wg.Done()
+		d.dispatch(run, jt)
+	}(*run)
+}
+
+func (d *TriggerDispatcher) dispatch(run pipeline.Run, jt jobTriggers) {
+	ctx, cancel := d.chStop.NewCtx()
+	defer cancel()
+
+	for _, t := range jt.triggers {
+		if !t.Matches(run) {
+			continue
+		}
+		chain := append(upstreamChain(run), jt.externalJobID.String())
+		lggr := d.lggr.With(
+			"jobID", t.JobID,
+			"runID", run.ID,
+			"downstreamExternalJobID", t.DownstreamExternalJobID,
+			"chain", chain,
+		)
+		if slices.Contains(chain, t.DownstreamExternalJobID.String()) {
+			lggr.Errorw("Not running triggered job, jobs trigger each other in a loop")
+			continue
+		}
+		if len(chain) >= maxTriggerDepth {
+			lggr.Errorw("Not running triggered job, too many jobs were triggered in a row", "maxDepth", maxTriggerDepth)
+			continue
+		}
+
+		meta := pipeline.JSONSerializable{Valid: true, Val: map[string]interface{}{
+			"upstream": map[string]interface{}{
+				"jobID":         t.JobID,
+				"externalJobID": jt.externalJobID.String(),
+				"runID":         run.ID,
+				"state":         string(run.State),
+				"outputs":       run.Outputs.Val,
+				"errors":        run.StringFatalErrors(),
+				"chain":         chain,
+			},
+		}}
+		runID, err := d.jobRunner.RunJob(ctx, t.DownstreamExternalJobID, "", meta)
+		if errors.Is(err, ErrJobNotExists) {
+			lggr.Warnw("Triggered job is not running, it may have been deleted or paused")
+			continue
+		} else if err != nil {
+			lggr.Errorw("Failed to run triggered job", "err", err)
+			continue
+		}
+		lggr.Debugw("Ran triggered job", "downstreamRunID", runID)
+	}
+}
+
+// upstreamChain returns the external job IDs of the jobs which triggered the run, oldest first.
+func upstreamChain(run pipeline.Run) []string {
+	inputs, _ := run.Inputs.Val.(map[string]interface{})
+	jobRun, _ := inputs["jobRun"].(map[string]interface{})
+	meta, _ := jobRun["meta"].(map[string]interface{})
+	upstream, _ := meta["upstream"].(map[string]interface{})
+
+	var chain []string
+	switch ids := upstream["chain"].(type) {
+	case []string:
+		chain = append(chain, ids...)
+	case []interface{}:
+		// resumed runs are loaded from the database
+		for _, id := range ids {
+			if s, ok := id.(string); ok {
+				chain = append(chain, s)
+			}
+		}
+	}
+	return chain
+}
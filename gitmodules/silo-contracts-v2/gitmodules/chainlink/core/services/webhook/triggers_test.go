@@ -0,0 +1,137 @@
+package webhook_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+)
+
+type triggeredRun struct {
+	jobUUID uuid.UUID
+	meta    pipeline.JSONSerializable
+}
+
+type fakeJobRunner chan triggeredRun
+
+func (r fakeJobRunner) RunJob(_ context.Context, jobUUID uuid.UUID, _ string, meta pipeline.JSONSerializable) (int64, error) {
+	r <- triggeredRun{jobUUID, meta}
+	return 1, nil
+}
+
+func TestTriggerDispatcher(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upstream   = uuid.New()
+		onSuccess  = uuid.New()
+		onError    = uuid.New()
+		always     = uuid.New()
+		specID     = int32(7)
+		onFinished func(*pipeline.Run)
+	)
+
+	runner := pipelinemocks.NewRunner(t)
+	runner.On("OnRunFinished", mock.Anything).Run(func(args mock.Arguments) {
+		onFinished = args.Get(0).(func(*pipeline.Run))
+	}).Once()
+	jobRunner := make(fakeJobRunner, 3)
+
+	d := webhook.NewTriggerDispatcher(runner, jobRunner, logger.TestLogger(t))
+	require.NotNil(t, onFinished)
+	require.NoError(t, d.Start(testutils.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, d.Close()) })
+
+	jb := job.Job{
+		ID:             1,
+		ExternalJobID:  upstream,
+		PipelineSpecID: specID,
+		Triggers: []job.Trigger{
+			{JobID: 1, DownstreamExternalJobID: onSuccess, Condition: job.TriggerOnSuccess},
+			{JobID: 1, DownstreamExternalJobID: onError, Condition: job.TriggerOnError},
+			{JobID: 1, DownstreamExternalJobID: always, Condition: job.TriggerAlways},
+		},
+	}
+	d.JobStarted(jb)
+
+	newRun := func(state pipeline.RunStatus, chain []interface{}) *pipeline.Run {
+		return &pipeline.Run{
+			ID:             42,
+			PipelineSpecID: specID,
+			State:          state,
+			Inputs: pipeline.JSONSerializable{Valid: true, Val: map[string]interface{}{
+				"jobRun": map[string]interface{}{
+					"meta": map[string]interface{}{"upstream": map[string]interface{}{"chain": chain}},
+				},
+			}},
+			Outputs: pipeline.JSONSerializable{Valid: true, Val: []interface{}{"100"}},
+		}
+	}
+	awaitRuns := func(t *testing.T, n int) map[uuid.UUID]pipeline.JSONSerializable {
+		runs := make(map[uuid.UUID]pipeline.JSONSerializable)
+		for i := 0; i < n; i++ {
+			select {
+			case run := <-jobRunner:
+				runs[run.jobUUID] = run.meta
+			case <-time.After(testutils.WaitTimeout(t)):
+				t.Fatal("timed out waiting for triggered runs")
+			}
+		}
+		return runs
+	}
+
+	t.Run("runs the jobs matching the outcome of the run", func(t *testing.T) {
+		onFinished(newRun(pipeline.RunStatusCompleted, nil))
+		runs := awaitRuns(t, 2)
+		require.Contains(t, runs, onSuccess)
+		require.Contains(t, runs, always)
+
+		upstreamMeta := runs[onSuccess].Val.(map[string]interface{})["upstream"].(map[string]interface{})
+		assert.Equal(t, upstream.String(), upstreamMeta["externalJobID"])
+		assert.Equal(t, int64(42), upstreamMeta["runID"])
+		assert.Equal(t, []interface{}{"100"}, upstreamMeta["outputs"])
+		assert.Equal(t, []string{upstream.String()}, upstreamMeta["chain"])
+
+		onFinished(newRun(pipeline.RunStatusErrored, nil))
+		runs = awaitRuns(t, 2)
+		require.Contains(t, runs, onError)
+		require.Contains(t, runs, always)
+	})
+
+	t.Run("extends the chain of triggered runs and does not run jobs in a loop", func(t *testing.T) {
+		first := uuid.New().String()
+		onFinished(newRun(pipeline.RunStatusCompleted, []interface{}{first, onSuccess.String()}))
+		runs := awaitRuns(t, 1)
+		require.Contains(t, runs, always)
+		upstreamMeta := runs[always].Val.(map[string]interface{})["upstream"].(map[string]interface{})
+		assert.Equal(t, []string{first, onSuccess.String(), upstream.String()}, upstreamMeta["chain"])
+	})
+
+	t.Run("ignores unfinished runs", func(t *testing.T) {
+		onFinished(newRun(pipeline.RunStatusSuspended, nil))
+		onFinished(newRun(pipeline.RunStatusCompleted, nil))
+		assert.Len(t, awaitRuns(t, 2), 2)
+		assert.Empty(t, jobRunner)
+	})
+
+	t.Run("ignores runs of stopped jobs", func(t *testing.T) {
+		d.JobStopped(jb)
+		onFinished(newRun(pipeline.RunStatusCompleted, nil))
+		select {
+		case run := <-jobRunner:
+			t.Fatalf("unexpected triggered run of %s", run.jobUUID)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE evm_heads ADD COLUMN is_finalized BOOLEAN NOT NULL DEFAULT FALSE;
+-- at most one head per chain is flagged, pointing at the latest finalized head
+CREATE UNIQUE INDEX idx_evm_heads_evm_chain_id_is_finalized ON evm_heads (evm_chain_id) WHERE is_finalized;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_evm_heads_evm_chain_id_is_finalized;
+ALTER TABLE evm_heads DROP COLUMN is_finalized;
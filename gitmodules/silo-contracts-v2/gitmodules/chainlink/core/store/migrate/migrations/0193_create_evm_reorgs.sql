@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE evm_reorgs (
+    id BIGSERIAL PRIMARY KEY,
+    evm_chain_id NUMERIC(78) NOT NULL,
+    prev_head_number BIGINT NOT NULL,
+    prev_head_hash BYTEA NOT NULL,
+    new_head_number BIGINT NOT NULL,
+    new_head_hash BYTEA NOT NULL,
+    -- common ancestor is NULL if it is older than the heads available to the head tracker
+    common_ancestor_number BIGINT,
+    common_ancestor_hash BYTEA,
+    depth BIGINT NOT NULL,
+    dropped_hashes BYTEA[] NOT NULL,
+    new_hashes BYTEA[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_evm_reorgs_evm_chain_id_created_at ON evm_reorgs (evm_chain_id, created_at);
+
+-- +goose Down
+DROP TABLE evm_reorgs;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE evm_forwarders ADD COLUMN type TEXT NOT NULL DEFAULT 'authorized_forwarder';
+
+-- +goose Down
+ALTER TABLE evm_forwarders DROP COLUMN type;
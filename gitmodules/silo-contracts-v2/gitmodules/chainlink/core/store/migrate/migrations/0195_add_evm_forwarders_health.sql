@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE evm_forwarders
+    ADD COLUMN stop_routing_on_drift BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN drifted_senders TEXT NOT NULL DEFAULT '',
+    ADD COLUMN health_checked_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE evm_forwarders
+    DROP COLUMN stop_routing_on_drift,
+    DROP COLUMN drifted_senders,
+    DROP COLUMN health_checked_at;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE subpipelines (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL CHECK (name != ''),
+    version INT NOT NULL CHECK (version > 0),
+    dot_dag_source TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (name, version)
+);
+
+-- +goose Down
+DROP TABLE subpipelines;
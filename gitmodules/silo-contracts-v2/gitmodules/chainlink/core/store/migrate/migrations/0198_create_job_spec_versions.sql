@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE job_spec_versions (
+    id BIGSERIAL PRIMARY KEY,
+    external_job_id UUID NOT NULL,
+    version INT NOT NULL CHECK (version > 0),
+    definition TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (external_job_id, version)
+);
+
+-- +goose Down
+DROP TABLE job_spec_versions;
@@ -0,0 +1,73 @@
+-- +goose Up
+CREATE TABLE evm_log_specs (
+    id SERIAL PRIMARY KEY,
+    evm_chain_id numeric(78) NOT NULL,
+    contract_address bytea NOT NULL CHECK (octet_length(contract_address) = 20),
+    event_abi text NOT NULL,
+    topic1 bytea[] NOT NULL DEFAULT '{}',
+    topic2 bytea[] NOT NULL DEFAULT '{}',
+    topic3 bytea[] NOT NULL DEFAULT '{}',
+    min_confirmations bigint NOT NULL,
+    poll_period bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+ALTER TABLE jobs
+    ADD COLUMN evm_log_spec_id INT REFERENCES evm_log_specs (id),
+    DROP CONSTRAINT chk_only_one_spec,
+    ADD CONSTRAINT chk_only_one_spec CHECK (
+        num_nonnulls(
+            ocr_oracle_spec_id, ocr2_oracle_spec_id,
+            direct_request_spec_id, flux_monitor_spec_id,
+            keeper_spec_id, cron_spec_id, webhook_spec_id,
+            vrf_spec_id, blockhash_store_spec_id,
+            block_header_feeder_spec_id, bootstrap_spec_id,
+            gateway_spec_id,
+            legacy_gas_station_server_spec_id,
+            legacy_gas_station_sidecar_spec_id,
+            evm_log_spec_id
+        ) = 1
+    );
+
+-- Logs processed by evmlog jobs in the blocks which may still be reorged, to process every log once.
+CREATE TABLE evm_log_job_logs (
+    job_id INT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    block_hash bytea NOT NULL,
+    block_number bigint NOT NULL,
+    log_index bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (job_id, block_hash, log_index)
+);
+CREATE INDEX idx_evm_log_job_logs_job_id_block_number ON evm_log_job_logs (job_id, block_number);
+
+-- The last block whose logs were processed by an evmlog job, to continue from it after a restart.
+CREATE TABLE evm_log_job_progress (
+    job_id INT PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    block_number bigint NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+-- +goose Down
+DROP TABLE evm_log_job_progress;
+DROP TABLE evm_log_job_logs;
+
+ALTER TABLE jobs
+    DROP CONSTRAINT chk_only_one_spec,
+    ADD CONSTRAINT chk_only_one_spec CHECK (
+        num_nonnulls(
+            ocr_oracle_spec_id, ocr2_oracle_spec_id,
+            direct_request_spec_id, flux_monitor_spec_id,
+            keeper_spec_id, cron_spec_id, webhook_spec_id,
+            vrf_spec_id, blockhash_store_spec_id,
+            block_header_feeder_spec_id, bootstrap_spec_id,
+            gateway_spec_id,
+            legacy_gas_station_server_spec_id,
+            legacy_gas_station_sidecar_spec_id
+        ) = 1
+    );
+
+ALTER TABLE jobs
+    DROP COLUMN evm_log_spec_id;
+
+DROP TABLE evm_log_specs;
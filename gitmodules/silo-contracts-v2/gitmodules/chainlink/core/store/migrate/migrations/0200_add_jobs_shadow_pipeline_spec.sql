@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE jobs ADD COLUMN shadow_pipeline_spec_id INT REFERENCES pipeline_specs (id) ON DELETE SET NULL DEFERRABLE INITIALLY IMMEDIATE;
+
+CREATE TABLE pipeline_shadow_runs (
+    id BIGSERIAL PRIMARY KEY,
+    job_id INT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    pipeline_spec_id INT NOT NULL REFERENCES pipeline_specs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    live_outputs JSONB,
+    live_errors JSONB,
+    shadow_outputs JSONB,
+    shadow_errors JSONB,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_pipeline_shadow_runs_pipeline_spec_id_created_at ON pipeline_shadow_runs (pipeline_spec_id, created_at);
+
+-- +goose Down
+DROP TABLE pipeline_shadow_runs;
+ALTER TABLE jobs DROP COLUMN shadow_pipeline_spec_id;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE job_templates (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL CHECK (name != ''),
+    version INT NOT NULL CHECK (version > 0),
+    definition TEXT NOT NULL,
+    parameters JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (name, version)
+);
+
+ALTER TABLE jobs ADD COLUMN job_template_id INT REFERENCES job_templates (id) DEFERRABLE INITIALLY IMMEDIATE;
+CREATE INDEX idx_jobs_job_template_id ON jobs (job_template_id);
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN job_template_id;
+DROP TABLE job_templates;
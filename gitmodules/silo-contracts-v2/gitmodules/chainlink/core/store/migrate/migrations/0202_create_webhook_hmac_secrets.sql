@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE webhook_hmac_secrets (
+    external_job_id UUID PRIMARY KEY,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+DROP TABLE webhook_hmac_secrets;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE cron_specs
+    ADD COLUMN time_zone TEXT NOT NULL DEFAULT '',
+    ADD COLUMN jitter BIGINT NOT NULL DEFAULT 0 CHECK (jitter >= 0),
+    ADD COLUMN catch_up TEXT NOT NULL DEFAULT 'skip';
+
+-- +goose Down
+ALTER TABLE cron_specs
+    DROP COLUMN time_zone,
+    DROP COLUMN jitter,
+    DROP COLUMN catch_up;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE job_triggers (
+    id BIGSERIAL PRIMARY KEY,
+    job_id INT NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    downstream_external_job_id UUID NOT NULL,
+    condition TEXT NOT NULL CHECK (condition IN ('success', 'error', 'always')),
+    UNIQUE (job_id, downstream_external_job_id)
+);
+
+-- +goose Down
+DROP TABLE job_triggers;
@@ -0,0 +1,33 @@
+-- +goose Up
+ALTER TABLE direct_request_specs
+    ADD COLUMN batch_fulfillment_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN batch_fulfillment_window BIGINT NOT NULL DEFAULT 0 CHECK (batch_fulfillment_window >= 0),
+    ADD COLUMN batch_fulfillment_max_size BIGINT NOT NULL DEFAULT 0 CHECK (batch_fulfillment_max_size >= 0);
+
+CREATE TABLE direct_request_fulfillments (
+    id BIGSERIAL PRIMARY KEY,
+    external_job_id UUID NOT NULL,
+    request_id BYTEA NOT NULL CHECK (octet_length(request_id) = 32),
+    payment NUMERIC(78, 0) NOT NULL,
+    callback_address BYTEA NOT NULL CHECK (octet_length(callback_address) = 20),
+    callback_function_id BYTEA NOT NULL CHECK (octet_length(callback_function_id) = 4),
+    expiration NUMERIC(78, 0) NOT NULL,
+    data BYTEA NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'submitted', 'fulfilled', 'cancelled', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    error TEXT,
+    submitted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (external_job_id, request_id)
+);
+
+CREATE INDEX idx_direct_request_fulfillments_status ON direct_request_fulfillments (external_job_id, status);
+
+-- +goose Down
+DROP TABLE direct_request_fulfillments;
+
+ALTER TABLE direct_request_specs
+    DROP COLUMN batch_fulfillment_enabled,
+    DROP COLUMN batch_fulfillment_window,
+    DROP COLUMN batch_fulfillment_max_size;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE flux_monitor_specs ADD COLUMN deviation_rule JSONB;
+
+-- +goose Down
+ALTER TABLE flux_monitor_specs DROP COLUMN deviation_rule;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE evm_forwarders ADD COLUMN next_hop bytea REFERENCES evm_forwarders (address),
+    ADD CONSTRAINT chk_next_hop_length CHECK (next_hop IS NULL OR octet_length(next_hop) = 20);
+
+-- +goose Down
+ALTER TABLE evm_forwarders DROP COLUMN next_hop;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Jobs created before job_spec_versions existed get a first version, so that their history starts
+-- with the definition they were created with. The approved definition of jobs created by a feeds
+-- manager is known. For the other jobs it is not stored, and the first version only has the fields
+-- kept in the jobs table, as single line TOML strings.
+INSERT INTO job_spec_versions (external_job_id, version, definition, created_at)
+SELECT
+    jobs.external_job_id,
+    1,
+    COALESCE(
+        (
+            SELECT job_proposal_specs.definition FROM job_proposal_specs
+            JOIN job_proposals ON job_proposals.id = job_proposal_specs.job_proposal_id
+            WHERE job_proposals.external_job_id = jobs.external_job_id AND job_proposal_specs.status = 'approved'
+            ORDER BY job_proposal_specs.version DESC
+            LIMIT 1
+        ),
+        concat_ws(E'\n',
+            '# The definition of this job was not stored when it was created, only these fields are known.',
+            'type = ' || to_json(jobs.type::text)::text,
+            'schemaVersion = ' || jobs.schema_version,
+            'name = ' || to_json(jobs.name::text)::text,
+            'externalJobID = ' || to_json(jobs.external_job_id::text)::text,
+            'observationSource = ' || to_json(pipeline_specs.dot_dag_source)::text
+        ) || E'\n'
+    ),
+    jobs.created_at
+FROM jobs
+JOIN pipeline_specs ON pipeline_specs.id = jobs.pipeline_spec_id
+WHERE NOT EXISTS (SELECT 1 FROM job_spec_versions WHERE job_spec_versions.external_job_id = jobs.external_job_id);
+
+-- +goose Down
+DELETE FROM job_spec_versions
+WHERE version = 1 AND definition LIKE '# The definition of this job was not stored when it was created%';
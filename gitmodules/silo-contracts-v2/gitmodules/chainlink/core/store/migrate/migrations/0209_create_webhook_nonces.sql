@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE webhook_nonces (
+    external_job_id UUID NOT NULL,
+    nonce TEXT NOT NULL,
+    used_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (external_job_id, nonce)
+);
+CREATE INDEX idx_webhook_nonces_used_at ON webhook_nonces (used_at);
+
+-- +goose Down
+DROP TABLE webhook_nonces;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- The latest time the schedule of a cron job was due at and handled, to catch up the runs missed
+-- while the node was down.
+CREATE TABLE cron_job_progress (
+    job_id INT PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    last_scheduled_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+-- Runs may have been pruned since, but the latest run is the best known estimate.
+INSERT INTO cron_job_progress (job_id, last_scheduled_at, updated_at)
+SELECT jobs.id, MAX(pipeline_runs.created_at), NOW()
+FROM jobs
+JOIN pipeline_runs ON pipeline_runs.pipeline_spec_id = jobs.pipeline_spec_id
+WHERE jobs.cron_spec_id IS NOT NULL
+GROUP BY jobs.id;
+
+-- +goose Down
+DROP TABLE cron_job_progress;
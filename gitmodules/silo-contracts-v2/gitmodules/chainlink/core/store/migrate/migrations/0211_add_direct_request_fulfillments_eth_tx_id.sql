@@ -0,0 +1,7 @@
+-- +goose Up
+-- eth_tx_id is the transaction the request was last submitted with. It has no foreign key as
+-- transactions are reaped independently of fulfillments.
+ALTER TABLE direct_request_fulfillments ADD COLUMN eth_tx_id BIGINT;
+
+-- +goose Down
+ALTER TABLE direct_request_fulfillments DROP COLUMN eth_tx_id;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Only keys a forwarder used to authorize are reported as drifted, so the drift recorded against
+-- all the enabled keys is cleared until the next health check.
+ALTER TABLE evm_forwarders ADD COLUMN authorized_senders TEXT NOT NULL DEFAULT '';
+UPDATE evm_forwarders SET drifted_senders = '';
+
+-- +goose Down
+ALTER TABLE evm_forwarders DROP COLUMN authorized_senders;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE direct_request_specs ADD COLUMN batch_fulfillment_use_batch_method BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE direct_request_specs DROP COLUMN batch_fulfillment_use_batch_method;
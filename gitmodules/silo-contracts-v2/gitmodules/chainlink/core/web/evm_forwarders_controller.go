@@ -1,10 +1,12 @@
 package web
 
 import (
+	"database/sql"
 	"math/big"
 	"net/http"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/forwarders"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
@@ -45,6 +47,12 @@ func (cc *EVMForwardersController) Index(c *gin.Context, size, page, offset int)
 type TrackEVMForwarderRequest struct {
 	EVMChainID *utils.Big     `json:"evmChainId"`
 	Address    common.Address `json:"address"`
+	// Type is the forwarder type, defaults to authorized_forwarder when empty.
+	Type string `json:"type"`
+	// StopRoutingOnDrift stops routing transactions from keys the forwarder no longer authorizes.
+	StopRoutingOnDrift bool `json:"stopRoutingOnDrift"`
+	// NextHop is a tracked forwarder the forwarder routes transactions through.
+	NextHop *common.Address `json:"nextHop"`
 }
 
 // Track adds a new EVM forwarder.
@@ -55,8 +63,13 @@ func (cc *EVMForwardersController) Track(c *gin.Context) {
 		jsonAPIError(c, http.StatusUnprocessableEntity, err)
 		return
 	}
+	fwdType, err := forwarders.ParseType(request.Type)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
 	orm := forwarders.NewORM(cc.App.GetSqlxDB(), cc.App.GetLogger(), cc.App.GetConfig().Database())
-	fwd, err := orm.CreateForwarder(request.Address, *request.EVMChainID)
+	fwd, err := orm.CreateForwarder(request.Address, *request.EVMChainID, fwdType, request.NextHop, request.StopRoutingOnDrift)
 
 	if err != nil {
 		jsonAPIError(c, http.StatusBadRequest, err)
@@ -67,10 +80,50 @@ func (cc *EVMForwardersController) Track(c *gin.Context) {
 		"forwarderID":         fwd.ID,
 		"forwarderAddress":    fwd.Address,
 		"forwarderEVMChainID": fwd.EVMChainID,
+		"forwarderType":       fwd.Type,
+		"forwarderNextHop":    fwd.NextHop,
+		"stopRoutingOnDrift":  fwd.StopRoutingOnDrift,
 	})
 	jsonAPIResponseWithStatus(c, presenters.NewEVMForwarderResource(fwd), "forwarder", http.StatusCreated)
 }
 
+// UpdateEVMForwarderRequest is a JSONAPI request for updating an EVM forwarder.
+type UpdateEVMForwarderRequest struct {
+	// StopRoutingOnDrift stops routing transactions from keys the forwarder no longer authorizes.
+	StopRoutingOnDrift bool `json:"stopRoutingOnDrift"`
+}
+
+// Update changes whether routing through an EVM forwarder stops for keys it no longer authorizes.
+func (cc *EVMForwardersController) Update(c *gin.Context) {
+	id, err := stringutils.ToInt64(c.Param("fwdID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	request := &UpdateEVMForwarderRequest{}
+	if err = c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	orm := forwarders.NewORM(cc.App.GetSqlxDB(), cc.App.GetLogger(), cc.App.GetConfig().Database())
+	fwd, err := orm.SetStopRoutingOnDrift(id, request.StopRoutingOnDrift)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("forwarder not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	cc.App.GetAuditLogger().Audit(audit.ForwarderUpdated, map[string]interface{}{
+		"forwarderID":        fwd.ID,
+		"stopRoutingOnDrift": fwd.StopRoutingOnDrift,
+	})
+	jsonAPIResponse(c, presenters.NewEVMForwarderResource(fwd), "forwarder")
+}
+
 // Delete removes an EVM Forwarder.
 func (cc *EVMForwardersController) Delete(c *gin.Context) {
 	id, err := stringutils.ToInt64(c.Param("fwdID"))
@@ -71,12 +71,95 @@ func Test_EVMForwardersController_Track(t *testing.T) {
 
 	require.Len(t, controller.app.GetRelayers().LegacyEVMChains().Slice(), 1)
 
+	body, err = json.Marshal(web.UpdateEVMForwarderRequest{StopRoutingOnDrift: true})
+	require.NoError(t, err)
+	resp, cleanup = controller.client.Patch("/v2/nodes/evm/forwarders/"+resource.ID, bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resource = presenters.EVMForwarderResource{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resource))
+	assert.True(t, resource.StopRoutingOnDrift)
+
+	resp, cleanup = controller.client.Patch("/v2/nodes/evm/forwarders/0", bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
 	resp, cleanup = controller.client.Delete("/v2/nodes/evm/forwarders/" + resource.ID)
 	t.Cleanup(cleanup)
 	require.Equal(t, http.StatusNoContent, resp.StatusCode)
 	assert.NoError(t, err)
 }
 
+func Test_EVMForwardersController_Track_Type(t *testing.T) {
+	t.Parallel()
+
+	chainId := utils.NewBig(testutils.NewRandomEVMChainID())
+	controller := setupEVMForwardersControllerTest(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM = evmcfg.EVMConfigs{
+			{ChainID: chainId, Enabled: ptr(true), Chain: evmcfg.Defaults(chainId)},
+		}
+	})
+
+	body, err := json.Marshal(web.TrackEVMForwarderRequest{
+		EVMChainID:         chainId,
+		Address:            testutils.NewAddress(),
+		Type:               "safe_module",
+		StopRoutingOnDrift: true,
+	})
+	require.NoError(t, err)
+
+	resp, cleanup := controller.client.Post("/v2/nodes/evm/forwarders/track", bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resource := presenters.EVMForwarderResource{}
+	err = web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resource)
+	require.NoError(t, err)
+	assert.Equal(t, "safe_module", resource.Type)
+	assert.True(t, resource.StopRoutingOnDrift)
+	assert.Empty(t, resource.DriftedSenders)
+
+	// route through the Safe
+	safe := resource.Address
+	body, err = json.Marshal(web.TrackEVMForwarderRequest{
+		EVMChainID: chainId,
+		Address:    testutils.NewAddress(),
+		NextHop:    &safe,
+	})
+	require.NoError(t, err)
+
+	resp, cleanup = controller.client.Post("/v2/nodes/evm/forwarders/track", bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resource = presenters.EVMForwarderResource{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resource))
+	require.NotNil(t, resource.NextHop)
+	assert.Equal(t, safe, *resource.NextHop)
+
+	untracked := testutils.NewAddress()
+	body, err = json.Marshal(web.TrackEVMForwarderRequest{
+		EVMChainID: chainId,
+		Address:    testutils.NewAddress(),
+		NextHop:    &untracked,
+	})
+	require.NoError(t, err)
+
+	resp, cleanup = controller.client.Post("/v2/nodes/evm/forwarders/track", bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	body, err = json.Marshal(web.TrackEVMForwarderRequest{
+		EVMChainID: chainId,
+		Address:    testutils.NewAddress(),
+		Type:       "bogus",
+	})
+	require.NoError(t, err)
+
+	resp, cleanup = controller.client.Post("/v2/nodes/evm/forwarders/track", bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
 func Test_EVMForwardersController_Index(t *testing.T) {
 	t.Parallel()
 
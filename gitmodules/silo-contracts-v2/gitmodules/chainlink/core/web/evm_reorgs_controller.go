@@ -0,0 +1,48 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// EVMReorgsController lists the reorgs recorded by the EVM head trackers.
+type EVMReorgsController struct {
+	App chainlink.Application
+}
+
+// Index lists the reorgs recorded for a chain, most recent first.
+// Example:
+//
+//	"<application>/v2/reorgs/evm?evmChainID=1"
+func (rc *EVMReorgsController) Index(c *gin.Context, size, page, offset int) {
+	chain, err := getChain(rc.App.GetRelayers().LegacyEVMChains(), c.Query("evmChainID"))
+	switch err {
+	case ErrInvalidChainID, ErrMultipleChains, ErrMissingChainID:
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	case nil:
+		break
+	default:
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	orm := headtracker.NewORM(rc.App.GetSqlxDB(), rc.App.GetLogger(), rc.App.GetConfig().Database(), *chain.ID())
+	reorgs, count, err := orm.Reorgs(c.Request.Context(), offset, size)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := make([]presenters.EVMReorgResource, len(reorgs))
+	for i, reorg := range reorgs {
+		resources[i] = presenters.NewEVMReorgResource(reorg)
+	}
+
+	paginatedResponse(c, "reorg", size, page, resources, count, err)
+}
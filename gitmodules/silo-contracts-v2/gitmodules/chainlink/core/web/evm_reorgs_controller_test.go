@@ -0,0 +1,75 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	evmcfg "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/null"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func Test_EVMReorgsController_Index(t *testing.T) {
+	t.Parallel()
+
+	chainID := utils.NewBig(testutils.NewRandomEVMChainID())
+	app := cltest.NewApplicationWithConfig(t, configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM = evmcfg.EVMConfigs{
+			{ChainID: chainID, Enabled: ptr(true), Chain: evmcfg.Defaults(chainID)},
+		}
+	}))
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+
+	orm := headtracker.NewORM(app.GetSqlxDB(), logger.TestLogger(t), app.GetConfig().Database(), *chainID.ToInt())
+	var reorgs []*headtracker.Reorg
+	for i := 0; i < 3; i++ {
+		ancestor := utils.NewHash()
+		reorg := &headtracker.Reorg{
+			PrevHeadNumber:       int64(10 + i),
+			PrevHeadHash:         utils.NewHash(),
+			NewHeadNumber:        int64(10 + i),
+			NewHeadHash:          utils.NewHash(),
+			CommonAncestorNumber: null.Int64From(int64(9 + i)),
+			CommonAncestorHash:   &ancestor,
+			Depth:                1,
+			DroppedHashes:        []common.Hash{utils.NewHash()},
+			NewHashes:            []common.Hash{utils.NewHash()},
+		}
+		require.NoError(t, orm.InsertReorg(testutils.Context(t), reorg))
+		reorgs = append(reorgs, reorg)
+	}
+
+	resp, cleanup := client.Get("/v2/reorgs/evm?size=2&evmChainID=" + chainID.String())
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var links jsonapi.Links
+	var resources []presenters.EVMReorgResource
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &resources, &links))
+	assert.NotEmpty(t, links["next"].Href)
+	require.Len(t, resources, 2)
+	// most recent first
+	assert.Equal(t, reorgs[2].NewHeadHash, resources[0].NewHeadHash)
+	assert.Equal(t, reorgs[1].NewHeadHash, resources[1].NewHeadHash)
+	assert.Equal(t, reorgs[2].DroppedHashes, resources[0].DroppedHashes)
+	assert.Equal(t, reorgs[2].CommonAncestorHash, resources[0].CommonAncestorHash)
+	assert.Equal(t, int64(11), resources[0].CommonAncestorNumber.Int64)
+
+	resp, cleanup = client.Get("/v2/reorgs/evm?evmChainID=" + testutils.NewRandomEVMChainID().String())
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// JobTemplatesController manages parameterized job specs
+type JobTemplatesController struct {
+	App chainlink.Application
+}
+
+// CreateJobTemplateRequest represents a request to create a job template, or a new version of it.
+type CreateJobTemplateRequest struct {
+	Name       string                 `json:"name"`
+	Definition string                 `json:"definition"`
+	Parameters job.TemplateParameters `json:"parameters"`
+}
+
+// Index lists the latest version of every job template.
+// Example:
+// "GET <application>/job_templates"
+func (jtc *JobTemplatesController) Index(c *gin.Context) {
+	templates, err := jtc.App.JobORM().FindTemplates(pg.WithParentCtx(c.Request.Context()))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobTemplateResources(templates), "jobTemplates")
+}
+
+// Show returns the latest version of a job template.
+// Example:
+// "GET <application>/job_templates/:name"
+func (jtc *JobTemplatesController) Show(c *gin.Context) {
+	t, ok := jtc.findTemplate(c)
+	if !ok {
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobTemplateResource(t), "jobTemplates")
+}
+
+// Create stores a job template, or a new version of it if a template with the same name exists.
+// Jobs created from previous versions are not changed.
+// Example:
+// "POST <application>/job_templates"
+func (jtc *JobTemplatesController) Create(c *gin.Context) {
+	request := CreateJobTemplateRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	t := job.Template{
+		Name:       request.Name,
+		Definition: request.Definition,
+		Parameters: request.Parameters,
+	}
+	if err := job.ValidateTemplate(t); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := jtc.App.JobORM().CreateTemplate(&t, pg.WithParentCtx(c.Request.Context())); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jtc.App.GetAuditLogger().Audit(audit.JobTemplateCreated, map[string]interface{}{"name": t.Name, "version": t.Version})
+	jsonAPIResponse(c, presenters.NewJobTemplateResource(t), "jobTemplates")
+}
+
+// Jobs lists the jobs created from any version of a job template, and whether they were created
+// from a previous version.
+// Example:
+// "GET <application>/job_templates/:name/jobs"
+func (jtc *JobTemplatesController) Jobs(c *gin.Context) {
+	t, ok := jtc.findTemplate(c)
+	if !ok {
+		return
+	}
+
+	jobs, err := jtc.App.JobORM().FindTemplateJobs(t.Name, pg.WithParentCtx(c.Request.Context()))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobTemplateJobResources(jobs, t.Version), "jobTemplateJobs")
+}
+
+// findTemplate returns the latest version of the template named in the request, or writes the
+// error response.
+func (jtc *JobTemplatesController) findTemplate(c *gin.Context) (job.Template, bool) {
+	t, err := jtc.App.JobORM().FindTemplate(c.Param("name"), pg.WithParentCtx(c.Request.Context()))
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("job template not found"))
+		return t, false
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return t, false
+	}
+	return t, true
+}
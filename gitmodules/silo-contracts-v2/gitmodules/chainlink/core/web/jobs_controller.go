@@ -5,7 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/cron"
 	"github.com/smartcontractkit/chainlink/v2/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/evmlog"
 	"github.com/smartcontractkit/chainlink/v2/core/services/fluxmonitorv2"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
@@ -27,6 +28,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/validate"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrbootstrap"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
@@ -88,9 +90,12 @@ func (jc *JobsController) Show(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewJobResource(jobSpec), "jobs")
 }
 
-// CreateJobRequest represents a request to create and start a job (V2).
+// CreateJobRequest represents a request to create and start a job (V2), either from its TOML
+// spec, or from the latest version of a job template and the values of its parameters.
 type CreateJobRequest struct {
-	TOML string `json:"toml"`
+	TOML     string            `json:"toml"`
+	Template string            `json:"template,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
 }
 
 // Create validates, saves and starts a new job.
@@ -103,17 +108,41 @@ func (jc *JobsController) Create(c *gin.Context) {
 		return
 	}
 
+	var jobTemplateID *int32
+	if request.Template != "" {
+		if request.TOML != "" {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("a job is created from either TOML or a template, not both"))
+			return
+		}
+		t, err := jc.App.JobORM().FindTemplate(request.Template, pg.WithParentCtx(c.Request.Context()))
+		if errors.Is(err, sql.ErrNoRows) {
+			jsonAPIError(c, http.StatusNotFound, errors.Errorf("job template %s not found", request.Template))
+			return
+		}
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		request.TOML, err = t.Render(request.Params)
+		if err != nil {
+			jsonAPIError(c, http.StatusBadRequest, err)
+			return
+		}
+		jobTemplateID = &t.ID
+	}
+
 	jb, status, err := jc.validateJobSpec(request.TOML)
 	if err != nil {
 		jsonAPIError(c, status, err)
 		return
 	}
+	jb.JobTemplateID = jobTemplateID
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 	err = jc.App.AddJobV2(ctx, &jb)
 	if err != nil {
-		if errors.Is(errors.Cause(err), job.ErrNoSuchKeyBundle) || errors.As(err, &keystore.KeyNotFoundError{}) || errors.Is(errors.Cause(err), job.ErrNoSuchTransmitterKey) || errors.Is(errors.Cause(err), job.ErrNoSuchSendingKey) {
+		if errors.Is(errors.Cause(err), job.ErrNoSuchKeyBundle) || errors.As(err, &keystore.KeyNotFoundError{}) || errors.Is(errors.Cause(err), job.ErrNoSuchTransmitterKey) || errors.Is(errors.Cause(err), job.ErrNoSuchSendingKey) || errors.Is(err, job.ErrInvalidTrigger) {
 			jsonAPIError(c, http.StatusBadRequest, err)
 			return
 		}
@@ -157,12 +186,164 @@ func (jc *JobsController) Delete(c *gin.Context) {
 	jsonAPIResponseWithStatus(c, nil, "job", http.StatusNoContent)
 }
 
+// Pause stops the services of a job, keeping its spec, runs and keys.
+// Example:
+// "POST <application>/jobs/:ID/pause"
+func (jc *JobsController) Pause(c *gin.Context) {
+	jc.setPaused(c, jc.App.PauseJob, audit.JobPaused)
+}
+
+// Resume restarts the services of a paused job.
+// Example:
+// "POST <application>/jobs/:ID/resume"
+func (jc *JobsController) Resume(c *gin.Context) {
+	jc.setPaused(c, jc.App.ResumePausedJob, audit.JobResumed)
+}
+
+func (jc *JobsController) setPaused(c *gin.Context, set func(ctx context.Context, jobID int32) error, event audit.EventID) {
+	j := job.Job{}
+	err := j.SetID(c.Param("ID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = set(c.Request.Context(), j.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("job not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jc.App.GetAuditLogger().Audit(event, map[string]interface{}{"id": j.ID})
+
+	j, err = jc.App.JobORM().FindJobTx(j.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewJobResource(j), "jobs")
+}
+
+// SetShadowRequest is a request to run a candidate observation source alongside a job.
+type SetShadowRequest struct {
+	ObservationSource string `json:"observationSource"`
+}
+
+// SetShadow makes the given observation source the shadow pipeline of a job. It is run with the
+// inputs of every finished run of the job, without writing on-chain, and its results are stored
+// to be compared to the live ones. It replaces any previous shadow pipeline and its results.
+// Example:
+// "PUT <application>/jobs/:ID/shadow"
+func (jc *JobsController) SetShadow(c *gin.Context) {
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	request := SetShadowRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	p, err := pipeline.Parse(request.ObservationSource)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, errors.Wrap(err, "invalid observation source"))
+		return
+	}
+
+	jc.updateShadow(c, j.ID, func(ctx context.Context) error {
+		return jc.App.SetJobShadow(ctx, j.ID, *p)
+	}, audit.JobShadowSet)
+}
+
+// RemoveShadow removes the shadow pipeline of a job and its results.
+// Example:
+// "DELETE <application>/jobs/:ID/shadow"
+func (jc *JobsController) RemoveShadow(c *gin.Context) {
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jc.updateShadow(c, j.ID, func(ctx context.Context) error {
+		return jc.App.RemoveJobShadow(ctx, j.ID)
+	}, audit.JobShadowRemoved)
+}
+
+func (jc *JobsController) updateShadow(c *gin.Context, jobID int32, update func(ctx context.Context) error, event audit.EventID) {
+	err := update(c.Request.Context())
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("job not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jc.App.GetAuditLogger().Audit(event, map[string]interface{}{"id": jobID})
+
+	j, err := jc.App.JobORM().FindJobTx(jobID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewJobResource(j), "jobs")
+}
+
+// ShadowReport compares the results of the latest runs of the shadow pipeline of a job to those
+// of the live runs. The number of runs defaults to 1000, and can be set with the limit parameter.
+// Example:
+// "GET <application>/jobs/:ID/shadow/report?limit=100"
+func (jc *JobsController) ShadowReport(c *gin.Context) {
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	limit := 1000
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid limit %q", l))
+			return
+		}
+		limit = n
+	}
+
+	j, err := jc.App.JobORM().FindJobTx(j.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("job not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if j.ShadowPipelineSpecID == nil {
+		jsonAPIError(c, http.StatusNotFound, errors.New("job has no shadow pipeline"))
+		return
+	}
+
+	shadowRuns, err := jc.App.PipelineORM().FindShadowRuns(j.ID, *j.ShadowPipelineSpecID, limit, pg.WithParentCtx(c.Request.Context()))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobShadowReportResource(j.ID, *j.ShadowPipelineSpecID, pipeline.NewShadowReport(shadowRuns)), "jobShadowReports")
+}
+
 // UpdateJobRequest represents a request to update a job with new toml and start a job (V2).
 type UpdateJobRequest struct {
 	TOML string `json:"toml"`
 }
 
-// Update validates a new TOML for an existing job, stops and deletes existing job, saves and starts a new job.
+// Update validates a new TOML for an existing job and replaces the spec of the job with it,
+// recording a new version of the spec. The job keeps its ID and run history.
 // Example:
 // "PUT <application>/jobs/:ID"
 func (jc *JobsController) Update(c *gin.Context) {
@@ -184,32 +365,89 @@ func (jc *JobsController) Update(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
+	jc.updateJob(c, &jb)
+}
+
+// Versions lists the recorded versions of the spec of a job.
+// Example:
+// "GET <application>/jobs/:ID/versions"
+func (jc *JobsController) Versions(c *gin.Context) {
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
 
-	// If the provided job id is not matching any job, delete will fail with 404 leaving state unchanged.
-	err = jc.App.DeleteJob(ctx, jb.ID)
-	// Error can be either come from ORM or from the activeJobs map.
+	versions, err := jc.App.JobORM().FindSpecVersions(j.ID, pg.WithParentCtx(c.Request.Context()))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) || strings.Contains(err.Error(), "job not found") {
-			jsonAPIError(c, http.StatusNotFound, errors.Wrap(err, "failed to update job"))
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobSpecVersionResources(versions), "jobSpecVersions")
+}
+
+// Rollback replaces the spec of a job with a previous version of it, which is recorded as
+// a new version.
+// Example:
+// "POST <application>/jobs/:ID/versions/:version/rollback"
+func (jc *JobsController) Rollback(c *gin.Context) {
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	version, err := strconv.ParseInt(c.Param("version"), 10, 32)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	specVersion, err := jc.App.JobORM().FindSpecVersion(j.ID, int32(version), pg.WithParentCtx(c.Request.Context()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			jsonAPIError(c, http.StatusNotFound, errors.Errorf("job %d has no version %d", j.ID, version))
 			return
 		}
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	err = jc.App.AddJobV2(ctx, &jb)
+	// the definition is validated again, since it may refer to keys or bridges which no longer exist
+	jb, status, err := jc.validateJobSpec(specVersion.Definition)
 	if err != nil {
-		if errors.Is(errors.Cause(err), job.ErrNoSuchKeyBundle) || errors.As(err, &keystore.KeyNotFoundError{}) || errors.Is(errors.Cause(err), job.ErrNoSuchTransmitterKey) || errors.Is(errors.Cause(err), job.ErrNoSuchSendingKey) {
+		jsonAPIError(c, status, err)
+		return
+	}
+	jb.ID = j.ID
+
+	if jc.updateJob(c, &jb) {
+		jc.App.GetAuditLogger().Audit(audit.JobRolledBack, map[string]interface{}{"id": jb.ID, "version": version})
+	}
+}
+
+// updateJob replaces the spec of the job with ID jb.ID and writes the response. It returns whether the job was updated.
+func (jc *JobsController) updateJob(c *gin.Context, jb *job.Job) bool {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// If the provided job id is not matching any job, the update will fail with 404 leaving state unchanged.
+	err := jc.App.UpdateJobV2(ctx, jb)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			jsonAPIError(c, http.StatusNotFound, errors.Wrap(err, "failed to update job"))
+			return false
+		}
+		if errors.Is(errors.Cause(err), job.ErrNoSuchKeyBundle) || errors.As(err, &keystore.KeyNotFoundError{}) || errors.Is(errors.Cause(err), job.ErrNoSuchTransmitterKey) || errors.Is(errors.Cause(err), job.ErrNoSuchSendingKey) || errors.Is(errors.Cause(err), job.ErrExternalJobIDChanged) || errors.Is(errors.Cause(err), job.ErrJobTypeChanged) || errors.Is(err, job.ErrInvalidTrigger) {
 			jsonAPIError(c, http.StatusBadRequest, err)
-			return
+			return false
 		}
 		jsonAPIError(c, http.StatusInternalServerError, err)
-		return
+		return false
 	}
 
-	jsonAPIResponse(c, presenters.NewJobResource(jb), jb.Type.String())
+	jsonAPIResponse(c, presenters.NewJobResource(*jb), jb.Type.String())
+	return true
 }
 
 func (jc *JobsController) validateJobSpec(tomlString string) (jb job.Job, statusCode int, err error) {
@@ -250,6 +488,8 @@ func (jc *JobsController) validateJobSpec(tomlString string) (jb job.Job, status
 		jb, err = ocrbootstrap.ValidatedBootstrapSpecToml(tomlString)
 	case job.Gateway:
 		jb, err = gateway.ValidatedGatewaySpec(tomlString)
+	case job.EVMLog:
+		jb, err = evmlog.ValidatedEVMLogSpec(tomlString)
 	default:
 		return jb, http.StatusUnprocessableEntity, errors.Errorf("unknown job type: %s", jobType)
 	}
@@ -257,5 +497,9 @@ func (jc *JobsController) validateJobSpec(tomlString string) (jb job.Job, status
 	if err != nil {
 		return jb, http.StatusBadRequest, err
 	}
+	if err = job.ValidateTriggers(jb.Triggers); err != nil {
+		return jb, http.StatusBadRequest, err
+	}
+	jb.Definition = tomlString
 	return jb, 0, nil
 }
@@ -533,6 +533,14 @@ func TestJobsController_Update_HappyPath(t *testing.T) {
 	require.Equal(t, dbJb.Name.String, updatedSpec.Name)
 
 	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	// the type of a job cannot be changed
+	body, _ = json.Marshal(web.UpdateJobRequest{
+		TOML: testspecs.GenerateWebhookSpec(testspecs.WebhookSpecParams{}).Toml(),
+	})
+	response, cleanup = client.Put("/v2/jobs/"+fmt.Sprintf("%v", jb.ID), bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, response, http.StatusBadRequest)
 }
 
 func TestJobsController_Update_NonExistentID(t *testing.T) {
@@ -1,10 +1,12 @@
 package web
 
 import (
+	"database/sql"
 	"encoding/json"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -151,6 +153,118 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 	jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("bad job ID"))
 }
 
+// Replay re-executes a stored pipeline run without saving it, substituting the recorded results of
+// http, bridge, ethcall, estimategaslimit and wsLatest tasks except those listed in the optional
+// "refetch" body field, and compares the output of each task to the stored one.
+// Example:
+// "POST <application>/pipeline/runs/:runID/replay"
+func (prc *PipelineRunsController) Replay(c *gin.Context) {
+	pipelineRun := pipeline.Run{}
+	err := pipelineRun.SetID(c.Param("runID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// the body is optional
+	var opts pipeline.ReplayOptions
+	if err = json.NewDecoder(c.Request.Body).Decode(&opts); err != nil && !errors.Is(err, io.EOF) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "failed to unmarshal JSON body"))
+		return
+	}
+
+	result, err := prc.App.ReplayJobRunV2(c.Request.Context(), pipelineRun.ID, opts)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("pipeline run not found"))
+		return
+	} else if errors.Is(err, pipeline.ErrInvalidReplayOptions) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	prc.App.GetAuditLogger().Audit(audit.JobRunReplayed, map[string]interface{}{"runID": pipelineRun.ID, "refetch": opts.Refetch})
+	jsonAPIResponse(c, presenters.NewPipelineRunReplayResource(result, prc.App.GetLogger()), "pipelineRunReplay")
+}
+
+// Archived returns the archived pipeline runs, optionally filtered by job and by the first and last
+// day they finished on.
+// Example:
+// "GET <application>/pipeline/archive/runs?jobID=1&from=2023-08-01&to=2023-08-31"
+func (prc *PipelineRunsController) Archived(c *gin.Context) {
+	filter, err := parseArchiveFilter(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	runs, err := prc.App.ArchivedJobRunsV2(filter)
+	if errors.Is(err, pipeline.ErrArchiveDisabled) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewPipelineRunResources(runs, prc.App.GetLogger()), "pipelineRun")
+}
+
+// ImportArchived inserts the archived pipeline runs matching the filter back into the database.
+// Runs that are already stored, or whose pipeline spec no longer exists, are skipped.
+// Example:
+// "POST <application>/pipeline/archive/import?jobID=1&from=2023-08-01&to=2023-08-31"
+func (prc *PipelineRunsController) ImportArchived(c *gin.Context) {
+	filter, err := parseArchiveFilter(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	runs, err := prc.App.ImportArchivedJobRunsV2(filter)
+	if errors.Is(err, pipeline.ErrArchiveDisabled) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	prc.App.GetAuditLogger().Audit(audit.JobRunsImported, map[string]interface{}{
+		"jobID": filter.JobID,
+		"from":  c.Query("from"),
+		"to":    c.Query("to"),
+		"count": len(runs),
+	})
+	jsonAPIResponse(c, presenters.NewPipelineRunResources(runs, prc.App.GetLogger()), "pipelineRun")
+}
+
+func parseArchiveFilter(c *gin.Context) (filter pipeline.ArchiveFilter, err error) {
+	if jobID := c.Query("jobID"); jobID != "" {
+		id, err := strconv.ParseInt(jobID, 10, 32)
+		if err != nil {
+			return filter, errors.Wrap(err, "invalid jobID")
+		}
+		filter.JobID = int32(id)
+	}
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = time.Parse(pipeline.ArchiveDayLayout, from); err != nil {
+			return filter, errors.Wrap(err, "invalid from, expected YYYY-MM-DD")
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = time.Parse(pipeline.ArchiveDayLayout, to); err != nil {
+			return filter, errors.Wrap(err, "invalid to, expected YYYY-MM-DD")
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return filter, errors.New("to must not be before from")
+	}
+	return filter, nil
+}
+
 // Resume finishes a task and resumes the pipeline run.
 // Example:
 // "PATCH <application>/jobs/:ID/runs/:runID"
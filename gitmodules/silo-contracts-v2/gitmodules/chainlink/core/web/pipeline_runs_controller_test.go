@@ -233,6 +233,52 @@ func TestPipelineRunsController_Show_HappyPath(t *testing.T) {
 	require.Len(t, parsedResponse.TaskRuns, 8)
 }
 
+func TestPipelineRunsController_Replay(t *testing.T) {
+	client, _, runIDs := setupPipelineRunsControllerTests(t)
+
+	replay := func(t *testing.T, runID string, body string) *http.Response {
+		response, cleanup := client.Post("/v2/pipeline/runs/"+runID+"/replay", strings.NewReader(body))
+		t.Cleanup(cleanup)
+		return response
+	}
+
+	t.Run("replays a stored run", func(t *testing.T) {
+		response := replay(t, strconv.FormatInt(runIDs[0], 10), "")
+		cltest.AssertServerResponse(t, response, http.StatusOK)
+
+		var parsedResponse presenters.PipelineRunReplayResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &parsedResponse))
+		assert.Equal(t, strconv.FormatInt(runIDs[0], 10), parsedResponse.ID)
+		assert.Equal(t, 0, parsedResponse.Changed)
+		require.Len(t, parsedResponse.Tasks, 8)
+		for _, task := range parsedResponse.Tasks {
+			assert.True(t, task.OriginalRan, task.DotID)
+			assert.True(t, task.ReplayedRan, task.DotID)
+			assert.False(t, task.Substituted, task.DotID)
+		}
+	})
+
+	t.Run("rejects refetching tasks that do not fetch external data", func(t *testing.T) {
+		response := replay(t, strconv.FormatInt(runIDs[0], 10), `{"refetch": ["ds1"]}`)
+		cltest.AssertServerResponse(t, response, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		response := replay(t, strconv.FormatInt(runIDs[0], 10), `{"refetch": "ds1"`)
+		cltest.AssertServerResponse(t, response, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("unknown run", func(t *testing.T) {
+		response := replay(t, "1000000", "")
+		cltest.AssertServerResponse(t, response, http.StatusNotFound)
+	})
+
+	t.Run("invalid run ID", func(t *testing.T) {
+		response := replay(t, "invalid-run-ID", "")
+		cltest.AssertServerResponse(t, response, http.StatusUnprocessableEntity)
+	})
+}
+
 func TestPipelineRunsController_ShowRun_InvalidID(t *testing.T) {
 	t.Parallel()
 	app := cltest.NewApplicationEVMDisabled(t)
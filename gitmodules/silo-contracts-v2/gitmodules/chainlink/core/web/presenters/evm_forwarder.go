@@ -12,10 +12,15 @@ import (
 // EVMForwarderResource is an EVM forwarder JSONAPI resource.
 type EVMForwarderResource struct {
 	JAID
-	Address    common.Address `json:"address"`
-	EVMChainID utils.Big      `json:"evmChainId"`
-	CreatedAt  time.Time      `json:"createdAt"`
-	UpdatedAt  time.Time      `json:"updatedAt"`
+	Address            common.Address   `json:"address"`
+	EVMChainID         utils.Big        `json:"evmChainId"`
+	Type               string           `json:"type"`
+	NextHop            *common.Address  `json:"nextHop"`
+	StopRoutingOnDrift bool             `json:"stopRoutingOnDrift"`
+	DriftedSenders     []common.Address `json:"driftedSenders"`
+	HealthCheckedAt    *time.Time       `json:"healthCheckedAt"`
+	CreatedAt          time.Time        `json:"createdAt"`
+	UpdatedAt          time.Time        `json:"updatedAt"`
 }
 
 // GetName implements the api2go EntityNamer interface
@@ -26,10 +31,15 @@ func (r EVMForwarderResource) GetName() string {
 // NewEVMForwarderResource returns a new EVMForwarderResource for chain.
 func NewEVMForwarderResource(fwd forwarders.Forwarder) EVMForwarderResource {
 	return EVMForwarderResource{
-		JAID:       NewJAIDInt64(fwd.ID),
-		Address:    fwd.Address,
-		EVMChainID: fwd.EVMChainID,
-		CreatedAt:  fwd.CreatedAt,
-		UpdatedAt:  fwd.UpdatedAt,
+		JAID:               NewJAIDInt64(fwd.ID),
+		Address:            fwd.Address,
+		EVMChainID:         fwd.EVMChainID,
+		Type:               string(fwd.Type),
+		NextHop:            fwd.NextHop,
+		StopRoutingOnDrift: fwd.StopRoutingOnDrift,
+		DriftedSenders:     fwd.DriftedSenders,
+		HealthCheckedAt:    fwd.HealthCheckedAt,
+		CreatedAt:          fwd.CreatedAt,
+		UpdatedAt:          fwd.UpdatedAt,
 	}
 }
@@ -0,0 +1,50 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	"github.com/smartcontractkit/chainlink/v2/core/null"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// EVMReorgResource is an EVM reorg JSONAPI resource.
+type EVMReorgResource struct {
+	JAID
+	EVMChainID           utils.Big     `json:"evmChainId"`
+	PrevHeadNumber       int64         `json:"prevHeadNumber"`
+	PrevHeadHash         common.Hash   `json:"prevHeadHash"`
+	NewHeadNumber        int64         `json:"newHeadNumber"`
+	NewHeadHash          common.Hash   `json:"newHeadHash"`
+	CommonAncestorNumber null.Int64    `json:"commonAncestorNumber"`
+	CommonAncestorHash   *common.Hash  `json:"commonAncestorHash"`
+	Depth                int64         `json:"depth"`
+	DroppedHashes        []common.Hash `json:"droppedHashes"`
+	NewHashes            []common.Hash `json:"newHashes"`
+	CreatedAt            time.Time     `json:"createdAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r EVMReorgResource) GetName() string {
+	return "evm_reorg"
+}
+
+// NewEVMReorgResource returns a new EVMReorgResource for reorg.
+func NewEVMReorgResource(reorg headtracker.Reorg) EVMReorgResource {
+	return EVMReorgResource{
+		JAID:                 NewJAIDInt64(reorg.ID),
+		EVMChainID:           reorg.EVMChainID,
+		PrevHeadNumber:       reorg.PrevHeadNumber,
+		PrevHeadHash:         reorg.PrevHeadHash,
+		NewHeadNumber:        reorg.NewHeadNumber,
+		NewHeadHash:          reorg.NewHeadHash,
+		CommonAncestorNumber: reorg.CommonAncestorNumber,
+		CommonAncestorHash:   reorg.CommonAncestorHash,
+		Depth:                reorg.Depth,
+		DroppedHashes:        reorg.DroppedHashes,
+		NewHashes:            reorg.NewHashes,
+		CreatedAt:            reorg.CreatedAt,
+	}
+}
@@ -37,19 +37,24 @@ const (
 	BlockHeaderFeederJobSpec JobSpecType = "blockheaderfeeder"
 	BootstrapJobSpec         JobSpecType = "bootstrap"
 	GatewayJobSpec           JobSpecType = "gateway"
+	EVMLogJobSpec            JobSpecType = "evmlog"
 )
 
 // DirectRequestSpec defines the spec details of a DirectRequest Job
 type DirectRequestSpec struct {
-	ContractAddress             ethkey.EIP55Address      `json:"contractAddress"`
-	MinIncomingConfirmations    clnull.Uint32            `json:"minIncomingConfirmations"`
-	MinIncomingConfirmationsEnv bool                     `json:"minIncomingConfirmationsEnv,omitempty"`
-	MinContractPayment          *assets.Link             `json:"minContractPaymentLinkJuels"`
-	Requesters                  models.AddressCollection `json:"requesters"`
-	Initiator                   string                   `json:"initiator"`
-	CreatedAt                   time.Time                `json:"createdAt"`
-	UpdatedAt                   time.Time                `json:"updatedAt"`
-	EVMChainID                  *utils.Big               `json:"evmChainID"`
+	ContractAddress                ethkey.EIP55Address      `json:"contractAddress"`
+	MinIncomingConfirmations       clnull.Uint32            `json:"minIncomingConfirmations"`
+	MinIncomingConfirmationsEnv    bool                     `json:"minIncomingConfirmationsEnv,omitempty"`
+	MinContractPayment             *assets.Link             `json:"minContractPaymentLinkJuels"`
+	Requesters                     models.AddressCollection `json:"requesters"`
+	Initiator                      string                   `json:"initiator"`
+	CreatedAt                      time.Time                `json:"createdAt"`
+	UpdatedAt                      time.Time                `json:"updatedAt"`
+	EVMChainID                     *utils.Big               `json:"evmChainID"`
+	BatchFulfillmentEnabled        bool                     `json:"batchFulfillmentEnabled"`
+	BatchFulfillmentWindow         models.Interval          `json:"batchFulfillmentWindow"`
+	BatchFulfillmentMaxSize        uint32                   `json:"batchFulfillmentMaxSize"`
+	BatchFulfillmentUseBatchMethod bool                     `json:"batchFulfillmentUseBatchMethod"`
 }
 
 // NewDirectRequestSpec initializes a new DirectRequestSpec from a
@@ -63,10 +68,14 @@ func NewDirectRequestSpec(spec *job.DirectRequestSpec) *DirectRequestSpec {
 		Requesters:                  spec.Requesters,
 		// This is hardcoded to runlog. When we support other initiators, we need
 		// to change this
-		Initiator:  "runlog",
-		CreatedAt:  spec.CreatedAt,
-		UpdatedAt:  spec.UpdatedAt,
-		EVMChainID: spec.EVMChainID,
+		Initiator:                      "runlog",
+		CreatedAt:                      spec.CreatedAt,
+		UpdatedAt:                      spec.UpdatedAt,
+		EVMChainID:                     spec.EVMChainID,
+		BatchFulfillmentEnabled:        spec.BatchFulfillmentEnabled,
+		BatchFulfillmentWindow:         spec.BatchFulfillmentWindow,
+		BatchFulfillmentMaxSize:        spec.BatchFulfillmentMaxSize,
+		BatchFulfillmentUseBatchMethod: spec.BatchFulfillmentUseBatchMethod,
 	}
 }
 
@@ -86,6 +95,8 @@ type FluxMonitorSpec struct {
 	CreatedAt           time.Time           `json:"createdAt"`
 	UpdatedAt           time.Time           `json:"updatedAt"`
 	EVMChainID          *utils.Big          `json:"evmChainID"`
+	// DeviationRule is only set if the spec selects a deviation rule
+	DeviationRule *job.FluxMonitorDeviationRule `json:"deviationRule,omitempty"`
 }
 
 // NewFluxMonitorSpec initializes a new DirectFluxMonitorSpec from a
@@ -115,6 +126,7 @@ func NewFluxMonitorSpec(spec *job.FluxMonitorSpec) *FluxMonitorSpec {
 		CreatedAt:           spec.CreatedAt,
 		UpdatedAt:           spec.UpdatedAt,
 		EVMChainID:          spec.EVMChainID,
+		DeviationRule:       spec.DeviationRule,
 	}
 }
 
@@ -269,15 +281,21 @@ func NewWebhookSpec(spec *job.WebhookSpec) *WebhookSpec {
 
 // CronSpec defines the spec details of a Cron Job
 type CronSpec struct {
-	CronSchedule string    `json:"schedule" tom:"schedule"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	CronSchedule string          `json:"schedule" tom:"schedule"`
+	TimeZone     string          `json:"timeZone"`
+	Jitter       models.Interval `json:"jitter"`
+	CatchUp      job.CronCatchUp `json:"catchUp"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
 }
 
 // NewCronSpec generates a new CronSpec from a job.CronSpec
 func NewCronSpec(spec *job.CronSpec) *CronSpec {
 	return &CronSpec{
 		CronSchedule: spec.CronSchedule,
+		TimeZone:     spec.TimeZone,
+		Jitter:       spec.Jitter,
+		CatchUp:      spec.CatchUp,
 		CreatedAt:    spec.CreatedAt,
 		UpdatedAt:    spec.UpdatedAt,
 	}
@@ -439,6 +457,36 @@ func NewGatewaySpec(spec *job.GatewaySpec) *GatewaySpec {
 	}
 }
 
+// EVMLogSpec defines the job parameters for an EVM log job.
+type EVMLogSpec struct {
+	EVMChainID       *utils.Big            `json:"evmChainID"`
+	ContractAddress  ethkey.EIP55Address   `json:"contractAddress"`
+	EventABI         string                `json:"eventABI"`
+	Topic1           job.EVMLogTopicValues `json:"topic1"`
+	Topic2           job.EVMLogTopicValues `json:"topic2"`
+	Topic3           job.EVMLogTopicValues `json:"topic3"`
+	MinConfirmations uint32                `json:"minConfirmations"`
+	PollPeriod       models.Interval       `json:"pollPeriod"`
+	CreatedAt        time.Time             `json:"createdAt"`
+	UpdatedAt        time.Time             `json:"updatedAt"`
+}
+
+// NewEVMLogSpec creates a new EVMLogSpec for the given parameters.
+func NewEVMLogSpec(spec *job.EVMLogSpec) *EVMLogSpec {
+	return &EVMLogSpec{
+		EVMChainID:       spec.EVMChainID,
+		ContractAddress:  spec.ContractAddress,
+		EventABI:         spec.EventABI,
+		Topic1:           spec.Topic1,
+		Topic2:           spec.Topic2,
+		Topic3:           spec.Topic3,
+		MinConfirmations: spec.MinConfirmations,
+		PollPeriod:       models.Interval(spec.PollPeriod),
+		CreatedAt:        spec.CreatedAt,
+		UpdatedAt:        spec.UpdatedAt,
+	}
+}
+
 // JobError represents errors on the job
 type JobError struct {
 	ID          int64     `json:"id"`
@@ -466,6 +514,8 @@ type JobResource struct {
 	SchemaVersion          uint32                  `json:"schemaVersion"`
 	GasLimit               clnull.Uint32           `json:"gasLimit"`
 	ForwardingAllowed      bool                    `json:"forwardingAllowed"`
+	Paused                 bool                    `json:"paused"`
+	JobTemplateID          *int32                  `json:"jobTemplateID"`
 	MaxTaskDuration        models.Interval         `json:"maxTaskDuration"`
 	ExternalJobID          uuid.UUID               `json:"externalJobID"`
 	DirectRequestSpec      *DirectRequestSpec      `json:"directRequestSpec"`
@@ -480,10 +530,28 @@ type JobResource struct {
 	BlockHeaderFeederSpec  *BlockHeaderFeederSpec  `json:"blockHeaderFeederSpec"`
 	BootstrapSpec          *BootstrapSpec          `json:"bootstrapSpec"`
 	GatewaySpec            *GatewaySpec            `json:"gatewaySpec"`
+	EVMLogSpec             *EVMLogSpec             `json:"evmLogSpec"`
 	PipelineSpec           PipelineSpec            `json:"pipelineSpec"`
+	ShadowPipelineSpec     *PipelineSpec           `json:"shadowPipelineSpec"`
+	Triggers               []JobTrigger            `json:"triggers,omitempty"`
 	Errors                 []JobError              `json:"errors"`
 }
 
+// JobTrigger is a downstream webhook job run when a run of the job finishes with an outcome
+// matching the condition.
+type JobTrigger struct {
+	ExternalJobID uuid.UUID            `json:"externalJobID"`
+	Condition     job.TriggerCondition `json:"condition"`
+}
+
+// NewJobTrigger generates a JobTrigger from a job.Trigger
+func NewJobTrigger(t job.Trigger) JobTrigger {
+	return JobTrigger{
+		ExternalJobID: t.DownstreamExternalJobID,
+		Condition:     t.Condition,
+	}
+}
+
 // NewJobResource initializes a new JSONAPI job resource
 func NewJobResource(j job.Job) *JobResource {
 	resource := &JobResource{
@@ -493,10 +561,19 @@ func NewJobResource(j job.Job) *JobResource {
 		SchemaVersion:     j.SchemaVersion,
 		GasLimit:          j.GasLimit,
 		ForwardingAllowed: j.ForwardingAllowed,
+		Paused:            j.Paused,
+		JobTemplateID:     j.JobTemplateID,
 		MaxTaskDuration:   j.MaxTaskDuration,
 		PipelineSpec:      NewPipelineSpec(j.PipelineSpec),
 		ExternalJobID:     j.ExternalJobID,
 	}
+	if j.ShadowPipelineSpec != nil {
+		shadow := NewPipelineSpec(j.ShadowPipelineSpec)
+		resource.ShadowPipelineSpec = &shadow
+	}
+	for _, t := range j.Triggers {
+		resource.Triggers = append(resource.Triggers, NewJobTrigger(t))
+	}
 
 	switch j.Type {
 	case job.DirectRequest:
@@ -523,6 +600,8 @@ func NewJobResource(j job.Job) *JobResource {
 		resource.BootstrapSpec = NewBootstrapSpec(j.BootstrapSpec)
 	case job.Gateway:
 		resource.GatewaySpec = NewGatewaySpec(j.GatewaySpec)
+	case job.EVMLog:
+		resource.EVMLogSpec = NewEVMLogSpec(j.EVMLogSpec)
 	}
 
 	jes := []JobError{}
@@ -538,3 +617,162 @@ func NewJobResource(j job.Job) *JobResource {
 func (r JobResource) GetName() string {
 	return "jobs"
 }
+
+// JobSpecVersionResource represents a recorded version of the TOML definition of a job
+type JobSpecVersionResource struct {
+	JAID
+	Version       int32     `json:"version"`
+	ExternalJobID uuid.UUID `json:"externalJobID"`
+	Definition    string    `json:"definition"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// NewJobSpecVersionResource initializes a new JSONAPI job spec version resource
+func NewJobSpecVersionResource(v job.SpecVersion) *JobSpecVersionResource {
+	return &JobSpecVersionResource{
+		JAID:          NewJAIDInt32(v.Version),
+		Version:       v.Version,
+		ExternalJobID: v.ExternalJobID,
+		Definition:    v.Definition,
+		CreatedAt:     v.CreatedAt,
+	}
+}
+
+// NewJobSpecVersionResources initializes a slice of JSONAPI job spec version resources
+func NewJobSpecVersionResources(vs []job.SpecVersion) []JobSpecVersionResource {
+	rs := []JobSpecVersionResource{}
+	for _, v := range vs {
+		rs = append(rs, *NewJobSpecVersionResource(v))
+	}
+
+	return rs
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobSpecVersionResource) GetName() string {
+	return "jobSpecVersions"
+}
+
+// JobShadowReportResource compares the results of the shadow runs of a job to those of the live
+// runs. The ID is the ID of the job.
+type JobShadowReportResource struct {
+	JAID
+	ShadowPipelineSpecID int32   `json:"shadowPipelineSpecID"`
+	Runs                 int     `json:"runs"`
+	LiveErrors           int     `json:"liveErrors"`
+	ShadowErrors         int     `json:"shadowErrors"`
+	LiveErrorRate        float64 `json:"liveErrorRate"`
+	ShadowErrorRate      float64 `json:"shadowErrorRate"`
+	Compared             int     `json:"compared"`
+	MeanDeviation        string  `json:"meanDeviation"`
+	MaxDeviation         string  `json:"maxDeviation"`
+}
+
+// NewJobShadowReportResource initializes a new JSONAPI job shadow report resource
+func NewJobShadowReportResource(jobID int32, shadowPipelineSpecID int32, r pipeline.ShadowReport) *JobShadowReportResource {
+	return &JobShadowReportResource{
+		JAID:                 NewJAIDInt32(jobID),
+		ShadowPipelineSpecID: shadowPipelineSpecID,
+		Runs:                 r.Runs,
+		LiveErrors:           r.LiveErrors,
+		ShadowErrors:         r.ShadowErrors,
+		LiveErrorRate:        r.LiveErrorRate(),
+		ShadowErrorRate:      r.ShadowErrorRate(),
+		Compared:             r.Compared,
+		MeanDeviation:        r.MeanDeviation.String(),
+		MaxDeviation:         r.MaxDeviation.String(),
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobShadowReportResource) GetName() string {
+	return "jobShadowReports"
+}
+
+// JobTemplateResource represents a version of a parameterized job spec
+type JobTemplateResource struct {
+	JAID
+	Name       string                 `json:"name"`
+	Version    int32                  `json:"version"`
+	Definition string                 `json:"definition"`
+	Parameters job.TemplateParameters `json:"parameters"`
+	CreatedAt  time.Time              `json:"createdAt"`
+}
+
+// NewJobTemplateResource initializes a new JSONAPI job template resource
+func NewJobTemplateResource(t job.Template) *JobTemplateResource {
+	return &JobTemplateResource{
+		JAID:       NewJAIDInt32(t.ID),
+		Name:       t.Name,
+		Version:    t.Version,
+		Definition: t.Definition,
+		Parameters: t.Parameters,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// NewJobTemplateResources initializes a slice of JSONAPI job template resources
+func NewJobTemplateResources(ts []job.Template) []JobTemplateResource {
+	rs := []JobTemplateResource{}
+	for _, t := range ts {
+		rs = append(rs, *NewJobTemplateResource(t))
+	}
+
+	return rs
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobTemplateResource) GetName() string {
+	return "jobTemplates"
+}
+
+// JobTemplateJobResource represents a job created from a version of a template. The ID is the
+// ID of the job.
+type JobTemplateJobResource struct {
+	JAID
+	Name            string `json:"name"`
+	TemplateVersion int32  `json:"templateVersion"`
+	// Outdated is whether the job was created from a previous version of the template
+	Outdated bool `json:"outdated"`
+}
+
+// NewJobTemplateJobResources initializes a slice of JSONAPI resources for the jobs created from
+// a template, whose latest version is latestVersion
+func NewJobTemplateJobResources(jobs []job.TemplateJob, latestVersion int32) []JobTemplateJobResource {
+	rs := []JobTemplateJobResource{}
+	for _, j := range jobs {
+		rs = append(rs, JobTemplateJobResource{
+			JAID:            NewJAIDInt32(j.JobID),
+			Name:            j.JobName.ValueOrZero(),
+			TemplateVersion: j.TemplateVersion,
+			Outdated:        j.TemplateVersion < latestVersion,
+		})
+	}
+
+	return rs
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobTemplateJobResource) GetName() string {
+	return "jobTemplateJobs"
+}
+
+// WebhookSecretResource represents the secret requests running a webhook job are signed with.
+// The ID is the ID of the job.
+type WebhookSecretResource struct {
+	JAID
+	Secret string `json:"secret"`
+}
+
+// NewWebhookSecretResource initializes a new JSONAPI webhook secret resource
+func NewWebhookSecretResource(jobID int32, secret string) *WebhookSecretResource {
+	return &WebhookSecretResource{
+		JAID:   NewJAIDInt32(jobID),
+		Secret: secret,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r WebhookSecretResource) GetName() string {
+	return "webhookSecrets"
+}
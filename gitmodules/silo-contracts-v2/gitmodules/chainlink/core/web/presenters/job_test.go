@@ -110,13 +110,18 @@ func TestJob(t *testing.T) {
 							"initiator": "runlog",
 							"createdAt":"2000-01-01T00:00:00Z",
 							"updatedAt":"2000-01-01T00:00:00Z",
-							"evmChainID": "42"
+							"evmChainID": "42",
+							"batchFulfillmentEnabled": false,
+							"batchFulfillmentWindow": "0s",
+							"batchFulfillmentMaxSize": 0,
+							"batchFulfillmentUseBatchMethod": false
 						},
 						"offChainReportingOracleSpec": null,
 						"offChainReporting2OracleSpec": null,
 						"fluxMonitorSpec": null,
 						"gasLimit": 1000,
 						"forwardingAllowed": false,
+						"paused": false,
 						"keeperSpec": null,
                         "cronSpec": null,
                         "vrfSpec": null,
@@ -190,6 +195,7 @@ func TestJob(t *testing.T) {
 						},
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"offChainReportingOracleSpec": null,
 						"offChainReporting2OracleSpec": null,
 						"directRequestSpec": null,
@@ -280,6 +286,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": 123,
 						"forwardingAllowed": true,
+						"paused": false,
 						"directRequestSpec": null,
 						"keeperSpec": null,
                         "cronSpec": null,
@@ -341,6 +348,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"directRequestSpec": null,
 						"cronSpec": null,
 						"webhookSpec": null,
@@ -363,6 +371,8 @@ func TestJob(t *testing.T) {
 				ID: 1,
 				CronSpec: &job.CronSpec{
 					CronSchedule: cronSchedule,
+					Jitter:       models.Interval(10 * time.Second),
+					CatchUp:      job.CronCatchUpRunOnce,
 					CreatedAt:    timestamp,
 					UpdatedAt:    timestamp,
 				},
@@ -394,12 +404,16 @@ func TestJob(t *testing.T) {
                         },
                         "cronSpec": {
                             "schedule": "%s",
+                            "timeZone": "",
+                            "jitter": "10s",
+                            "catchUp": "run-once",
                             "createdAt":"2000-01-01T00:00:00Z",
                             "updatedAt":"2000-01-01T00:00:00Z"
                         },
                         "fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
                         "directRequestSpec": null,
                         "keeperSpec": null,
                         "offChainReportingOracleSpec": null,
@@ -456,6 +470,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"directRequestSpec": null,
 						"keeperSpec": null,
 						"cronSpec": null,
@@ -514,6 +529,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"cronSpec": null,
 						"offChainReportingOracleSpec": null,
 						"offChainReporting2OracleSpec": null,
@@ -593,6 +609,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"cronSpec": null,
 						"offChainReportingOracleSpec": null,
 						"offChainReporting2OracleSpec": null,
@@ -663,6 +680,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"cronSpec": null,
 						"offChainReportingOracleSpec": null,
 						"offChainReporting2OracleSpec": null,
@@ -727,6 +745,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"cronSpec": null,
 						"offChainReportingOracleSpec": null,
 						"offChainReporting2OracleSpec": null,
@@ -811,6 +830,7 @@ func TestJob(t *testing.T) {
 						"fluxMonitorSpec": null,
 						"gasLimit": null,
 						"forwardingAllowed": false,
+						"paused": false,
 						"directRequestSpec": null,
 						"cronSpec": null,
 						"webhookSpec": null,
@@ -103,3 +103,79 @@ func NewPipelineRunResources(prs []pipeline.Run, lggr logger.Logger) []PipelineR
 
 	return out
 }
+
+// PipelineRunReplayResource is the outcome of replaying a stored pipeline run. The ID is the ID of
+// the stored run.
+type PipelineRunReplayResource struct {
+	JAID
+	Outputs     []*string                       `json:"outputs"`
+	FatalErrors []*string                       `json:"fatalErrors"`
+	Changed     int                             `json:"changed"`
+	Tasks       []PipelineRunReplayTaskResource `json:"tasks"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r PipelineRunReplayResource) GetName() string {
+	return "pipelineRunReplay"
+}
+
+// PipelineRunReplayTaskResource compares the output of a task in the original and replayed run.
+type PipelineRunReplayTaskResource struct {
+	DotID          string            `json:"dotId"`
+	Type           pipeline.TaskType `json:"type"`
+	Substituted    bool              `json:"substituted"`
+	Changed        bool              `json:"changed"`
+	OriginalRan    bool              `json:"originalRan"`
+	OriginalOutput *string           `json:"originalOutput"`
+	OriginalError  *string           `json:"originalError"`
+	ReplayedRan    bool              `json:"replayedRan"`
+	ReplayedOutput *string           `json:"replayedOutput"`
+	ReplayedError  *string           `json:"replayedError"`
+}
+
+func NewPipelineRunReplayResource(rr pipeline.ReplayResult, lggr logger.Logger) PipelineRunReplayResource {
+	lggr = lggr.Named("PipelineRunReplayResource")
+	outputs, err := rr.Run.StringOutputs()
+	if err != nil {
+		lggr.Errorw(err.Error(), "out", rr.Run.Outputs)
+	}
+
+	tasks := []PipelineRunReplayTaskResource{}
+	for _, t := range rr.Tasks {
+		task := PipelineRunReplayTaskResource{
+			DotID:       t.DotID,
+			Type:        t.Type,
+			Substituted: t.Substituted,
+			Changed:     t.Changed,
+		}
+		if t.Original != nil {
+			task.OriginalRan = true
+			task.OriginalOutput, task.OriginalError = replayTaskOutputStrings(*t.Original)
+		}
+		if t.Replayed != nil {
+			task.ReplayedRan = true
+			task.ReplayedOutput, task.ReplayedError = replayTaskOutputStrings(*t.Replayed)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return PipelineRunReplayResource{
+		JAID:        NewJAIDInt64(rr.OriginalRunID),
+		Outputs:     outputs,
+		FatalErrors: rr.Run.StringFatalErrors(),
+		Changed:     rr.Changed(),
+		Tasks:       tasks,
+	}
+}
+
+func replayTaskOutputStrings(o pipeline.ReplayTaskOutput) (output *string, errString *string) {
+	if o.Output.Valid {
+		outputBytes, _ := o.Output.MarshalJSON()
+		outputStr := string(outputBytes)
+		output = &outputStr
+	}
+	if o.Error.Valid {
+		errString = &o.Error.String
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package presenters
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// SubpipelineResource represents a version of a subpipeline JSONAPI resource.
+type SubpipelineResource struct {
+	JAID
+	Name         string    `json:"name"`
+	Version      int32     `json:"version"`
+	DotDagSource string    `json:"dotDagSource"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r SubpipelineResource) GetName() string {
+	return "subpipelines"
+}
+
+// NewSubpipelineResource constructs a new SubpipelineResource. The ID is the name and version,
+// e.g. median_price@2.
+func NewSubpipelineResource(s pipeline.Subpipeline) SubpipelineResource {
+	return SubpipelineResource{
+		JAID:         NewJAID(fmt.Sprintf("%s@%d", s.Name, s.Version)),
+		Name:         s.Name,
+		Version:      s.Version,
+		DotDagSource: s.DotDagSource,
+		CreatedAt:    s.CreatedAt,
+	}
+}
+
+// NewSubpipelineResources constructs a slice of SubpipelineResources.
+func NewSubpipelineResources(subpipelines []pipeline.Subpipeline) []SubpipelineResource {
+	rs := []SubpipelineResource{}
+	for _, s := range subpipelines {
+		rs = append(rs, NewSubpipelineResource(s))
+	}
+	return rs
+}
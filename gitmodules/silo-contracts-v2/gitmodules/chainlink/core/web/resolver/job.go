@@ -91,6 +91,11 @@ func (r *JobResolver) ForwardingAllowed() *bool {
 	return &r.j.ForwardingAllowed
 }
 
+// Paused resolves whether the job is paused.
+func (r *JobResolver) Paused() bool {
+	return r.j.Paused
+}
+
 // Type resolves the job's type.
 func (r *JobResolver) Type() string {
 	return string(r.j.Type)
@@ -258,3 +263,73 @@ func NewDeleteJobSuccess(app chainlink.Application, job *job.Job) *DeleteJobSucc
 func (r *DeleteJobSuccessResolver) Job() *JobResolver {
 	return NewJob(r.app, *r.j)
 }
+
+// -- PauseJob Mutation --
+
+type PauseJobPayloadResolver struct {
+	app chainlink.Application
+	j   *job.Job
+	NotFoundErrorUnionType
+}
+
+func NewPauseJobPayload(app chainlink.Application, j *job.Job, err error) *PauseJobPayloadResolver {
+	e := NotFoundErrorUnionType{err: err, message: "job not found"}
+
+	return &PauseJobPayloadResolver{app: app, j: j, NotFoundErrorUnionType: e}
+}
+
+func (r *PauseJobPayloadResolver) ToPauseJobSuccess() (*PauseJobSuccessResolver, bool) {
+	if r.j == nil {
+		return nil, false
+	}
+
+	return NewPauseJobSuccess(r.app, r.j), true
+}
+
+type PauseJobSuccessResolver struct {
+	app chainlink.Application
+	j   *job.Job
+}
+
+func NewPauseJobSuccess(app chainlink.Application, job *job.Job) *PauseJobSuccessResolver {
+	return &PauseJobSuccessResolver{app: app, j: job}
+}
+
+func (r *PauseJobSuccessResolver) Job() *JobResolver {
+	return NewJob(r.app, *r.j)
+}
+
+// -- ResumeJob Mutation --
+
+type ResumeJobPayloadResolver struct {
+	app chainlink.Application
+	j   *job.Job
+	NotFoundErrorUnionType
+}
+
+func NewResumeJobPayload(app chainlink.Application, j *job.Job, err error) *ResumeJobPayloadResolver {
+	e := NotFoundErrorUnionType{err: err, message: "job not found"}
+
+	return &ResumeJobPayloadResolver{app: app, j: j, NotFoundErrorUnionType: e}
+}
+
+func (r *ResumeJobPayloadResolver) ToResumeJobSuccess() (*ResumeJobSuccessResolver, bool) {
+	if r.j == nil {
+		return nil, false
+	}
+
+	return NewResumeJobSuccess(r.app, r.j), true
+}
+
+type ResumeJobSuccessResolver struct {
+	app chainlink.Application
+	j   *job.Job
+}
+
+func NewResumeJobSuccess(app chainlink.Application, job *job.Job) *ResumeJobSuccessResolver {
+	return &ResumeJobSuccessResolver{app: app, j: job}
+}
+
+func (r *ResumeJobSuccessResolver) Job() *JobResolver {
+	return NewJob(r.app, *r.j)
+}
@@ -3,6 +3,7 @@ package resolver
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains"
@@ -325,6 +327,7 @@ func TestResolver_CreateJob(t *testing.T) {
 	}
 	jb, err := directrequest.ValidatedDirectRequestSpec(testspecs.DirectRequestSpec)
 	assert.NoError(t, err)
+	jb.Definition = testspecs.DirectRequestSpec
 
 	d, err := json.Marshal(map[string]interface{}{
 		"createJob": map[string]interface{}{
@@ -559,3 +562,106 @@ func TestResolver_DeleteJob(t *testing.T) {
 
 	RunGQLTests(t, testCases)
 }
+
+func TestResolver_PauseJob(t *testing.T) {
+	t.Parallel()
+
+	testSetJobPaused(t, "pauseJob", "PauseJobSuccess", "PauseJob", true)
+}
+
+func TestResolver_ResumeJob(t *testing.T) {
+	t.Parallel()
+
+	testSetJobPaused(t, "resumeJob", "ResumeJobSuccess", "ResumePausedJob", false)
+}
+
+func testSetJobPaused(t *testing.T, mutationName, successType, appMethod string, paused bool) {
+	id := int32(123)
+	mutation := fmt.Sprintf(`
+		mutation SetJobPaused($id: ID!) {
+			%s(id: $id) {
+				... on %s {
+					job {
+						id
+						name
+						paused
+					}
+				}
+				... on NotFoundError {
+					code
+					message
+				}
+			}
+		}`, mutationName, successType)
+	variables := map[string]interface{}{
+		"id": "123",
+	}
+	d, err := json.Marshal(map[string]interface{}{
+		mutationName: map[string]interface{}{
+			"job": map[string]interface{}{
+				"id":     "123",
+				"name":   "test-job",
+				"paused": paused,
+			},
+		},
+	})
+	require.NoError(t, err)
+	gError := errors.New("error")
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: mutation, variables: variables}, mutationName),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On(appMethod, mock.Anything, id).Return(nil)
+				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
+					ID:     id,
+					Name:   null.StringFrom("test-job"),
+					Paused: paused,
+				}, nil)
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+			},
+			query:     mutation,
+			variables: variables,
+			result:    string(d),
+		},
+		{
+			name:          "not found",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On(appMethod, mock.Anything, id).Return(sql.ErrNoRows)
+			},
+			query:     mutation,
+			variables: variables,
+			result: fmt.Sprintf(`
+				{
+					"%s": {
+						"code": "NOT_FOUND",
+						"message": "job not found"
+					}
+				}
+			`, mutationName),
+		},
+		{
+			name:          "generic error",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On(appMethod, mock.Anything, id).Return(gError)
+			},
+			query:     mutation,
+			variables: variables,
+			result:    `null`,
+			errors: []*gqlerrors.QueryError{
+				{
+					Extensions:    nil,
+					ResolverError: gError,
+					Path:          []interface{}{mutationName},
+					Message:       gError.Error(),
+				},
+			},
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
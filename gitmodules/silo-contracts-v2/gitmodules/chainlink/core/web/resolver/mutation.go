@@ -22,6 +22,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/cron"
 	"github.com/smartcontractkit/chainlink/v2/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/evmlog"
 	"github.com/smartcontractkit/chainlink/v2/core/services/feeds"
 	"github.com/smartcontractkit/chainlink/v2/core/services/fluxmonitorv2"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
@@ -1040,6 +1041,8 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 		jb, err = ocrbootstrap.ValidatedBootstrapSpecToml(args.Input.TOML)
 	case job.Gateway:
 		jb, err = gateway.ValidatedGatewaySpec(args.Input.TOML)
+	case job.EVMLog:
+		jb, err = evmlog.ValidatedEVMLogSpec(args.Input.TOML)
 	default:
 		return NewCreateJobPayload(r.App, nil, map[string]string{
 			"Job Type": fmt.Sprintf("unknown job type: %s", jbt),
@@ -1048,6 +1051,7 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 	if err != nil {
 		return nil, err
 	}
+	jb.Definition = args.Input.TOML
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -1097,6 +1101,66 @@ func (r *Resolver) DeleteJob(ctx context.Context, args struct {
 	return NewDeleteJobPayload(r.App, &j, nil), nil
 }
 
+func (r *Resolver) PauseJob(ctx context.Context, args struct {
+	ID graphql.ID
+}) (*PauseJobPayloadResolver, error) {
+	if err := authenticateUserCanEdit(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := stringutils.ToInt32(string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.App.PauseJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NewPauseJobPayload(r.App, nil, err), nil
+		}
+
+		return nil, err
+	}
+
+	j, err := r.App.JobORM().FindJobWithoutSpecErrors(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.App.GetAuditLogger().Audit(audit.JobPaused, map[string]interface{}{"id": args.ID})
+	return NewPauseJobPayload(r.App, &j, nil), nil
+}
+
+func (r *Resolver) ResumeJob(ctx context.Context, args struct {
+	ID graphql.ID
+}) (*ResumeJobPayloadResolver, error) {
+	if err := authenticateUserCanEdit(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := stringutils.ToInt32(string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.App.ResumePausedJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NewResumeJobPayload(r.App, nil, err), nil
+		}
+
+		return nil, err
+	}
+
+	j, err := r.App.JobORM().FindJobWithoutSpecErrors(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.App.GetAuditLogger().Audit(audit.JobResumed, map[string]interface{}{"id": args.ID})
+	return NewResumeJobPayload(r.App, &j, nil), nil
+}
+
 func (r *Resolver) DismissJobError(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DismissJobErrorPayloadResolver, error) {
@@ -117,6 +117,16 @@ func (r *SpecResolver) ToGatewaySpec() (*GatewaySpecResolver, bool) {
 	return &GatewaySpecResolver{spec: *r.j.GatewaySpec}, true
 }
 
+// ToEVMLogSpec returns the EVMLogSpec from the SpecResolver if the job is an
+// EVMLog job.
+func (r *SpecResolver) ToEVMLogSpec() (*EVMLogSpecResolver, bool) {
+	if r.j.Type != job.EVMLog {
+		return nil, false
+	}
+
+	return &EVMLogSpecResolver{spec: *r.j.EVMLogSpec}, true
+}
+
 type CronSpecResolver struct {
 	spec job.CronSpec
 }
@@ -125,6 +135,21 @@ func (r *CronSpecResolver) Schedule() string {
 	return r.spec.CronSchedule
 }
 
+// TimeZone resolves the spec's time zone, which is empty if it is set in the schedule.
+func (r *CronSpecResolver) TimeZone() string {
+	return r.spec.TimeZone
+}
+
+// Jitter resolves the spec's maximum random delay of scheduled runs.
+func (r *CronSpecResolver) Jitter() string {
+	return r.spec.Jitter.Duration().String()
+}
+
+// CatchUp resolves the spec's policy for missed runs.
+func (r *CronSpecResolver) CatchUp() string {
+	return string(r.spec.CatchUp)
+}
+
 // CreatedAt resolves the spec's created at timestamp.
 func (r *CronSpecResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.spec.CreatedAt}
@@ -134,6 +159,26 @@ type DirectRequestSpecResolver struct {
 	spec job.DirectRequestSpec
 }
 
+// BatchFulfillmentEnabled resolves whether the spec's requests are fulfilled in batches.
+func (r *DirectRequestSpecResolver) BatchFulfillmentEnabled() bool {
+	return r.spec.BatchFulfillmentEnabled
+}
+
+// BatchFulfillmentMaxSize resolves the spec's maximum number of requests fulfilled in a batch.
+func (r *DirectRequestSpecResolver) BatchFulfillmentMaxSize() int32 {
+	return int32(r.spec.BatchFulfillmentMaxSize)
+}
+
+// BatchFulfillmentUseBatchMethod resolves whether the spec's responses are submitted with the batched method of the Operator.
+func (r *DirectRequestSpecResolver) BatchFulfillmentUseBatchMethod() bool {
+	return r.spec.BatchFulfillmentUseBatchMethod
+}
+
+// BatchFulfillmentWindow resolves how long the spec's responses are accumulated before being fulfilled.
+func (r *DirectRequestSpecResolver) BatchFulfillmentWindow() string {
+	return r.spec.BatchFulfillmentWindow.Duration().String()
+}
+
 // ContractAddress resolves the spec's contract address.
 func (r *DirectRequestSpecResolver) ContractAddress() string {
 	return r.spec.ContractAddress.String()
@@ -204,6 +249,15 @@ func (r *FluxMonitorSpecResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.spec.CreatedAt}
 }
 
+// DeviationRule resolves the spec's deviation rule, if one is selected.
+func (r *FluxMonitorSpecResolver) DeviationRule() *FluxMonitorDeviationRuleResolver {
+	if r.spec.DeviationRule == nil {
+		return nil
+	}
+
+	return &FluxMonitorDeviationRuleResolver{rule: *r.spec.DeviationRule}
+}
+
 // AbsoluteThreshold resolves the spec's absolute threshold.
 func (r *FluxMonitorSpecResolver) DrumbeatEnabled() bool {
 	return r.spec.DrumbeatEnabled
@@ -275,6 +329,50 @@ func (r *FluxMonitorSpecResolver) Threshold() float64 {
 	return float64(r.spec.Threshold)
 }
 
+type FluxMonitorDeviationRuleResolver struct {
+	rule job.FluxMonitorDeviationRule
+}
+
+// DownThreshold resolves the rule's relative threshold of decreasing answers.
+func (r *FluxMonitorDeviationRuleResolver) DownThreshold() float64 {
+	return float64(r.rule.DownThreshold)
+}
+
+// MaxThreshold resolves the rule's maximum volatility-adapted threshold.
+func (r *FluxMonitorDeviationRuleResolver) MaxThreshold() float64 {
+	return float64(r.rule.MaxThreshold)
+}
+
+// MinSubmissionInterval resolves the rule's minimum time between submissions.
+func (r *FluxMonitorDeviationRuleResolver) MinSubmissionInterval() string {
+	return r.rule.MinSubmissionInterval.Duration().String()
+}
+
+// MinThreshold resolves the rule's minimum volatility-adapted threshold.
+func (r *FluxMonitorDeviationRuleResolver) MinThreshold() float64 {
+	return float64(r.rule.MinThreshold)
+}
+
+// Type resolves the rule's type.
+func (r *FluxMonitorDeviationRuleResolver) Type() string {
+	return r.rule.Type
+}
+
+// UpThreshold resolves the rule's relative threshold of increasing answers.
+func (r *FluxMonitorDeviationRuleResolver) UpThreshold() float64 {
+	return float64(r.rule.UpThreshold)
+}
+
+// VolatilityMultiplier resolves the rule's multiplier of the standard deviation of changes.
+func (r *FluxMonitorDeviationRuleResolver) VolatilityMultiplier() float64 {
+	return float64(r.rule.VolatilityMultiplier)
+}
+
+// VolatilityWindow resolves the rule's number of answers the volatility is computed over.
+func (r *FluxMonitorDeviationRuleResolver) VolatilityWindow() int32 {
+	return int32(r.rule.VolatilityWindow)
+}
+
 type KeeperSpecResolver struct {
 	spec job.KeeperSpec
 }
@@ -1029,3 +1127,69 @@ func (r *GatewaySpecResolver) GatewayConfig() gqlscalar.Map {
 func (r *GatewaySpecResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.spec.CreatedAt}
 }
+
+// EVMLogSpecResolver exposes the job parameters for an EVMLogSpec.
+type EVMLogSpecResolver struct {
+	spec job.EVMLogSpec
+}
+
+func (r *EVMLogSpecResolver) ID() graphql.ID {
+	return graphql.ID(stringutils.FromInt32(r.spec.ID))
+}
+
+// EVMChainID resolves the spec's evm chain id.
+func (r *EVMLogSpecResolver) EVMChainID() *string {
+	if r.spec.EVMChainID == nil {
+		return nil
+	}
+	chainID := r.spec.EVMChainID.String()
+	return &chainID
+}
+
+// ContractAddress resolves the spec's contract address.
+func (r *EVMLogSpecResolver) ContractAddress() string {
+	return r.spec.ContractAddress.String()
+}
+
+// EventABI resolves the spec's event signature.
+func (r *EVMLogSpecResolver) EventABI() string {
+	return r.spec.EventABI
+}
+
+// Topic1 resolves the values accepted for the first indexed argument.
+func (r *EVMLogSpecResolver) Topic1() []string {
+	return topicStrings(r.spec.Topic1)
+}
+
+// Topic2 resolves the values accepted for the second indexed argument.
+func (r *EVMLogSpecResolver) Topic2() []string {
+	return topicStrings(r.spec.Topic2)
+}
+
+// Topic3 resolves the values accepted for the third indexed argument.
+func (r *EVMLogSpecResolver) Topic3() []string {
+	return topicStrings(r.spec.Topic3)
+}
+
+// MinConfirmations resolves the spec's min confirmations.
+func (r *EVMLogSpecResolver) MinConfirmations() int32 {
+	return int32(r.spec.MinConfirmations)
+}
+
+// PollPeriod resolves the spec's poll period.
+func (r *EVMLogSpecResolver) PollPeriod() string {
+	return r.spec.PollPeriod.String()
+}
+
+// CreatedAt resolves the spec's created at timestamp.
+func (r *EVMLogSpecResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.spec.CreatedAt}
+}
+
+func topicStrings(values job.EVMLogTopicValues) []string {
+	topics := make([]string, len(values))
+	for i, v := range values {
+		topics[i] = v.String()
+	}
+	return topics
+}
@@ -36,7 +36,10 @@ func TestResolver_CronSpec(t *testing.T) {
 				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
 					Type: job.Cron,
 					CronSpec: &job.CronSpec{
-						CronSchedule: "CRON_TZ=UTC 0 0 1 1 *",
+						CronSchedule: "0 0 1 1 *",
+						TimeZone:     "UTC",
+						Jitter:       models.Interval(30 * time.Second),
+						CatchUp:      job.CronCatchUpRunAll,
 						CreatedAt:    f.Timestamp(),
 					},
 				}, nil)
@@ -49,6 +52,9 @@ func TestResolver_CronSpec(t *testing.T) {
 								__typename
 								... on CronSpec {
 									schedule
+									timeZone
+									jitter
+									catchUp
 									createdAt
 								}
 							}
@@ -61,7 +67,10 @@ func TestResolver_CronSpec(t *testing.T) {
 					"job": {
 						"spec": {
 							"__typename": "CronSpec",
-							"schedule": "CRON_TZ=UTC 0 0 1 1 *",
+							"schedule": "0 0 1 1 *",
+							"timeZone": "UTC",
+							"jitter": "30s",
+							"catchUp": "run-all",
 							"createdAt": "2021-01-01T00:00:00Z"
 						}
 					}
@@ -90,13 +99,17 @@ func TestResolver_DirectRequestSpec(t *testing.T) {
 				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
 					Type: job.DirectRequest,
 					DirectRequestSpec: &job.DirectRequestSpec{
-						ContractAddress:             contractAddress,
-						CreatedAt:                   f.Timestamp(),
-						EVMChainID:                  utils.NewBigI(42),
-						MinIncomingConfirmations:    clnull.NewUint32(1, true),
-						MinIncomingConfirmationsEnv: true,
-						MinContractPayment:          assets.NewLinkFromJuels(1000),
-						Requesters:                  models.AddressCollection{requesterAddress},
+						ContractAddress:                contractAddress,
+						CreatedAt:                      f.Timestamp(),
+						EVMChainID:                     utils.NewBigI(42),
+						MinIncomingConfirmations:       clnull.NewUint32(1, true),
+						MinIncomingConfirmationsEnv:    true,
+						MinContractPayment:             assets.NewLinkFromJuels(1000),
+						Requesters:                     models.AddressCollection{requesterAddress},
+						BatchFulfillmentEnabled:        true,
+						BatchFulfillmentWindow:         models.Interval(5 * time.Second),
+						BatchFulfillmentMaxSize:        20,
+						BatchFulfillmentUseBatchMethod: true,
 					},
 				}, nil)
 			},
@@ -107,6 +120,10 @@ func TestResolver_DirectRequestSpec(t *testing.T) {
 							spec {
 								__typename
 								... on DirectRequestSpec {
+									batchFulfillmentEnabled
+									batchFulfillmentMaxSize
+									batchFulfillmentUseBatchMethod
+									batchFulfillmentWindow
 									contractAddress
 									createdAt
 									evmChainID
@@ -125,6 +142,10 @@ func TestResolver_DirectRequestSpec(t *testing.T) {
 					"job": {
 						"spec": {
 							"__typename": "DirectRequestSpec",
+							"batchFulfillmentEnabled": true,
+							"batchFulfillmentMaxSize": 20,
+							"batchFulfillmentUseBatchMethod": true,
+							"batchFulfillmentWindow": "5s",
 							"contractAddress": "0x613a38AC1659769640aaE063C651F48E0250454C",
 							"createdAt": "2021-01-01T00:00:00Z",
 							"evmChainID": "42",
@@ -236,6 +257,12 @@ func TestResolver_FluxMonitorSpec(t *testing.T) {
 						MinPayment:          assets.NewLinkFromJuels(1000),
 						PollTimerDisabled:   true,
 						PollTimerPeriod:     time.Duration(1 * time.Minute),
+						DeviationRule: &job.FluxMonitorDeviationRule{
+							Type:                  "asymmetric",
+							UpThreshold:           1,
+							DownThreshold:         0.5,
+							MinSubmissionInterval: models.Interval(5 * time.Minute),
+						},
 					},
 				}, nil)
 			},
@@ -249,6 +276,12 @@ func TestResolver_FluxMonitorSpec(t *testing.T) {
 									absoluteThreshold
 									contractAddress
 									createdAt
+									deviationRule {
+										downThreshold
+										minSubmissionInterval
+										type
+										upThreshold
+									}
 									drumbeatEnabled
 									drumbeatRandomDelay
 									drumbeatSchedule
@@ -272,6 +305,12 @@ func TestResolver_FluxMonitorSpec(t *testing.T) {
 							"absoluteThreshold": 0,
 							"contractAddress": "0x613a38AC1659769640aaE063C651F48E0250454C",
 							"createdAt": "2021-01-01T00:00:00Z",
+							"deviationRule": {
+								"downThreshold": 0.5,
+								"minSubmissionInterval": "5m0s",
+								"type": "asymmetric",
+								"upThreshold": 1
+							},
 							"drumbeatEnabled": true,
 							"drumbeatRandomDelay": "1s",
 							"drumbeatSchedule": "CRON_TZ=UTC 0 0 1 1 *",
@@ -230,6 +230,8 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	prc := PipelineRunsController{app}
 	psec := PipelineJobSpecErrorsController{app}
 	unauthedv2.PATCH("/resume/:runID", prc.Resume)
+	wc := NewWebhooksController(app)
+	unauthedv2.POST("/webhooks/:ID/runs", wc.Run)
 
 	authv2 := r.Group("/v2", auth.Authenticate(app.SessionORM(),
 		auth.AuthenticateByToken,
@@ -286,6 +288,9 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		rc := ReplayController{app}
 		authv2.POST("/replay_from_block/:number", auth.RequiresRunRole(rc.ReplayFromBlock))
 
+		erc := EVMReorgsController{app}
+		authv2.GET("/reorgs/evm", paginatedRequest(erc.Index))
+
 		csakc := CSAKeysController{app}
 		authv2.GET("/keys/csa", csakc.Index)
 		authv2.POST("/keys/csa", auth.RequiresEditRole(csakc.Create))
@@ -365,11 +370,29 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/jobs", auth.RequiresEditRole(jc.Create))
 		authv2.PUT("/jobs/:ID", auth.RequiresEditRole(jc.Update))
 		authv2.DELETE("/jobs/:ID", auth.RequiresEditRole(jc.Delete))
+		authv2.POST("/jobs/:ID/pause", auth.RequiresEditRole(jc.Pause))
+		authv2.POST("/jobs/:ID/resume", auth.RequiresEditRole(jc.Resume))
+		authv2.GET("/jobs/:ID/versions", jc.Versions)
+		authv2.POST("/jobs/:ID/versions/:version/rollback", auth.RequiresEditRole(jc.Rollback))
+		authv2.PUT("/jobs/:ID/shadow", auth.RequiresEditRole(jc.SetShadow))
+		authv2.DELETE("/jobs/:ID/shadow", auth.RequiresEditRole(jc.RemoveShadow))
+		authv2.GET("/jobs/:ID/shadow/report", jc.ShadowReport)
+		authv2.POST("/jobs/:ID/webhook_secret", auth.RequiresEditRole(wc.GenerateSecret))
+		authv2.DELETE("/jobs/:ID/webhook_secret", auth.RequiresEditRole(wc.DeleteSecret))
+
+		jtc := JobTemplatesController{app}
+		authv2.GET("/job_templates", jtc.Index)
+		authv2.POST("/job_templates", auth.RequiresEditRole(jtc.Create))
+		authv2.GET("/job_templates/:name", jtc.Show)
+		authv2.GET("/job_templates/:name/jobs", jtc.Jobs)
 
 		// PipelineRunsController
 		authv2.GET("/pipeline/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs/:runID", prc.Show)
+		authv2.POST("/pipeline/runs/:runID/replay", auth.RequiresRunRole(prc.Replay))
+		authv2.GET("/pipeline/archive/runs", prc.Archived)
+		authv2.POST("/pipeline/archive/import", auth.RequiresEditRole(prc.ImportArchived))
 
 		// FeaturesController
 		fc := FeaturesController{app}
@@ -417,8 +440,15 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		efc := EVMForwardersController{app}
 		authv2.GET("/nodes/evm/forwarders", paginatedRequest(efc.Index))
 		authv2.POST("/nodes/evm/forwarders/track", auth.RequiresEditRole(efc.Track))
+		authv2.PATCH("/nodes/evm/forwarders/:fwdID", auth.RequiresEditRole(efc.Update))
 		authv2.DELETE("/nodes/evm/forwarders/:fwdID", auth.RequiresEditRole(efc.Delete))
 
+		sc := SubpipelinesController{app}
+		authv2.GET("/subpipelines", sc.Index)
+		authv2.POST("/subpipelines", auth.RequiresEditRole(sc.Create))
+		authv2.GET("/subpipelines/:name", sc.Show)
+		authv2.DELETE("/subpipelines/:name", auth.RequiresEditRole(sc.Delete))
+
 		buildInfo := BuildInfoController{app}
 		authv2.GET("/build_info", buildInfo.Show)
 
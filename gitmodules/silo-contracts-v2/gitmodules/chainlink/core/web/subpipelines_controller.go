@@ -0,0 +1,111 @@
+package web
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// SubpipelinesController manages named subpipelines, which jobs invoke with a subpipeline task.
+type SubpipelinesController struct {
+	App chainlink.Application
+}
+
+// Index lists every version of every subpipeline.
+// Example:
+// "GET <application>/subpipelines"
+func (sc *SubpipelinesController) Index(c *gin.Context) {
+	subpipelines, err := sc.App.PipelineORM().FindSubpipelines()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewSubpipelineResources(subpipelines), "subpipelines")
+}
+
+// Show returns a version of a subpipeline, the latest one unless the version query param is set.
+// Example:
+// "GET <application>/subpipelines/:name?version=2"
+func (sc *SubpipelinesController) Show(c *gin.Context) {
+	var version int64
+	if v := c.Query("version"); v != "" {
+		var err error
+		if version, err = strconv.ParseInt(v, 10, 32); err != nil || version <= 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid version %q", v))
+			return
+		}
+	}
+
+	subpipeline, err := sc.App.PipelineORM().FindSubpipeline(c.Param("name"), int32(version))
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("subpipeline not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewSubpipelineResource(subpipeline), "subpipeline")
+}
+
+// CreateSubpipelineRequest is a JSONAPI request for creating a version of a subpipeline.
+type CreateSubpipelineRequest struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// Create stores a new version of a subpipeline, creating it if needed.
+// Example:
+// "POST <application>/subpipelines"
+func (sc *SubpipelinesController) Create(c *gin.Context) {
+	request := CreateSubpipelineRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	subpipeline, err := sc.App.PipelineORM().CreateSubpipeline(request.Name, request.Source)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	sc.App.GetAuditLogger().Audit(audit.SubpipelineCreated, map[string]interface{}{
+		"name":    subpipeline.Name,
+		"version": subpipeline.Version,
+	})
+	jsonAPIResponseWithStatus(c, presenters.NewSubpipelineResource(subpipeline), "subpipeline", http.StatusCreated)
+}
+
+// Delete removes every version of a subpipeline.
+// Example:
+// "DELETE <application>/subpipelines/:name"
+func (sc *SubpipelinesController) Delete(c *gin.Context) {
+	name := c.Param("name")
+	err := sc.App.PipelineORM().DeleteSubpipeline(name)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("subpipeline not found"))
+		return
+	}
+	if errors.Is(err, pipeline.ErrSubpipelineInUse) {
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	sc.App.GetAuditLogger().Audit(audit.SubpipelineDeleted, map[string]interface{}{"name": name})
+	jsonAPIResponseWithStatus(c, nil, "subpipeline", http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func Test_SubpipelinesController(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+
+	create := func(t *testing.T, name, source string) *http.Response {
+		body, err := json.Marshal(web.CreateSubpipelineRequest{Name: name, Source: source})
+		require.NoError(t, err)
+		resp, cleanup := client.Post("/v2/subpipelines", bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		return resp
+	}
+
+	resp := create(t, "double", `mul [type=multiply input="$(inputs.value)" times=2]`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resource := presenters.SubpipelineResource{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resource))
+	assert.Equal(t, "double@1", resource.ID)
+	assert.Equal(t, int32(1), resource.Version)
+
+	resp = create(t, "double", `mul [type=multiply input="$(inputs.value)" times=3]`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = create(t, "self", `a [type=subpipeline name="self"]`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	t.Run("Index", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/subpipelines")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var resources []presenters.SubpipelineResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resources))
+		require.Len(t, resources, 2)
+	})
+
+	t.Run("Show", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/subpipelines/double")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resource))
+		assert.Equal(t, int32(2), resource.Version)
+
+		resp, cleanup = client.Get("/v2/subpipelines/double?version=1")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resource))
+		assert.Equal(t, int32(1), resource.Version)
+
+		resp, cleanup = client.Get("/v2/subpipelines/nope")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		resp := create(t, "quadruple", `a [type=subpipeline name="double"]`)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, cleanup := client.Delete("/v2/subpipelines/double")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		resp, cleanup = client.Delete("/v2/subpipelines/quadruple")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, cleanup = client.Delete("/v2/subpipelines/double")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, cleanup = client.Delete("/v2/subpipelines/double")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
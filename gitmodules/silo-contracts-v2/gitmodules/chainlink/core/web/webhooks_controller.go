@@ -0,0 +1,125 @@
+package web
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// WebhooksController manages the runs of webhook jobs by requests signed with a secret of the
+// job, rather than with node credentials.
+type WebhooksController struct {
+	App chainlink.Application
+	ORM webhook.ORM
+}
+
+func NewWebhooksController(app chainlink.Application) *WebhooksController {
+	return &WebhooksController{App: app, ORM: webhook.NewORM(app.GetSqlxDB(), app.GetLogger(), app.GetConfig().Database())}
+}
+
+// Run triggers a run of a webhook job by its external job ID, with the request body as input.
+// The request must be signed with the secret of the job, see webhook.Sign.
+// Example:
+// "POST <application>/webhooks/:ID/runs"
+func (wc *WebhooksController) Run(c *gin.Context) {
+	jobUUID, err := uuid.Parse(c.Param("ID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("bad external job ID"))
+		return
+	}
+	bodyBytes, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	authorizer := webhook.NewHMACAuthorizer(wc.ORM, c.Request.Header, bodyBytes)
+	canRun, err := authorizer.CanRun(c.Request.Context(), wc.App.GetConfig().JobPipeline(), jobUUID)
+	if errors.Is(err, webhook.ErrUnauthorizedRequest) {
+		jsonAPIError(c, http.StatusUnauthorized, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if !canRun {
+		jsonAPIError(c, http.StatusUnauthorized, webhook.ErrUnauthorizedRequest)
+		return
+	}
+
+	jobRunID, err := wc.App.RunWebhookJobV2(c.Request.Context(), jobUUID, string(bodyBytes), pipeline.JSONSerializable{})
+	if errors.Is(err, webhook.ErrJobNotExists) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	pipelineRun, err := wc.App.PipelineORM().FindRun(jobRunID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewPipelineRunResource(pipelineRun, wc.App.GetLogger()), "pipelineRun")
+}
+
+// GenerateSecret sets a new secret for a webhook job, which is required to sign requests running
+// the job without node credentials, and returns it. The previous secret stops being accepted.
+// Example:
+// "POST <application>/jobs/:ID/webhook_secret"
+func (wc *WebhooksController) GenerateSecret(c *gin.Context) {
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	secret, err := wc.ORM.GenerateSecret(j.ID, pg.WithParentCtx(c.Request.Context()))
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("webhook job not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	wc.App.GetAuditLogger().Audit(audit.WebhookSecretGenerated, map[string]interface{}{"id": j.ID})
+	jsonAPIResponse(c, presenters.NewWebhookSecretResource(j.ID, secret), "webhookSecrets")
+}
+
+// DeleteSecret removes the secret of a webhook job, so it can no longer be run by signed requests.
+// Example:
+// "DELETE <application>/jobs/:ID/webhook_secret"
+func (wc *WebhooksController) DeleteSecret(c *gin.Context) {
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err := wc.ORM.DeleteSecret(j.ID, pg.WithParentCtx(c.Request.Context()))
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("webhook secret not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	wc.App.GetAuditLogger().Audit(audit.WebhookSecretDeleted, map[string]interface{}{"id": j.ID})
+	jsonAPIResponseWithStatus(c, nil, "webhookSecrets", http.StatusNoContent)
+}
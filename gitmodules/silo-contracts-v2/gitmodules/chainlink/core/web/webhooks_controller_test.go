@@ -0,0 +1,144 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	clhttptest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/httptest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestWebhooksController_Run(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+
+	jb, err := webhook.ValidatedWebhookSpec(fmt.Sprintf(`
+type            = "webhook"
+schemaVersion   = 1
+externalJobID   = "%s"
+observationSource   = """
+    parse    [type=jsonparse path="data,result" data="$(jobRun.requestBody)"];
+    multiply [type=multiply times="100"];
+
+    parse -> multiply;
+"""
+`, uuid.New()), app.GetExternalInitiatorManager())
+	require.NoError(t, err)
+	require.NoError(t, app.AddJobV2(testutils.Context(t), &jb))
+	jobID := strconv.FormatInt(int64(jb.ID), 10)
+
+	generateSecret := func(t *testing.T) string {
+		response, cleanup := client.Post("/v2/jobs/"+jobID+"/webhook_secret", nil)
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, response, http.StatusOK)
+
+		var resource presenters.WebhookSecretResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &resource))
+		assert.Equal(t, jobID, resource.ID)
+		require.NotEmpty(t, resource.Secret)
+		return resource.Secret
+	}
+
+	body := []byte(`{"data":{"result":"1.23"}}`)
+	newRequest := func(t *testing.T, externalJobID string, body []byte) *http.Request {
+		request, err := http.NewRequest("POST", app.Server.URL+"/v2/webhooks/"+externalJobID+"/runs", bytes.NewReader(body))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", web.MediaType)
+		return request
+	}
+	sign := func(request *http.Request, secret string, nonce string, body []byte) {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		request.Header.Set(webhook.TimestampHeader, timestamp)
+		request.Header.Set(webhook.NonceHeader, nonce)
+		request.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, timestamp, nonce, body))
+	}
+	do := func(t *testing.T, request *http.Request) *http.Response {
+		response, err := clhttptest.NewTestLocalOnlyHTTPClient().Do(request)
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, response.Body.Close()) })
+		return response
+	}
+
+	t.Run("job without secret", func(t *testing.T) {
+		request := newRequest(t, jb.ExternalJobID.String(), body)
+		sign(request, "secret", "1", body)
+		cltest.AssertServerResponse(t, do(t, request), http.StatusUnauthorized)
+	})
+
+	secret := generateSecret(t)
+
+	t.Run("signed request", func(t *testing.T) {
+		request := newRequest(t, jb.ExternalJobID.String(), body)
+		sign(request, secret, "2", body)
+		response := do(t, request)
+		cltest.AssertServerResponse(t, response, http.StatusOK)
+
+		var run presenters.PipelineRunResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &run))
+		assert.True(t, run.FinishedAt.Valid)
+		require.Len(t, run.Outputs, 1)
+		assert.Equal(t, "123", *run.Outputs[0])
+		require.Len(t, run.TaskRuns, 2)
+
+		// the same request is rejected when it is replayed
+		replayed := newRequest(t, jb.ExternalJobID.String(), body)
+		replayed.Header = request.Header.Clone()
+		cltest.AssertServerResponse(t, do(t, replayed), http.StatusUnauthorized)
+	})
+
+	t.Run("unsigned request", func(t *testing.T) {
+		cltest.AssertServerResponse(t, do(t, newRequest(t, jb.ExternalJobID.String(), body)), http.StatusUnauthorized)
+	})
+
+	t.Run("request signed with the wrong secret", func(t *testing.T) {
+		request := newRequest(t, jb.ExternalJobID.String(), body)
+		sign(request, "wrong secret", "3", body)
+		cltest.AssertServerResponse(t, do(t, request), http.StatusUnauthorized)
+	})
+
+	t.Run("node credentials are not accepted", func(t *testing.T) {
+		response, cleanup := client.Post("/v2/webhooks/"+jb.ExternalJobID.String()+"/runs", bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, response, http.StatusUnauthorized)
+	})
+
+	t.Run("invalid external job ID", func(t *testing.T) {
+		request := newRequest(t, "invalid", body)
+		sign(request, secret, "4", body)
+		cltest.AssertServerResponse(t, do(t, request), http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rotated and deleted secret", func(t *testing.T) {
+		newSecret := generateSecret(t)
+		request := newRequest(t, jb.ExternalJobID.String(), body)
+		sign(request, secret, "5", body)
+		cltest.AssertServerResponse(t, do(t, request), http.StatusUnauthorized)
+
+		response, cleanup := client.Delete("/v2/jobs/" + jobID + "/webhook_secret")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, response, http.StatusNoContent)
+
+		request = newRequest(t, jb.ExternalJobID.String(), body)
+		sign(request, newSecret, "6", body)
+		cltest.AssertServerResponse(t, do(t, request), http.StatusUnauthorized)
+
+		response, cleanup = client.Delete("/v2/jobs/" + jobID + "/webhook_secret")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, response, http.StatusNotFound)
+	})
+}